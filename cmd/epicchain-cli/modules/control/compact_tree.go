@@ -0,0 +1,80 @@
+package control
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/common"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/commonflags"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/key"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	controlSvc "github.com/epicchainlabs/epicchain-node/pkg/services/control/server"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	rawclient "github.com/epicchainlabs/neofs-api-go/v2/rpc/client"
+	"github.com/spf13/cobra"
+)
+
+var compactTreeCmd = &cobra.Command{
+	Use:   "compact-tree",
+	Short: "Compact operation log of the tree",
+	Long: `Remove operations synchronized by all container nodes from the operation log
+of the tree in an object tree service.`,
+	Args: cobra.NoArgs,
+	Run:  compactTree,
+}
+
+func initControlCompactTreeCmd() {
+	initControlFlags(compactTreeCmd)
+
+	flags := compactTreeCmd.Flags()
+	flags.String(commonflags.CIDFlag, "", commonflags.CIDFlagUsage)
+	flags.String(synchronizeTreeIDFlag, "", "Tree ID")
+}
+
+func compactTree(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	pk := key.Get(cmd)
+
+	var cnr cid.ID
+	cidStr, _ := cmd.Flags().GetString(commonflags.CIDFlag)
+	common.ExitOnErr(cmd, "can't decode container ID: %w", cnr.DecodeString(cidStr))
+
+	treeID, _ := cmd.Flags().GetString(synchronizeTreeIDFlag)
+	if treeID == "" {
+		common.ExitOnErr(cmd, "", errors.New("tree ID must not be empty"))
+	}
+
+	rawCID := make([]byte, sha256.Size)
+	cnr.Encode(rawCID)
+
+	req := &control.CompactTreeRequest{
+		Body: &control.CompactTreeRequest_Body{
+			ContainerId: rawCID,
+			TreeId:      treeID,
+		},
+	}
+
+	err := controlSvc.SignMessage(pk, req)
+	common.ExitOnErr(cmd, "could not sign request: %w", err)
+
+	cli := getClient(ctx, cmd)
+
+	var resp *control.CompactTreeResponse
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.CompactTree(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	if resp.GetBody().GetHeight() == 0 {
+		cmd.Println("Container nodes haven't synchronized the tree yet, nothing to compact.")
+		return
+	}
+
+	cmd.Printf("Tree log has been compacted up to height %d, removed operations: %d.\n",
+		resp.GetBody().GetHeight(), resp.GetBody().GetRemoved())
+}
@@ -0,0 +1,157 @@
+package control
+
+import (
+	"crypto/sha256"
+
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/common"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/commonflags"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/key"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	rawclient "github.com/epicchainlabs/neofs-api-go/v2/rpc/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	quotaSoftSizeFlag    = "soft-size"
+	quotaHardSizeFlag    = "hard-size"
+	quotaSoftObjectsFlag = "soft-objects"
+	quotaHardObjectsFlag = "hard-objects"
+)
+
+var quotaCmd = &cobra.Command{
+	Use:   "quota",
+	Short: "Operations with container quotas of the node",
+	Long: `Operations with limits of the container data stored by the node.
+Quotas set by the node operator are merged with the ones set by the container owner
+in the container attributes, the strictest limits are applied.`,
+}
+
+var quotaSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set container quota",
+	Long: `Set limits of the container data stored by the node. Zero limit means no limit,
+setting all limits to zero removes the quota. Objects exceeding hard limits are rejected,
+exceeding soft limits is only reported.`,
+	Args: cobra.NoArgs,
+	Run:  setContainerQuota,
+}
+
+var quotaGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show container usage and quota",
+	Long:  "Show the size and the number of the container objects stored by the node and the quota set by the node operator",
+	Args:  cobra.NoArgs,
+	Run:   getContainerUsage,
+}
+
+func initControlQuotaCmd() {
+	quotaCmd.AddCommand(quotaSetCmd)
+	quotaCmd.AddCommand(quotaGetCmd)
+
+	initControlFlags(quotaSetCmd)
+	initControlFlags(quotaGetCmd)
+
+	flags := quotaSetCmd.Flags()
+	flags.String(commonflags.CIDFlag, "", commonflags.CIDFlagUsage)
+	flags.Uint64(quotaSoftSizeFlag, 0, "Soft limit of the total payload size in bytes")
+	flags.Uint64(quotaHardSizeFlag, 0, "Hard limit of the total payload size in bytes")
+	flags.Uint64(quotaSoftObjectsFlag, 0, "Soft limit of the number of objects")
+	flags.Uint64(quotaHardObjectsFlag, 0, "Hard limit of the number of objects")
+
+	_ = quotaSetCmd.MarkFlagRequired(commonflags.CIDFlag)
+
+	flags = quotaGetCmd.Flags()
+	flags.String(commonflags.CIDFlag, "", commonflags.CIDFlagUsage)
+
+	_ = quotaGetCmd.MarkFlagRequired(commonflags.CIDFlag)
+}
+
+func readQuotaCID(cmd *cobra.Command) []byte {
+	var cnr cid.ID
+	cidStr, _ := cmd.Flags().GetString(commonflags.CIDFlag)
+	common.ExitOnErr(cmd, "can't decode container ID: %w", cnr.DecodeString(cidStr))
+
+	rawCID := make([]byte, sha256.Size)
+	cnr.Encode(rawCID)
+
+	return rawCID
+}
+
+func setContainerQuota(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	pk := key.Get(cmd)
+
+	flags := cmd.Flags()
+	q := new(control.ContainerQuota)
+	q.SoftSize, _ = flags.GetUint64(quotaSoftSizeFlag)
+	q.HardSize, _ = flags.GetUint64(quotaHardSizeFlag)
+	q.SoftObjects, _ = flags.GetUint64(quotaSoftObjectsFlag)
+	q.HardObjects, _ = flags.GetUint64(quotaHardObjectsFlag)
+
+	req := &control.SetContainerQuotaRequest{
+		Body: &control.SetContainerQuotaRequest_Body{
+			ContainerId: readQuotaCID(cmd),
+			Quota:       q,
+		},
+	}
+
+	signRequest(cmd, pk, req)
+
+	cli := getClient(ctx, cmd)
+
+	var resp *control.SetContainerQuotaResponse
+	var err error
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.SetContainerQuota(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	cmd.Println("Container quota has been set.")
+}
+
+func getContainerUsage(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	pk := key.Get(cmd)
+
+	req := &control.GetContainerUsageRequest{
+		Body: &control.GetContainerUsageRequest_Body{
+			ContainerId: readQuotaCID(cmd),
+		},
+	}
+
+	signRequest(cmd, pk, req)
+
+	cli := getClient(ctx, cmd)
+
+	var resp *control.GetContainerUsageResponse
+	var err error
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.GetContainerUsage(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	body := resp.GetBody()
+	q := body.GetQuota()
+
+	limit := func(v uint64) any {
+		if v == 0 {
+			return "none"
+		}
+		return v
+	}
+
+	cmd.Printf("Stored: %d bytes, %d objects\n", body.GetSize(), body.GetObjects())
+	cmd.Printf("Size limits (bytes): soft %v, hard %v\n", limit(q.GetSoftSize()), limit(q.GetHardSize()))
+	cmd.Printf("Objects limits: soft %v, hard %v\n", limit(q.GetSoftObjects()), limit(q.GetHardObjects()))
+}
@@ -0,0 +1,72 @@
+package control
+
+import (
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/common"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/commonflags"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/key"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	rawclient "github.com/epicchainlabs/neofs-api-go/v2/rpc/client"
+	"github.com/spf13/cobra"
+)
+
+var reloadStatusCmd = &cobra.Command{
+	Use:   "reload-status",
+	Short: "Get the outcome of the last configuration reload",
+	Long: `Get the outcome of the last configuration reload triggered by SIGHUP: the
+changes applied to the node configuration sections and their errors.`,
+	Args: cobra.NoArgs,
+	Run:  getReloadStatus,
+}
+
+func initControlReloadStatusCmd() {
+	initControlFlags(reloadStatusCmd)
+}
+
+func getReloadStatus(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	pk := key.Get(cmd)
+
+	req := &control.GetReloadStatusRequest{
+		Body: new(control.GetReloadStatusRequest_Body),
+	}
+
+	signRequest(cmd, pk, req)
+
+	cli := getClient(ctx, cmd)
+
+	var resp *control.GetReloadStatusResponse
+	var err error
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.GetReloadStatus(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	body := resp.GetBody()
+	if body.GetTime() == 0 {
+		cmd.Println("Configuration has not been reloaded yet.")
+		return
+	}
+
+	cmd.Printf("Last reload: %s\n", time.Unix(body.GetTime(), 0).Format(time.RFC3339))
+
+	changes := body.GetChanges()
+	if len(changes) == 0 {
+		cmd.Println("No changes.")
+		return
+	}
+
+	for _, ch := range changes {
+		cmd.Printf("%s: %s %s", ch.GetSection(), ch.GetTarget(), ch.GetAction())
+		if e := ch.GetError(); e != "" {
+			cmd.Printf(" (error: %s)", e)
+		}
+		cmd.Println()
+	}
+}
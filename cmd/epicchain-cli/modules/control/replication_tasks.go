@@ -0,0 +1,81 @@
+package control
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/common"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/commonflags"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/key"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	rawclient "github.com/epicchainlabs/neofs-api-go/v2/rpc/client"
+	"github.com/spf13/cobra"
+)
+
+var replicationTasksCmd = &cobra.Command{
+	Use:   "replication-tasks",
+	Short: "List object replication tasks",
+	Long: `List object replication tasks being handled by the node. Active tasks transmit
+the object at the moment, queued ones wait for the bandwidth budget or for the retry.`,
+	Args: cobra.NoArgs,
+	Run:  listReplicationTasks,
+}
+
+func initControlReplicationTasksCmd() {
+	initControlFlags(replicationTasksCmd)
+}
+
+func listReplicationTasks(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	pk := key.Get(cmd)
+
+	req := &control.ListReplicationTasksRequest{
+		Body: new(control.ListReplicationTasksRequest_Body),
+	}
+
+	signRequest(cmd, pk, req)
+
+	cli := getClient(ctx, cmd)
+
+	var resp *control.ListReplicationTasksResponse
+	var err error
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.ListReplicationTasks(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	tasks := resp.GetBody().GetTasks()
+	if len(tasks) == 0 {
+		cmd.Println("No replication tasks.")
+		return
+	}
+
+	for _, t := range tasks {
+		var (
+			cnr cid.ID
+			obj oid.ID
+		)
+
+		common.ExitOnErr(cmd, "invalid container ID in response: %w", cnr.Decode(t.GetContainerId()))
+		common.ExitOnErr(cmd, "invalid object ID in response: %w", obj.Decode(t.GetObjectId()))
+
+		state := "queued"
+		if t.GetActive() {
+			state = "active"
+		}
+
+		cmd.Printf("%s/%s: %s, %d copies left, started %s\n", cnr, obj, state, t.GetCopies(),
+			time.Unix(t.GetStarted(), 0).Format(time.RFC3339))
+
+		for _, node := range t.GetNodes() {
+			cmd.Printf("\t%s\n", hex.EncodeToString(node))
+		}
+	}
+}
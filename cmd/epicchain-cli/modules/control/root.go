@@ -33,6 +33,10 @@ func init() {
 		dropObjectsCmd,
 		shardsCmd,
 		synchronizeTreeCmd,
+		compactTreeCmd,
+		quotaCmd,
+		replicationTasksCmd,
+		reloadStatusCmd,
 	)
 
 	initControlHealthCheckCmd()
@@ -40,4 +44,8 @@ func init() {
 	initControlDropObjectsCmd()
 	initControlShardsCmd()
 	initControlSynchronizeTreeCmd()
+	initControlCompactTreeCmd()
+	initControlQuotaCmd()
+	initControlReplicationTasksCmd()
+	initControlReloadStatusCmd()
 }
@@ -17,6 +17,11 @@ func initControlShardsCmd() {
 	shardsCmd.AddCommand(restoreShardCmd)
 	shardsCmd.AddCommand(evacuateShardCmd)
 	shardsCmd.AddCommand(flushCacheCmd)
+	shardsCmd.AddCommand(rebalanceCmd)
+	shardsCmd.AddCommand(evacuationCmd)
+	shardsCmd.AddCommand(checkShardsCmd)
+	shardsCmd.AddCommand(detachShardsCmd)
+	shardsCmd.AddCommand(attachShardCmd)
 
 	initControlShardsListCmd()
 	initControlSetShardModeCmd()
@@ -24,4 +29,9 @@ func initControlShardsCmd() {
 	initControlRestoreShardCmd()
 	initControlEvacuateShardCmd()
 	initControlFlushCacheCmd()
+	initControlShardsRebalanceCmd()
+	initControlShardsEvacuationCmd()
+	initControlShardsCheckCmd()
+	initControlDetachShardsCmd()
+	initControlAttachShardCmd()
 }
@@ -0,0 +1,76 @@
+package control
+
+import (
+	"os"
+
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/common"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/commonflags"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/key"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	rawclient "github.com/epicchainlabs/neofs-api-go/v2/rpc/client"
+	"github.com/mr-tron/base58"
+	"github.com/spf13/cobra"
+)
+
+const shardConfigFlag = "config"
+
+var attachShardCmd = &cobra.Command{
+	Use:   "attach",
+	Short: "Attach new shard to the storage engine",
+	Long: `Open the shard and add it to the storage engine. The shard configuration
+file (YAML or JSON) has the structure of the shard section of the node
+configuration file, e.g.:
+
+  metabase:
+    path: /storage/meta
+  blobstor:
+    - type: fstree
+      path: /storage/fstree
+
+Defaults of the node configuration shard section are not applied. The shard
+stays attached after the node restart.`,
+	Args: cobra.NoArgs,
+	Run:  attachShard,
+}
+
+func initControlAttachShardCmd() {
+	initControlFlags(attachShardCmd)
+
+	flags := attachShardCmd.Flags()
+	flags.String(shardConfigFlag, "", "Path to the shard configuration file")
+
+	_ = attachShardCmd.MarkFlagRequired(shardConfigFlag)
+}
+
+func attachShard(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	pk := key.Get(cmd)
+
+	path, _ := cmd.Flags().GetString(shardConfigFlag)
+
+	cfg, err := os.ReadFile(path)
+	common.ExitOnErr(cmd, "can't read shard configuration: %w", err)
+
+	req := &control.AttachShardRequest{
+		Body: &control.AttachShardRequest_Body{
+			Config: cfg,
+		},
+	}
+
+	signRequest(cmd, pk, req)
+
+	cli := getClient(ctx, cmd)
+
+	var resp *control.AttachShardResponse
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.AttachShard(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	cmd.Printf("Shard %s has been attached successfully.\n", base58.Encode(resp.GetBody().GetShard_ID()))
+}
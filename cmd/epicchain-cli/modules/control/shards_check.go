@@ -0,0 +1,299 @@
+package control
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/common"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/commonflags"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/key"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	"github.com/epicchainlabs/epicchain-sdk-go/client"
+	rawclient "github.com/epicchainlabs/neofs-api-go/v2/rpc/client"
+	"github.com/mr-tron/base58"
+	"github.com/spf13/cobra"
+)
+
+const (
+	checkAwaitFlag  = "await"
+	checkOffsetFlag = "offset"
+	checkLimitFlag  = "limit"
+)
+
+// checkAwaitInterval is an interval between status requests of the awaited
+// shard check.
+const checkAwaitInterval = time.Second
+
+var checkShardsCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check consistency of the shard metabases in the background",
+	Long: `Check that the shard metabases match the objects stored in the blobstor and the write-cache.
+Reports orphan blobs, missing payloads, wrong storage IDs, stale container volumes and object counters.
+Nothing is repaired, use 'epicchain-lens meta repair' on the stopped node to fix the problems.`,
+}
+
+var checkShardsStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start shard check",
+	Long:  "Start shard check",
+	Args:  cobra.NoArgs,
+	Run:   startShardCheck,
+}
+
+var checkShardsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show shard check progress and found problems",
+	Long:  "Show shard check progress and a page of the found problems",
+	Args:  cobra.NoArgs,
+	Run:   shardCheckStatus,
+}
+
+var checkShardsStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop shard check",
+	Long:  "Stop shard check, the problems found before are kept",
+	Args:  cobra.NoArgs,
+	Run:   stopShardCheck,
+}
+
+func startShardCheck(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	pk := key.Get(cmd)
+
+	req := &control.StartShardCheckRequest{Body: new(control.StartShardCheckRequest_Body)}
+	req.Body.Shard_ID = getShardIDList(cmd)
+
+	signRequest(cmd, pk, req)
+
+	cli := getClient(ctx, cmd)
+
+	var resp *control.StartShardCheckResponse
+	var err error
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.StartShardCheck(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	cmd.Println("Shard check has been started.")
+
+	if await, _ := cmd.Flags().GetBool(checkAwaitFlag); !await {
+		return
+	}
+
+	isJSON, _ := cmd.Flags().GetBool(commonflags.JSON)
+
+	var st *control.GetShardCheckStatusResponse_Body
+	for {
+		// problems are not requested until the check is finished
+		st = getShardCheckStatus(cmd, cli, 0, 1)
+		if !st.GetRunning() {
+			break
+		}
+
+		if !isJSON {
+			cmd.Printf("Shards checked: %d of %d, problems found: %d\n",
+				st.GetChecked(), len(st.GetShard_ID()), st.GetTotalIssues())
+		}
+
+		time.Sleep(checkAwaitInterval)
+	}
+
+	// fetch all the found problems page by page
+	var issues []*control.GetShardCheckStatusResponse_Body_Issue
+	for uint64(len(issues)) < st.GetTotalIssues() {
+		page := getShardCheckStatus(cmd, cli, uint32(len(issues)), 0).GetIssues()
+		if len(page) == 0 {
+			break
+		}
+		issues = append(issues, page...)
+	}
+	st.Issues = issues
+
+	printShardCheckStatus(cmd, st, 0)
+}
+
+func shardCheckStatus(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	offset, _ := cmd.Flags().GetUint32(checkOffsetFlag)
+	limit, _ := cmd.Flags().GetUint32(checkLimitFlag)
+
+	printShardCheckStatus(cmd, getShardCheckStatus(cmd, getClient(ctx, cmd), offset, limit), offset)
+}
+
+func getShardCheckStatus(cmd *cobra.Command, cli *client.Client, offset, limit uint32) *control.GetShardCheckStatusResponse_Body {
+	pk := key.Get(cmd)
+
+	req := &control.GetShardCheckStatusRequest{Body: &control.GetShardCheckStatusRequest_Body{
+		Offset: offset,
+		Limit:  limit,
+	}}
+
+	signRequest(cmd, pk, req)
+
+	var resp *control.GetShardCheckStatusResponse
+	var err error
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.GetShardCheckStatus(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	return resp.GetBody()
+}
+
+func printShardCheckStatus(cmd *cobra.Command, st *control.GetShardCheckStatusResponse_Body, offset uint32) {
+	issues := st.GetIssues()
+
+	isJSON, _ := cmd.Flags().GetBool(commonflags.JSON)
+	if isJSON {
+		prettyPrintShardIssuesJSON(cmd, issues)
+		return
+	}
+
+	if len(st.GetShard_ID()) == 0 {
+		cmd.Println("Shard check has never been started.")
+		return
+	}
+
+	state := "finished"
+	switch {
+	case st.GetRunning():
+		state = "running"
+	case st.GetError() != "":
+		state = "failed: " + st.GetError()
+	case int(st.GetChecked()) < len(st.GetShard_ID()):
+		state = "stopped"
+	}
+
+	if st.GetStartedAt() != 0 {
+		state += ", started at " + time.Unix(st.GetStartedAt(), 0).Format(time.RFC3339)
+	}
+
+	cmd.Printf("Status: %s\n", state)
+	cmd.Println("Shards:")
+	for _, id := range st.GetShard_ID() {
+		cmd.Printf("  %s\n", base58.Encode(id))
+	}
+	cmd.Printf("Shards checked: %d of %d, problems found: %d\n",
+		st.GetChecked(), len(st.GetShard_ID()), st.GetTotalIssues())
+
+	for _, i := range issues {
+		var sb strings.Builder
+		sb.WriteString("Shard " + base58.Encode(i.GetShard_ID()) + ": " + i.GetProblem())
+		if obj := issueObject(i); obj != "" {
+			sb.WriteString(" " + obj)
+		}
+		if i.GetStored() != "" || i.GetActual() != "" {
+			sb.WriteString(", stored: " + i.GetStored() + ", actual: " + i.GetActual())
+		}
+
+		cmd.Println(sb.String())
+	}
+
+	if shown := uint64(offset) + uint64(len(issues)); len(issues) != 0 && (offset > 0 || shown < st.GetTotalIssues()) {
+		cmd.Printf("Shown problems %d-%d of %d, use --%s and --%s flags to see the others.\n",
+			offset+1, shown, st.GetTotalIssues(), checkOffsetFlag, checkLimitFlag)
+	}
+}
+
+func stopShardCheck(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	pk := key.Get(cmd)
+
+	req := &control.StopShardCheckRequest{Body: new(control.StopShardCheckRequest_Body)}
+
+	signRequest(cmd, pk, req)
+
+	cli := getClient(ctx, cmd)
+
+	var resp *control.StopShardCheckResponse
+	var err error
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.StopShardCheck(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	cmd.Println("Shard check has been stopped.")
+}
+
+// issueObject returns the address of the object or the ID of the container
+// the issue relates to.
+func issueObject(i *control.GetShardCheckStatusResponse_Body_Issue) string {
+	if len(i.GetContainerId()) == 0 {
+		return ""
+	}
+
+	res := base58.Encode(i.GetContainerId())
+	if len(i.GetObjectId()) != 0 {
+		res += "/" + base58.Encode(i.GetObjectId())
+	}
+
+	return res
+}
+
+func prettyPrintShardIssuesJSON(cmd *cobra.Command, issues []*control.GetShardCheckStatusResponse_Body_Issue) {
+	out := make([]map[string]any, 0, len(issues))
+	for _, i := range issues {
+		m := map[string]any{
+			"shard_id": base58.Encode(i.GetShard_ID()),
+			"problem":  i.GetProblem(),
+		}
+		if len(i.GetContainerId()) != 0 {
+			m["container_id"] = base58.Encode(i.GetContainerId())
+		}
+		if len(i.GetObjectId()) != 0 {
+			m["object_id"] = base58.Encode(i.GetObjectId())
+		}
+		if i.GetStored() != "" || i.GetActual() != "" {
+			m["stored"] = i.GetStored()
+			m["actual"] = i.GetActual()
+		}
+		out = append(out, m)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	enc := json.NewEncoder(buf)
+	enc.SetIndent("", "  ")
+	common.ExitOnErr(cmd, "cannot encode problems to JSON: %w", enc.Encode(out))
+
+	cmd.Print(buf.String())
+}
+
+func initControlShardsCheckCmd() {
+	checkShardsCmd.AddCommand(checkShardsStartCmd)
+	checkShardsCmd.AddCommand(checkShardsStatusCmd)
+	checkShardsCmd.AddCommand(checkShardsStopCmd)
+
+	initControlFlags(checkShardsStartCmd)
+	initControlFlags(checkShardsStatusCmd)
+	initControlFlags(checkShardsStopCmd)
+
+	flags := checkShardsStartCmd.Flags()
+	flags.StringSlice(shardIDFlag, nil, "List of shard IDs in base58 encoding")
+	flags.Bool(shardAllFlag, false, "Process all shards")
+	flags.Bool(checkAwaitFlag, false, "Wait for the check to finish and print all the found problems")
+	flags.Bool(commonflags.JSON, false, "Print found problems as a JSON array (with --await)")
+
+	checkShardsStartCmd.MarkFlagsOneRequired(shardIDFlag, shardAllFlag)
+
+	flags = checkShardsStatusCmd.Flags()
+	flags.Uint32(checkOffsetFlag, 0, "Number of the found problems to skip")
+	flags.Uint32(checkLimitFlag, 0, "Maximum number of the found problems to print, zero means the server default")
+	flags.Bool(commonflags.JSON, false, "Print found problems as a JSON array")
+}
@@ -0,0 +1,58 @@
+package control
+
+import (
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/common"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/commonflags"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/key"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	rawclient "github.com/epicchainlabs/neofs-api-go/v2/rpc/client"
+	"github.com/spf13/cobra"
+)
+
+var detachShardsCmd = &cobra.Command{
+	Use:   "detach",
+	Short: "Detach shards from the storage engine",
+	Long: `Close the shards and remove them from the storage engine. Objects of the
+detached shards become unavailable, evacuate them beforehand if needed.
+The shards stay detached after the node restart.`,
+	Args: cobra.NoArgs,
+	Run:  detachShards,
+}
+
+func initControlDetachShardsCmd() {
+	initControlFlags(detachShardsCmd)
+
+	flags := detachShardsCmd.Flags()
+	flags.StringSlice(shardIDFlag, nil, "List of shard IDs in base58 encoding")
+
+	_ = detachShardsCmd.MarkFlagRequired(shardIDFlag)
+}
+
+func detachShards(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	pk := key.Get(cmd)
+
+	req := &control.DetachShardsRequest{
+		Body: &control.DetachShardsRequest_Body{
+			Shard_ID: getShardIDList(cmd),
+		},
+	}
+
+	signRequest(cmd, pk, req)
+
+	cli := getClient(ctx, cmd)
+
+	var resp *control.DetachShardsResponse
+	var err error
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.DetachShards(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	cmd.Println("Shards have been detached successfully.")
+}
@@ -12,12 +12,20 @@ import (
 const (
 	dumpFilepathFlag     = "path"
 	dumpIgnoreErrorsFlag = "no-errors"
+	dumpIncrementalFlag  = "incremental"
+	dumpSinceFlag        = "since"
 )
 
 var dumpShardCmd = &cobra.Command{
 	Use:   "dump",
 	Short: "Dump objects from shard",
-	Long:  "Dump objects from shard to a file",
+	Long: `Dump objects from shard to a file.
+Incremental dumps contain only the objects added and removed since the marker
+printed for the previous incremental dump, the first one is made since zero marker
+and contains the whole shard. They must be restored in the same order.
+Incremental dumps require the metabase change log to be enabled in the shard
+configuration, changes are dropped from it once dumped, so only the marker of
+the last dump can be used for the next one.`,
 	Args:  cobra.NoArgs,
 	Run:   dumpShard,
 }
@@ -37,6 +45,11 @@ func dumpShard(cmd *cobra.Command, _ []string) {
 	ignore, _ := cmd.Flags().GetBool(dumpIgnoreErrorsFlag)
 	body.SetIgnoreErrors(ignore)
 
+	since, _ := cmd.Flags().GetUint64(dumpSinceFlag)
+	if incremental, _ := cmd.Flags().GetBool(dumpIncrementalFlag); incremental || since != 0 {
+		body.SetIncremental(since)
+	}
+
 	req := new(control.DumpShardRequest)
 	req.SetBody(body)
 
@@ -55,6 +68,10 @@ func dumpShard(cmd *cobra.Command, _ []string) {
 	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
 
 	cmd.Println("Shard has been dumped successfully.")
+
+	if body.GetIncremental() {
+		cmd.Printf("Marker for the next incremental dump: %d\n", resp.GetBody().GetMarker())
+	}
 }
 
 func initControlDumpShardCmd() {
@@ -64,6 +81,8 @@ func initControlDumpShardCmd() {
 	flags.String(shardIDFlag, "", "Shard ID in base58 encoding")
 	flags.String(dumpFilepathFlag, "", "File to write objects to")
 	flags.Bool(dumpIgnoreErrorsFlag, false, "Skip invalid/unreadable objects")
+	flags.Bool(dumpIncrementalFlag, false, "Write incremental dump")
+	flags.Uint64(dumpSinceFlag, 0, "Marker of the previous incremental dump, implies --"+dumpIncrementalFlag)
 
 	_ = dumpShardCmd.MarkFlagRequired(shardIDFlag)
 	_ = dumpShardCmd.MarkFlagRequired(dumpFilepathFlag)
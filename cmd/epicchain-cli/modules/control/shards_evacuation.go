@@ -0,0 +1,193 @@
+package control
+
+import (
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/common"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/commonflags"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/key"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	"github.com/epicchainlabs/epicchain-sdk-go/client"
+	rawclient "github.com/epicchainlabs/neofs-api-go/v2/rpc/client"
+	"github.com/mr-tron/base58"
+	"github.com/spf13/cobra"
+)
+
+const evacuationAwaitFlag = "await"
+
+// evacuationAwaitInterval is an interval between status requests of the
+// awaited evacuation.
+const evacuationAwaitInterval = time.Second
+
+var evacuationCmd = &cobra.Command{
+	Use:   "evacuation",
+	Short: "Evacuate objects from shards in the background",
+	Long: `Move all objects from the read-only shards to the other ones in the background.
+Objects which can't be put to other shards are replicated to other nodes.
+Stopped evacuation is continued from the saved checkpoint when started again for the same shards.`,
+}
+
+var evacuationStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start shard evacuation",
+	Long:  "Start shard evacuation",
+	Args:  cobra.NoArgs,
+	Run:   startEvacuation,
+}
+
+var evacuationStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show shard evacuation progress",
+	Long:  "Show shard evacuation progress",
+	Args:  cobra.NoArgs,
+	Run:   evacuationStatus,
+}
+
+var evacuationStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop shard evacuation",
+	Long:  "Stop shard evacuation, it can be continued later with the start command",
+	Args:  cobra.NoArgs,
+	Run:   stopEvacuation,
+}
+
+func startEvacuation(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	pk := key.Get(cmd)
+
+	req := &control.StartShardEvacuationRequest{Body: new(control.StartShardEvacuationRequest_Body)}
+	req.Body.Shard_ID = getShardIDList(cmd)
+	req.Body.IgnoreErrors, _ = cmd.Flags().GetBool(dumpIgnoreErrorsFlag)
+
+	signRequest(cmd, pk, req)
+
+	cli := getClient(ctx, cmd)
+
+	var resp *control.StartShardEvacuationResponse
+	var err error
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.StartShardEvacuation(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	cmd.Println("Shard evacuation has been started.")
+
+	if await, _ := cmd.Flags().GetBool(evacuationAwaitFlag); !await {
+		return
+	}
+
+	for {
+		st := getEvacuationStatus(cmd, cli)
+		printEvacuationStatus(cmd, st)
+
+		if !st.GetRunning() {
+			break
+		}
+
+		time.Sleep(evacuationAwaitInterval)
+	}
+}
+
+func evacuationStatus(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	printEvacuationStatus(cmd, getEvacuationStatus(cmd, getClient(ctx, cmd)))
+}
+
+func getEvacuationStatus(cmd *cobra.Command, cli *client.Client) *control.GetShardEvacuationStatusResponse_Body {
+	pk := key.Get(cmd)
+
+	req := &control.GetShardEvacuationStatusRequest{Body: new(control.GetShardEvacuationStatusRequest_Body)}
+
+	signRequest(cmd, pk, req)
+
+	var resp *control.GetShardEvacuationStatusResponse
+	var err error
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.GetShardEvacuationStatus(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	return resp.GetBody()
+}
+
+func printEvacuationStatus(cmd *cobra.Command, st *control.GetShardEvacuationStatusResponse_Body) {
+	if len(st.GetShard_ID()) == 0 {
+		cmd.Println("Shard evacuation has never been started.")
+		return
+	}
+
+	state := "finished"
+	switch {
+	case st.GetRunning():
+		state = "running"
+	case st.GetError() != "":
+		state = "failed: " + st.GetError()
+	case st.GetInterrupted():
+		state = "stopped"
+	}
+
+	if st.GetStartedAt() != 0 {
+		state += ", started at " + time.Unix(st.GetStartedAt(), 0).Format(time.RFC3339)
+	}
+
+	cmd.Printf("Status: %s\n", state)
+	cmd.Println("Shards:")
+	for _, id := range st.GetShard_ID() {
+		cmd.Printf("  %s\n", base58.Encode(id))
+	}
+	cmd.Printf("Objects evacuated: %d, failed: %d, remaining: %d\n",
+		st.GetEvacuated(), st.GetFailed(), st.GetRemaining())
+}
+
+func stopEvacuation(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	pk := key.Get(cmd)
+
+	req := &control.StopShardEvacuationRequest{Body: new(control.StopShardEvacuationRequest_Body)}
+
+	signRequest(cmd, pk, req)
+
+	cli := getClient(ctx, cmd)
+
+	var resp *control.StopShardEvacuationResponse
+	var err error
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.StopShardEvacuation(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	cmd.Println("Shard evacuation has been stopped.")
+}
+
+func initControlShardsEvacuationCmd() {
+	evacuationCmd.AddCommand(evacuationStartCmd)
+	evacuationCmd.AddCommand(evacuationStatusCmd)
+	evacuationCmd.AddCommand(evacuationStopCmd)
+
+	initControlFlags(evacuationStartCmd)
+	initControlFlags(evacuationStatusCmd)
+	initControlFlags(evacuationStopCmd)
+
+	flags := evacuationStartCmd.Flags()
+	flags.StringSlice(shardIDFlag, nil, "List of shard IDs in base58 encoding")
+	flags.Bool(shardAllFlag, false, "Process all shards")
+	flags.Bool(dumpIgnoreErrorsFlag, false, "Skip invalid/unreadable objects")
+	flags.Bool(evacuationAwaitFlag, false, "Wait for the evacuation to finish printing its progress")
+
+	evacuationStartCmd.MarkFlagsOneRequired(shardIDFlag, shardAllFlag)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/mr-tron/base58"
 	rawclient "github.com/epicchainlabs/neofs-api-go/v2/rpc/client"
@@ -64,14 +65,32 @@ func listShards(cmd *cobra.Command, _ []string) {
 func prettyPrintShardsJSON(cmd *cobra.Command, ii []*control.ShardInfo) {
 	out := make([]map[string]any, 0, len(ii))
 	for _, i := range ii {
-		out = append(out, map[string]any{
+		m := map[string]any{
 			"shard_id":    base58.Encode(i.Shard_ID),
 			"mode":        shardModeToString(i.GetMode()),
 			"metabase":    i.GetMetabasePath(),
 			"blobstor":    i.GetBlobstor(),
 			"writecache":  i.GetWritecachePath(),
 			"error_count": i.GetErrorCount(),
-		})
+		}
+		if p := i.GetWritecacheFlushPolicy(); p != nil {
+			m["writecache_flush_policy"] = map[string]any{
+				"max_age":    p.GetMaxAge(),
+				"fill_ratio": p.GetFillRatio(),
+				"windows":    p.GetWindows(),
+				"rate_limit": p.GetRateLimit(),
+			}
+		}
+		if sc := i.GetScrub(); sc.GetEnabled() || sc.GetPasses() != 0 {
+			m["scrub"] = map[string]any{
+				"enabled":   sc.GetEnabled(),
+				"checked":   sc.GetChecked(),
+				"total":     sc.GetTotal(),
+				"corrupted": sc.GetCorrupted(),
+				"passes":    sc.GetPasses(),
+			}
+		}
+		out = append(out, m)
 	}
 
 	buf := bytes.NewBuffer(nil)
@@ -103,7 +122,9 @@ func prettyPrintShards(cmd *cobra.Command, ii []*control.ShardInfo) {
 			pathPrinter("Metabase", i.GetMetabasePath())+
 			sb.String()+
 			pathPrinter("Write-cache", i.GetWritecachePath())+
+			flushPolicyPrinter(i.GetWritecacheFlushPolicy())+
 			pathPrinter("Pilorama", i.GetPiloramaPath())+
+			scrubStatusPrinter(i.GetScrub())+
 			fmt.Sprintf("Error count: %d\n", i.GetErrorCount()),
 			base58.Encode(i.Shard_ID),
 			shardModeToString(i.GetMode()),
@@ -111,6 +132,55 @@ func prettyPrintShards(cmd *cobra.Command, ii []*control.ShardInfo) {
 	}
 }
 
+func flushPolicyPrinter(p *control.WriteCacheFlushPolicy) string {
+	if p == nil {
+		return ""
+	}
+
+	var triggers []string
+	if p.GetMaxAge() != 0 {
+		triggers = append(triggers, fmt.Sprintf("max age %s", time.Duration(p.GetMaxAge())*time.Second))
+	}
+	if p.GetFillRatio() != 0 {
+		triggers = append(triggers, fmt.Sprintf("fill ratio %g", p.GetFillRatio()))
+	}
+	if len(p.GetWindows()) != 0 {
+		triggers = append(triggers, "windows "+strings.Join(p.GetWindows(), ", "))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Write-cache flush policy: ")
+	if len(triggers) == 0 {
+		sb.WriteString("immediate")
+	} else {
+		sb.WriteString(strings.Join(triggers, "; "))
+	}
+	if p.GetRateLimit() != 0 {
+		sb.WriteString(fmt.Sprintf(", rate limit %d B/s", p.GetRateLimit()))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+func scrubStatusPrinter(s *control.ShardScrubStatus) string {
+	if !s.GetEnabled() && s.GetPasses() == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Scrubbing: ")
+	if !s.GetEnabled() {
+		sb.WriteString("disabled, ")
+	}
+	if s.GetTotal() != 0 {
+		sb.WriteString(fmt.Sprintf("%d/%d objects checked, ", s.GetChecked(), s.GetTotal()))
+	}
+	sb.WriteString(fmt.Sprintf("%d passes completed, %d corrupted objects found\n", s.GetPasses(), s.GetCorrupted()))
+
+	return sb.String()
+}
+
 func shardModeToString(m control.ShardMode) string {
 	strMode, ok := lookUpShardModeString(m)
 	if ok {
@@ -0,0 +1,187 @@
+package control
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/common"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/commonflags"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/key"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	"github.com/epicchainlabs/epicchain-sdk-go/client"
+	rawclient "github.com/epicchainlabs/neofs-api-go/v2/rpc/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	rebalanceRateFlag  = "rate"
+	rebalanceAwaitFlag = "await"
+)
+
+// rebalanceAwaitInterval is an interval between status requests of the
+// awaited rebalance.
+const rebalanceAwaitInterval = time.Second
+
+var rebalanceCmd = &cobra.Command{
+	Use:   "rebalance",
+	Short: "Move objects to their preferred shards",
+	Long: `Move objects to their preferred shards in the background.
+Objects are placed on shards by HRW, so adding a shard changes the preferred
+shard of some objects stored before. Rebalance moves them to the new place,
+interrupted rebalance is resumed after the node restart.`,
+}
+
+var rebalanceStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start shard rebalance",
+	Long:  "Start shard rebalance",
+	Args:  cobra.NoArgs,
+	Run:   startRebalance,
+}
+
+var rebalanceStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show shard rebalance progress",
+	Long:  "Show shard rebalance progress",
+	Args:  cobra.NoArgs,
+	Run:   rebalanceStatus,
+}
+
+var rebalanceStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop shard rebalance",
+	Long:  "Stop shard rebalance, objects moved already stay on their new shards",
+	Args:  cobra.NoArgs,
+	Run:   stopRebalance,
+}
+
+func startRebalance(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	pk := key.Get(cmd)
+
+	req := &control.StartShardRebalanceRequest{Body: new(control.StartShardRebalanceRequest_Body)}
+	req.Body.Rate, _ = cmd.Flags().GetUint32(rebalanceRateFlag)
+
+	signRequest(cmd, pk, req)
+
+	cli := getClient(ctx, cmd)
+
+	var resp *control.StartShardRebalanceResponse
+	var err error
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.StartShardRebalance(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	cmd.Println("Shard rebalance has been started.")
+
+	if await, _ := cmd.Flags().GetBool(rebalanceAwaitFlag); !await {
+		return
+	}
+
+	for {
+		st := getRebalanceStatus(cmd, cli)
+		printRebalanceStatus(cmd, st)
+
+		if !st.GetRunning() {
+			break
+		}
+
+		time.Sleep(rebalanceAwaitInterval)
+	}
+}
+
+func rebalanceStatus(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	printRebalanceStatus(cmd, getRebalanceStatus(cmd, getClient(ctx, cmd)))
+}
+
+func getRebalanceStatus(cmd *cobra.Command, cli *client.Client) *control.GetShardRebalanceStatusResponse_Body {
+	pk := key.Get(cmd)
+
+	req := &control.GetShardRebalanceStatusRequest{Body: new(control.GetShardRebalanceStatusRequest_Body)}
+
+	signRequest(cmd, pk, req)
+
+	var resp *control.GetShardRebalanceStatusResponse
+	var err error
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.GetShardRebalanceStatus(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	return resp.GetBody()
+}
+
+func printRebalanceStatus(cmd *cobra.Command, st *control.GetShardRebalanceStatusResponse_Body) {
+	if st.GetStartedAt() == 0 {
+		cmd.Println("Shard rebalance has never been started.")
+		return
+	}
+
+	state := "finished"
+	if st.GetRunning() {
+		state = "running"
+	} else if st.GetError() != "" {
+		state = "failed: " + st.GetError()
+	}
+
+	rate := "unlimited"
+	if st.GetRate() > 0 {
+		rate = strconv.FormatUint(uint64(st.GetRate()), 10) + " objects/s"
+	}
+
+	cmd.Printf("Status: %s, started at %s, rate: %s\n", state,
+		time.Unix(st.GetStartedAt(), 0).Format(time.RFC3339), rate)
+	cmd.Printf("Shards: %d/%d, objects processed: %d, moved: %d, failed: %d\n",
+		st.GetShardsDone(), st.GetShardsTotal(), st.GetProcessed(), st.GetMoved(), st.GetFailed())
+}
+
+func stopRebalance(cmd *cobra.Command, _ []string) {
+	ctx, cancel := commonflags.GetCommandContext(cmd)
+	defer cancel()
+
+	pk := key.Get(cmd)
+
+	req := &control.StopShardRebalanceRequest{Body: new(control.StopShardRebalanceRequest_Body)}
+
+	signRequest(cmd, pk, req)
+
+	cli := getClient(ctx, cmd)
+
+	var resp *control.StopShardRebalanceResponse
+	var err error
+	err = cli.ExecRaw(func(client *rawclient.Client) error {
+		resp, err = control.StopShardRebalance(client, req)
+		return err
+	})
+	common.ExitOnErr(cmd, "rpc error: %w", err)
+
+	verifyResponse(cmd, resp.GetSignature(), resp.GetBody())
+
+	cmd.Println("Shard rebalance has been stopped.")
+}
+
+func initControlShardsRebalanceCmd() {
+	rebalanceCmd.AddCommand(rebalanceStartCmd)
+	rebalanceCmd.AddCommand(rebalanceStatusCmd)
+	rebalanceCmd.AddCommand(rebalanceStopCmd)
+
+	initControlFlags(rebalanceStartCmd)
+	initControlFlags(rebalanceStatusCmd)
+	initControlFlags(rebalanceStopCmd)
+
+	flags := rebalanceStartCmd.Flags()
+	flags.Uint32(rebalanceRateFlag, 0, "Maximum number of objects moved per second (default from the node config)")
+	flags.Bool(rebalanceAwaitFlag, false, "Wait for the rebalance to finish printing its progress")
+}
@@ -3,18 +3,25 @@ package object
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	internalclient "github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/client"
 	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/common"
 	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/commonflags"
 	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-cli/internal/key"
+	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
 	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
 	"github.com/epicchainlabs/epicchain-sdk-go/object"
 	oidSDK "github.com/epicchainlabs/epicchain-sdk-go/object/id"
 	"github.com/spf13/cobra"
 )
 
+const (
+	searchLimitFlag  = "limit"
+	searchCursorFlag = "cursor"
+)
+
 var (
 	searchFilters []string
 
@@ -42,6 +49,8 @@ func initObjectSearchCmd() {
 	flags.Bool("root", false, "Search for user objects")
 	flags.Bool("phy", false, "Search physically stored objects")
 	flags.String(commonflags.OIDFlag, "", "Search object by identifier")
+	flags.Uint32(searchLimitFlag, 0, "Maximum number of objects to return, objects are ordered by their IDs")
+	flags.String(searchCursorFlag, "", "Return objects following the given object ID, use the cursor printed by the previous limited search")
 }
 
 func searchObject(cmd *cobra.Command, _ []string) {
@@ -66,6 +75,22 @@ func searchObject(cmd *cobra.Command, _ []string) {
 	prm.SetContainerID(cnr)
 	prm.SetFilters(sf)
 
+	limit, _ := cmd.Flags().GetUint32(searchLimitFlag)
+	cursor, _ := cmd.Flags().GetString(searchCursorFlag)
+	if cursor != "" {
+		var id oidSDK.ID
+		common.ExitOnErr(cmd, "invalid cursor: %w", id.DecodeString(cursor))
+	}
+
+	xHeaders := parseXHeaders(cmd)
+	if limit > 0 {
+		xHeaders = append(xHeaders, objectcore.XHeaderSearchLimit, strconv.FormatUint(uint64(limit), 10))
+	}
+	if cursor != "" {
+		xHeaders = append(xHeaders, objectcore.XHeaderSearchCursor, cursor)
+	}
+	prm.SetXHeaders(xHeaders)
+
 	res, err := internalclient.SearchObjects(ctx, prm)
 	common.ExitOnErr(cmd, "rpc error: %w", err)
 
@@ -75,6 +100,10 @@ func searchObject(cmd *cobra.Command, _ []string) {
 	for i := range ids {
 		cmd.Println(ids[i].String())
 	}
+
+	if limit > 0 && len(ids) >= int(limit) {
+		cmd.Printf("Next page cursor: %s\n", ids[len(ids)-1])
+	}
 }
 
 var searchUnaryOpVocabulary = map[string]object.SearchMatchType{
@@ -50,11 +50,15 @@ func defaultConfiguration(cfg *viper.Viper) {
 	cfg.SetDefault("morph.dial_timeout", 15*time.Second)
 	cfg.SetDefault("morph.reconnections_number", 5)
 	cfg.SetDefault("morph.reconnections_delay", 5*time.Second)
+	cfg.SetDefault("morph.health_check_interval", 10*time.Second)
+	cfg.SetDefault("morph.switch_checks_number", 3)
 	cfg.SetDefault("morph.validators", []string{})
 
 	cfg.SetDefault("mainnet.dial_timeout", 15*time.Second)
 	cfg.SetDefault("mainnet.reconnections_number", 5)
 	cfg.SetDefault("mainnet.reconnections_delay", 5*time.Second)
+	cfg.SetDefault("mainnet.health_check_interval", 10*time.Second)
+	cfg.SetDefault("mainnet.switch_checks_number", 3)
 
 	cfg.SetDefault("wallet.path", "")     // inner ring node NEP-6 wallet
 	cfg.SetDefault("wallet.address", "")  // account address
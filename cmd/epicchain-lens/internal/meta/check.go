@@ -0,0 +1,150 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	common "github.com/epicchainlabs/epicchain-node/cmd/epicchain-lens/internal"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-lens/internal/storage"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"github.com/spf13/cobra"
+)
+
+const (
+	shardFlagName   = "shard"
+	problemFlagName = "problem"
+)
+
+var (
+	vConfig   string
+	vShardID  string
+	vProblems []string
+)
+
+var checkCMD = &cobra.Command{
+	Use:   "check",
+	Short: "Check consistency of the shard metabases",
+	Long: `Check that the metabases of the shards from the node config match the objects stored
+in the blobstor and the write-cache. Prints found problems as a JSON array. The node must be stopped.`,
+	Args: cobra.NoArgs,
+	Run:  checkFunc,
+}
+
+var repairCMD = &cobra.Command{
+	Use:   "repair",
+	Short: "Repair the shard metabases",
+	Long: `Check the shard metabases and fix problems of the given classes:
+  orphan_blob       objects without metabase records are put to the metabase or
+                    dropped from the blobstor if they have been removed
+  missing_payload   metabase records of the objects with no payload are deleted
+  wrong_storage_id  storage IDs are updated
+  container_volume  container sizes are recalculated
+  object_counters   object counters are recalculated
+Prints found problems as a JSON array. The node must be stopped.`,
+	Args: cobra.NoArgs,
+	Run:  repairFunc,
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{checkCMD, repairCMD} {
+		common.AddConfigFileFlag(cmd, &vConfig)
+		cmd.Flags().StringVar(&vShardID, shardFlagName, "", "ID of the shard to process in base58 encoding, all shards if not set")
+	}
+
+	repairCMD.Flags().StringSliceVar(&vProblems, problemFlagName, nil, "Classes of the problems to repair, all if not set")
+}
+
+func checkFunc(cmd *cobra.Command, _ []string) {
+	printIssues(cmd, checkShards(cmd, mode.ReadOnly, nil))
+}
+
+func repairFunc(cmd *cobra.Command, _ []string) {
+	repair := shard.Problems()
+	if len(vProblems) != 0 {
+		repair = make([]shard.Problem, 0, len(vProblems))
+		for i := range vProblems {
+			p, err := shard.ParseProblem(vProblems[i])
+			common.ExitOnErr(cmd, err)
+
+			repair = append(repair, p)
+		}
+	}
+
+	printIssues(cmd, checkShards(cmd, mode.ReadWrite, repair))
+}
+
+type shardIssue struct {
+	shardID string
+	shard.Issue
+}
+
+func checkShards(cmd *cobra.Command, m mode.Mode, repair []shard.Problem) []shardIssue {
+	var res []shardIssue
+	var found bool
+
+	for _, opts := range storage.ShardsOptions(cmd, vConfig) {
+		sh := shard.New(append(opts, shard.WithMode(m))...)
+		common.ExitOnErr(cmd, common.Errf("could not read shard ID: %w", sh.UpdateID()))
+
+		id := sh.ID().String()
+		if vShardID != "" && id != vShardID {
+			continue
+		}
+		found = true
+
+		common.ExitOnErr(cmd, common.Errf("could not open shard: %w", sh.Open()))
+		common.ExitOnErr(cmd, common.Errf("could not init shard: %w", sh.Init()))
+
+		var prm shard.CheckPrm
+		prm.SetRepair(repair...)
+
+		checkRes, err := sh.Check(prm)
+		_ = sh.Close()
+		if err != nil {
+			common.ExitOnErr(cmd, fmt.Errorf("could not check shard %s: %w", id, err))
+		}
+
+		for _, issue := range checkRes.Issues() {
+			res = append(res, shardIssue{shardID: id, Issue: issue})
+		}
+	}
+
+	if vShardID != "" && !found {
+		common.ExitOnErr(cmd, fmt.Errorf("shard %s not found in the config", vShardID))
+	}
+
+	return res
+}
+
+func printIssues(cmd *cobra.Command, issues []shardIssue) {
+	out := make([]map[string]any, 0, len(issues))
+	for _, i := range issues {
+		m := map[string]any{
+			"shard_id": i.shardID,
+			"problem":  i.Problem.String(),
+		}
+		if i.Container != (cid.ID{}) {
+			m["container_id"] = i.Container.EncodeToString()
+		}
+		if i.Object != (oid.ID{}) {
+			m["object_id"] = i.Object.EncodeToString()
+		}
+		if i.Stored != "" || i.Actual != "" {
+			m["stored"] = i.Stored
+			m["actual"] = i.Actual
+		}
+		m["repaired"] = i.Repaired
+		out = append(out, m)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	enc := json.NewEncoder(buf)
+	enc.SetIndent("", "  ")
+	common.ExitOnErr(cmd, common.Errf("could not encode problems to JSON: %w", enc.Encode(out)))
+
+	cmd.Print(buf.String())
+}
@@ -35,6 +35,8 @@ func init() {
 		listGarbageCMD,
 		writeObjectCMD,
 		getCMD,
+		checkCMD,
+		repairCMD,
 	)
 }
 
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	common "github.com/epicchainlabs/epicchain-node/cmd/epicchain-lens/internal"
+	blobstorcommon "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/compression"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"github.com/spf13/cobra"
+)
+
+var storageCodecsCMD = &cobra.Command{
+	Use:   "codecs",
+	Short: "Compression codecs statistics",
+	Long: `Walk over all blobstor sub-storages of the configured shards and report
+number and total size of the stored objects per compression codec.`,
+	Args: cobra.NoArgs,
+	Run:  codecsFunc,
+}
+
+func init() {
+	common.AddConfigFileFlag(storageCodecsCMD, &vConfig)
+}
+
+type codecStat struct {
+	objects uint64
+	bytes   uint64
+}
+
+func codecsFunc(cmd *cobra.Command, _ []string) {
+	for i, shCfg := range readShardConfigs(cmd, vConfig) {
+		cmd.Printf("Shard #%d:\n", i)
+
+		for j, ss := range subStorages(shCfg) {
+			configured := compression.CodecNone
+			if ss.Compression != nil {
+				configured = ss.Compression.CodecName()
+			} else if shCfg.Compress {
+				configured = shCfg.CompressionCodec
+				if configured == "" {
+					configured = compression.CodecZstd
+				}
+			}
+
+			cmd.Printf("  %s %s (configured codec: %s)\n", ss.Storage.Type(), shCfg.SubStorages[j].Path, configured)
+
+			stats := codecStats(cmd, ss.Storage)
+			for _, name := range compression.Codecs() {
+				st, ok := stats[name]
+				if !ok {
+					continue
+				}
+
+				cmd.Printf("    %s: %d objects, %d bytes\n", name, st.objects, st.bytes)
+			}
+		}
+	}
+}
+
+// codecStats collects codec statistics of the objects stored in the given
+// sub-storage.
+func codecStats(cmd *cobra.Command, st blobstorcommon.Storage) map[string]codecStat {
+	// decompression is never performed, but storages expect initialized config
+	var cc compression.Config
+	common.ExitOnErr(cmd, common.Errf("init compression config: %w", cc.Init()))
+	defer func() { _ = cc.Close() }()
+
+	st.SetCompressor(&cc)
+
+	common.ExitOnErr(cmd, common.Errf("open sub-storage: %w", st.Open(true)))
+	defer func() { _ = st.Close() }()
+
+	common.ExitOnErr(cmd, common.Errf("init sub-storage: %w", st.Init()))
+
+	stats := make(map[string]codecStat)
+
+	_, err := st.Iterate(blobstorcommon.IteratePrm{
+		Handler: func(el blobstorcommon.IterationElement) error {
+			name := compression.Detect(el.ObjectData)
+
+			s := stats[name]
+			s.objects++
+			s.bytes += uint64(len(el.ObjectData))
+			stats[name] = s
+
+			return nil
+		},
+		IgnoreErrors: true,
+		ErrorHandler: func(addr oid.Address, err error) error {
+			cmd.PrintErrf("failed to read object %s: %v\n", addr, err)
+			return nil
+		},
+		DontDecompress: true,
+	})
+	common.ExitOnErr(cmd, common.Errf("iterate over sub-storage: %w", err))
+
+	return stats
+}
@@ -8,10 +8,12 @@ import (
 	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config"
 	engineconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine"
 	shardconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard"
+	erasureconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/blobstor/erasure"
 	fstreeconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/blobstor/fstree"
 	peapodconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/blobstor/peapod"
 	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/storage"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/erasure"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/fstree"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/peapod"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/engine"
@@ -45,14 +47,10 @@ func init() {
 		storageGetObjCMD,
 		storageListObjsCMD,
 		storageStatusObjCMD,
+		storageCodecsCMD,
 	)
 }
 
-type shardOptsWithID struct {
-	configID string
-	shOpts   []shard.Option
-}
-
 type epochState struct {
 }
 
@@ -61,10 +59,92 @@ func (e epochState) CurrentEpoch() uint64 {
 }
 
 func openEngine(cmd *cobra.Command) *engine.StorageEngine {
-	appCfg := config.New(config.Prm{}, config.WithConfigFile(vConfig))
-
 	ls := engine.New()
 
+	for _, shOpts := range ShardsOptions(cmd, vConfig) {
+		_, err := ls.AddShard(append(shOpts, shard.WithMode(mode.ReadOnly))...)
+		common.ExitOnErr(cmd, err)
+	}
+
+	common.ExitOnErr(cmd, ls.Open())
+	common.ExitOnErr(cmd, ls.Init())
+
+	return ls
+}
+
+// ShardsOptions reads the node config file and returns the options of the
+// configured shards in the config order.
+func ShardsOptions(cmd *cobra.Command, configFile string) [][]shard.Option {
+	var res [][]shard.Option
+	for _, shCfg := range readShardConfigs(cmd, configFile) {
+		var writeCacheOpts []writecache.Option
+		if wcRead := shCfg.WritecacheCfg; wcRead.Enabled {
+			writeCacheOpts = append(writeCacheOpts,
+				writecache.WithPath(wcRead.Path),
+				writecache.WithMaxBatchSize(wcRead.MaxBatchSize),
+				writecache.WithMaxBatchDelay(wcRead.MaxBatchDelay),
+				writecache.WithMaxObjectSize(wcRead.MaxObjSize),
+				writecache.WithSmallObjectSize(wcRead.SmallObjectSize),
+				writecache.WithFlushWorkersCount(wcRead.FlushWorkerCount),
+				writecache.WithMaxCacheSize(wcRead.SizeLimit),
+				writecache.WithNoSync(wcRead.NoSync),
+			)
+		}
+
+		var piloramaOpts []pilorama.Option
+		if prRead := shCfg.PiloramaCfg; prRead.Enabled {
+			piloramaOpts = append(piloramaOpts,
+				pilorama.WithPath(prRead.Path),
+				pilorama.WithPerm(prRead.Perm),
+				pilorama.WithNoSync(prRead.NoSync),
+				pilorama.WithMaxBatchSize(prRead.MaxBatchSize),
+				pilorama.WithMaxBatchDelay(prRead.MaxBatchDelay),
+			)
+		}
+
+		res = append(res, []shard.Option{
+			shard.WithRefillMetabase(shCfg.RefillMetabase),
+			shard.WithMode(shCfg.Mode),
+			shard.WithBlobStorOptions(
+				blobstor.WithCompressObjects(shCfg.Compress),
+				blobstor.WithCompressionCodec(shCfg.CompressionCodec, shCfg.CompressionLevel),
+				blobstor.WithUncompressableContentTypes(shCfg.UncompressableContentType),
+				blobstor.WithStorages(subStorages(shCfg)),
+			),
+			shard.WithMetaBaseOptions(
+				meta.WithPath(shCfg.MetaCfg.Path),
+				meta.WithPermissions(shCfg.MetaCfg.Perm),
+				meta.WithMaxBatchSize(shCfg.MetaCfg.MaxBatchSize),
+				meta.WithMaxBatchDelay(shCfg.MetaCfg.MaxBatchDelay),
+				meta.WithChangeLog(shCfg.MetaCfg.ChangeLog),
+				meta.WithBoltDBOptions(&bbolt.Options{
+					Timeout: time.Second,
+				}),
+
+				meta.WithEpochState(epochState{}),
+			),
+			shard.WithPiloramaOptions(piloramaOpts...),
+			shard.WithWriteCache(shCfg.WritecacheCfg.Enabled),
+			shard.WithWriteCacheOptions(writeCacheOpts...),
+			shard.WithRemoverBatchSize(shCfg.GcCfg.RemoverBatchSize),
+			shard.WithGCRemoverSleepInterval(shCfg.GcCfg.RemoverSleepInterval),
+			shard.WithGCWorkerPoolInitializer(func(sz int) util.WorkerPool {
+				pool, err := ants.NewPool(sz)
+				common.ExitOnErr(cmd, err)
+
+				return pool
+			}),
+		})
+	}
+
+	return res
+}
+
+// readShardConfigs reads configurations of all shards from the node config
+// file.
+func readShardConfigs(cmd *cobra.Command, configFile string) []storage.ShardCfg {
+	appCfg := config.New(config.Prm{}, config.WithConfigFile(configFile))
+
 	var shards []storage.ShardCfg
 	err := engineconfig.IterateShards(appCfg, false, func(sc *shardconfig.Config) error {
 		var sh storage.ShardCfg
@@ -72,6 +152,8 @@ func openEngine(cmd *cobra.Command) *engine.StorageEngine {
 		sh.RefillMetabase = sc.RefillMetabase()
 		sh.Mode = sc.Mode()
 		sh.Compress = sc.Compress()
+		sh.CompressionCodec = sc.CompressionCodec()
+		sh.CompressionLevel = sc.CompressionLevel()
 		sh.UncompressableContentType = sc.UncompressableContentTypes()
 		sh.SmallSizeObjectLimit = sc.SmallSizeLimit()
 
@@ -118,6 +200,8 @@ func openEngine(cmd *cobra.Command) *engine.StorageEngine {
 			sCfg.Typ = storagesCfg[i].Type()
 			sCfg.Path = storagesCfg[i].Path()
 			sCfg.Perm = storagesCfg[i].Perm()
+			sCfg.CompressionCodec = storagesCfg[i].CompressionCodec()
+			sCfg.CompressionLevel = storagesCfg[i].CompressionLevel()
 
 			switch storagesCfg[i].Type() {
 			case fstree.Type:
@@ -127,6 +211,13 @@ func openEngine(cmd *cobra.Command) *engine.StorageEngine {
 			case peapod.Type:
 				peapodCfg := peapodconfig.From((*config.Config)(storagesCfg[i]))
 				sCfg.FlushInterval = peapodCfg.FlushInterval()
+			case erasure.Type:
+				ec := erasureconfig.From((*config.Config)(storagesCfg[i]))
+				sCfg.Parts = ec.Parts()
+				sCfg.Parity = ec.Parity()
+				sCfg.Depth = ec.Depth()
+				sCfg.NoSync = ec.NoSync()
+				sCfg.RepairInterval = ec.RepairInterval()
 			default:
 				return fmt.Errorf("can't initiate storage. invalid storage type: %s", storagesCfg[i].Type())
 			}
@@ -156,104 +247,51 @@ func openEngine(cmd *cobra.Command) *engine.StorageEngine {
 	})
 	common.ExitOnErr(cmd, err)
 
-	var shardsWithMeta []shardOptsWithID
-	for _, shCfg := range shards {
-		var writeCacheOpts []writecache.Option
-		if wcRead := shCfg.WritecacheCfg; wcRead.Enabled {
-			writeCacheOpts = append(writeCacheOpts,
-				writecache.WithPath(wcRead.Path),
-				writecache.WithMaxBatchSize(wcRead.MaxBatchSize),
-				writecache.WithMaxBatchDelay(wcRead.MaxBatchDelay),
-				writecache.WithMaxObjectSize(wcRead.MaxObjSize),
-				writecache.WithSmallObjectSize(wcRead.SmallObjectSize),
-				writecache.WithFlushWorkersCount(wcRead.FlushWorkerCount),
-				writecache.WithMaxCacheSize(wcRead.SizeLimit),
-				writecache.WithNoSync(wcRead.NoSync),
-			)
-		}
-
-		var piloramaOpts []pilorama.Option
-		if prRead := shCfg.PiloramaCfg; prRead.Enabled {
-			piloramaOpts = append(piloramaOpts,
-				pilorama.WithPath(prRead.Path),
-				pilorama.WithPerm(prRead.Perm),
-				pilorama.WithNoSync(prRead.NoSync),
-				pilorama.WithMaxBatchSize(prRead.MaxBatchSize),
-				pilorama.WithMaxBatchDelay(prRead.MaxBatchDelay),
-			)
-		}
-
-		var ss []blobstor.SubStorage
-		for _, sRead := range shCfg.SubStorages {
-			switch sRead.Typ {
-			case fstree.Type:
-				ss = append(ss, blobstor.SubStorage{
-					Storage: fstree.New(
-						fstree.WithPath(sRead.Path),
-						fstree.WithPerm(sRead.Perm),
-						fstree.WithDepth(sRead.Depth),
-						fstree.WithNoSync(sRead.NoSync)),
-					Policy: func(_ *objectSDK.Object, data []byte) bool {
-						return true
-					},
-				})
-			case peapod.Type:
-				ss = append(ss, blobstor.SubStorage{
-					Storage: peapod.New(sRead.Path, sRead.Perm, sRead.FlushInterval),
-					Policy: func(_ *objectSDK.Object, data []byte) bool {
-						return uint64(len(data)) < shCfg.SmallSizeObjectLimit
-					},
-				})
-			default:
-				// should never happen, that has already
-				// been handled: when the config was read
-			}
-		}
-
-		var sh shardOptsWithID
-		sh.configID = shCfg.ID()
-		sh.shOpts = []shard.Option{
-			shard.WithRefillMetabase(shCfg.RefillMetabase),
-			shard.WithMode(shCfg.Mode),
-			shard.WithBlobStorOptions(
-				blobstor.WithCompressObjects(shCfg.Compress),
-				blobstor.WithUncompressableContentTypes(shCfg.UncompressableContentType),
-				blobstor.WithStorages(ss),
-			),
-			shard.WithMetaBaseOptions(
-				meta.WithPath(shCfg.MetaCfg.Path),
-				meta.WithPermissions(shCfg.MetaCfg.Perm),
-				meta.WithMaxBatchSize(shCfg.MetaCfg.MaxBatchSize),
-				meta.WithMaxBatchDelay(shCfg.MetaCfg.MaxBatchDelay),
-				meta.WithBoltDBOptions(&bbolt.Options{
-					Timeout: time.Second,
-				}),
-
-				meta.WithEpochState(epochState{}),
-			),
-			shard.WithPiloramaOptions(piloramaOpts...),
-			shard.WithWriteCache(shCfg.WritecacheCfg.Enabled),
-			shard.WithWriteCacheOptions(writeCacheOpts...),
-			shard.WithRemoverBatchSize(shCfg.GcCfg.RemoverBatchSize),
-			shard.WithGCRemoverSleepInterval(shCfg.GcCfg.RemoverSleepInterval),
-			shard.WithGCWorkerPoolInitializer(func(sz int) util.WorkerPool {
-				pool, err := ants.NewPool(sz)
-				common.ExitOnErr(cmd, err)
+	return shards
+}
 
-				return pool
-			}),
+// subStorages constructs blobstor sub-storages described by the shard config.
+func subStorages(shCfg storage.ShardCfg) []blobstor.SubStorage {
+	var ss []blobstor.SubStorage
+	for _, sRead := range shCfg.SubStorages {
+		switch sRead.Typ {
+		case fstree.Type:
+			ss = append(ss, blobstor.SubStorage{
+				Storage: fstree.New(
+					fstree.WithPath(sRead.Path),
+					fstree.WithPerm(sRead.Perm),
+					fstree.WithDepth(sRead.Depth),
+					fstree.WithNoSync(sRead.NoSync)),
+				Policy: func(_ *objectSDK.Object, data []byte) bool {
+					return true
+				},
+				Compression: sRead.Compression(),
+			})
+		case peapod.Type:
+			ss = append(ss, blobstor.SubStorage{
+				Storage: peapod.New(sRead.Path, sRead.Perm, sRead.FlushInterval),
+				Policy: func(_ *objectSDK.Object, data []byte) bool {
+					return uint64(len(data)) < shCfg.SmallSizeObjectLimit
+				},
+				Compression: sRead.Compression(),
+			})
+		case erasure.Type:
+			ss = append(ss, blobstor.SubStorage{
+				Storage: erasure.New(sRead.Path, sRead.Parts, sRead.Parity,
+					erasure.WithPerm(sRead.Perm),
+					erasure.WithDepth(sRead.Depth),
+					erasure.WithNoSync(sRead.NoSync),
+					erasure.WithRepairInterval(sRead.RepairInterval)),
+				Policy: func(_ *objectSDK.Object, data []byte) bool {
+					return true
+				},
+				Compression: sRead.Compression(),
+			})
+		default:
+			// should never happen, that has already
+			// been handled: when the config was read
 		}
-
-		shardsWithMeta = append(shardsWithMeta, sh)
-	}
-
-	for _, optsWithMeta := range shardsWithMeta {
-		_, err := ls.AddShard(append(optsWithMeta.shOpts, shard.WithMode(mode.ReadOnly))...)
-		common.ExitOnErr(cmd, err)
 	}
 
-	common.ExitOnErr(cmd, ls.Open())
-	common.ExitOnErr(cmd, ls.Init())
-
-	return ls
+	return ss
 }
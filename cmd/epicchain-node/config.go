@@ -22,6 +22,7 @@ import (
 	contractsconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/contracts"
 	engineconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine"
 	shardconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard"
+	erasureconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/blobstor/erasure"
 	fstreeconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/blobstor/fstree"
 	peapodconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/blobstor/peapod"
 	loggerconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/logger"
@@ -35,9 +36,11 @@ import (
 	"github.com/epicchainlabs/epicchain-node/misc"
 	"github.com/epicchainlabs/epicchain-node/pkg/core/container"
 	netmapCore "github.com/epicchainlabs/epicchain-node/pkg/core/netmap"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/erasure"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/fstree"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/peapod"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/engine"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/writecache"
 	"github.com/epicchainlabs/epicchain-node/pkg/metrics"
 	"github.com/epicchainlabs/epicchain-node/pkg/morph/client"
 	cntClient "github.com/epicchainlabs/epicchain-node/pkg/morph/client/container"
@@ -48,6 +51,8 @@ import (
 	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
 	controlSvc "github.com/epicchainlabs/epicchain-node/pkg/services/control/server"
 	getsvc "github.com/epicchainlabs/epicchain-node/pkg/services/object/get"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/ratelimit"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object_manager/tombstone"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/policer"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/replicator"
 	trustcontroller "github.com/epicchainlabs/epicchain-node/pkg/services/reputation/local/controller"
@@ -93,6 +98,7 @@ type applicationConfiguration struct {
 	engine struct {
 		errorThreshold uint32
 		shardPoolSize  uint32
+		rebalanceRate  uint32
 		shards         []storage.ShardCfg
 	}
 
@@ -103,12 +109,19 @@ type applicationConfiguration struct {
 		objectBatchSize     uint32
 	}
 
+	object struct {
+		putPoolSizeRemote int
+		rateLimits        ratelimit.Limits
+	}
+
 	morph struct {
 		endpoints                 []string
 		dialTimeout               time.Duration
 		cacheTTL                  time.Duration
 		reconnectionRetriesNumber int
 		reconnectionRetriesDelay  time.Duration
+		healthCheckInterval       time.Duration
+		switchChecksNumber        int
 	}
 
 	contracts struct {
@@ -151,10 +164,16 @@ func (a *applicationConfiguration) readConfig(c *config.Config) error {
 	a.policer.replicationCooldown = policerconfig.ReplicationCooldown(c)
 	a.policer.objectBatchSize = policerconfig.ObjectBatchSize(c)
 
+	// Object
+
+	a.object.putPoolSizeRemote = objectconfig.Put(c).PoolSizeRemote()
+	a.object.rateLimits = objectconfig.RateLimits(c)
+
 	// Storage Engine
 
 	a.engine.errorThreshold = engineconfig.ShardErrorThreshold(c)
 	a.engine.shardPoolSize = engineconfig.ShardPoolSize(c)
+	a.engine.rebalanceRate = engineconfig.RebalanceRate(c)
 
 	// Morph
 
@@ -163,6 +182,8 @@ func (a *applicationConfiguration) readConfig(c *config.Config) error {
 	a.morph.cacheTTL = morphconfig.CacheTTL(c)
 	a.morph.reconnectionRetriesNumber = morphconfig.ReconnectionRetriesNumber(c)
 	a.morph.reconnectionRetriesDelay = morphconfig.ReconnectionRetriesDelay(c)
+	a.morph.healthCheckInterval = morphconfig.HealthCheckInterval(c)
+	a.morph.switchChecksNumber = morphconfig.SwitchChecksNumber(c)
 
 	// Contracts
 
@@ -172,94 +193,136 @@ func (a *applicationConfiguration) readConfig(c *config.Config) error {
 	a.contracts.proxy = contractsconfig.Proxy(c)
 	a.contracts.reputation = contractsconfig.Reputation(c)
 
+	treeEnabled := config.BoolSafe(c.Sub("tree"), "enabled")
+
 	return engineconfig.IterateShards(c, false, func(sc *shardconfig.Config) error {
-		var sh storage.ShardCfg
-
-		sh.RefillMetabase = sc.RefillMetabase()
-		sh.Mode = sc.Mode()
-		sh.Compress = sc.Compress()
-		sh.UncompressableContentType = sc.UncompressableContentTypes()
-		sh.SmallSizeObjectLimit = sc.SmallSizeLimit()
-
-		// write-cache
-
-		writeCacheCfg := sc.WriteCache()
-		if writeCacheCfg.Enabled() {
-			wc := &sh.WritecacheCfg
-
-			wc.Enabled = true
-			wc.Path = writeCacheCfg.Path()
-			wc.MaxBatchSize = writeCacheCfg.BoltDB().MaxBatchSize()
-			wc.MaxBatchDelay = writeCacheCfg.BoltDB().MaxBatchDelay()
-			wc.MaxObjSize = writeCacheCfg.MaxObjectSize()
-			wc.SmallObjectSize = writeCacheCfg.SmallObjectSize()
-			wc.FlushWorkerCount = writeCacheCfg.WorkersNumber()
-			wc.SizeLimit = writeCacheCfg.SizeLimit()
-			wc.NoSync = writeCacheCfg.NoSync()
+		sh, err := readShardConfig(sc, treeEnabled)
+		if err != nil {
+			return err
 		}
 
-		// blobstor with substorages
+		a.engine.shards = append(a.engine.shards, sh)
 
-		blobStorCfg := sc.BlobStor()
-		storagesCfg := blobStorCfg.Storages()
-		metabaseCfg := sc.Metabase()
-		gcCfg := sc.GC()
+		return nil
+	})
+}
 
-		if config.BoolSafe(c.Sub("tree"), "enabled") {
-			piloramaCfg := sc.Pilorama()
-			pr := &sh.PiloramaCfg
+// readShardConfig reads the shard configuration. Pilorama is configured only
+// if the tree service is enabled.
+func readShardConfig(sc *shardconfig.Config, treeEnabled bool) (storage.ShardCfg, error) {
+	var sh storage.ShardCfg
+
+	sh.RefillMetabase = sc.RefillMetabase()
+	sh.Mode = sc.Mode()
+	sh.Compress = sc.Compress()
+	sh.CompressionCodec = sc.CompressionCodec()
+	sh.CompressionLevel = sc.CompressionLevel()
+	sh.UncompressableContentType = sc.UncompressableContentTypes()
+	sh.SmallSizeObjectLimit = sc.SmallSizeLimit()
+
+	// write-cache
+
+	writeCacheCfg := sc.WriteCache()
+	if writeCacheCfg.Enabled() {
+		wc := &sh.WritecacheCfg
+
+		wc.Enabled = true
+		wc.Path = writeCacheCfg.Path()
+		wc.MaxBatchSize = writeCacheCfg.BoltDB().MaxBatchSize()
+		wc.MaxBatchDelay = writeCacheCfg.BoltDB().MaxBatchDelay()
+		wc.MaxObjSize = writeCacheCfg.MaxObjectSize()
+		wc.SmallObjectSize = writeCacheCfg.SmallObjectSize()
+		wc.FlushWorkerCount = writeCacheCfg.WorkersNumber()
+		wc.SizeLimit = writeCacheCfg.SizeLimit()
+		wc.NoSync = writeCacheCfg.NoSync()
+
+		flushCfg := writeCacheCfg.FlushPolicy()
+		wc.FlushPolicy = writecache.FlushPolicy{
+			MaxAge:    flushCfg.MaxAge(),
+			FillRatio: flushCfg.FillRatio(),
+			Windows:   flushCfg.Windows(),
+			RateLimit: flushCfg.RateLimit(),
+		}
+	}
 
-			pr.Enabled = true
-			pr.Path = piloramaCfg.Path()
-			pr.Perm = piloramaCfg.Perm()
-			pr.NoSync = piloramaCfg.NoSync()
-			pr.MaxBatchSize = piloramaCfg.MaxBatchSize()
-			pr.MaxBatchDelay = piloramaCfg.MaxBatchDelay()
+	// blobstor with substorages
+
+	blobStorCfg := sc.BlobStor()
+	storagesCfg := blobStorCfg.Storages()
+	metabaseCfg := sc.Metabase()
+	gcCfg := sc.GC()
+
+	if treeEnabled {
+		piloramaCfg := sc.Pilorama()
+		pr := &sh.PiloramaCfg
+
+		pr.Enabled = true
+		pr.Path = piloramaCfg.Path()
+		pr.Perm = piloramaCfg.Perm()
+		pr.NoSync = piloramaCfg.NoSync()
+		pr.MaxBatchSize = piloramaCfg.MaxBatchSize()
+		pr.MaxBatchDelay = piloramaCfg.MaxBatchDelay()
+	}
+
+	ss := make([]storage.SubStorageCfg, 0, len(storagesCfg))
+	for i := range storagesCfg {
+		var sCfg storage.SubStorageCfg
+
+		sCfg.Typ = storagesCfg[i].Type()
+		sCfg.Path = storagesCfg[i].Path()
+		sCfg.Perm = storagesCfg[i].Perm()
+		sCfg.CompressionCodec = storagesCfg[i].CompressionCodec()
+		sCfg.CompressionLevel = storagesCfg[i].CompressionLevel()
+
+		switch storagesCfg[i].Type() {
+		case fstree.Type:
+			sub := fstreeconfig.From((*config.Config)(storagesCfg[i]))
+			sCfg.Depth = sub.Depth()
+			sCfg.NoSync = sub.NoSync()
+		case peapod.Type:
+			peapodCfg := peapodconfig.From((*config.Config)(storagesCfg[i]))
+			sCfg.FlushInterval = peapodCfg.FlushInterval()
+		case erasure.Type:
+			ec := erasureconfig.From((*config.Config)(storagesCfg[i]))
+			sCfg.Parts = ec.Parts()
+			sCfg.Parity = ec.Parity()
+			sCfg.Depth = ec.Depth()
+			sCfg.NoSync = ec.NoSync()
+			sCfg.RepairInterval = ec.RepairInterval()
+		default:
+			return sh, fmt.Errorf("invalid storage type: %s", storagesCfg[i].Type())
 		}
 
-		ss := make([]storage.SubStorageCfg, 0, len(storagesCfg))
-		for i := range storagesCfg {
-			var sCfg storage.SubStorageCfg
-
-			sCfg.Typ = storagesCfg[i].Type()
-			sCfg.Path = storagesCfg[i].Path()
-			sCfg.Perm = storagesCfg[i].Perm()
-
-			switch storagesCfg[i].Type() {
-			case fstree.Type:
-				sub := fstreeconfig.From((*config.Config)(storagesCfg[i]))
-				sCfg.Depth = sub.Depth()
-				sCfg.NoSync = sub.NoSync()
-			case peapod.Type:
-				peapodCfg := peapodconfig.From((*config.Config)(storagesCfg[i]))
-				sCfg.FlushInterval = peapodCfg.FlushInterval()
-			default:
-				return fmt.Errorf("invalid storage type: %s", storagesCfg[i].Type())
-			}
+		ss = append(ss, sCfg)
+	}
 
-			ss = append(ss, sCfg)
-		}
+	sh.SubStorages = ss
 
-		sh.SubStorages = ss
+	// meta
 
-		// meta
+	m := &sh.MetaCfg
 
-		m := &sh.MetaCfg
+	m.Path = metabaseCfg.Path()
+	m.Perm = metabaseCfg.BoltDB().Perm()
+	m.MaxBatchDelay = metabaseCfg.BoltDB().MaxBatchDelay()
+	m.MaxBatchSize = metabaseCfg.BoltDB().MaxBatchSize()
+	m.IndexedAttributes = metabaseCfg.IndexedAttributes()
+	m.ChangeLog = metabaseCfg.ChangeLog()
 
-		m.Path = metabaseCfg.Path()
-		m.Perm = metabaseCfg.BoltDB().Perm()
-		m.MaxBatchDelay = metabaseCfg.BoltDB().MaxBatchDelay()
-		m.MaxBatchSize = metabaseCfg.BoltDB().MaxBatchSize()
+	// GC
 
-		// GC
+	sh.GcCfg.RemoverBatchSize = gcCfg.RemoverBatchSize()
+	sh.GcCfg.RemoverSleepInterval = gcCfg.RemoverSleepInterval()
 
-		sh.GcCfg.RemoverBatchSize = gcCfg.RemoverBatchSize()
-		sh.GcCfg.RemoverSleepInterval = gcCfg.RemoverSleepInterval()
+	// I/O limits
 
-		a.engine.shards = append(a.engine.shards, sh)
+	sh.IOLimits = sc.Throttle().Limits()
 
-		return nil
-	})
+	// integrity scrubbing
+
+	sh.Scrub = sc.Scrub().Scrub()
+
+	return sh, nil
 }
 
 // internals contains application-specific internals that are created
@@ -399,6 +462,11 @@ type cfg struct {
 	cfgControlService cfgControlService
 	cfgReputation     cfgReputation
 	cfgObject         cfgObject
+
+	reload reloadState
+
+	// serializes changes of the storage engine shard set
+	shardsMtx sync.Mutex
 }
 
 // ReadCurrentNetMap reads network map which has been cached at the
@@ -471,11 +539,17 @@ type cfgObject struct {
 
 	cfgLocalStorage cfgLocalStorage
 
+	healthyCopies *healthyCopySource
+
 	tombstoneLifetime uint64
+
+	rateLimiter *ratelimit.Limiter
 }
 
 type cfgLocalStorage struct {
 	localStorage *engine.StorageEngine
+
+	tombstoneSource *tombstone.ExpirationChecker
 }
 
 type cfgObjectRoutines struct {
@@ -577,6 +651,10 @@ func initCfg(appCfg *config.Config) *cfg {
 		Buffers:          &buffers,
 		Logger:           c.internals.log,
 	}
+	if metricsconfig.Enabled(appCfg) {
+		c.metricsCollector = metrics.NewNodeMetrics(misc.Version)
+	}
+
 	basicSharedConfig := initBasics(c, key, persistate)
 	c.shared = shared{
 		basics:         basicSharedConfig,
@@ -614,8 +692,7 @@ func initCfg(appCfg *config.Config) *cfg {
 
 	c.ownerIDFromKey = user.ResolveFromECDSAPublicKey(key.PrivateKey.PublicKey)
 
-	if metricsconfig.Enabled(c.cfgReader) {
-		c.metricsCollector = metrics.NewNodeMetrics(misc.Version)
+	if c.metricsCollector != nil {
 		c.basics.networkState.metrics = c.metricsCollector
 	}
 
@@ -624,6 +701,11 @@ func initCfg(appCfg *config.Config) *cfg {
 	c.onShutdown(c.putClientCache.CloseAll) // clean up connections
 	c.onShutdown(func() { _ = c.persistate.Close() })
 
+	err = c.applyShardOverrides()
+	if err != nil {
+		c.log.Error("could not apply shard overrides", zap.Error(err))
+	}
+
 	return c
 }
 
@@ -638,7 +720,7 @@ func initBasics(c *cfg, key *keys.PrivateKey, stateStorage *state.PersistentStor
 		c.log.Warn("can't get last processed side chain block number", zap.String("error", err.Error()))
 	}
 
-	cli, err := client.New(key,
+	morphOpts := []client.Option{
 		client.WithDialTimeout(c.applicationConfiguration.morph.dialTimeout),
 		client.WithLogger(c.log),
 		client.WithAutoSidechainScope(),
@@ -655,7 +737,14 @@ func initBasics(c *cfg, key *keys.PrivateKey, stateStorage *state.PersistentStor
 			c.internalErr <- errors.New("morph connection has been lost")
 		}),
 		client.WithMinRequiredBlockHeight(fromSideChainBlock),
-	)
+		client.WithHealthCheckInterval(c.applicationConfiguration.morph.healthCheckInterval),
+		client.WithSwitchChecksNumber(c.applicationConfiguration.morph.switchChecksNumber),
+	}
+	if c.metricsCollector != nil {
+		morphOpts = append(morphOpts, client.WithMetrics(c.metricsCollector))
+	}
+
+	cli, err := client.New(key, morphOpts...)
 	if err != nil {
 		c.log.Info("failed to create neo RPC client",
 			zap.Any("endpoints", addresses),
@@ -813,7 +902,7 @@ func (c *cfg) needBootstrap() bool {
 // It is calculated as size/capacity ratio of "remote object put" worker.
 // Returns float value between 0.0 and 1.0.
 func (c *cfg) ObjectServiceLoad() float64 {
-	return float64(c.cfgObject.pool.putRemote.Running()) / float64(c.cfgObject.pool.putRemoteCapacity)
+	return float64(c.cfgObject.pool.putRemote.Running()) / float64(c.cfgObject.pool.putRemote.Cap())
 }
 
 func (c *cfg) configWatcher(ctx context.Context) {
@@ -825,38 +914,7 @@ func (c *cfg) configWatcher(ctx context.Context) {
 		case <-ch:
 			c.log.Info("SIGHUP has been received, rereading configuration...")
 
-			err := c.readConfig(c.cfgReader)
-			if err != nil {
-				c.log.Error("configuration reading", zap.Error(err))
-				continue
-			}
-
-			// Logger
-
-			err = c.internals.logLevel.UnmarshalText([]byte(c.logger.level))
-			if err != nil {
-				c.log.Error("invalid logger level configuration", zap.Error(err))
-				continue
-			}
-
-			// Policer
-
-			c.shared.policer.Reload(c.policerOpts()...)
-
-			// Storage Engine
-
-			var rcfg engine.ReConfiguration
-			for _, optsWithID := range c.shardOpts() {
-				rcfg.AddShard(optsWithID.configID, optsWithID.shOpts)
-			}
-
-			err = c.cfgObject.cfgLocalStorage.localStorage.Reload(rcfg)
-			if err != nil {
-				c.log.Error("storage engine configuration update", zap.Error(err))
-				continue
-			}
-
-			c.log.Info("configuration has been reloaded successfully")
+			c.reloadConfig()
 		case <-ctx.Done():
 			return
 		}
@@ -77,3 +77,19 @@ func TestConfig_SetDefault(t *testing.T) {
 		require.Equal(t, "y", config.String(s, "overridden"))
 	})
 }
+
+func TestParse(t *testing.T) {
+	c, err := config.Parse([]byte(`
+section:
+  value: 1
+`))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, c.Sub("section").Value("value"))
+
+	c, err = config.Parse([]byte(`{"section": {"value": "str"}}`))
+	require.NoError(t, err)
+	require.Equal(t, "str", c.Sub("section").Value("value"))
+
+	_, err = config.Parse([]byte("section: [value"))
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
@@ -60,6 +61,23 @@ func New(_ Prm, opts ...Option) *Config {
 	}
 }
 
+// Parse creates a new Config instance from the YAML (or JSON) document.
+// Unlike New, environment variables are not read.
+func Parse(data []byte) (*Config, error) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+
+	err := v.ReadConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	return &Config{
+		v:    v,
+		opts: *defaultOpts(),
+	}, nil
+}
+
 // Reload reads configuration path if it was provided to New.
 func (x *Config) Reload() error {
 	if x.opts.path != "" {
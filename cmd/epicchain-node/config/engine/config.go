@@ -15,6 +15,10 @@ const (
 	// ShardPoolSizeDefault is a default value of routine pool size per-shard to
 	// process object PUT operations in a storage engine.
 	ShardPoolSizeDefault = 20
+
+	// RebalanceRateDefault is a default maximum number of objects moved per
+	// second by the shard rebalance.
+	RebalanceRateDefault = 100
 )
 
 // ErrNoShardConfigured is returned when at least 1 shard is required but none are found.
@@ -83,3 +87,15 @@ func ShardPoolSize(c *config.Config) uint32 {
 func ShardErrorThreshold(c *config.Config) uint32 {
 	return config.Uint32Safe(c.Sub(subsection), "shard_ro_error_threshold")
 }
+
+// RebalanceRate returns the value of "rebalance_rate" config parameter from "storage" section.
+//
+// Returns RebalanceRateDefault if the value is not a positive number.
+func RebalanceRate(c *config.Config) uint32 {
+	v := config.Uint32Safe(c.Sub(subsection), "rebalance_rate")
+	if v > 0 {
+		return v
+	}
+
+	return RebalanceRateDefault
+}
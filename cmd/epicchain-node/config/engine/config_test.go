@@ -8,12 +8,17 @@ import (
 	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config"
 	engineconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine"
 	shardconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard"
+	erasureconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/blobstor/erasure"
 	fstreeconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/blobstor/fstree"
 	peapodconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/blobstor/peapod"
 	piloramaconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/pilorama"
 	configtest "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/test"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/erasure"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/peapod"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/writecache"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,6 +42,7 @@ func TestEngineSection(t *testing.T) {
 
 		require.EqualValues(t, 0, engineconfig.ShardErrorThreshold(empty))
 		require.EqualValues(t, engineconfig.ShardPoolSizeDefault, engineconfig.ShardPoolSize(empty))
+		require.EqualValues(t, engineconfig.RebalanceRateDefault, engineconfig.RebalanceRate(empty))
 		require.EqualValues(t, mode.ReadWrite, shardconfig.From(empty).Mode())
 	})
 
@@ -47,6 +53,7 @@ func TestEngineSection(t *testing.T) {
 
 		require.EqualValues(t, 100, engineconfig.ShardErrorThreshold(c))
 		require.EqualValues(t, 15, engineconfig.ShardPoolSize(c))
+		require.EqualValues(t, 50, engineconfig.RebalanceRate(c))
 
 		err := engineconfig.IterateShards(c, true, func(sc *shardconfig.Config) error {
 			defer func() {
@@ -77,12 +84,25 @@ func TestEngineSection(t *testing.T) {
 				require.EqualValues(t, 30, wc.WorkersNumber())
 				require.EqualValues(t, 3221225472, wc.SizeLimit())
 
+				fp := wc.FlushPolicy()
+				require.Equal(t, time.Hour, fp.MaxAge())
+				require.Equal(t, 0.75, fp.FillRatio())
+				require.Equal(t, []writecache.FlushWindow{
+					{Start: time.Hour, End: 5 * time.Hour},
+					{Start: 22*time.Hour + 30*time.Minute, End: 23*time.Hour + 30*time.Minute},
+				}, fp.Windows())
+				require.EqualValues(t, 32<<20, fp.RateLimit())
+
 				require.Equal(t, "tmp/0/meta", meta.Path())
 				require.Equal(t, fs.FileMode(0644), meta.BoltDB().Perm())
 				require.Equal(t, 100, meta.BoltDB().MaxBatchSize())
 				require.Equal(t, 10*time.Millisecond, meta.BoltDB().MaxBatchDelay())
+				require.Equal(t, []string{"Timestamp", "FilePath"}, meta.IndexedAttributes())
+				require.True(t, meta.ChangeLog())
 
 				require.Equal(t, true, sc.Compress())
+				require.Equal(t, "zstd", sc.CompressionCodec())
+				require.Equal(t, 9, sc.CompressionLevel())
 				require.Equal(t, []string{"audio/*", "video/*"}, sc.UncompressableContentTypes())
 				require.EqualValues(t, 102400, sc.SmallSizeLimit())
 
@@ -92,6 +112,8 @@ func TestEngineSection(t *testing.T) {
 				require.EqualValues(t, 0644, ss[0].Perm())
 				require.EqualValues(t, peapod.Type, ss[0].Type())
 				require.EqualValues(t, 10*time.Millisecond, ppd.FlushInterval())
+				require.Equal(t, "lz4", ss[0].CompressionCodec())
+				require.Equal(t, 0, ss[0].CompressionLevel())
 
 				require.Equal(t, "tmp/0/blob", ss[1].Path())
 				require.EqualValues(t, 0644, ss[1].Perm())
@@ -103,6 +125,19 @@ func TestEngineSection(t *testing.T) {
 				require.EqualValues(t, 150, gc.RemoverBatchSize())
 				require.Equal(t, 2*time.Minute, gc.RemoverSleepInterval())
 
+				require.Equal(t, throttle.Limits{
+					ReadIOPS:        1000,
+					WriteIOPS:       500,
+					ReadBandwidth:   200 << 20,
+					WriteBandwidth:  100 << 20,
+					BackgroundShare: 0.3,
+				}, sc.Throttle().Limits())
+
+				require.Equal(t, shard.ScrubConfig{
+					Rate:     10 << 20,
+					Interval: 168 * time.Hour,
+				}, sc.Scrub().Scrub())
+
 				require.Equal(t, false, sc.RefillMetabase())
 				require.Equal(t, mode.ReadOnly, sc.Mode())
 			case 1:
@@ -120,13 +155,21 @@ func TestEngineSection(t *testing.T) {
 				require.EqualValues(t, 134217728, wc.MaxObjectSize())
 				require.EqualValues(t, 30, wc.WorkersNumber())
 				require.EqualValues(t, 4294967296, wc.SizeLimit())
+				require.Zero(t, wc.FlushPolicy().MaxAge())
+				require.Zero(t, wc.FlushPolicy().FillRatio())
+				require.Empty(t, wc.FlushPolicy().Windows())
+				require.Zero(t, wc.FlushPolicy().RateLimit())
 
 				require.Equal(t, "tmp/1/meta", meta.Path())
 				require.Equal(t, fs.FileMode(0644), meta.BoltDB().Perm())
 				require.Equal(t, 200, meta.BoltDB().MaxBatchSize())
 				require.Equal(t, 20*time.Millisecond, meta.BoltDB().MaxBatchDelay())
+				require.Empty(t, meta.IndexedAttributes())
+				require.False(t, meta.ChangeLog())
 
 				require.Equal(t, false, sc.Compress())
+				require.Equal(t, "", sc.CompressionCodec())
+				require.Equal(t, 0, sc.CompressionLevel())
 				require.Equal(t, []string(nil), sc.UncompressableContentTypes())
 				require.EqualValues(t, 102400, sc.SmallSizeLimit())
 
@@ -139,6 +182,7 @@ func TestEngineSection(t *testing.T) {
 
 				require.Equal(t, "tmp/1/blob", ss[1].Path())
 				require.EqualValues(t, 0644, ss[1].Perm())
+				require.Equal(t, "", ss[1].CompressionCodec())
 
 				fst := fstreeconfig.From((*config.Config)(ss[1]))
 				require.EqualValues(t, 5, fst.Depth())
@@ -147,13 +191,39 @@ func TestEngineSection(t *testing.T) {
 				require.EqualValues(t, 200, gc.RemoverBatchSize())
 				require.Equal(t, 5*time.Minute, gc.RemoverSleepInterval())
 
+				require.Equal(t, throttle.Limits{BackgroundShare: throttle.DefaultBackgroundShare}, sc.Throttle().Limits())
+
+				require.Equal(t, shard.ScrubConfig{Interval: shard.DefaultScrubInterval}, sc.Scrub().Scrub())
+
+				require.Equal(t, true, sc.RefillMetabase())
+				require.Equal(t, mode.ReadWrite, sc.Mode())
+			case 2:
+				require.Equal(t, "tmp/2/pilorama.db", pl.Path())
+				require.Equal(t, false, wc.Enabled())
+				require.Equal(t, "tmp/2/meta", meta.Path())
+
+				require.Equal(t, 2, len(ss))
+				require.EqualValues(t, peapod.Type, ss[0].Type())
+				require.Equal(t, "tmp/2/peapod.db", ss[0].Path())
+
+				require.EqualValues(t, erasure.Type, ss[1].Type())
+				require.Equal(t, "tmp/2/blob", ss[1].Path())
+				require.EqualValues(t, 0644, ss[1].Perm())
+
+				ec := erasureconfig.From((*config.Config)(ss[1]))
+				require.Equal(t, []string{"tmp/2/blob/0", "tmp/2/blob/1", "tmp/2/blob/2"}, ec.Parts())
+				require.Equal(t, 1, ec.Parity())
+				require.EqualValues(t, 3, ec.Depth())
+				require.Equal(t, false, ec.NoSync())
+				require.Equal(t, 12*time.Hour, ec.RepairInterval())
+
 				require.Equal(t, true, sc.RefillMetabase())
 				require.Equal(t, mode.ReadWrite, sc.Mode())
 			}
 			return nil
 		})
 		require.NoError(t, err)
-		require.Equal(t, 2, num)
+		require.Equal(t, 3, num)
 	}
 
 	configtest.ForEachFileType(path, fileConfigTest)
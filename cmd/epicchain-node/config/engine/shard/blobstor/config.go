@@ -5,6 +5,7 @@ import (
 
 	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config"
 	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/blobstor/storage"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/erasure"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/fstree"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/peapod"
 )
@@ -28,7 +29,7 @@ func (x *Config) Storages() []*storage.Config {
 		switch typ {
 		case "":
 			return ss
-		case fstree.Type, peapod.Type:
+		case fstree.Type, peapod.Type, erasure.Type:
 			sub := storage.From((*config.Config)(x).Sub(strconv.Itoa(i)))
 			ss = append(ss, sub)
 		default:
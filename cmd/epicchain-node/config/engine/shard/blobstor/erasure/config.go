@@ -0,0 +1,95 @@
+package erasureconfig
+
+import (
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/erasure"
+)
+
+// Config is a wrapper over the config section
+// which provides access to erasure-coded storage configurations.
+type Config config.Config
+
+// Various erasure-coded storage config defaults.
+const (
+	// ParityDefault is a default number of parity parts.
+	ParityDefault = 1
+
+	// RepairIntervalDefault is a default time interval between background
+	// repair passes.
+	RepairIntervalDefault = 24 * time.Hour
+)
+
+// From wraps config section into Config.
+func From(c *config.Config) *Config {
+	return (*Config)(c)
+}
+
+// Parts returns the value of "parts" config parameter.
+//
+// Panics if the value is missing or is not a list of strings.
+func (x *Config) Parts() []string {
+	p := config.StringSlice(
+		(*config.Config)(x),
+		"parts",
+	)
+
+	if len(p) == 0 {
+		panic("erasure parts not set")
+	}
+
+	return p
+}
+
+// Parity returns the value of "parity" config parameter.
+//
+// Returns ParityDefault if the value is not a positive number.
+func (x *Config) Parity() int {
+	p := config.IntSafe(
+		(*config.Config)(x),
+		"parity",
+	)
+
+	if p > 0 {
+		return int(p)
+	}
+
+	return ParityDefault
+}
+
+// Depth returns the value of "depth" config parameter.
+//
+// Returns erasure.DefaultDepth if the value is missing or is out of
+// [1:erasure.MaxDepth] range.
+func (x *Config) Depth() uint64 {
+	d := config.UintSafe(
+		(*config.Config)(x),
+		"depth",
+	)
+
+	if d >= 1 && d <= erasure.MaxDepth {
+		return d
+	}
+
+	return erasure.DefaultDepth
+}
+
+// NoSync returns the value of "no_sync" config parameter.
+//
+// Returns false if the value is not a boolean or is missing.
+func (x *Config) NoSync() bool {
+	return config.BoolSafe((*config.Config)(x), "no_sync")
+}
+
+// RepairInterval returns the value of "repair_interval" config parameter.
+//
+// Returns RepairIntervalDefault if the value is missing, zero or negative
+// value disables background repair.
+func (x *Config) RepairInterval() time.Duration {
+	if config.StringSafe((*config.Config)(x), "repair_interval") == "" {
+		return RepairIntervalDefault
+	}
+
+	return config.DurationSafe((*config.Config)(x), "repair_interval")
+}
@@ -0,0 +1,22 @@
+package erasureconfig_test
+
+import (
+	"testing"
+
+	erasureconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/blobstor/erasure"
+	configtest "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/test"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/erasure"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErasureSection(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		c := erasureconfig.From(configtest.EmptyConfig())
+
+		require.Panics(t, func() { c.Parts() })
+		require.Equal(t, erasureconfig.ParityDefault, c.Parity())
+		require.EqualValues(t, erasure.DefaultDepth, c.Depth())
+		require.False(t, c.NoSync())
+		require.Equal(t, erasureconfig.RepairIntervalDefault, c.RepairInterval())
+	})
+}
@@ -53,3 +53,24 @@ func (x *Config) Perm() fs.FileMode {
 
 	return fs.FileMode(p)
 }
+
+// CompressionCodec returns the value of "compression_codec" config parameter.
+//
+// Returns empty string if the value is missing, shard-wide compression
+// settings are used in this case.
+func (x *Config) CompressionCodec() string {
+	return config.StringSafe(
+		(*config.Config)(x),
+		"compression_codec",
+	)
+}
+
+// CompressionLevel returns the value of "compression_level" config parameter.
+//
+// Returns 0 (codec's default level) if the value is missing or is invalid.
+func (x *Config) CompressionLevel() int {
+	return int(config.IntSafe(
+		(*config.Config)(x),
+		"compression_level",
+	))
+}
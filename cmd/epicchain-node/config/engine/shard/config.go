@@ -8,6 +8,8 @@ import (
 	gcconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/gc"
 	metabaseconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/metabase"
 	piloramaconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/pilorama"
+	scrubconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/scrub"
+	throttleconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/throttle"
 	writecacheconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/writecache"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
 )
@@ -34,6 +36,27 @@ func (x *Config) Compress() bool {
 	)
 }
 
+// CompressionCodec returns the value of "compression_codec" config parameter.
+//
+// Returns empty string if the value is missing or is invalid, the default
+// codec (zstd) is used in this case.
+func (x *Config) CompressionCodec() string {
+	return config.StringSafe(
+		(*config.Config)(x),
+		"compression_codec",
+	)
+}
+
+// CompressionLevel returns the value of "compression_level" config parameter.
+//
+// Returns 0 (codec's default level) if the value is missing or is invalid.
+func (x *Config) CompressionLevel() int {
+	return int(config.IntSafe(
+		(*config.Config)(x),
+		"compression_level",
+	))
+}
+
 // UncompressableContentTypes returns the value of "compress_skip_content_types" config parameter.
 //
 // Returns nil if a the value is missing or is invalid.
@@ -99,6 +122,22 @@ func (x *Config) GC() *gcconfig.Config {
 	)
 }
 
+// Throttle returns "throttle" subsection as a throttleconfig.Config.
+func (x *Config) Throttle() *throttleconfig.Config {
+	return throttleconfig.From(
+		(*config.Config)(x).
+			Sub("throttle"),
+	)
+}
+
+// Scrub returns "scrub" subsection as a scrubconfig.Config.
+func (x *Config) Scrub() *scrubconfig.Config {
+	return scrubconfig.From(
+		(*config.Config)(x).
+			Sub("scrub"),
+	)
+}
+
 // RefillMetabase returns the value of "resync_metabase" config parameter.
 //
 // Returns false if the value is not a valid bool.
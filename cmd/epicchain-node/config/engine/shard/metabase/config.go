@@ -30,6 +30,20 @@ func (x *Config) Path() string {
 	return p
 }
 
+// IndexedAttributes returns the value of "indexed_attributes" config parameter.
+//
+// Returns nil if the value is missing.
+func (x *Config) IndexedAttributes() []string {
+	return config.StringSliceSafe((*config.Config)(x), "indexed_attributes")
+}
+
+// ChangeLog returns the value of "change_log" config parameter.
+//
+// Returns false if the value is missing or invalid.
+func (x *Config) ChangeLog() bool {
+	return config.BoolSafe((*config.Config)(x), "change_log")
+}
+
 // BoltDB returns config instance for querying bolt db specific parameters.
 func (x *Config) BoltDB() *boltdbconfig.Config {
 	return (*boltdbconfig.Config)(x)
@@ -0,0 +1,44 @@
+package scrubconfig
+
+import (
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
+)
+
+// Config is a wrapper over the config section
+// which provides access to Shard's integrity scrubbing configurations.
+type Config config.Config
+
+// From wraps config section into Config.
+func From(c *config.Config) *Config {
+	return (*Config)(c)
+}
+
+// Rate returns the value of "rate" config parameter.
+//
+// Returns 0 (scrubbing is disabled) if the value is not a positive number.
+func (x *Config) Rate() uint64 {
+	return config.SizeInBytesSafe((*config.Config)(x), "rate")
+}
+
+// Interval returns the value of "interval" config parameter.
+//
+// Returns shard.DefaultScrubInterval if the value is not a positive number.
+func (x *Config) Interval() time.Duration {
+	v := config.DurationSafe((*config.Config)(x), "interval")
+	if v > 0 {
+		return v
+	}
+
+	return shard.DefaultScrubInterval
+}
+
+// Scrub returns all the parameters as shard.ScrubConfig.
+func (x *Config) Scrub() shard.ScrubConfig {
+	return shard.ScrubConfig{
+		Rate:     x.Rate(),
+		Interval: x.Interval(),
+	}
+}
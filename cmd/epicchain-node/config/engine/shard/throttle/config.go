@@ -0,0 +1,73 @@
+package throttleconfig
+
+import (
+	"fmt"
+
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
+)
+
+// Config is a wrapper over the config section
+// which provides access to Shard's I/O limits configurations.
+type Config config.Config
+
+// From wraps config section into Config.
+func From(c *config.Config) *Config {
+	return (*Config)(c)
+}
+
+// ReadIOPS returns the value of "read_iops" config parameter.
+//
+// Returns 0 (no limit) if the value is not a positive number.
+func (x *Config) ReadIOPS() uint64 {
+	return config.UintSafe((*config.Config)(x), "read_iops")
+}
+
+// WriteIOPS returns the value of "write_iops" config parameter.
+//
+// Returns 0 (no limit) if the value is not a positive number.
+func (x *Config) WriteIOPS() uint64 {
+	return config.UintSafe((*config.Config)(x), "write_iops")
+}
+
+// ReadBandwidth returns the value of "read_bandwidth" config parameter.
+//
+// Returns 0 (no limit) if the value is not a positive number.
+func (x *Config) ReadBandwidth() uint64 {
+	return config.SizeInBytesSafe((*config.Config)(x), "read_bandwidth")
+}
+
+// WriteBandwidth returns the value of "write_bandwidth" config parameter.
+//
+// Returns 0 (no limit) if the value is not a positive number.
+func (x *Config) WriteBandwidth() uint64 {
+	return config.SizeInBytesSafe((*config.Config)(x), "write_bandwidth")
+}
+
+// BackgroundShare returns the value of "background_share" config parameter.
+//
+// Returns throttle.DefaultBackgroundShare if the value is missing or zero.
+// Panics if the value is not in the [0, 1] range.
+func (x *Config) BackgroundShare() float64 {
+	r := config.FloatSafe((*config.Config)(x), "background_share")
+	if r < 0 || r > 1 {
+		panic(fmt.Sprintf("invalid background I/O share %v, must be in [0, 1] range", r))
+	}
+
+	if r == 0 {
+		return throttle.DefaultBackgroundShare
+	}
+
+	return r
+}
+
+// Limits returns all the limits as throttle.Limits.
+func (x *Config) Limits() throttle.Limits {
+	return throttle.Limits{
+		ReadIOPS:        x.ReadIOPS(),
+		WriteIOPS:       x.WriteIOPS(),
+		ReadBandwidth:   x.ReadBandwidth(),
+		WriteBandwidth:  x.WriteBandwidth(),
+		BackgroundShare: x.BackgroundShare(),
+	}
+}
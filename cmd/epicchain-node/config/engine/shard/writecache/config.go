@@ -1,8 +1,12 @@
 package writecacheconfig
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config"
 	boltdbconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/boltdb"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/writecache"
 )
 
 // Config is a wrapper over the config section
@@ -126,3 +130,64 @@ func (x *Config) NoSync() bool {
 func (x *Config) BoltDB() *boltdbconfig.Config {
 	return (*boltdbconfig.Config)(x)
 }
+
+// FlushPolicy returns config instance for querying flush policy parameters.
+func (x *Config) FlushPolicy() *FlushPolicyConfig {
+	return (*FlushPolicyConfig)((*config.Config)(x).Sub("flush_policy"))
+}
+
+// FlushPolicyConfig is a wrapper over the config section
+// which provides access to write-cache flush policy configurations.
+type FlushPolicyConfig config.Config
+
+// MaxAge returns the value of "max_age" config parameter.
+//
+// Returns 0 if the value is not a positive duration.
+func (x *FlushPolicyConfig) MaxAge() time.Duration {
+	d := config.DurationSafe((*config.Config)(x), "max_age")
+	if d > 0 {
+		return d
+	}
+
+	return 0
+}
+
+// FillRatio returns the value of "fill_ratio" config parameter.
+//
+// Panics if the value is not in the [0, 1] range.
+func (x *FlushPolicyConfig) FillRatio() float64 {
+	r := config.FloatSafe((*config.Config)(x), "fill_ratio")
+	if r < 0 || r > 1 {
+		panic(fmt.Sprintf("invalid write-cache flush fill ratio %v, must be in [0, 1] range", r))
+	}
+
+	return r
+}
+
+// Windows returns the value of "windows" config parameter.
+//
+// Panics if any of the values is not a valid "HH:MM-HH:MM" time window.
+func (x *FlushPolicyConfig) Windows() []writecache.FlushWindow {
+	ss := config.StringSliceSafe((*config.Config)(x), "windows")
+	if len(ss) == 0 {
+		return nil
+	}
+
+	res := make([]writecache.FlushWindow, len(ss))
+	for i := range ss {
+		w, err := writecache.ParseFlushWindow(ss[i])
+		if err != nil {
+			panic(fmt.Errorf("write-cache flush policy: %w", err))
+		}
+		res[i] = w
+	}
+
+	return res
+}
+
+// RateLimit returns the value of "rate_limit" config parameter.
+//
+// Returns 0 (no limit) if the value is not a positive number.
+func (x *FlushPolicyConfig) RateLimit() uint64 {
+	return config.SizeInBytesSafe((*config.Config)(x), "rate_limit")
+}
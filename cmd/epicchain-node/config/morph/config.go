@@ -21,6 +21,13 @@ const (
 	ReconnectionRetriesNumberDefault = 5
 	// ReconnectionRetriesDelayDefault is a default delay b/w reconnections.
 	ReconnectionRetriesDelayDefault = 5 * time.Second
+
+	// HealthCheckIntervalDefault is a default interval b/w health checks of
+	// the endpoints.
+	HealthCheckIntervalDefault = 10 * time.Second
+	// SwitchChecksNumberDefault is a default number of consecutive health
+	// checks required to switch to the healthier endpoint.
+	SwitchChecksNumberDefault = 3
 )
 
 // Endpoints returns list of the values of "endpoints" config parameter
@@ -86,3 +93,30 @@ func ReconnectionRetriesDelay(c *config.Config) time.Duration {
 
 	return ReconnectionRetriesDelayDefault
 }
+
+// HealthCheckInterval returns the value of "health_check_interval" config
+// parameter from "morph" section.
+//
+// Returns HealthCheckIntervalDefault if the value is not specified. Negative
+// value disables health checks.
+func HealthCheckInterval(c *config.Config) time.Duration {
+	res := config.DurationSafe(c.Sub(subsection), "health_check_interval")
+	if res != 0 {
+		return res
+	}
+
+	return HealthCheckIntervalDefault
+}
+
+// SwitchChecksNumber returns the value of "switch_checks_number" config
+// parameter from "morph" section.
+//
+// Returns SwitchChecksNumberDefault if the value is not a positive number.
+func SwitchChecksNumber(c *config.Config) int {
+	res := config.IntSafe(c.Sub(subsection), "switch_checks_number")
+	if res > 0 {
+		return int(res)
+	}
+
+	return SwitchChecksNumberDefault
+}
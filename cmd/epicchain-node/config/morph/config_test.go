@@ -19,6 +19,8 @@ func TestMorphSection(t *testing.T) {
 		require.Equal(t, morphconfig.CacheTTLDefault, morphconfig.CacheTTL(empty))
 		require.Equal(t, 5, morphconfig.ReconnectionRetriesNumber(empty))
 		require.Equal(t, 5*time.Second, morphconfig.ReconnectionRetriesDelay(empty))
+		require.Equal(t, morphconfig.HealthCheckIntervalDefault, morphconfig.HealthCheckInterval(empty))
+		require.Equal(t, morphconfig.SwitchChecksNumberDefault, morphconfig.SwitchChecksNumber(empty))
 	})
 
 	const path = "../../../../config/example/node"
@@ -31,6 +33,8 @@ func TestMorphSection(t *testing.T) {
 		require.Equal(t, 15*time.Second, morphconfig.CacheTTL(c))
 		require.Equal(t, 6, morphconfig.ReconnectionRetriesNumber(c))
 		require.Equal(t, 6*time.Second, morphconfig.ReconnectionRetriesDelay(c))
+		require.Equal(t, 15*time.Second, morphconfig.HealthCheckInterval(c))
+		require.Equal(t, 4, morphconfig.SwitchChecksNumber(c))
 	}
 
 	configtest.ForEachFileType(path, fileConfigTest)
@@ -6,6 +6,8 @@ import (
 	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config"
 	objectconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/object"
 	configtest "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/test"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/ratelimit"
+	"github.com/epicchainlabs/epicchain-sdk-go/container/acl"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,6 +17,7 @@ func TestObjectSection(t *testing.T) {
 
 		require.Equal(t, objectconfig.PutPoolSizeDefault, objectconfig.Put(empty).PoolSizeRemote())
 		require.EqualValues(t, objectconfig.DefaultTombstoneLifetime, objectconfig.TombstoneLifetime(empty))
+		require.Equal(t, ratelimit.Limits{Roles: map[acl.Role]ratelimit.Rule{}}, objectconfig.RateLimits(empty))
 	})
 
 	const path = "../../../../config/example/node"
@@ -22,6 +25,13 @@ func TestObjectSection(t *testing.T) {
 	var fileConfigTest = func(c *config.Config) {
 		require.Equal(t, 100, objectconfig.Put(c).PoolSizeRemote())
 		require.EqualValues(t, 10, objectconfig.TombstoneLifetime(c))
+		require.Equal(t, ratelimit.Limits{
+			Owner:     ratelimit.Rule{Rate: 100, Burst: 200},
+			Container: ratelimit.Rule{Rate: 500, Burst: 1000},
+			Roles: map[acl.Role]ratelimit.Rule{
+				acl.RoleOthers: {Rate: 1000},
+			},
+		}, objectconfig.RateLimits(c))
 	}
 
 	configtest.ForEachFileType(path, fileConfigTest)
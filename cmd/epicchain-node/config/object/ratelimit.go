@@ -0,0 +1,46 @@
+package objectconfig
+
+import (
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/ratelimit"
+	"github.com/epicchainlabs/epicchain-sdk-go/container/acl"
+)
+
+const (
+	rateLimitSubsection = "rate_limit"
+
+	rolesSubsection = "roles"
+)
+
+// RateLimits returns the request rate limits set in "rate_limit" subsection
+// of "object" section. Missing values mean no limit.
+func RateLimits(c *config.Config) ratelimit.Limits {
+	sub := c.Sub(subsection).Sub(rateLimitSubsection)
+	roles := sub.Sub(rolesSubsection)
+
+	res := ratelimit.Limits{
+		Owner:     rateLimitRule(sub.Sub("owner")),
+		Container: rateLimitRule(sub.Sub("container")),
+		Roles:     make(map[acl.Role]ratelimit.Rule),
+	}
+
+	for role, name := range map[acl.Role]string{
+		acl.RoleOwner:     "owner",
+		acl.RoleContainer: "container",
+		acl.RoleInnerRing: "inner_ring",
+		acl.RoleOthers:    "others",
+	} {
+		if r := rateLimitRule(roles.Sub(name)); r.Rate > 0 {
+			res.Roles[role] = r
+		}
+	}
+
+	return res
+}
+
+func rateLimitRule(c *config.Config) ratelimit.Rule {
+	return ratelimit.Rule{
+		Rate:  config.FloatSafe(c, "rate"),
+		Burst: config.UintSafe(c, "burst"),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/replicator"
 )
 
 const (
@@ -31,3 +32,33 @@ func PutTimeout(c *config.Config) time.Duration {
 func PoolSize(c *config.Config) int {
 	return int(config.IntSafe(c.Sub(subsection), "pool_size"))
 }
+
+// Bandwidth returns the value of "bandwidth" config parameter
+// from "replicator" section.
+//
+// Returns 0 (no limit) if the value is not a positive number.
+func Bandwidth(c *config.Config) uint64 {
+	return config.SizeInBytesSafe(c.Sub(subsection), "bandwidth")
+}
+
+// NodeBandwidth returns the value of "node_bandwidth" config parameter
+// from "replicator" section.
+//
+// Returns 0 (no limit) if the value is not a positive number.
+func NodeBandwidth(c *config.Config) uint64 {
+	return config.SizeInBytesSafe(c.Sub(subsection), "node_bandwidth")
+}
+
+// NodeErrorBudget returns the value of "node_error_budget" config parameter
+// from "replicator" section.
+//
+// Returns replicator.DefaultNodeErrorBudget if the value is not a positive
+// number.
+func NodeErrorBudget(c *config.Config) uint32 {
+	v := config.Uint32Safe(c.Sub(subsection), "node_error_budget")
+	if v > 0 {
+		return v
+	}
+
+	return replicator.DefaultNodeErrorBudget
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config"
 	replicatorconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/replicator"
 	configtest "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/test"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/replicator"
 	"github.com/stretchr/testify/require"
 )
 
@@ -16,6 +17,9 @@ func TestReplicatorSection(t *testing.T) {
 
 		require.Equal(t, replicatorconfig.PutTimeoutDefault, replicatorconfig.PutTimeout(empty))
 		require.Equal(t, 0, replicatorconfig.PoolSize(empty))
+		require.Zero(t, replicatorconfig.Bandwidth(empty))
+		require.Zero(t, replicatorconfig.NodeBandwidth(empty))
+		require.EqualValues(t, replicator.DefaultNodeErrorBudget, replicatorconfig.NodeErrorBudget(empty))
 	})
 
 	const path = "../../../../config/example/node"
@@ -23,6 +27,9 @@ func TestReplicatorSection(t *testing.T) {
 	var fileConfigTest = func(c *config.Config) {
 		require.Equal(t, 15*time.Second, replicatorconfig.PutTimeout(c))
 		require.Equal(t, 10, replicatorconfig.PoolSize(c))
+		require.EqualValues(t, 100<<20, replicatorconfig.Bandwidth(c))
+		require.EqualValues(t, 10<<20, replicatorconfig.NodeBandwidth(c))
+		require.EqualValues(t, 5, replicatorconfig.NodeErrorBudget(c))
 	}
 
 	configtest.ForEachFileType(path, fileConfigTest)
@@ -21,6 +21,7 @@ func TestTreeSection(t *testing.T) {
 		require.Equal(t, 0, treeSec.ReplicationChannelCapacity())
 		require.Equal(t, 0, treeSec.ReplicationWorkerCount())
 		require.Equal(t, time.Duration(0), treeSec.ReplicationTimeout())
+		require.False(t, treeSec.LogCompaction())
 	})
 
 	const path = "../../../../config/example/node"
@@ -34,6 +35,7 @@ func TestTreeSection(t *testing.T) {
 		require.Equal(t, 32, treeSec.ReplicationWorkerCount())
 		require.Equal(t, 5*time.Second, treeSec.ReplicationTimeout())
 		require.Equal(t, time.Hour, treeSec.SyncInterval())
+		require.True(t, treeSec.LogCompaction())
 	}
 
 	configtest.ForEachFileType(path, fileConfigTest)
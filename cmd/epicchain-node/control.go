@@ -21,6 +21,10 @@ func (t treeSynchronizer) Synchronize(ctx context.Context, cnr cid.ID, treeID st
 	return t.treeSvc.SynchronizeTree(ctx, cnr, treeID)
 }
 
+func (t treeSynchronizer) Compact(ctx context.Context, cnr cid.ID, treeID string) (uint64, uint64, error) {
+	return t.treeSvc.CompactTree(ctx, cnr, treeID)
+}
+
 func initControlService(c *cfg) {
 	endpoint := controlconfig.GRPC(c.cfgReader).Endpoint()
 	if endpoint == controlconfig.GRPCEndpointDefault {
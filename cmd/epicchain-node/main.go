@@ -151,6 +151,8 @@ func initApp(c *cfg) {
 		c.replicator,
 		c,
 		treeSynchronizer{c.treeService},
+		c,
+		c,
 	)
 }
 
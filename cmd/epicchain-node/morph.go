@@ -109,6 +109,7 @@ func listenMorphNotifications(c *cfg) {
 		Logger:             c.log,
 		Client:             c.cfgMorph.client,
 		WorkerPoolCapacity: listenerPoolCap,
+		BlockHeightStorage: c.persistate.BlockHeight(persistateSideChainLastBlockKey),
 	})
 	fatalOnErr(err)
 
@@ -128,19 +129,17 @@ func listenMorphNotifications(c *cfg) {
 
 		return res, err
 	})
+
+	// new epochs are handled once and in order
+	newEpochType := event.TypeFromString(newEpochNotification)
+	c.cfgNetmap.subscribers[newEpochType] = []event.Handler{newEpochHandler(c, c.cfgNetmap.subscribers[newEpochType])}
+
 	registerNotificationHandlers(c.shared.basics.netmapSH, lis, c.cfgNetmap.parsers, c.cfgNetmap.subscribers)
 	registerNotificationHandlers(c.shared.basics.containerSH, lis, c.cfgContainer.parsers, c.cfgContainer.subscribers)
 
 	registerBlockHandler(lis, func(block *block.Block) {
 		c.log.Debug("new block", zap.Uint32("index", block.Index))
 
-		err = c.persistate.SetUInt32(persistateSideChainLastBlockKey, block.Index)
-		if err != nil {
-			c.log.Warn("can't update persistent state",
-				zap.String("chain", "side"),
-				zap.Uint32("block_index", block.Index))
-		}
-
 		tickBlockTimers(c)
 	})
 }
@@ -16,6 +16,7 @@ import (
 	netmapTransportGRPC "github.com/epicchainlabs/epicchain-node/pkg/network/transport/netmap/grpc"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
 	netmapService "github.com/epicchainlabs/epicchain-node/pkg/services/netmap"
+	"github.com/epicchainlabs/epicchain-node/pkg/util"
 	netmapSDK "github.com/epicchainlabs/epicchain-sdk-go/netmap"
 	"github.com/epicchainlabs/epicchain-sdk-go/version"
 	"go.uber.org/zap"
@@ -23,7 +24,7 @@ import (
 
 // primary solution of local network state dump.
 type networkState struct {
-	epoch atomic.Uint64
+	epoch util.EpochTracker
 
 	controlNetStatus atomic.Value // control.NetmapStatus
 
@@ -42,14 +43,21 @@ func newNetworkState() *networkState {
 }
 
 func (s *networkState) CurrentEpoch() uint64 {
-	return s.epoch.Load()
+	return s.epoch.Last()
 }
 
-func (s *networkState) setCurrentEpoch(v uint64) {
-	s.epoch.Store(v)
+// setCurrentEpoch updates the current epoch if v is greater than it. Returns
+// false if the epoch is not updated.
+func (s *networkState) setCurrentEpoch(v uint64) bool {
+	if !s.epoch.Update(v) {
+		return false
+	}
+
 	if s.metrics != nil {
 		s.metrics.SetEpoch(v)
 	}
+
+	return true
 }
 
 func (s *networkState) setNodeInfo(ni *netmapSDK.NodeInfo) {
@@ -162,10 +170,6 @@ func initNetmapService(c *cfg) {
 		netmapGRPC.RegisterNetmapServiceServer(srv, server)
 	}
 
-	addNewEpochNotificationHandler(c, func(ev event.Event) {
-		c.cfgNetmap.state.setCurrentEpoch(ev.(netmapEvent.NewEpoch).EpochNumber())
-	})
-
 	addNewEpochAsyncNotificationHandler(c, func(ev event.Event) {
 		if !c.needBootstrap() || c.cfgNetmap.reBoostrapTurnedOff.Load() { // fixes #470
 			return
@@ -302,6 +306,30 @@ func (c *cfg) netmapLocalNodeState(epoch uint64) (*netmapSDK.NodeInfo, error) {
 	return nil, nil
 }
 
+// newEpochHandler returns handler that updates the current epoch and passes
+// the NewEpoch event to the given handlers only if the epoch is greater than
+// the current one. Notifications of the missed blocks may bring the epochs
+// the node has already switched to, their side effects (re-bootstrap, notary
+// deposit, etc.) must not be repeated.
+func newEpochHandler(c *cfg, hs []event.Handler) event.Handler {
+	return func(ev event.Event) {
+		e := ev.(netmapEvent.NewEpoch).EpochNumber()
+
+		if !c.cfgNetmap.state.setCurrentEpoch(e) {
+			c.log.Debug("skip new epoch event of the outdated epoch",
+				zap.Uint64("epoch", e),
+				zap.Uint64("current", c.cfgNetmap.state.CurrentEpoch()),
+			)
+
+			return
+		}
+
+		for i := range hs {
+			hs[i](ev)
+		}
+	}
+}
+
 // addNewEpochNotificationHandler adds handler that will be executed synchronously.
 func addNewEpochNotificationHandler(c *cfg, h event.Handler) {
 	addNetmapNotificationHandler(c, newEpochNotification, h)
@@ -5,6 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"sync"
+	"time"
 
 	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/epicchainlabs/neofs-api-go/v2/object"
@@ -13,6 +16,8 @@ import (
 	coreclient "github.com/epicchainlabs/epicchain-node/pkg/core/client"
 	containercore "github.com/epicchainlabs/epicchain-node/pkg/core/container"
 	"github.com/epicchainlabs/epicchain-node/pkg/core/netmap"
+	"github.com/epicchainlabs/epicchain-node/pkg/morph/event"
+	netmapEvent "github.com/epicchainlabs/epicchain-node/pkg/morph/event/netmap"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/engine"
 	morphClient "github.com/epicchainlabs/epicchain-node/pkg/morph/client"
 	cntClient "github.com/epicchainlabs/epicchain-node/pkg/morph/client/container"
@@ -22,11 +27,13 @@ import (
 	v2 "github.com/epicchainlabs/epicchain-node/pkg/services/object/acl/v2"
 	deletesvc "github.com/epicchainlabs/epicchain-node/pkg/services/object/delete"
 	deletesvcV2 "github.com/epicchainlabs/epicchain-node/pkg/services/object/delete/v2"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/ec"
 	getsvc "github.com/epicchainlabs/epicchain-node/pkg/services/object/get"
 	getsvcV2 "github.com/epicchainlabs/epicchain-node/pkg/services/object/get/v2"
 	headsvc "github.com/epicchainlabs/epicchain-node/pkg/services/object/head"
 	putsvc "github.com/epicchainlabs/epicchain-node/pkg/services/object/put"
 	putsvcV2 "github.com/epicchainlabs/epicchain-node/pkg/services/object/put/v2"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/ratelimit"
 	searchsvc "github.com/epicchainlabs/epicchain-node/pkg/services/object/search"
 	searchsvcV2 "github.com/epicchainlabs/epicchain-node/pkg/services/object/search/v2"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/object/split"
@@ -38,6 +45,7 @@ import (
 	truststorage "github.com/epicchainlabs/epicchain-node/pkg/services/reputation/local/storage"
 	"github.com/epicchainlabs/epicchain-sdk-go/client"
 	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	neofsecdsa "github.com/epicchainlabs/epicchain-sdk-go/crypto/ecdsa"
 	eaclSDK "github.com/epicchainlabs/epicchain-sdk-go/eacl"
 	netmapsdk "github.com/epicchainlabs/epicchain-sdk-go/netmap"
 	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
@@ -177,6 +185,8 @@ func initObjectService(c *cfg) {
 		basicConstructor: c.putClientCache,
 	}
 
+	ecStorage := ec.NewNodeStorage(ls, keyStorage, coreConstructor)
+
 	irFetcher := &innerRingFetcherWithNotary{
 		sidechain: c.cfgMorph.client,
 	}
@@ -190,9 +200,16 @@ func initObjectService(c *cfg) {
 		replicator.WithRemoteSender(
 			putsvc.NewRemoteSender(keyStorage, (*coreClientConstructor)(clientConstructor)),
 		),
+		replicator.WithBandwidth(
+			replicatorconfig.Bandwidth(c.cfgReader),
+			replicatorconfig.NodeBandwidth(c.cfgReader),
+		),
+		replicator.WithNodeErrorBudget(
+			replicatorconfig.NodeErrorBudget(c.cfgReader),
+		),
 	)
 
-	c.shared.policer = policer.New(
+	policerOpts := []policer.Option{
 		policer.WithLogger(c.log),
 		policer.WithLocalStorage(ls),
 		policer.WithContainerSource(c.cfgObject.cnrSource),
@@ -222,7 +239,41 @@ func initObjectService(c *cfg) {
 		policer.WithNetwork(c),
 		policer.WithReplicationCooldown(c.applicationConfiguration.policer.replicationCooldown),
 		policer.WithObjectBatchSize(c.applicationConfiguration.policer.objectBatchSize),
-	)
+		policer.WithErasureCoding(ecStorage, neofsecdsa.SignerRFC6979(c.key.PrivateKey)),
+	}
+
+	if c.metricsCollector != nil {
+		policerOpts = append(policerOpts, policer.WithMetrics(c.metricsCollector))
+	}
+
+	c.shared.policer = policer.New(policerOpts...)
+
+	addNewEpochAsyncNotificationHandler(c, func(ev event.Event) {
+		e := ev.(netmapEvent.NewEpoch).EpochNumber()
+		if e == 0 {
+			return
+		}
+
+		prev, err := c.netMapSource.GetNetMapByEpoch(e - 1)
+		if err != nil {
+			c.log.Debug("could not get previous network map for the policer",
+				zap.Uint64("epoch", e-1),
+				zap.String("error", err.Error()),
+			)
+			return
+		}
+
+		cur, err := c.netMapSource.GetNetMapByEpoch(e)
+		if err != nil {
+			c.log.Debug("could not get network map for the policer",
+				zap.Uint64("epoch", e),
+				zap.String("error", err.Error()),
+			)
+			return
+		}
+
+		c.shared.policer.HandleNetmapChange(*prev, *cur)
+	})
 
 	traverseGen := util.NewTraverserGenerator(c.netMapSource, c.cfgObject.cnrSource, c)
 
@@ -239,6 +290,7 @@ func initObjectService(c *cfg) {
 		),
 		getsvc.WithNetMapSource(c.netMapSource),
 		getsvc.WithKeyStorage(keyStorage),
+		getsvc.WithErasureCoding(c.cfgObject.cnrSource, ecStorage),
 	)
 
 	*c.cfgObject.getSvc = *sGet // need smth better
@@ -251,6 +303,9 @@ func initObjectService(c *cfg) {
 	cnrNodes, err := newContainerNodes(c.cfgObject.cnrSource, c.netMapSource)
 	fatalOnErr(err)
 
+	c.cfgObject.healthyCopies.init(newRemoteContainerNodes(cnrNodes, c.IsLocalKey), ecStorage,
+		replicatorconfig.PutTimeout(c.cfgReader))
+
 	sSearch := searchsvc.New(newRemoteContainerNodes(cnrNodes, c.IsLocalKey),
 		searchsvc.WithLogger(c.log),
 		searchsvc.WithLocalStorageEngine(ls),
@@ -269,6 +324,7 @@ func initObjectService(c *cfg) {
 		putsvc.WithMaxSizeSource(newCachedMaxObjectSizeSource(c)),
 		putsvc.WithObjectStorage(storageEngine{engine: ls}),
 		putsvc.WithContainerSource(c.cfgObject.cnrSource),
+		putsvc.WithContainerQuotas(ls),
 		putsvc.WithNetworkMapSource(c.netMapSource),
 		putsvc.WithNetmapKeys(c),
 		putsvc.WithNetworkState(c.cfgNetmap.state),
@@ -276,8 +332,14 @@ func initObjectService(c *cfg) {
 		putsvc.WithLogger(c.log),
 		putsvc.WithSplitChainVerifier(split.NewVerifier(sGet)),
 		putsvc.WithTombstoneVerifier(tombstone.NewVerifier(objectSource{sGet, sSearch})),
+		putsvc.WithErasureCoding(ecStorage),
+		putsvc.WithMultipartUploads(c.privateTokenStore, objectSource{sGet, sSearch}, c.cfgObject.tombstoneLifetime),
 	)
 
+	addNewEpochAsyncNotificationHandler(c, func(ev event.Event) {
+		sPut.AbortExpiredUploads(context.Background(), ev.(netmapEvent.NewEpoch).EpochNumber())
+	})
+
 	sPutV2 := putsvcV2.NewService(
 		putsvcV2.WithInternalService(sPut),
 		putsvcV2.WithKey(&c.key.PrivateKey),
@@ -320,8 +382,16 @@ func initObjectService(c *cfg) {
 	// should help a lot here
 	const cachedFirstObjectsNumber = 1000
 
+	var rateLimitMetrics ratelimit.Metrics
+	if c.metricsCollector != nil {
+		rateLimitMetrics = c.metricsCollector
+	}
+
+	c.cfgObject.rateLimiter = ratelimit.New(c.applicationConfiguration.object.rateLimits, rateLimitMetrics)
+
 	aclSvc := v2.New(
 		v2.WithLogger(c.log),
+		v2.WithRateLimiter(c.cfgObject.rateLimiter),
 		v2.WithIRFetcher(newCachedIRFetcher(irFetcher)),
 		v2.WithNetmapSource(c.netMapSource),
 		v2.WithContainerSource(
@@ -631,6 +701,89 @@ func (x *remoteContainerNodes) ForEachRemoteContainerNode(cnr cid.ID, f func(net
 	})
 }
 
+// healthyCopySource provides the storage engine with the copies of the
+// corrupted objects stored on the other container nodes.
+//
+// Implements [shard.HealthyCopySource].
+type healthyCopySource struct {
+	mtx     sync.RWMutex
+	nodes   *remoteContainerNodes
+	storage *ec.NodeStorage
+	timeout time.Duration
+}
+
+var errHealthyCopySourceNotReady = errors.New("object service is not initialized yet")
+
+// init sets the components the source depends on. Shards are attached to the
+// storage engine before the object service is initialized, so the source is
+// created empty and filled later.
+func (x *healthyCopySource) init(nodes *remoteContainerNodes, storage *ec.NodeStorage, timeout time.Duration) {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	x.nodes = nodes
+	x.storage = storage
+	x.timeout = timeout
+}
+
+// HealthyCopy requests the object from the remote container nodes one by one
+// and returns the first received copy.
+func (x *healthyCopySource) HealthyCopy(addr oid.Address) (*objectSDK.Object, error) {
+	x.mtx.RLock()
+	nodes, storage, timeout := x.nodes, x.storage, x.timeout
+	x.mtx.RUnlock()
+
+	if nodes == nil {
+		return nil, errHealthyCopySourceNotReady
+	}
+
+	var (
+		res     *objectSDK.Object
+		lastErr error
+		seen    = make(map[string]struct{})
+	)
+
+	err := nodes.ForEachRemoteContainerNode(addr.Container(), func(n netmapsdk.NodeInfo) {
+		key := string(n.PublicKey())
+		if _, ok := seen[key]; ok || res != nil {
+			return
+		}
+
+		seen[key] = struct{}{}
+
+		var node ec.Node
+
+		err := coreclient.NodeInfoFromRawNetmapElement(&node.Info, netmap.Node(n))
+		if err != nil {
+			lastErr = err
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		obj, err := storage.GetPart(ctx, node, addr)
+		cancel()
+
+		if err != nil {
+			lastErr = err
+			return
+		}
+
+		res = obj
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get container nodes: %w", err)
+	}
+
+	if res == nil {
+		if lastErr == nil {
+			lastErr = errors.New("no remote container nodes")
+		}
+		return nil, lastErr
+	}
+
+	return res, nil
+}
+
 // nodeForObjects represents NeoFS storage node for object storage.
 type nodeForObjects struct {
 	putObjectService *putsvc.Service
@@ -698,6 +851,28 @@ func (o objectSource) Head(ctx context.Context, addr oid.Address) (*objectSDK.Ob
 	return hw.h, err
 }
 
+type payloadWriter struct {
+	w io.Writer
+}
+
+func (payloadWriter) WriteHeader(*objectSDK.Object) error {
+	return nil
+}
+
+func (p payloadWriter) WriteChunk(chunk []byte) error {
+	_, err := p.w.Write(chunk)
+	return err
+}
+
+func (o objectSource) ReadPayload(ctx context.Context, addr oid.Address, w io.Writer) error {
+	var prm getsvc.Prm
+	prm.SetObjectWriter(payloadWriter{w})
+	prm.WithAddress(addr)
+	prm.WithRawFlag(true)
+
+	return o.get.Get(ctx, prm)
+}
+
 func (o objectSource) Search(ctx context.Context, cnr cid.ID, filters objectSDK.SearchFilters) ([]oid.ID, error) {
 	var sw searchWriter
 
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	replicatorconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/replicator"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/storage"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/engine"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/writecache"
+	controlSvc "github.com/epicchainlabs/epicchain-node/pkg/services/control/server"
+	"go.uber.org/zap"
+)
+
+// Configuration sections reported in the reload changes.
+const (
+	reloadSectionConfig     = "config"
+	reloadSectionLogger     = "logger"
+	reloadSectionPolicer    = "policer"
+	reloadSectionReplicator = "replicator"
+	reloadSectionObject     = "object"
+	reloadSectionEngine     = "engine"
+)
+
+const reloadActionUpdated = "updated"
+
+// reloadState holds the outcome of the last configuration reload.
+type reloadState struct {
+	mtx sync.Mutex
+
+	time    time.Time
+	changes []controlSvc.ReloadChange
+}
+
+// LastReload implements control.ReloadStatus interface.
+func (c *cfg) LastReload() (time.Time, []controlSvc.ReloadChange) {
+	c.reload.mtx.Lock()
+	defer c.reload.mtx.Unlock()
+
+	return c.reload.time, append([]controlSvc.ReloadChange(nil), c.reload.changes...)
+}
+
+// reloadReport collects the changes applied by the configuration reload.
+type reloadReport struct {
+	log     *zap.Logger
+	changes []controlSvc.ReloadChange
+}
+
+func (r *reloadReport) add(section, target, action string, err error) {
+	r.changes = append(r.changes, controlSvc.ReloadChange{
+		Section: section,
+		Target:  target,
+		Action:  action,
+		Err:     err,
+	})
+
+	fields := []zap.Field{
+		zap.String("section", section),
+		zap.String("target", target),
+		zap.String("action", action),
+	}
+
+	if err != nil {
+		r.log.Error("could not apply configuration change", append(fields, zap.Error(err))...)
+	} else {
+		r.log.Info("configuration change applied", fields...)
+	}
+}
+
+// reloadConfig rereads the configuration, diffs it with the current one and
+// applies the changes live. The outcome is logged and saved to be reported
+// through the control service.
+func (c *cfg) reloadConfig() {
+	report := reloadReport{log: c.log}
+
+	defer func() {
+		c.reload.mtx.Lock()
+		c.reload.time = time.Now()
+		c.reload.changes = report.changes
+		c.reload.mtx.Unlock()
+	}()
+
+	c.shardsMtx.Lock()
+	defer c.shardsMtx.Unlock()
+
+	prev := c.applicationConfiguration
+
+	err := c.readConfig(c.cfgReader)
+	if err != nil {
+		report.add(reloadSectionConfig, "", "read", err)
+		return
+	}
+
+	err = c.applyShardOverrides()
+	if err != nil {
+		report.add(reloadSectionEngine, "", "read shard overrides", err)
+	}
+
+	// Logger
+
+	if prev.logger != c.logger {
+		err = c.internals.logLevel.UnmarshalText([]byte(c.logger.level))
+		report.add(reloadSectionLogger, "level", reloadActionUpdated, err)
+		if err != nil {
+			return
+		}
+	}
+
+	// Policer
+
+	c.shared.policer.Reload(c.policerOpts()...)
+	if prev.policer != c.applicationConfiguration.policer {
+		report.add(reloadSectionPolicer, "", reloadActionUpdated, nil)
+	}
+
+	// Object service
+
+	c.reloadObjectPools(&report, prev.object.putPoolSizeRemote)
+
+	c.cfgObject.rateLimiter.SetLimits(c.object.rateLimits)
+	if !reflect.DeepEqual(prev.object.rateLimits, c.object.rateLimits) {
+		report.add(reloadSectionObject, "rate_limit", reloadActionUpdated, nil)
+	}
+
+	// Storage Engine
+
+	c.reloadEngine(&report, prev.engine.shards)
+
+	if prev.engine.errorThreshold != c.engine.errorThreshold {
+		report.add(reloadSectionEngine, "shard_ro_error_threshold", reloadActionUpdated, nil)
+	}
+
+	if prev.engine.shardPoolSize != c.engine.shardPoolSize {
+		report.add(reloadSectionEngine, "shard_pool_size", reloadActionUpdated, nil)
+	}
+
+	c.log.Info("configuration has been reloaded",
+		zap.Int("changes", len(report.changes)))
+}
+
+// reloadObjectPools resizes the remote PUT and the replication worker pools.
+func (c *cfg) reloadObjectPools(report *reloadReport, prevPutPoolSize int) {
+	pool := &c.cfgObject.pool
+
+	if size := c.object.putPoolSizeRemote; size != prevPutPoolSize {
+		pool.putRemote.Tune(size)
+		report.add(reloadSectionObject, "put.pool_size_remote", reloadActionUpdated, nil)
+	}
+
+	replicatorPoolSize := replicatorconfig.PoolSize(c.cfgReader)
+	if replicatorPoolSize <= 0 {
+		replicatorPoolSize = c.object.putPoolSizeRemote
+	}
+
+	if replicatorPoolSize != pool.replication.Cap() {
+		pool.replication.Tune(replicatorPoolSize)
+		report.add(reloadSectionReplicator, "pool_size", reloadActionUpdated, nil)
+	}
+}
+
+// reloadEngine attaches, detaches, reloads and reopens the shards according
+// to the difference between the previous and the current configurations.
+func (c *cfg) reloadEngine(report *reloadReport, prevShards []storage.ShardCfg) {
+	prev := make(map[string]storage.ShardCfg, len(prevShards))
+	for _, sh := range prevShards {
+		prev[sh.ID()] = sh
+	}
+
+	cur := make(map[string]storage.ShardCfg, len(c.engine.shards))
+	for _, sh := range c.engine.shards {
+		cur[sh.ID()] = sh
+	}
+
+	var rcfg engine.ReConfiguration
+	rcfg.SetErrorsThreshold(c.engine.errorThreshold)
+	rcfg.SetShardPoolSize(c.engine.shardPoolSize)
+
+	for _, optsWithID := range c.shardOpts() {
+		rcfg.AddShard(optsWithID.configID,
+			append(optsWithID.shOpts, shard.WithTombstoneSource(c.cfgObject.cfgLocalStorage.tombstoneSource)))
+
+		if p, ok := prev[optsWithID.configID]; ok && shardNeedsReopen(p, cur[optsWithID.configID]) {
+			rcfg.ReopenShard(optsWithID.configID)
+		}
+	}
+
+	changes, _ := c.cfgObject.cfgLocalStorage.localStorage.Reload(rcfg)
+
+	for _, ch := range changes {
+		if ch.Type == engine.ShardReloaded && ch.Err == nil &&
+			reflect.DeepEqual(prev[ch.ConfigID], cur[ch.ConfigID]) {
+			continue
+		}
+
+		target := ch.ConfigID
+		if ch.ID != nil {
+			target = ch.ID.String() + " (" + ch.ConfigID + ")"
+		}
+
+		report.add(reloadSectionEngine, target, ch.Type.String(), ch.Err)
+	}
+}
+
+// shardNeedsReopen checks whether the shard configuration changes cannot be
+// applied to the working shard, so it must be closed and opened again.
+func shardNeedsReopen(prev, cur storage.ShardCfg) bool {
+	return !reflect.DeepEqual(withoutReloadableOptions(prev), withoutReloadableOptions(cur))
+}
+
+// withoutReloadableOptions returns the shard configuration with the options
+// applied by shard.Shard.Reload reset.
+func withoutReloadableOptions(c storage.ShardCfg) storage.ShardCfg {
+	c.RefillMetabase = false
+	c.MetaCfg.Path = ""
+	c.IOLimits = throttle.Limits{}
+	c.Scrub = shard.ScrubConfig{}
+	c.WritecacheCfg.FlushPolicy = writecache.FlushPolicy{}
+
+	return c
+}
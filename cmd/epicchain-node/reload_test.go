@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/storage"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardNeedsReopen(t *testing.T) {
+	var prev storage.ShardCfg
+	prev.SubStorages = []storage.SubStorageCfg{{Typ: "fstree", Path: "/storage/0"}}
+	prev.MetaCfg.Path = "/storage/0/meta"
+	prev.WritecacheCfg.Enabled = true
+	prev.WritecacheCfg.Path = "/storage/0/cache"
+
+	require.False(t, shardNeedsReopen(prev, prev))
+
+	cur := prev
+	cur.MetaCfg.Path = "/storage/1/meta"
+	cur.RefillMetabase = true
+	cur.IOLimits.WriteBandwidth = 1 << 20
+	cur.Scrub.Interval = time.Hour
+	cur.WritecacheCfg.FlushPolicy.MaxAge = time.Minute
+	require.False(t, shardNeedsReopen(prev, cur))
+
+	cur = prev
+	cur.WritecacheCfg.SizeLimit = 1 << 30
+	require.True(t, shardNeedsReopen(prev, cur))
+
+	cur = prev
+	cur.WritecacheCfg.Enabled = false
+	require.True(t, shardNeedsReopen(prev, cur))
+
+	cur = prev
+	cur.Mode = mode.ReadOnly
+	require.True(t, shardNeedsReopen(prev, cur))
+}
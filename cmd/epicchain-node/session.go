@@ -11,6 +11,7 @@ import (
 	"github.com/epicchainlabs/epicchain-node/pkg/morph/event"
 	"github.com/epicchainlabs/epicchain-node/pkg/morph/event/netmap"
 	sessionTransportGRPC "github.com/epicchainlabs/epicchain-node/pkg/network/transport/session/grpc"
+	putsvc "github.com/epicchainlabs/epicchain-node/pkg/services/object/put"
 	sessionSvc "github.com/epicchainlabs/epicchain-node/pkg/services/session"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/session/storage"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/session/storage/persistent"
@@ -23,6 +24,8 @@ type sessionStorage interface {
 	Get(ownerID user.ID, tokenID []byte) *storage.PrivateToken
 	RemoveOld(epoch uint64)
 
+	putsvc.MultipartStorage
+
 	Close() error
 }
 
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config"
+	shardconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/storage"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
+	"go.uber.org/zap"
+)
+
+var persistateShardOverridesKey = []byte("shard_overrides")
+
+// shardOverrides are the changes of the configured shard set made through
+// the control service. They are kept in the persistent state and applied
+// on top of the configuration file on each (re)read.
+type shardOverrides struct {
+	// Config IDs of the detached shards.
+	Detached []string `json:"detached,omitempty"`
+	// Configuration documents of the attached shards.
+	Attached [][]byte `json:"attached,omitempty"`
+}
+
+func (c *cfg) readShardOverrides() (shardOverrides, error) {
+	var ov shardOverrides
+
+	data, err := c.persistate.Bytes(persistateShardOverridesKey)
+	if err != nil || len(data) == 0 {
+		return ov, err
+	}
+
+	err = json.Unmarshal(data, &ov)
+	if err != nil {
+		return ov, fmt.Errorf("decode shard overrides: %w", err)
+	}
+
+	return ov, nil
+}
+
+func (c *cfg) writeShardOverrides(ov shardOverrides) error {
+	data, err := json.Marshal(ov)
+	if err != nil {
+		return fmt.Errorf("encode shard overrides: %w", err)
+	}
+
+	err = c.persistate.SetBytes(persistateShardOverridesKey, data)
+	if err != nil {
+		return fmt.Errorf("save shard overrides: %w", err)
+	}
+
+	return nil
+}
+
+// applyShardOverrides removes the detached shards from the configured ones
+// and adds the attached shards. Attached shard replaces the configured one
+// with the same config ID.
+func (c *cfg) applyShardOverrides() error {
+	ov, err := c.readShardOverrides()
+	if err != nil {
+		return err
+	}
+
+	treeEnabled := c.treeEnabled()
+
+	attached := make([]storage.ShardCfg, 0, len(ov.Attached))
+	for i := range ov.Attached {
+		sh, err := parseShardConfig(ov.Attached[i], treeEnabled)
+		if err != nil {
+			c.log.Error("invalid configuration of the attached shard, skip", zap.Error(err))
+			continue
+		}
+
+		attached = append(attached, sh)
+	}
+
+	skip := make(map[string]struct{}, len(ov.Detached)+len(attached))
+	for _, id := range ov.Detached {
+		skip[id] = struct{}{}
+	}
+	for i := range attached {
+		skip[attached[i].ID()] = struct{}{}
+	}
+
+	shards := make([]storage.ShardCfg, 0, len(c.engine.shards)+len(attached))
+	for _, sh := range c.engine.shards {
+		if _, ok := skip[sh.ID()]; !ok {
+			shards = append(shards, sh)
+		}
+	}
+
+	c.engine.shards = append(shards, attached...)
+
+	return nil
+}
+
+// parseShardConfig reads the shard configuration from the YAML (or JSON)
+// document with the same structure as the shard section of the node
+// configuration file. Shard defaults of the configuration file are not
+// applied.
+func parseShardConfig(data []byte, treeEnabled bool) (sh storage.ShardCfg, err error) {
+	c, err := config.Parse(data)
+	if err != nil {
+		return sh, err
+	}
+
+	if c.Value("metabase.path") == nil {
+		return sh, errors.New("missing metabase path")
+	}
+
+	// configuration accessors panic on invalid values
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid shard configuration: %v", r)
+		}
+	}()
+
+	sc := shardconfig.From(c)
+	if sc.Mode() == mode.Disabled {
+		return sh, errors.New("shard is disabled")
+	}
+
+	sh, err = readShardConfig(sc, treeEnabled)
+	if err != nil {
+		return sh, err
+	}
+
+	if len(sh.SubStorages) == 0 {
+		return sh, errors.New("missing blobstor substorages")
+	}
+
+	return sh, nil
+}
+
+func (c *cfg) treeEnabled() bool {
+	return config.BoolSafe(c.cfgReader.Sub("tree"), "enabled")
+}
+
+// shardConfigID returns the config ID of the working shard.
+func shardConfigID(info shard.Info) string {
+	// This calculation should be kept in sync with
+	// storage.ShardCfg.ID.
+	var sb strings.Builder
+	for _, sub := range info.BlobStorInfo.SubStorages {
+		sb.WriteString(filepath.Clean(sub.Path))
+	}
+	return sb.String()
+}
+
+// DetachShards implements control.ShardManager interface.
+func (c *cfg) DetachShards(ids []*shard.ID) error {
+	c.shardsMtx.Lock()
+	defer c.shardsMtx.Unlock()
+
+	ls := c.cfgObject.cfgLocalStorage.localStorage
+
+	configIDs := make(map[string]string, len(ids))
+	for _, info := range ls.DumpInfo().Shards {
+		configIDs[info.ID.String()] = shardConfigID(info)
+	}
+
+	err := ls.DetachShards(ids)
+	if err != nil {
+		return err
+	}
+
+	detachedIDs := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		detachedIDs[configIDs[id.String()]] = struct{}{}
+	}
+
+	shards := make([]storage.ShardCfg, 0, len(c.engine.shards))
+	for _, sh := range c.engine.shards {
+		if _, ok := detachedIDs[sh.ID()]; !ok {
+			shards = append(shards, sh)
+		}
+	}
+	c.engine.shards = shards
+
+	ov, err := c.readShardOverrides()
+	if err != nil {
+		return err
+	}
+
+	// shards attached through the control service are just forgotten
+	attached := make([][]byte, 0, len(ov.Attached))
+	for i := range ov.Attached {
+		sh, err := parseShardConfig(ov.Attached[i], c.treeEnabled())
+		if err == nil {
+			if _, ok := detachedIDs[sh.ID()]; ok {
+				delete(detachedIDs, sh.ID())
+				continue
+			}
+		}
+
+		attached = append(attached, ov.Attached[i])
+	}
+
+	ov.Attached = attached
+	for id := range detachedIDs {
+		ov.Detached = append(ov.Detached, id)
+	}
+
+	return c.writeShardOverrides(ov)
+}
+
+// AttachShard implements control.ShardManager interface.
+func (c *cfg) AttachShard(data []byte) (*shard.ID, error) {
+	c.shardsMtx.Lock()
+	defer c.shardsMtx.Unlock()
+
+	sh, err := parseShardConfig(data, c.treeEnabled())
+	if err != nil {
+		return nil, err
+	}
+
+	configID := sh.ID()
+	for i := range c.engine.shards {
+		if c.engine.shards[i].ID() == configID {
+			return nil, errors.New("shard with the same storage paths is already attached")
+		}
+	}
+
+	optsWithID := c.shardOptsFromConfig(sh)
+
+	id, err := c.cfgObject.cfgLocalStorage.localStorage.AttachShard(
+		append(optsWithID.shOpts, shard.WithTombstoneSource(c.cfgObject.cfgLocalStorage.tombstoneSource))...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.engine.shards = append(c.engine.shards[:len(c.engine.shards):len(c.engine.shards)], sh)
+
+	ov, err := c.readShardOverrides()
+	if err != nil {
+		return nil, fmt.Errorf("shard %s is attached but not saved: %w", id, err)
+	}
+
+	detached := make([]string, 0, len(ov.Detached))
+	for _, d := range ov.Detached {
+		if d != configID {
+			detached = append(detached, d)
+		}
+	}
+
+	ov.Detached = detached
+	ov.Attached = append(ov.Attached, data)
+
+	err = c.writeShardOverrides(ov)
+	if err != nil {
+		return nil, fmt.Errorf("shard %s is attached but not saved: %w", id, err)
+	}
+
+	return id, nil
+}
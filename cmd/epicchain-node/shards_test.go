@@ -0,0 +1,67 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	configtest "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/test"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/storage"
+	"github.com/epicchainlabs/epicchain-node/pkg/util/state"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testShardConfig = `
+mode: read-only
+metabase:
+  path: /storage/1/meta
+blobstor:
+  - type: fstree
+    path: /storage/1/fstree
+`
+
+func TestParseShardConfig(t *testing.T) {
+	sh, err := parseShardConfig([]byte(testShardConfig), false)
+	require.NoError(t, err)
+	require.Equal(t, "/storage/1/meta", sh.MetaCfg.Path)
+	require.Equal(t, "/storage/1/fstree", sh.ID())
+	require.False(t, sh.PiloramaCfg.Enabled)
+
+	for name, cfg := range map[string]string{
+		"invalid document": "metabase: [",
+		"no metabase":      "blobstor:\n  - type: fstree\n    path: /storage/1/fstree\n",
+		"no blobstor":      "metabase:\n  path: /storage/1/meta\n",
+		"invalid mode":     "mode: unknown\nmetabase:\n  path: /storage/1/meta\n",
+		"disabled":         "mode: disabled\nmetabase:\n  path: /storage/1/meta\n",
+	} {
+		_, err = parseShardConfig([]byte(cfg), false)
+		require.Error(t, err, name)
+	}
+}
+
+func TestApplyShardOverrides(t *testing.T) {
+	persistate, err := state.NewPersistentStorage(filepath.Join(t.TempDir(), "state"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = persistate.Close() })
+
+	newShard := func(path string) storage.ShardCfg {
+		var sh storage.ShardCfg
+		sh.SubStorages = []storage.SubStorageCfg{{Typ: "fstree", Path: path}}
+		return sh
+	}
+
+	c := &cfg{}
+	c.persistate = persistate
+	c.cfgReader = configtest.EmptyConfig()
+	c.internals.log = zap.NewNop()
+	c.engine.shards = []storage.ShardCfg{newShard("/storage/0/fstree"), newShard("/storage/1/fstree")}
+
+	require.NoError(t, c.writeShardOverrides(shardOverrides{
+		Detached: []string{"/storage/0/fstree"},
+		Attached: [][]byte{[]byte(testShardConfig), []byte("invalid: [")},
+	}))
+
+	require.NoError(t, c.applyShardOverrides())
+	require.Len(t, c.engine.shards, 1)
+	require.Equal(t, "/storage/1/meta", c.engine.shards[0].MetaCfg.Path)
+}
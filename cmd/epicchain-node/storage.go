@@ -4,7 +4,10 @@ import (
 	"time"
 
 	engineconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine"
+	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/storage"
+	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/erasure"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/fstree"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/peapod"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/engine"
@@ -46,6 +49,7 @@ func initLocalStorage(c *cfg) {
 	// allocate memory for the service;
 	// service will be created later
 	c.cfgObject.getSvc = new(getsvc.Service)
+	c.cfgObject.healthyCopies = new(healthyCopySource)
 
 	var tssPrm tsourse.TombstoneSourcePrm
 	tssPrm.SetGetService(c.cfgObject.getSvc)
@@ -71,6 +75,7 @@ func initLocalStorage(c *cfg) {
 	}
 
 	c.cfgObject.cfgLocalStorage.localStorage = ls
+	c.cfgObject.cfgLocalStorage.tombstoneSource = tombstoneSource
 
 	c.onShutdown(func() {
 		c.log.Info("closing components of the storage engine...")
@@ -92,6 +97,8 @@ func (c *cfg) engineOpts() []engine.Option {
 	opts = append(opts,
 		engine.WithShardPoolSize(c.engine.shardPoolSize),
 		engine.WithErrorThreshold(c.engine.errorThreshold),
+		engine.WithRebalanceRate(c.engine.rebalanceRate),
+		engine.WithStateStorage(c.persistate),
 
 		engine.WithLogger(c.log),
 	)
@@ -118,99 +125,126 @@ func (c *cfg) shardOpts() []shardOptsWithID {
 	shards := make([]shardOptsWithID, 0, len(c.engine.shards))
 
 	for _, shCfg := range c.engine.shards {
-		var writeCacheOpts []writecache.Option
-		if wcRead := shCfg.WritecacheCfg; wcRead.Enabled {
-			writeCacheOpts = append(writeCacheOpts,
-				writecache.WithPath(wcRead.Path),
-				writecache.WithMaxBatchSize(wcRead.MaxBatchSize),
-				writecache.WithMaxBatchDelay(wcRead.MaxBatchDelay),
-				writecache.WithMaxObjectSize(wcRead.MaxObjSize),
-				writecache.WithSmallObjectSize(wcRead.SmallObjectSize),
-				writecache.WithFlushWorkersCount(wcRead.FlushWorkerCount),
-				writecache.WithMaxCacheSize(wcRead.SizeLimit),
-				writecache.WithNoSync(wcRead.NoSync),
-				writecache.WithLogger(c.log),
-			)
-		}
+		shards = append(shards, c.shardOptsFromConfig(shCfg))
+	}
 
-		var piloramaOpts []pilorama.Option
-		if prRead := shCfg.PiloramaCfg; prRead.Enabled {
-			piloramaOpts = append(piloramaOpts,
-				pilorama.WithPath(prRead.Path),
-				pilorama.WithPerm(prRead.Perm),
-				pilorama.WithNoSync(prRead.NoSync),
-				pilorama.WithMaxBatchSize(prRead.MaxBatchSize),
-				pilorama.WithMaxBatchDelay(prRead.MaxBatchDelay),
-			)
-		}
+	return shards
+}
 
-		var ss []blobstor.SubStorage
-		for _, sRead := range shCfg.SubStorages {
-			switch sRead.Typ {
-			case fstree.Type:
-				ss = append(ss, blobstor.SubStorage{
-					Storage: fstree.New(
-						fstree.WithPath(sRead.Path),
-						fstree.WithPerm(sRead.Perm),
-						fstree.WithDepth(sRead.Depth),
-						fstree.WithNoSync(sRead.NoSync)),
-					Policy: func(_ *objectSDK.Object, data []byte) bool {
-						return true
-					},
-				})
-			case peapod.Type:
-				ss = append(ss, blobstor.SubStorage{
-					Storage: peapod.New(sRead.Path, sRead.Perm, sRead.FlushInterval),
-					Policy: func(_ *objectSDK.Object, data []byte) bool {
-						return uint64(len(data)) < shCfg.SmallSizeObjectLimit
-					},
-				})
-			default:
-				// should never happen, that has already
-				// been handled: when the config was read
-			}
+func (c *cfg) shardOptsFromConfig(shCfg storage.ShardCfg) shardOptsWithID {
+	var writeCacheOpts []writecache.Option
+	if wcRead := shCfg.WritecacheCfg; wcRead.Enabled {
+		writeCacheOpts = append(writeCacheOpts,
+			writecache.WithPath(wcRead.Path),
+			writecache.WithMaxBatchSize(wcRead.MaxBatchSize),
+			writecache.WithMaxBatchDelay(wcRead.MaxBatchDelay),
+			writecache.WithMaxObjectSize(wcRead.MaxObjSize),
+			writecache.WithSmallObjectSize(wcRead.SmallObjectSize),
+			writecache.WithFlushWorkersCount(wcRead.FlushWorkerCount),
+			writecache.WithMaxCacheSize(wcRead.SizeLimit),
+			writecache.WithNoSync(wcRead.NoSync),
+			writecache.WithFlushPolicy(wcRead.FlushPolicy),
+			writecache.WithLogger(c.log),
+		)
+	}
+
+	var piloramaOpts []pilorama.Option
+	if prRead := shCfg.PiloramaCfg; prRead.Enabled {
+		piloramaOpts = append(piloramaOpts,
+			pilorama.WithPath(prRead.Path),
+			pilorama.WithPerm(prRead.Perm),
+			pilorama.WithNoSync(prRead.NoSync),
+			pilorama.WithMaxBatchSize(prRead.MaxBatchSize),
+			pilorama.WithMaxBatchDelay(prRead.MaxBatchDelay),
+		)
+	}
+
+	var ss []blobstor.SubStorage
+	for _, sRead := range shCfg.SubStorages {
+		switch sRead.Typ {
+		case fstree.Type:
+			ss = append(ss, blobstor.SubStorage{
+				Storage: fstree.New(
+					fstree.WithPath(sRead.Path),
+					fstree.WithPerm(sRead.Perm),
+					fstree.WithDepth(sRead.Depth),
+					fstree.WithNoSync(sRead.NoSync)),
+				Policy: func(_ *objectSDK.Object, data []byte) bool {
+					return true
+				},
+				Compression: sRead.Compression(),
+			})
+		case peapod.Type:
+			ss = append(ss, blobstor.SubStorage{
+				Storage: peapod.New(sRead.Path, sRead.Perm, sRead.FlushInterval),
+				Policy: func(_ *objectSDK.Object, data []byte) bool {
+					return uint64(len(data)) < shCfg.SmallSizeObjectLimit
+				},
+				Compression: sRead.Compression(),
+			})
+		case erasure.Type:
+			ss = append(ss, blobstor.SubStorage{
+				Storage: erasure.New(sRead.Path, sRead.Parts, sRead.Parity,
+					erasure.WithPerm(sRead.Perm),
+					erasure.WithDepth(sRead.Depth),
+					erasure.WithNoSync(sRead.NoSync),
+					erasure.WithRepairInterval(sRead.RepairInterval),
+					erasure.WithLogger(c.log)),
+				Policy: func(_ *objectSDK.Object, data []byte) bool {
+					return true
+				},
+				Compression: sRead.Compression(),
+			})
+		default:
+			// should never happen, that has already
+			// been handled: when the config was read
 		}
+	}
 
-		var sh shardOptsWithID
-		sh.configID = shCfg.ID()
-		sh.shOpts = []shard.Option{
-			shard.WithLogger(c.log),
-			shard.WithRefillMetabase(shCfg.RefillMetabase),
-			shard.WithMode(shCfg.Mode),
-			shard.WithBlobStorOptions(
-				blobstor.WithCompressObjects(shCfg.Compress),
-				blobstor.WithUncompressableContentTypes(shCfg.UncompressableContentType),
-				blobstor.WithStorages(ss),
-
-				blobstor.WithLogger(c.log),
-			),
-			shard.WithMetaBaseOptions(
-				meta.WithPath(shCfg.MetaCfg.Path),
-				meta.WithPermissions(shCfg.MetaCfg.Perm),
-				meta.WithMaxBatchSize(shCfg.MetaCfg.MaxBatchSize),
-				meta.WithMaxBatchDelay(shCfg.MetaCfg.MaxBatchDelay),
-				meta.WithBoltDBOptions(&bbolt.Options{
-					Timeout: time.Second,
-				}),
-
-				meta.WithLogger(c.log),
-				meta.WithEpochState(c.cfgNetmap.state),
-			),
-			shard.WithPiloramaOptions(piloramaOpts...),
-			shard.WithWriteCache(shCfg.WritecacheCfg.Enabled),
-			shard.WithWriteCacheOptions(writeCacheOpts...),
-			shard.WithRemoverBatchSize(shCfg.GcCfg.RemoverBatchSize),
-			shard.WithGCRemoverSleepInterval(shCfg.GcCfg.RemoverSleepInterval),
-			shard.WithGCWorkerPoolInitializer(func(sz int) util.WorkerPool {
-				pool, err := ants.NewPool(sz)
-				fatalOnErr(err)
-
-				return pool
+	var sh shardOptsWithID
+	sh.configID = shCfg.ID()
+	sh.shOpts = []shard.Option{
+		shard.WithLogger(c.log),
+		shard.WithRefillMetabase(shCfg.RefillMetabase),
+		shard.WithMode(shCfg.Mode),
+		shard.WithBlobStorOptions(
+			blobstor.WithCompressObjects(shCfg.Compress),
+			blobstor.WithCompressionCodec(shCfg.CompressionCodec, shCfg.CompressionLevel),
+			blobstor.WithUncompressableContentTypes(shCfg.UncompressableContentType),
+			blobstor.WithStorages(ss),
+
+			blobstor.WithLogger(c.log),
+		),
+		shard.WithMetaBaseOptions(
+			meta.WithPath(shCfg.MetaCfg.Path),
+			meta.WithPermissions(shCfg.MetaCfg.Perm),
+			meta.WithMaxBatchSize(shCfg.MetaCfg.MaxBatchSize),
+			meta.WithMaxBatchDelay(shCfg.MetaCfg.MaxBatchDelay),
+			meta.WithIndexedAttributes(shCfg.MetaCfg.IndexedAttributes...),
+			meta.WithChangeLog(shCfg.MetaCfg.ChangeLog),
+			meta.WithBoltDBOptions(&bbolt.Options{
+				Timeout: time.Second,
 			}),
-		}
 
-		shards = append(shards, sh)
+			meta.WithLogger(c.log),
+			meta.WithEpochState(c.cfgNetmap.state),
+		),
+		shard.WithPiloramaOptions(piloramaOpts...),
+		shard.WithWriteCache(shCfg.WritecacheCfg.Enabled),
+		shard.WithWriteCacheOptions(writeCacheOpts...),
+		shard.WithRemoverBatchSize(shCfg.GcCfg.RemoverBatchSize),
+		shard.WithGCRemoverSleepInterval(shCfg.GcCfg.RemoverSleepInterval),
+		shard.WithIOLimits(shCfg.IOLimits),
+		shard.WithScrubConfig(shCfg.Scrub),
+		shard.WithIntegrityValidator(objectcore.NewFormatValidator()),
+		shard.WithHealthyCopySource(c.cfgObject.healthyCopies),
+		shard.WithGCWorkerPoolInitializer(func(sz int) util.WorkerPool {
+			pool, err := ants.NewPool(sz)
+			fatalOnErr(err)
+
+			return pool
+		}),
 	}
 
-	return shards
+	return sh
 }
@@ -6,11 +6,17 @@ import (
 	"strings"
 	"time"
 
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/compression"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
 	shardmode "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/writecache"
 )
 
 type ShardCfg struct {
 	Compress                  bool
+	CompressionCodec          string
+	CompressionLevel          int
 	SmallSizeObjectLimit      uint64
 	UncompressableContentType []string
 	RefillMetabase            bool
@@ -21,6 +27,9 @@ type ShardCfg struct {
 		Perm          fs.FileMode
 		MaxBatchSize  int
 		MaxBatchDelay time.Duration
+
+		IndexedAttributes []string
+		ChangeLog         bool
 	}
 
 	SubStorages []SubStorageCfg
@@ -30,6 +39,10 @@ type ShardCfg struct {
 		RemoverSleepInterval time.Duration
 	}
 
+	IOLimits throttle.Limits
+
+	Scrub shard.ScrubConfig
+
 	WritecacheCfg struct {
 		Enabled          bool
 		Path             string
@@ -40,6 +53,7 @@ type ShardCfg struct {
 		FlushWorkerCount int
 		SizeLimit        uint64
 		NoSync           bool
+		FlushPolicy      writecache.FlushPolicy
 	}
 
 	PiloramaCfg struct {
@@ -57,12 +71,35 @@ type SubStorageCfg struct {
 	Path string
 	Perm fs.FileMode
 
+	// compression overrides, shard-wide settings are used if codec is empty
+	CompressionCodec string
+	CompressionLevel int
+
 	// tree-specific (FS)
 	Depth  uint64
 	NoSync bool
 
 	// Peapod-specific
 	FlushInterval time.Duration
+
+	// erasure-specific, Depth and NoSync are shared with FS tree
+	Parts          []string
+	Parity         int
+	RepairInterval time.Duration
+}
+
+// Compression returns sub-storage specific compression settings or nil if
+// shard-wide ones should be used.
+func (c *SubStorageCfg) Compression() *compression.Config {
+	if c.CompressionCodec == "" {
+		return nil
+	}
+
+	return &compression.Config{
+		Enabled: true,
+		Codec:   c.CompressionCodec,
+		Level:   c.CompressionLevel,
+	}
 }
 
 // ID returns persistent id of a shard. It is different from the ID used in runtime
@@ -41,7 +41,7 @@ func initTreeService(c *cfg) {
 		return
 	}
 
-	c.treeService = tree.New(
+	opts := []tree.Option{
 		tree.WithContainerSource(cnrSource{
 			src: c.cfgObject.cnrSource,
 			cli: c.shared.basics.cCli,
@@ -54,7 +54,15 @@ func initTreeService(c *cfg) {
 		tree.WithContainerCacheSize(treeConfig.CacheSize()),
 		tree.WithReplicationTimeout(treeConfig.ReplicationTimeout()),
 		tree.WithReplicationChannelCapacity(treeConfig.ReplicationChannelCapacity()),
-		tree.WithReplicationWorkerCount(treeConfig.ReplicationWorkerCount()))
+		tree.WithReplicationWorkerCount(treeConfig.ReplicationWorkerCount()),
+		tree.WithLogCompaction(treeConfig.LogCompaction()),
+	}
+
+	if c.metricsCollector != nil {
+		opts = append(opts, tree.WithMetrics(c.metricsCollector))
+	}
+
+	c.treeService = tree.New(opts...)
 
 	for _, srv := range c.cfgGRPC.servers {
 		tree.RegisterTreeServiceServer(srv, c.treeService)
@@ -7,8 +7,10 @@ import (
 	"github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config"
 	engineconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine"
 	shardconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard"
+	erasureconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/engine/shard/blobstor/erasure"
 	loggerconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/logger"
 	treeconfig "github.com/epicchainlabs/epicchain-node/cmd/epicchain-node/config/tree"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/erasure"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/fstree"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/peapod"
 	"go.uber.org/zap/zapcore"
@@ -55,6 +57,24 @@ func validateConfig(c *config.Config) error {
 		for i := range blobstor {
 			switch blobstor[i].Type() {
 			case fstree.Type, peapod.Type:
+			case erasure.Type:
+				if i != len(blobstor)-1 {
+					return fmt.Errorf("erasure blobstor component must be the last one (shard %d)", shardNum)
+				}
+
+				ec := erasureconfig.From((*config.Config)(blobstor[i]))
+				parts := ec.Parts()
+				if ec.Parity() >= len(parts) {
+					return fmt.Errorf("erasure blobstor component has %d parity parts of %d total (shard %d)",
+						ec.Parity(), len(parts), shardNum)
+				}
+
+				for j := range parts {
+					err := addPath(paths, fmt.Sprintf("blobstor[%d].parts[%d]", i, j), shardNum, parts[j])
+					if err != nil {
+						return err
+					}
+				}
 			default:
 				// FIXME #1764 (@fyrchik): this line is currently unreachable,
 				//   because we panic in `sc.BlobStor().Storages()`.
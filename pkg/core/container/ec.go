@@ -0,0 +1,85 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/epicchainlabs/epicchain-sdk-go/container"
+)
+
+// Container attributes the container owner can enable erasure coding of the
+// container objects with.
+const (
+	AttributeECDataParts   = "__NEOFS__EC_DATA_PARTS"
+	AttributeECParityParts = "__NEOFS__EC_PARITY_PARTS"
+)
+
+// MaxECParts is the maximum total number of data and parity parts.
+const MaxECParts = 256
+
+// ErasureCoding describes erasure coding of the container objects. Payload of
+// each regular object is split into DataParts parts, ParityParts parity parts
+// are computed from them, and every part is stored on a separate container
+// node. Any DataParts parts are enough to restore the payload. Zero value
+// means erasure coding is disabled and objects are replicated according to
+// the placement policy.
+type ErasureCoding struct {
+	DataParts   int
+	ParityParts int
+}
+
+// Enabled checks whether erasure coding is enabled.
+func (x ErasureCoding) Enabled() bool {
+	return x.DataParts > 0
+}
+
+// TotalParts returns the total number of object parts.
+func (x ErasureCoding) TotalParts() int {
+	return x.DataParts + x.ParityParts
+}
+
+// String implements fmt.Stringer.
+func (x ErasureCoding) String() string {
+	return fmt.Sprintf("EC %d/%d", x.DataParts, x.ParityParts)
+}
+
+// ErasureCodingFromAttributes reads erasure coding parameters set by the
+// container owner in the container attributes. Both attributes must be set
+// to enable erasure coding, missing attributes mean it is disabled.
+func ErasureCodingFromAttributes(cnr container.Container) (ErasureCoding, error) {
+	data, parity := cnr.Attribute(AttributeECDataParts), cnr.Attribute(AttributeECParityParts)
+	if data == "" && parity == "" {
+		return ErasureCoding{}, nil
+	}
+
+	if data == "" || parity == "" {
+		return ErasureCoding{}, fmt.Errorf("both %s and %s attributes must be set", AttributeECDataParts, AttributeECParityParts)
+	}
+
+	var (
+		x   ErasureCoding
+		err error
+	)
+
+	x.DataParts, err = strconv.Atoi(data)
+	if err != nil {
+		return ErasureCoding{}, fmt.Errorf("invalid %s attribute: %w", AttributeECDataParts, err)
+	}
+
+	x.ParityParts, err = strconv.Atoi(parity)
+	if err != nil {
+		return ErasureCoding{}, fmt.Errorf("invalid %s attribute: %w", AttributeECParityParts, err)
+	}
+
+	switch {
+	case x.DataParts <= 0:
+		return ErasureCoding{}, errors.New("number of data parts must be positive")
+	case x.ParityParts <= 0:
+		return ErasureCoding{}, errors.New("number of parity parts must be positive")
+	case x.TotalParts() > MaxECParts:
+		return ErasureCoding{}, fmt.Errorf("too many parts %d, max %d", x.TotalParts(), MaxECParts)
+	}
+
+	return x, nil
+}
@@ -0,0 +1,122 @@
+package container
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/epicchainlabs/epicchain-sdk-go/container"
+	"github.com/epicchainlabs/neofs-api-go/v2/object"
+	"github.com/epicchainlabs/neofs-api-go/v2/status"
+)
+
+// Container attributes the container owner can set the quota with.
+const (
+	AttributeQuotaSoftSize    = "__NEOFS__QUOTA_SOFT_SIZE"
+	AttributeQuotaHardSize    = "__NEOFS__QUOTA_HARD_SIZE"
+	AttributeQuotaSoftObjects = "__NEOFS__QUOTA_SOFT_OBJECTS"
+	AttributeQuotaHardObjects = "__NEOFS__QUOTA_HARD_OBJECTS"
+)
+
+// Quota describes limits of the container data stored on a node. Sizes are
+// payload sizes in bytes, objects are the numbers of regular objects. Zero
+// value means no limit.
+//
+// Soft limits are advisory: exceeding them is reported but objects are
+// still accepted. Exceeding hard limits makes the node reject new objects.
+type Quota struct {
+	SoftSize    uint64 `json:"soft_size,omitempty"`
+	HardSize    uint64 `json:"hard_size,omitempty"`
+	SoftObjects uint64 `json:"soft_objects,omitempty"`
+	HardObjects uint64 `json:"hard_objects,omitempty"`
+}
+
+// IsZero checks whether the quota sets no limits.
+func (q Quota) IsZero() bool {
+	return q == Quota{}
+}
+
+// Merge returns the quota with the strictest limits of q and other.
+func (q Quota) Merge(other Quota) Quota {
+	return Quota{
+		SoftSize:    minLimit(q.SoftSize, other.SoftSize),
+		HardSize:    minLimit(q.HardSize, other.HardSize),
+		SoftObjects: minLimit(q.SoftObjects, other.SoftObjects),
+		HardObjects: minLimit(q.HardObjects, other.HardObjects),
+	}
+}
+
+func minLimit(a, b uint64) uint64 {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// QuotaFromAttributes reads the quota set by the container owner in the
+// container attributes. Missing attributes mean no limit.
+func QuotaFromAttributes(cnr container.Container) (Quota, error) {
+	var (
+		q   Quota
+		err error
+	)
+
+	for _, a := range []struct {
+		key string
+		dst *uint64
+	}{
+		{AttributeQuotaSoftSize, &q.SoftSize},
+		{AttributeQuotaHardSize, &q.HardSize},
+		{AttributeQuotaSoftObjects, &q.SoftObjects},
+		{AttributeQuotaHardObjects, &q.HardObjects},
+	} {
+		v := cnr.Attribute(a.key)
+		if v == "" {
+			continue
+		}
+
+		*a.dst, err = strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return Quota{}, fmt.Errorf("invalid %s attribute: %w", a.key, err)
+		}
+	}
+
+	return q, nil
+}
+
+// StatusQuotaExceeded is a local object failure status code returned when
+// the container quota is exceeded. Unlike the internal server error, it tells
+// the client that retrying the same PUT on this node is useless.
+const StatusQuotaExceeded status.Code = 6
+
+// QuotaExceeded describes the status of the object PUT failure caused by the
+// exceeded container quota.
+type QuotaExceeded struct {
+	msg string
+}
+
+// NewQuotaExceeded returns QuotaExceeded status with the given message.
+func NewQuotaExceeded(msg string) QuotaExceeded {
+	return QuotaExceeded{msg: msg}
+}
+
+func (x QuotaExceeded) Error() string {
+	return fmt.Sprintf("status: code = %d message = %s", x.code(), x.message())
+}
+
+func (x QuotaExceeded) message() string {
+	return "container quota exceeded: " + x.msg
+}
+
+func (x QuotaExceeded) code() status.Code {
+	c := StatusQuotaExceeded
+	object.GlobalizeFail(&c)
+	return c
+}
+
+// ErrorToV2 implements apistatus.StatusV2 interface.
+func (x QuotaExceeded) ErrorToV2() *status.Status {
+	var st status.Status
+	st.SetCode(x.code())
+	st.SetMessage(x.message())
+	return &st
+}
@@ -1,16 +1,19 @@
 package object
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"strconv"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/core/netmap"
+	"github.com/epicchainlabs/epicchain-sdk-go/checksum"
 	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
 	"github.com/epicchainlabs/epicchain-sdk-go/object"
 	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
 	"github.com/epicchainlabs/epicchain-sdk-go/storagegroup"
+	"github.com/epicchainlabs/tzhash/tz"
 )
 
 // FormatValidator represents an object format validator.
@@ -215,6 +218,54 @@ func (v *FormatValidator) validateSignatureKey(obj *object.Object) error {
 	return nil
 }
 
+// ValidateIntegrity checks that the stored object is not damaged: its
+// identifier and signature correspond to the header, parent header is signed
+// correctly, payload size and checksums correspond to the payload.
+//
+// Unlike Validate, it does not depend on the network state, so expired
+// objects are not reported.
+func (v *FormatValidator) ValidateIntegrity(obj *object.Object) error {
+	if obj == nil {
+		return errNilObject
+	}
+
+	if err := obj.CheckHeaderVerificationFields(); err != nil {
+		return fmt.Errorf("invalid header: %w", err)
+	}
+
+	if err := v.validateSignatureKey(obj); err != nil {
+		return fmt.Errorf("invalid signature key: %w", err)
+	}
+
+	if par := obj.Parent(); par != nil && par.Signature() != nil {
+		if err := par.CheckHeaderVerificationFields(); err != nil {
+			return fmt.Errorf("invalid parent header: %w", err)
+		}
+	}
+
+	payload := obj.Payload()
+
+	if size := obj.PayloadSize(); size != uint64(len(payload)) {
+		return fmt.Errorf("payload size mismatch: header %d, actual %d", size, len(payload))
+	}
+
+	if err := obj.VerifyPayloadChecksum(); err != nil {
+		return err
+	}
+
+	if cs, ok := obj.PayloadHomomorphicHash(); ok {
+		if cs.Type() != checksum.TZ {
+			return fmt.Errorf("unsupported homomorphic checksum type %s", cs.Type())
+		}
+
+		if h := tz.Sum(payload); !bytes.Equal(cs.Value(), h[:]) {
+			return errors.New("payload homomorphic checksum mismatch")
+		}
+	}
+
+	return nil
+}
+
 // ContentMeta describes NeoFS meta information that brings object's payload if the object
 // is one of:
 //   - object.TypeTombstone;
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/epicchainlabs/epicchain-go/pkg/crypto/keys"
+	"github.com/epicchainlabs/epicchain-sdk-go/checksum"
 	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
 	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
 	neofscrypto "github.com/epicchainlabs/epicchain-sdk-go/crypto"
@@ -16,6 +17,7 @@ import (
 	sessiontest "github.com/epicchainlabs/epicchain-sdk-go/session/test"
 	"github.com/epicchainlabs/epicchain-sdk-go/storagegroup"
 	"github.com/epicchainlabs/epicchain-sdk-go/user"
+	"github.com/epicchainlabs/tzhash/tz"
 	"github.com/stretchr/testify/require"
 )
 
@@ -409,3 +411,71 @@ func TestLinkObjectSplitV2(t *testing.T) {
 		})
 	})
 }
+
+func TestFormatValidator_ValidateIntegrity(t *testing.T) {
+	v := NewFormatValidator()
+
+	ownerKey, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	signer := user.NewAutoIDSignerRFC6979(ownerKey.PrivateKey)
+
+	homoHash := func(payload []byte) checksum.Checksum {
+		var cs checksum.Checksum
+		cs.SetTillichZemor(tz.Sum(payload))
+		return cs
+	}
+
+	newObject := func(t *testing.T) *object.Object {
+		obj := blankValidObject(signer)
+		obj.SetPayload([]byte("Hello, world!"))
+		obj.SetPayloadSize(uint64(len(obj.Payload())))
+		obj.SetPayloadHomomorphicHash(homoHash(obj.Payload()))
+
+		var a object.Attribute
+		a.SetKey(object.AttributeExpirationEpoch)
+		a.SetValue("1") // expired objects are not damaged
+		obj.SetAttributes(a)
+
+		require.NoError(t, obj.SetVerificationFields(signer))
+
+		return obj
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		require.NoError(t, v.ValidateIntegrity(newObject(t)))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		require.ErrorIs(t, v.ValidateIntegrity(nil), errNilObject)
+	})
+
+	t.Run("damaged header", func(t *testing.T) {
+		obj := newObject(t)
+		obj.SetCreationEpoch(obj.CreationEpoch() + 1)
+
+		require.Error(t, v.ValidateIntegrity(obj))
+	})
+
+	t.Run("damaged payload", func(t *testing.T) {
+		obj := newObject(t)
+		obj.Payload()[0]++
+
+		require.Error(t, v.ValidateIntegrity(obj))
+	})
+
+	t.Run("truncated payload", func(t *testing.T) {
+		obj := newObject(t)
+		obj.SetPayload(obj.Payload()[1:])
+
+		require.Error(t, v.ValidateIntegrity(obj))
+	})
+
+	t.Run("damaged homomorphic hash", func(t *testing.T) {
+		obj := newObject(t)
+		obj.SetPayloadHomomorphicHash(homoHash([]byte("other")))
+		require.NoError(t, obj.SetIDWithSignature(signer))
+
+		require.Error(t, v.ValidateIntegrity(obj))
+	})
+}
@@ -0,0 +1,128 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+)
+
+// X-headers of the multipart upload PUT requests. Multipart upload allows to
+// upload the object payload in independent parts in any order and in
+// parallel. The node handling the upload keeps its state, so all requests of
+// the upload must be sent to the same node.
+const (
+	// XHeaderMultipartAction is a key of the X-header with the multipart
+	// upload operation: "init", "part", "complete" or "abort".
+	XHeaderMultipartAction = "__NEOFS__MULTIPART_ACTION"
+	// XHeaderMultipartUpload is a key of the X-header with the upload ID
+	// returned in the object ID field of the "init" operation response.
+	XHeaderMultipartUpload = "__NEOFS__MULTIPART_UPLOAD"
+	// XHeaderMultipartPart is a key of the X-header with the number of the
+	// uploaded part as a base-10 integer from 1 to [MaxMultipartParts]. Parts
+	// are joined in ascending order of their numbers.
+	XHeaderMultipartPart = "__NEOFS__MULTIPART_PART"
+)
+
+// MaxMultipartParts is the maximum number of parts in the multipart upload.
+const MaxMultipartParts = 10000
+
+// MultipartAction enumerates multipart upload operations.
+type MultipartAction uint8
+
+const (
+	// MultipartNone is a regular PUT.
+	MultipartNone MultipartAction = iota
+	// MultipartInit starts the upload of the object with the header from the
+	// request.
+	MultipartInit
+	// MultipartPart uploads the payload part.
+	MultipartPart
+	// MultipartComplete joins the uploaded parts into the object.
+	MultipartComplete
+	// MultipartAbort cancels the upload and removes the uploaded parts.
+	MultipartAbort
+)
+
+var multipartActions = map[string]MultipartAction{
+	"init":     MultipartInit,
+	"part":     MultipartPart,
+	"complete": MultipartComplete,
+	"abort":    MultipartAbort,
+}
+
+// MultipartRequest describes multipart upload operation of the PUT request.
+type MultipartRequest struct {
+	// Action is the requested operation, MultipartNone for regular PUT.
+	Action MultipartAction
+	// Upload is the ID of the upload, not set for MultipartInit.
+	Upload oid.ID
+	// Part is the part number, set for MultipartPart only.
+	Part uint32
+}
+
+// MultipartRequestFromXHeaders reads MultipartRequest from the request
+// X-headers given as key-value pairs.
+func MultipartRequestFromXHeaders(xHdrs []string) (MultipartRequest, error) {
+	var (
+		res               MultipartRequest
+		uploadSet, numSet bool
+	)
+
+	for i := 0; i+1 < len(xHdrs); i += 2 {
+		switch key, val := xHdrs[i], xHdrs[i+1]; key {
+		case XHeaderMultipartAction:
+			a, ok := multipartActions[val]
+			if !ok {
+				return res, fmt.Errorf("invalid %s X-header: unknown action %q", key, val)
+			}
+
+			res.Action = a
+		case XHeaderMultipartUpload:
+			err := res.Upload.DecodeString(val)
+			if err != nil {
+				return res, fmt.Errorf("invalid %s X-header: %w", key, err)
+			}
+
+			uploadSet = true
+		case XHeaderMultipartPart:
+			n, err := strconv.ParseUint(val, 10, 32)
+			if err != nil {
+				return res, fmt.Errorf("invalid %s X-header: %w", key, err)
+			}
+
+			if n == 0 || n > MaxMultipartParts {
+				return res, fmt.Errorf("invalid %s X-header: part number %d out of range", key, n)
+			}
+
+			res.Part = uint32(n)
+			numSet = true
+		}
+	}
+
+	switch res.Action {
+	case MultipartNone:
+		if uploadSet || numSet {
+			return res, fmt.Errorf("missing %s X-header", XHeaderMultipartAction)
+		}
+	case MultipartInit:
+		if uploadSet || numSet {
+			return res, errors.New("upload ID and part number must not be set on init")
+		}
+	case MultipartPart:
+		if !uploadSet || !numSet {
+			return res, errors.New("upload ID and part number are required to upload the part")
+		}
+	default:
+		if !uploadSet {
+			return res, fmt.Errorf("missing %s X-header", XHeaderMultipartUpload)
+		}
+
+		if numSet {
+			return res, errors.New("part number must not be set")
+		}
+	}
+
+	return res, nil
+}
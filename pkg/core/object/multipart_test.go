@@ -0,0 +1,51 @@
+package object
+
+import (
+	"testing"
+
+	oidtest "github.com/epicchainlabs/epicchain-sdk-go/object/id/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultipartRequestFromXHeaders(t *testing.T) {
+	req, err := MultipartRequestFromXHeaders([]string{"key", "val"})
+	require.NoError(t, err)
+	require.Zero(t, req)
+
+	req, err = MultipartRequestFromXHeaders([]string{XHeaderMultipartAction, "init"})
+	require.NoError(t, err)
+	require.Equal(t, MultipartInit, req.Action)
+
+	id := oidtest.ID()
+
+	req, err = MultipartRequestFromXHeaders([]string{
+		"key", "val",
+		XHeaderMultipartAction, "part",
+		XHeaderMultipartUpload, id.EncodeToString(),
+		XHeaderMultipartPart, "10",
+	})
+	require.NoError(t, err)
+	require.Equal(t, MultipartRequest{Action: MultipartPart, Upload: id, Part: 10}, req)
+
+	req, err = MultipartRequestFromXHeaders([]string{
+		XHeaderMultipartAction, "complete",
+		XHeaderMultipartUpload, id.EncodeToString(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, MultipartRequest{Action: MultipartComplete, Upload: id}, req)
+
+	for _, xs := range [][]string{
+		{XHeaderMultipartAction, "unknown"},
+		{XHeaderMultipartUpload, id.EncodeToString()},
+		{XHeaderMultipartAction, "init", XHeaderMultipartUpload, id.EncodeToString()},
+		{XHeaderMultipartAction, "part", XHeaderMultipartUpload, id.EncodeToString()},
+		{XHeaderMultipartAction, "part", XHeaderMultipartUpload, id.EncodeToString(), XHeaderMultipartPart, "0"},
+		{XHeaderMultipartAction, "part", XHeaderMultipartUpload, id.EncodeToString(), XHeaderMultipartPart, "10001"},
+		{XHeaderMultipartAction, "abort", XHeaderMultipartUpload, "not an ID"},
+		{XHeaderMultipartAction, "abort", XHeaderMultipartUpload, id.EncodeToString(), XHeaderMultipartPart, "1"},
+		{XHeaderMultipartAction, "complete"},
+	} {
+		_, err = MultipartRequestFromXHeaders(xs)
+		require.Error(t, err, xs)
+	}
+}
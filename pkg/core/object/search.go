@@ -0,0 +1,57 @@
+package object
+
+import (
+	"fmt"
+	"strconv"
+
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+)
+
+// X-headers of the paginated SEARCH requests. Objects are returned ordered by
+// their IDs, the last ID of the page is the cursor of the next one.
+const (
+	// XHeaderSearchLimit is a key of the X-header with the maximum number of
+	// objects to return as a base-10 integer.
+	XHeaderSearchLimit = "__NEOFS__SEARCH_LIMIT"
+	// XHeaderSearchCursor is a key of the X-header with the object ID to
+	// return objects following it.
+	XHeaderSearchCursor = "__NEOFS__SEARCH_CURSOR"
+)
+
+// SearchPage describes the page of the paginated SEARCH.
+type SearchPage struct {
+	// Limit is the maximum number of objects on the page. Zero means no
+	// pagination.
+	Limit uint32
+	// Cursor is the last object of the previous page, nil for the first page.
+	Cursor *oid.ID
+}
+
+// SearchPageFromXHeaders reads SearchPage from the request X-headers given as
+// key-value pairs.
+func SearchPageFromXHeaders(xHdrs []string) (SearchPage, error) {
+	var res SearchPage
+
+	for i := 0; i+1 < len(xHdrs); i += 2 {
+		switch key, val := xHdrs[i], xHdrs[i+1]; key {
+		case XHeaderSearchLimit:
+			n, err := strconv.ParseUint(val, 10, 32)
+			if err != nil {
+				return res, fmt.Errorf("invalid %s X-header: %w", key, err)
+			}
+
+			res.Limit = uint32(n)
+		case XHeaderSearchCursor:
+			var id oid.ID
+
+			err := id.DecodeString(val)
+			if err != nil {
+				return res, fmt.Errorf("invalid %s X-header: %w", key, err)
+			}
+
+			res.Cursor = &id
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,31 @@
+package object
+
+import (
+	"testing"
+
+	oidtest "github.com/epicchainlabs/epicchain-sdk-go/object/id/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchPageFromXHeaders(t *testing.T) {
+	page, err := SearchPageFromXHeaders([]string{"key", "val"})
+	require.NoError(t, err)
+	require.Zero(t, page)
+
+	id := oidtest.ID()
+
+	page, err = SearchPageFromXHeaders([]string{
+		"key", "val",
+		XHeaderSearchLimit, "100",
+		XHeaderSearchCursor, id.EncodeToString(),
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 100, page.Limit)
+	require.Equal(t, &id, page.Cursor)
+
+	_, err = SearchPageFromXHeaders([]string{XHeaderSearchLimit, "-1"})
+	require.Error(t, err)
+
+	_, err = SearchPageFromXHeaders([]string{XHeaderSearchCursor, "not an ID"})
+	require.Error(t, err)
+}
@@ -142,6 +142,9 @@ type (
 		name string
 		from uint32 // block height
 
+		// last processed block height storage
+		height event.BlockHeightStorage
+
 		withAutoSidechainScope bool
 	}
 )
@@ -244,27 +247,9 @@ func (s *Server) Start(ctx context.Context, intError chan<- error) (err error) {
 			zap.Uint32("index", b.Index),
 		)
 
-		err = s.persistate.SetUInt32(persistateSideChainLastBlockKey, b.Index)
-		if err != nil {
-			s.log.Warn("can't update persistent state",
-				zap.String("chain", "side"),
-				zap.Uint32("block_index", b.Index))
-		}
-
 		s.tickTimers(b.Index)
 	})
 
-	if !s.withoutMainNet {
-		s.mainnetListener.RegisterBlockHandler(func(b *block.Block) {
-			err = s.persistate.SetUInt32(persistateMainChainLastBlockKey, b.Index)
-			if err != nil {
-				s.log.Warn("can't update persistent state",
-					zap.String("chain", "main"),
-					zap.Uint32("block_index", b.Index))
-			}
-		})
-	}
-
 	for _, runner := range s.runners {
 		if err := runner(intError); err != nil {
 			return err
@@ -347,6 +332,8 @@ func New(ctx context.Context, log *zap.Logger, cfg *viper.Viper, errChan chan<-
 		cfg:  cfg,
 		name: morphPrefix,
 		from: fromSideChainBlock,
+
+		height: server.persistate.BlockHeight(persistateSideChainLastBlockKey),
 	}
 
 	const walletPathKey = "wallet.path"
@@ -605,6 +592,7 @@ func New(ctx context.Context, log *zap.Logger, cfg *viper.Viper, errChan chan<-
 			log.Warn("can't get last processed main chain block number", zap.String("error", err.Error()))
 		}
 		mainnetChain.from = fromMainChainBlock
+		mainnetChain.height = server.persistate.BlockHeight(persistateMainChainLastBlockKey)
 
 		// create mainnet client
 		server.mainnetClient, err = server.createClient(ctx, mainnetChain, errChan)
@@ -1056,6 +1044,7 @@ func createListener(cli *client.Client, p chainParams) (event.Listener, error) {
 		Logger:             p.log.With(zap.String("chain", p.name)),
 		Client:             cli,
 		WorkerPoolCapacity: listenerPoolCap,
+		BlockHeightStorage: p.height,
 	})
 	if err != nil {
 		return nil, err
@@ -1092,10 +1081,15 @@ func (s *Server) createClient(ctx context.Context, p chainParams, errChan chan<-
 			errChan <- fmt.Errorf("%s chain connection has been lost", p.name)
 		}),
 		client.WithMinRequiredBlockHeight(p.from),
+		client.WithHealthCheckInterval(p.cfg.GetDuration(p.name + ".health_check_interval")),
+		client.WithSwitchChecksNumber(p.cfg.GetInt(p.name + ".switch_checks_number")),
 	}
 	if p.withAutoSidechainScope {
 		options = append(options, client.WithAutoSidechainScope())
 	}
+	if s.metrics != nil {
+		options = append(options, client.WithMetrics(s.metrics))
+	}
 
 	return client.New(p.key, options...)
 }
@@ -9,6 +9,15 @@ import (
 func (ap *Processor) HandleGasEmission(ev event.Event) {
 	ne := ev.(netmapEvent.NewEpoch)
 
+	// GAS must not be emitted again for the epochs brought by the replayed
+	// blocks
+	if !ap.lastEpoch.Update(ne.EpochNumber()) {
+		ap.log.Info("skip gas emission of the outdated epoch",
+			zap.Uint64("epoch", ne.EpochNumber()),
+			zap.Uint64("last", ap.lastEpoch.Last()))
+		return
+	}
+
 	ap.log.Info("gas emission", zap.Uint64("epoch", ne.EpochNumber()))
 
 	// send event to the worker pool
@@ -9,6 +9,7 @@ import (
 	nmClient "github.com/epicchainlabs/epicchain-node/pkg/morph/client/netmap"
 	"github.com/epicchainlabs/epicchain-node/pkg/morph/event"
 	netmapEvent "github.com/epicchainlabs/epicchain-node/pkg/morph/event/netmap"
+	nodeutil "github.com/epicchainlabs/epicchain-node/pkg/util"
 	"github.com/panjf2000/ants/v2"
 	"go.uber.org/zap"
 )
@@ -40,6 +41,9 @@ type (
 		morphClient       *client.Client
 		irList            Indexer
 		storageEmission   uint64
+
+		// lastEpoch is the epoch of the last handled NewEpoch event.
+		lastEpoch nodeutil.EpochTracker
 	}
 
 	// Params of the processor constructor.
@@ -27,6 +27,12 @@ func (np *Processor) handleNewEpoch(ev event.Event) {
 		zap.String("type", "new epoch"),
 		zap.Uint64("value", epochEvent.EpochNumber()))
 
+	if !np.lastEpoch.Update(epochEvent.EpochNumber()) {
+		np.log.Info("skip new epoch event of the outdated epoch",
+			zap.Uint64("last", np.lastEpoch.Last()))
+		return
+	}
+
 	// send an event to the worker pool
 
 	err := np.pool.Submit(func() {
@@ -10,6 +10,7 @@ import (
 	nmClient "github.com/epicchainlabs/epicchain-node/pkg/morph/client/netmap"
 	"github.com/epicchainlabs/epicchain-node/pkg/morph/event"
 	netmapEvent "github.com/epicchainlabs/epicchain-node/pkg/morph/event/netmap"
+	"github.com/epicchainlabs/epicchain-node/pkg/util"
 	"github.com/epicchainlabs/epicchain-sdk-go/netmap"
 	"github.com/panjf2000/ants/v2"
 	"go.uber.org/zap"
@@ -66,6 +67,9 @@ type (
 		nodeValidator NodeValidator
 
 		nodeStateSettings state.NetworkSettings
+
+		// lastEpoch is the epoch of the last handled NewEpoch event.
+		lastEpoch util.EpochTracker
 	}
 
 	// Params of the processor constructor.
@@ -14,6 +14,11 @@ import (
 type SubStorage struct {
 	Storage common.Storage
 	Policy  func(*objectSDK.Object, []byte) bool
+	// Compression overrides BlobStor-wide compression settings for this
+	// sub-storage if set. Only the codec settings are used by the
+	// sub-storage, UncompressableContentTypes are checked by
+	// BlobStor.NeedsCompression against the BlobStor-wide settings only.
+	Compression *compression.Config
 }
 
 // BlobStor represents NeoFS local BLOB storage.
@@ -34,6 +39,8 @@ type Info struct {
 type SubStorageInfo struct {
 	Type string
 	Path string
+	// Codec is a name of the codec used to compress new objects.
+	Codec string
 }
 
 // Option represents BlobStor's constructor option.
@@ -59,6 +66,10 @@ func New(opts ...Option) *BlobStor {
 	}
 
 	for i := range bs.storage {
+		if bs.storage[i].Compression != nil {
+			bs.storage[i].Storage.SetCompressor(bs.storage[i].Compression)
+			continue
+		}
 		bs.storage[i].Storage.SetCompressor(&bs.compression)
 	}
 
@@ -87,7 +98,8 @@ func WithLogger(l *zap.Logger) Option {
 // WithCompressObjects returns option to toggle
 // compression of the stored objects.
 //
-// If true, Zstandard algorithm is used for data compression.
+// If true, Zstandard algorithm is used for data compression unless
+// another codec is set via WithCompressionCodec.
 //
 // If compressor (decompressor) creation failed,
 // the uncompressed option will be used, and the error
@@ -98,6 +110,19 @@ func WithCompressObjects(comp bool) Option {
 	}
 }
 
+// WithCompressionCodec returns option to set the name of the codec and
+// codec-specific level used for compression of the stored objects. See
+// compression package for supported codecs.
+//
+// Data compressed by any supported codec can be read regardless of the
+// codec configured.
+func WithCompressionCodec(codec string, level int) Option {
+	return func(c *cfg) {
+		c.compression.Codec = codec
+		c.compression.Level = level
+	}
+}
+
 // WithUncompressableContentTypes returns option to disable decompression
 // for specific content types as seen by object.AttributeContentType attribute.
 func WithUncompressableContentTypes(values []string) Option {
@@ -0,0 +1,128 @@
+package common
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+)
+
+const (
+	// payloadField is a number of the payload field in the object message.
+	payloadField = 4
+
+	// maxHeaderSize limits size of the object fields preceding the payload
+	// to protect from reading the corrupted data into memory.
+	maxHeaderSize = 4 << 20
+)
+
+// ReadHeader reads the object in a canonical NeoFS binary format from r up to
+// the payload and returns the object without payload. The returned reader
+// reads exactly the payload from r and returns [io.ErrUnexpectedEOF] if r ends
+// earlier. Payload must be the last field of the object like it is in the
+// canonical format.
+func ReadHeader(r io.Reader) (*objectSDK.Object, io.Reader, error) {
+	br := bufio.NewReader(r)
+
+	var (
+		hdr       []byte
+		payloadLn uint64
+		payload   bool
+	)
+
+	for {
+		tag, err := binary.ReadUvarint(br)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// object without payload
+				break
+			}
+			return nil, nil, fmt.Errorf("read field tag: %w", err)
+		}
+
+		if tag&7 != 2 {
+			return nil, nil, fmt.Errorf("unexpected wire type %d of field #%d", tag&7, tag>>3)
+		}
+
+		ln, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read length of field #%d: %w", tag>>3, unexpectedEOF(err))
+		}
+
+		if tag>>3 == payloadField {
+			payloadLn = ln
+			payload = true
+			break
+		}
+
+		if ln > maxHeaderSize-uint64(len(hdr)) {
+			return nil, nil, fmt.Errorf("object header exceeds %d bytes", maxHeaderSize)
+		}
+
+		hdr = binary.AppendUvarint(hdr, tag)
+		hdr = binary.AppendUvarint(hdr, ln)
+
+		off := len(hdr)
+		hdr = append(hdr, make([]byte, ln)...)
+
+		_, err = io.ReadFull(br, hdr[off:])
+		if err != nil {
+			return nil, nil, fmt.Errorf("read field #%d: %w", tag>>3, unexpectedEOF(err))
+		}
+	}
+
+	obj := objectSDK.New()
+
+	err := obj.Unmarshal(hdr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode object header: %w", err)
+	}
+
+	if !payload {
+		return obj, eofReader{}, nil
+	}
+
+	return obj, &payloadReader{r: br, left: payloadLn}, nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+type eofReader struct{}
+
+func (eofReader) Read([]byte) (int, error) { return 0, io.EOF }
+
+// payloadReader reads exactly left bytes from r.
+type payloadReader struct {
+	r    io.Reader
+	left uint64
+}
+
+func (x *payloadReader) Read(p []byte) (int, error) {
+	if x.left == 0 {
+		return 0, io.EOF
+	}
+
+	if uint64(len(p)) > x.left {
+		p = p[:x.left]
+	}
+
+	n, err := x.r.Read(p)
+	x.left -= uint64(n)
+
+	if errors.Is(err, io.EOF) {
+		if x.left > 0 {
+			return n, io.ErrUnexpectedEOF
+		}
+		err = nil
+	}
+
+	return n, err
+}
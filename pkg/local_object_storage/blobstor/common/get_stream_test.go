@@ -0,0 +1,67 @@
+package common_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/internal/blobstortest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadHeader(t *testing.T) {
+	t.Run("with payload", func(t *testing.T) {
+		obj := blobstortest.NewObject(1024)
+
+		raw, err := obj.Marshal()
+		require.NoError(t, err)
+
+		hdr, payload, err := common.ReadHeader(bytes.NewReader(raw))
+		require.NoError(t, err)
+		require.Equal(t, obj.CutPayload(), hdr)
+
+		b, err := io.ReadAll(payload)
+		require.NoError(t, err)
+		require.Equal(t, obj.Payload(), b)
+	})
+
+	t.Run("without payload", func(t *testing.T) {
+		obj := blobstortest.NewObject(1024)
+		obj.SetPayload(nil)
+
+		raw, err := obj.Marshal()
+		require.NoError(t, err)
+
+		hdr, payload, err := common.ReadHeader(bytes.NewReader(raw))
+		require.NoError(t, err)
+		require.Equal(t, obj.CutPayload(), hdr)
+
+		b, err := io.ReadAll(payload)
+		require.NoError(t, err)
+		require.Empty(t, b)
+	})
+
+	t.Run("truncated payload", func(t *testing.T) {
+		obj := blobstortest.NewObject(1024)
+
+		raw, err := obj.Marshal()
+		require.NoError(t, err)
+
+		_, payload, err := common.ReadHeader(bytes.NewReader(raw[:len(raw)-1]))
+		require.NoError(t, err)
+
+		_, err = io.ReadAll(payload)
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("truncated header", func(t *testing.T) {
+		obj := blobstortest.NewObject(1024)
+
+		raw, err := obj.Marshal()
+		require.NoError(t, err)
+
+		_, _, err = common.ReadHeader(bytes.NewReader(raw[:10]))
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
@@ -18,6 +18,9 @@ type IteratePrm struct {
 	LazyHandler  func(oid.Address, func() ([]byte, error)) error
 	IgnoreErrors bool
 	ErrorHandler func(oid.Address, error) error
+	// DontDecompress makes storage to pass stored data as is, without
+	// decompression.
+	DontDecompress bool
 }
 
 // IterateRes groups the resulting values of Iterate operation.
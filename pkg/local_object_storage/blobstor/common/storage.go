@@ -2,8 +2,10 @@ package common
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/compression"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
 	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
 )
 
@@ -24,6 +26,10 @@ type Storage interface {
 	// GetBytes reads object by address into memory buffer in a canonical NeoFS
 	// binary format. Returns [apistatus.ObjectNotFound] if object is missing.
 	GetBytes(oid.Address) ([]byte, error)
+	// GetStream reads object header by address and opens its payload stream.
+	// The header is returned without payload, the stream must be closed by the
+	// caller. Returns [apistatus.ObjectNotFound] if object is missing.
+	GetStream(oid.Address) (*objectSDK.Object, io.ReadCloser, error)
 	Get(GetPrm) (GetRes, error)
 	GetRange(GetRangePrm) (GetRangeRes, error)
 	Exists(ExistsPrm) (ExistsRes, error)
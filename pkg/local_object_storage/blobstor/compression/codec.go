@@ -0,0 +1,291 @@
+package compression
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4"
+)
+
+// Names of the codecs supported out of the box.
+const (
+	// CodecZstd is a Zstandard codec. It is used by default.
+	CodecZstd = "zstd"
+	// CodecLZ4 is an LZ4 codec storing data in LZ4 frame format. It is much
+	// faster than Zstandard at the cost of compression ratio, so it suits
+	// frequently accessed data.
+	CodecLZ4 = "lz4"
+	// CodecNone is a pseudo-codec that leaves data untouched. It allows to
+	// turn compression off for particular sub-storages.
+	CodecNone = "none"
+)
+
+// Encoder compresses data.
+type Encoder interface {
+	// Encode returns compressed src.
+	Encode(src []byte) []byte
+	// Close releases all resources occupied by the Encoder.
+	Close() error
+}
+
+// Decoder decompresses data compressed by the corresponding Encoder.
+type Decoder interface {
+	// Decode returns decompressed src.
+	Decode(src []byte) ([]byte, error)
+	// Close releases all resources occupied by the Decoder.
+	Close() error
+}
+
+// Codec describes compression algorithm that can be used by Config.
+//
+// Data produced by any codec's Encoder must be self-describing, i.e. start with
+// the codec-specific Magic, so codec can be detected when data is read without
+// any external metadata. Codecs with empty Magic are considered producing
+// uncompressed data.
+type Codec struct {
+	// Name is a unique codec name used in configuration.
+	Name string
+	// Magic is a prefix of any data compressed by the codec.
+	Magic []byte
+	// NewEncoder constructs Encoder for the given codec-specific compression
+	// level. Zero level means codec's default level.
+	NewEncoder func(level int) (Encoder, error)
+	// NewDecoder constructs Decoder. Nil for codecs with empty Magic.
+	NewDecoder func() (Decoder, error)
+	// NewReader constructs reader decompressing the stream read from r.
+	// Optional, streams of codecs without it are decompressed with Decoder
+	// in memory.
+	NewReader func(r io.Reader) (io.ReadCloser, error)
+}
+
+var (
+	codecsMtx sync.RWMutex
+	// registered codecs in the registration order which also defines detection
+	// priority.
+	codecs []Codec
+)
+
+func init() {
+	RegisterCodec(Codec{
+		Name:       CodecZstd,
+		Magic:      zstdFrameMagic,
+		NewEncoder: newZstdEncoder,
+		NewDecoder: newZstdDecoder,
+		NewReader:  newZstdReader,
+	})
+	RegisterCodec(Codec{
+		Name:       CodecLZ4,
+		Magic:      lz4FrameMagic,
+		NewEncoder: newLZ4Encoder,
+		NewDecoder: newLZ4Decoder,
+		NewReader:  newLZ4Reader,
+	})
+	RegisterCodec(Codec{
+		Name: CodecNone,
+		NewEncoder: func(int) (Encoder, error) {
+			return noopEncoder{}, nil
+		},
+	})
+}
+
+// RegisterCodec makes the codec available for Config by its name. Codecs
+// with a name or a non-empty magic already registered are rejected.
+//
+// RegisterCodec is intended to be called from init functions, Config instances
+// initialized before registration don't see the codec.
+func RegisterCodec(c Codec) {
+	if c.Name == "" || c.NewEncoder == nil {
+		panic("compression: codec without name or encoder constructor")
+	}
+	if len(c.Magic) > 0 && c.NewDecoder == nil {
+		panic(fmt.Sprintf("compression: codec %q has magic but no decoder constructor", c.Name))
+	}
+
+	codecsMtx.Lock()
+	defer codecsMtx.Unlock()
+
+	for i := range codecs {
+		if codecs[i].Name == c.Name {
+			panic(fmt.Sprintf("compression: codec %q is already registered", c.Name))
+		}
+		if len(c.Magic) > 0 && len(codecs[i].Magic) > 0 &&
+			(bytes.HasPrefix(c.Magic, codecs[i].Magic) || bytes.HasPrefix(codecs[i].Magic, c.Magic)) {
+			panic(fmt.Sprintf("compression: magic of codec %q conflicts with codec %q", c.Name, codecs[i].Name))
+		}
+	}
+
+	codecs = append(codecs, c)
+}
+
+// Codecs returns names of all registered codecs.
+func Codecs() []string {
+	codecsMtx.RLock()
+	defer codecsMtx.RUnlock()
+
+	res := make([]string, len(codecs))
+	for i := range codecs {
+		res[i] = codecs[i].Name
+	}
+
+	return res
+}
+
+// lookupCodec returns registered codec by name.
+func lookupCodec(name string) (Codec, bool) {
+	codecsMtx.RLock()
+	defer codecsMtx.RUnlock()
+
+	for i := range codecs {
+		if codecs[i].Name == name {
+			return codecs[i], true
+		}
+	}
+
+	return Codec{}, false
+}
+
+// Detect returns name of the codec data was compressed with. CodecNone is
+// returned for data without any known codec magic.
+func Detect(data []byte) string {
+	codecsMtx.RLock()
+	defer codecsMtx.RUnlock()
+
+	for i := range codecs {
+		if len(codecs[i].Magic) > 0 && bytes.HasPrefix(data, codecs[i].Magic) {
+			return codecs[i].Name
+		}
+	}
+
+	return CodecNone
+}
+
+// maxMagicLen returns length of the longest registered codec magic.
+func maxMagicLen() int {
+	codecsMtx.RLock()
+	defer codecsMtx.RUnlock()
+
+	var res int
+	for i := range codecs {
+		if len(codecs[i].Magic) > res {
+			res = len(codecs[i].Magic)
+		}
+	}
+
+	return res
+}
+
+type noopEncoder struct{}
+
+func (noopEncoder) Encode(src []byte) []byte { return src }
+
+func (noopEncoder) Close() error { return nil }
+
+// zstdFrameMagic contains first 4 bytes of any compressed object
+// https://github.com/klauspost/compress/blob/master/zstd/framedec.go#L58 .
+var zstdFrameMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+type zstdEncoder struct {
+	*zstd.Encoder
+}
+
+func newZstdEncoder(level int) (Encoder, error) {
+	var opts []zstd.EOption
+	if level != 0 {
+		opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	}
+
+	enc, err := zstd.NewWriter(nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return zstdEncoder{enc}, nil
+}
+
+func (x zstdEncoder) Encode(src []byte) []byte {
+	return x.EncodeAll(src, make([]byte, 0, x.MaxEncodedSize(len(src))))
+}
+
+type zstdDecoder struct {
+	*zstd.Decoder
+}
+
+func newZstdDecoder() (Decoder, error) {
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return zstdDecoder{dec}, nil
+}
+
+func (x zstdDecoder) Decode(src []byte) ([]byte, error) {
+	return x.DecodeAll(src, nil)
+}
+
+func (x zstdDecoder) Close() error {
+	x.Decoder.Close()
+	return nil
+}
+
+func newZstdReader(r io.Reader) (io.ReadCloser, error) {
+	// single goroutine and low memory mode keep resources used by the
+	// concurrent streams bounded
+	dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1), zstd.WithDecoderLowmem(true))
+	if err != nil {
+		return nil, err
+	}
+
+	return dec.IOReadCloser(), nil
+}
+
+// lz4FrameMagic contains first 4 bytes of any LZ4 frame
+// https://github.com/lz4/lz4/blob/dev/doc/lz4_Frame_format.md#general-structure-of-lz4-frame-format .
+var lz4FrameMagic = []byte{0x04, 0x22, 0x4d, 0x18}
+
+type lz4Encoder struct {
+	level int
+}
+
+func newLZ4Encoder(level int) (Encoder, error) {
+	if level < 0 {
+		return nil, fmt.Errorf("negative LZ4 compression level %d", level)
+	}
+
+	return lz4Encoder{level: level}, nil
+}
+
+func (x lz4Encoder) Encode(src []byte) []byte {
+	var buf bytes.Buffer
+	buf.Grow(lz4.CompressBlockBound(len(src)))
+
+	w := lz4.NewWriter(&buf)
+	w.Header = lz4.Header{CompressionLevel: x.level}
+
+	// writing into bytes.Buffer never fails, so the errors can be ignored
+	_, _ = w.Write(src)
+	_ = w.Close()
+
+	return buf.Bytes()
+}
+
+func (lz4Encoder) Close() error { return nil }
+
+type lz4Decoder struct{}
+
+func newLZ4Decoder() (Decoder, error) {
+	return lz4Decoder{}, nil
+}
+
+func (lz4Decoder) Decode(src []byte) ([]byte, error) {
+	return io.ReadAll(lz4.NewReader(bytes.NewReader(src)))
+}
+
+func (lz4Decoder) Close() error { return nil }
+
+func newLZ4Reader(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(lz4.NewReader(r)), nil
+}
@@ -1,45 +1,88 @@
 package compression
 
 import (
+	"bufio"
 	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"strings"
 
-	"github.com/klauspost/compress/zstd"
 	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
 )
 
 // Config represents common compression-related configuration.
 type Config struct {
-	Enabled                    bool
+	Enabled bool
+	// Codec is a name of the registered codec used to compress data. Zstandard
+	// is used if empty.
+	Codec string
+	// Level is a codec-specific compression level. Zero means codec's default.
+	Level                      int
 	UncompressableContentTypes []string
 
-	encoder *zstd.Encoder
-	decoder *zstd.Decoder
+	encoder  Encoder
+	decoders map[string]Decoder
 }
 
-// zstdFrameMagic contains first 4 bytes of any compressed object
-// https://github.com/klauspost/compress/blob/master/zstd/framedec.go#L58 .
-var zstdFrameMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
-
 // Init initializes compression routines.
+//
+// Decompression is always available for all registered codecs regardless of
+// Enabled, so any data written earlier (including legacy Zstandard-only one)
+// can be read.
 func (c *Config) Init() error {
-	var err error
-
 	if c.Enabled {
-		c.encoder, err = zstd.NewWriter(nil)
+		name := c.codecName()
+
+		codec, ok := lookupCodec(name)
+		if !ok {
+			return fmt.Errorf("unknown compression codec %q", name)
+		}
+
+		var err error
+		c.encoder, err = codec.NewEncoder(c.Level)
 		if err != nil {
-			return err
+			return fmt.Errorf("init %s encoder: %w", name, err)
 		}
 	}
 
-	c.decoder, err = zstd.NewReader(nil)
-	if err != nil {
-		return err
+	codecsMtx.RLock()
+	defer codecsMtx.RUnlock()
+
+	c.decoders = make(map[string]Decoder, len(codecs))
+	for i := range codecs {
+		if codecs[i].NewDecoder == nil {
+			continue
+		}
+
+		dec, err := codecs[i].NewDecoder()
+		if err != nil {
+			return fmt.Errorf("init %s decoder: %w", codecs[i].Name, err)
+		}
+
+		c.decoders[codecs[i].Name] = dec
 	}
 
 	return nil
 }
 
+// codecName returns name of the codec used for compression.
+func (c *Config) codecName() string {
+	if c.Codec == "" {
+		return CodecZstd
+	}
+	return c.Codec
+}
+
+// CodecName returns name of the codec used to compress new data or CodecNone
+// if compression is disabled.
+func (c *Config) CodecName() string {
+	if c == nil || !c.Enabled {
+		return CodecNone
+	}
+	return c.codecName()
+}
+
 // NeedsCompression returns true if the object should be compressed.
 // For an object to be compressed 2 conditions must hold:
 // 1. Compression is enabled in settings.
@@ -71,13 +114,14 @@ func (c *Config) NeedsCompression(obj *objectSDK.Object) bool {
 	return c.Enabled
 }
 
-// IsCompressed checks whether given data is compressed.
+// IsCompressed checks whether given data is compressed
+// by any registered codec.
 func (c *Config) IsCompressed(data []byte) bool {
-	return len(data) >= 4 && bytes.Equal(data[:4], zstdFrameMagic)
+	return Detect(data) != CodecNone
 }
 
 // Decompress decompresses data if it starts with the magic
-// and returns data untouched otherwise.
+// of any registered codec and returns data untouched otherwise.
 func (c *Config) Decompress(data []byte) ([]byte, error) {
 	if !c.IsCompressed(data) {
 		return data, nil
@@ -85,9 +129,59 @@ func (c *Config) Decompress(data []byte) ([]byte, error) {
 	return c.DecompressForce(data)
 }
 
-// DecompressForce decompresses given compressed data.
+// DecompressForce decompresses given compressed data. Codec is detected by
+// the data magic, data without known magic is treated as Zstandard one.
 func (c *Config) DecompressForce(data []byte) ([]byte, error) {
-	return c.decoder.DecodeAll(data, nil)
+	name := Detect(data)
+	if name == CodecNone {
+		name = CodecZstd
+	}
+
+	var dec Decoder
+	ok := c != nil
+	if ok {
+		dec, ok = c.decoders[name]
+	}
+	if !ok {
+		return nil, fmt.Errorf("no decoder for %s codec", name)
+	}
+
+	return dec.Decode(data)
+}
+
+// DecompressReader returns reader of the data read from r decompressed if it
+// starts with the magic of any registered codec and untouched otherwise.
+// Resulting reader must be closed to release the resources, r is not closed.
+func (c *Config) DecompressReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+
+	// EOF is not an error here, short data is just not compressed
+	magic, err := br.Peek(maxMagicLen())
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	name := Detect(magic)
+	if name == CodecNone {
+		return io.NopCloser(br), nil
+	}
+
+	codec, ok := lookupCodec(name)
+	if ok && codec.NewReader != nil {
+		return codec.NewReader(br)
+	}
+
+	data, err := io.ReadAll(br)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = c.DecompressForce(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
 }
 
 // Compress compresses data if compression is enabled
@@ -96,18 +190,21 @@ func (c *Config) Compress(data []byte) []byte {
 	if c == nil || !c.Enabled {
 		return data
 	}
-	maxSize := c.encoder.MaxEncodedSize(len(data))
-	return c.encoder.EncodeAll(data, make([]byte, 0, maxSize))
+	return c.encoder.Encode(data)
 }
 
-// Close closes encoder and decoder, returns any error occurred.
+// Close closes encoder and decoders, returns any error occurred.
 func (c *Config) Close() error {
-	var err error
+	var errs []error
 	if c.encoder != nil {
-		err = c.encoder.Close()
+		if err := c.encoder.Close(); err != nil {
+			errs = append(errs, err)
+		}
 	}
-	if c.decoder != nil {
-		c.decoder.Close()
+	for _, dec := range c.decoders {
+		if err := dec.Close(); err != nil {
+			errs = append(errs, err)
+		}
 	}
-	return err
+	return errors.Join(errs...)
 }
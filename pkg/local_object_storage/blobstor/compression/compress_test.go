@@ -0,0 +1,95 @@
+package compression
+
+import (
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Codecs(t *testing.T) {
+	data := notSoRandomSlice(64*1024, 123)
+
+	for _, name := range Codecs() {
+		t.Run(name, func(t *testing.T) {
+			c := Config{Enabled: true, Codec: name}
+			require.NoError(t, c.Init())
+			t.Cleanup(func() { require.NoError(t, c.Close()) })
+
+			compressed := c.Compress(data)
+			require.Equal(t, name, Detect(compressed))
+			require.Equal(t, name != CodecNone, c.IsCompressed(compressed))
+
+			// any codec must be readable with default settings
+			var rc Config
+			require.NoError(t, rc.Init())
+			t.Cleanup(func() { require.NoError(t, rc.Close()) })
+
+			res, err := rc.Decompress(compressed)
+			require.NoError(t, err)
+			require.Equal(t, data, res)
+		})
+	}
+}
+
+func TestConfig_Level(t *testing.T) {
+	data := notSoRandomSlice(64*1024, 123)
+
+	for _, level := range []int{1, 3, 9, 19} {
+		c := Config{Enabled: true, Codec: CodecZstd, Level: level}
+		require.NoError(t, c.Init())
+
+		res, err := c.Decompress(c.Compress(data))
+		require.NoError(t, err)
+		require.Equal(t, data, res)
+		require.NoError(t, c.Close())
+	}
+}
+
+func TestConfig_LegacyZstd(t *testing.T) {
+	data := notSoRandomSlice(1024, 12)
+
+	enc, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	compressed := enc.EncodeAll(data, nil)
+	require.NoError(t, enc.Close())
+
+	c := Config{Enabled: true, Codec: CodecLZ4}
+	require.NoError(t, c.Init())
+	t.Cleanup(func() { require.NoError(t, c.Close()) })
+
+	require.True(t, c.IsCompressed(compressed))
+	res, err := c.Decompress(compressed)
+	require.NoError(t, err)
+	require.Equal(t, data, res)
+}
+
+func TestConfig_Disabled(t *testing.T) {
+	data := notSoRandomSlice(1024, 12)
+
+	var c Config
+	require.NoError(t, c.Init())
+	t.Cleanup(func() { require.NoError(t, c.Close()) })
+
+	require.Equal(t, data, c.Compress(data))
+	require.Equal(t, CodecNone, c.CodecName())
+}
+
+func TestConfig_UnknownCodec(t *testing.T) {
+	c := Config{Enabled: true, Codec: "unknown"}
+	require.Error(t, c.Init())
+}
+
+func TestRegisterCodec(t *testing.T) {
+	require.Panics(t, func() {
+		RegisterCodec(Codec{Name: CodecZstd, NewEncoder: func(int) (Encoder, error) { return noopEncoder{}, nil }})
+	})
+	require.Panics(t, func() {
+		RegisterCodec(Codec{
+			Name:       "zstd-clone",
+			Magic:      zstdFrameMagic,
+			NewEncoder: func(int) (Encoder, error) { return noopEncoder{}, nil },
+			NewDecoder: newZstdDecoder,
+		})
+	})
+}
@@ -30,6 +30,12 @@ func (b *BlobStor) Init() error {
 	}
 
 	for i := range b.storage {
+		if cc := b.storage[i].Compression; cc != nil {
+			if err := cc.Init(); err != nil {
+				return fmt.Errorf("init compression of substorage %s: %w", b.storage[i].Storage.Type(), err)
+			}
+		}
+
 		err := b.storage[i].Storage.Init()
 		if err != nil {
 			return fmt.Errorf("init substorage %s: %w", b.storage[i].Storage.Type(), err)
@@ -55,6 +61,14 @@ func (b *BlobStor) Close() error {
 		}
 	}
 
+	for i := range b.storage {
+		if cc := b.storage[i].Compression; cc != nil {
+			if err := cc.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
 	err := b.compression.Close()
 	if firstErr == nil {
 		firstErr = err
@@ -0,0 +1,265 @@
+package erasure
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/compression"
+	"github.com/epicchainlabs/epicchain-node/pkg/util"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"github.com/klauspost/reedsolomon"
+	"go.uber.org/zap"
+)
+
+// Storage is a common.Storage that splits each object into data parts,
+// computes Reed-Solomon parity parts for them and stores every part in a
+// separate directory (usually located on a separate disk). An object can be
+// read as long as at most parity number of its parts are lost or corrupted,
+// missing parts are rebuilt on read and by the background repair routine.
+//
+// Storage uses empty storage ID just like FSTree does, so it is expected to be
+// the last sub-storage of the BlobStor.
+type Storage struct {
+	cfg
+
+	compress *compression.Config
+	enc      reedsolomon.Encoder
+	readOnly bool
+
+	// serializes repair passes
+	repairMtx sync.Mutex
+
+	// serialize restored parts writes with object removal
+	objMtx [objectLocks]sync.Mutex
+
+	chClose    chan struct{}
+	repairDone chan struct{}
+}
+
+type cfg struct {
+	path   string
+	parts  []string
+	parity int
+	perm   fs.FileMode
+	depth  uint64
+	noSync bool
+
+	repairInterval time.Duration
+
+	log       *zap.Logger
+	reportErr func(string, error)
+}
+
+// Type is erasure storage type used in logs and configuration.
+const Type = "erasure"
+
+// Various Storage defaults.
+const (
+	// DefaultDepth is a default depth of the directory tree of each part.
+	DefaultDepth = 2
+	// MaxDepth is a maximum depth of the directory tree of each part.
+	MaxDepth = 8
+	// MaxParts is a maximum total number of object parts.
+	MaxParts = 256
+)
+
+// objectLocks is a number of mutexes objects are distributed over to
+// serialize removal and restoration of their parts.
+const objectLocks = 64
+
+var _ common.Storage = (*Storage)(nil)
+
+// errNotEnoughParts is returned when object can't be restored because too many
+// of its parts are missing.
+var errNotEnoughParts = errors.New("not enough object parts")
+
+// Option represents Storage's constructor option.
+type Option func(*cfg)
+
+// WithPerm returns option to set permission bits of the created files and
+// directories.
+func WithPerm(perm fs.FileMode) Option {
+	return func(c *cfg) {
+		c.perm = perm
+	}
+}
+
+// WithDepth returns option to set depth of the directory tree in each part
+// directory.
+func WithDepth(depth uint64) Option {
+	return func(c *cfg) {
+		c.depth = depth
+	}
+}
+
+// WithNoSync returns option to disable fsync of the written parts.
+func WithNoSync(noSync bool) Option {
+	return func(c *cfg) {
+		c.noSync = noSync
+	}
+}
+
+// WithRepairInterval returns option to set time interval between background
+// repair passes. Non-positive value disables background repair.
+func WithRepairInterval(d time.Duration) Option {
+	return func(c *cfg) {
+		c.repairInterval = d
+	}
+}
+
+// WithLogger returns option to specify Storage's logger.
+func WithLogger(l *zap.Logger) Option {
+	return func(c *cfg) {
+		c.log = l
+	}
+}
+
+// New creates new Storage identified by path that keeps object parts in the
+// given directories. The last parity directories hold parity parts, the other
+// ones hold data parts. There must be at least one data part and one parity
+// part, and at most 256 parts in total.
+//
+// Note that resulting Storage is NOT ready-to-go:
+//   - configure compression first (SetCompressor method)
+//   - then open the instance (Open method). Opened Storage must be finally closed
+//   - initialize internal structure (Init method). May be skipped for read-only usage
+func New(path string, parts []string, parity int, opts ...Option) *Storage {
+	s := &Storage{
+		cfg: cfg{
+			path:      path,
+			parts:     parts,
+			parity:    parity,
+			perm:      0o700,
+			depth:     DefaultDepth,
+			log:       zap.NewNop(),
+			reportErr: func(string, error) {},
+		},
+	}
+
+	for i := range opts {
+		opts[i](&s.cfg)
+	}
+
+	return s
+}
+
+// objectLock returns mutex that must be held while parts of the object are
+// being removed or restored.
+func (s *Storage) objectLock(addr oid.Address) *sync.Mutex {
+	id := addr.Object()
+	return &s.objMtx[id[0]%objectLocks]
+}
+
+// dataParts returns number of data parts of each object.
+func (s *Storage) dataParts() int {
+	return len(s.parts) - s.parity
+}
+
+// Open checks the Storage configuration and prepares the Reed-Solomon encoder.
+func (s *Storage) Open(readOnly bool) error {
+	if s.parity <= 0 || s.dataParts() <= 0 || len(s.parts) > MaxParts {
+		return fmt.Errorf("invalid number of parts: %d total, %d parity", len(s.parts), s.parity)
+	}
+	if s.depth > MaxDepth {
+		return fmt.Errorf("too big depth %d > %d", s.depth, MaxDepth)
+	}
+
+	enc, err := reedsolomon.New(s.dataParts(), s.parity)
+	if err != nil {
+		return fmt.Errorf("init Reed-Solomon encoder: %w", err)
+	}
+
+	s.enc = enc
+	s.readOnly = readOnly
+
+	return nil
+}
+
+// Init creates part directories and runs background repair routine if
+// configured.
+func (s *Storage) Init() error {
+	for i := range s.parts {
+		err := util.MkdirAllX(s.parts[i], s.perm)
+		if err != nil {
+			return fmt.Errorf("mkdir all for %q: %w", s.parts[i], err)
+		}
+	}
+
+	if !s.readOnly && s.repairInterval > 0 && s.chClose == nil {
+		s.chClose = make(chan struct{})
+		s.repairDone = make(chan struct{})
+
+		go s.repairLoop()
+	}
+
+	return nil
+}
+
+// Close stops background repair routine.
+func (s *Storage) Close() error {
+	if s.chClose != nil {
+		close(s.chClose)
+		<-s.repairDone
+		s.chClose = nil
+	}
+	return nil
+}
+
+// Type implements common.Storage.
+func (*Storage) Type() string {
+	return Type
+}
+
+// Path implements common.Storage.
+func (s *Storage) Path() string {
+	return s.path
+}
+
+// SetCompressor implements common.Storage.
+func (s *Storage) SetCompressor(cc *compression.Config) {
+	s.compress = cc
+}
+
+// SetReportErrorFunc implements common.Storage.
+func (s *Storage) SetReportErrorFunc(f func(string, error)) {
+	s.reportErr = f
+}
+
+// reportPartErr reports error that occurred with the i-th part directory.
+func (s *Storage) reportPartErr(i int, err error) {
+	s.log.Debug("erasure part failure",
+		zap.String("part", s.parts[i]),
+		zap.Error(err))
+	s.reportErr("erasure part "+s.parts[i], err)
+}
+
+// repairLoop runs Repair once per configured interval until the Storage
+// is closed.
+func (s *Storage) repairLoop() {
+	defer close(s.repairDone)
+
+	t := time.NewTicker(s.repairInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-s.chClose:
+			return
+		case <-t.C:
+			res, err := s.repair(s.chClose)
+			if err != nil {
+				s.log.Warn("erasure repair pass failed", zap.Error(err))
+				continue
+			}
+
+			s.log.Info("erasure repair pass completed",
+				zap.Uint64("checked", res.Checked),
+				zap.Uint64("repaired", res.Repaired),
+				zap.Uint64("unrecoverable", res.Unrecoverable))
+		}
+	}
+}
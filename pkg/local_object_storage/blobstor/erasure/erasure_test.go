@@ -0,0 +1,163 @@
+package erasure_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	objectCore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/erasure"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/internal/blobstortest"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"github.com/stretchr/testify/require"
+)
+
+func newParts(dir string, n int) []string {
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = filepath.Join(dir, strconv.Itoa(i))
+	}
+	return parts
+}
+
+func newStorage(t *testing.T) *erasure.Storage {
+	dir := t.TempDir()
+	return erasure.New(dir, newParts(dir, 5), 2, erasure.WithNoSync(true))
+}
+
+func TestGeneric(t *testing.T) {
+	blobstortest.TestAll(t, func(t *testing.T) common.Storage {
+		return newStorage(t)
+	}, 2048, 16*1024)
+
+	t.Run("info", func(t *testing.T) {
+		dir := t.TempDir()
+		blobstortest.TestInfo(t, func(t *testing.T) common.Storage {
+			return erasure.New(dir, newParts(dir, 3), 1)
+		}, erasure.Type, dir)
+	})
+}
+
+func TestControl(t *testing.T) {
+	blobstortest.TestControl(t, func(t *testing.T) common.Storage {
+		return newStorage(t)
+	}, 2048, 2048)
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, tc := range []struct {
+		parts, parity int
+	}{
+		{parts: 3, parity: 0},
+		{parts: 3, parity: 3},
+		{parts: 0, parity: 1},
+		{parts: 300, parity: 2},
+	} {
+		require.Error(t, erasure.New(dir, newParts(dir, tc.parts), tc.parity).Open(false), tc)
+	}
+}
+
+// removes object part files from the given part directories.
+func removeParts(t *testing.T, dir string, addr oid.Address, parts ...int) {
+	for _, i := range parts {
+		var found bool
+		err := filepath.WalkDir(filepath.Join(dir, strconv.Itoa(i)), func(path string, d os.DirEntry, err error) error {
+			if err == nil && !d.IsDir() && d.Name() == addr.Object().EncodeToString()+"."+addr.Container().EncodeToString() {
+				found = true
+				return os.Remove(path)
+			}
+			return err
+		})
+		require.NoError(t, err)
+		require.True(t, found)
+	}
+}
+
+// corrupts object part files in the given part directories.
+func corruptParts(t *testing.T, dir string, addr oid.Address, parts ...int) {
+	for _, i := range parts {
+		err := filepath.WalkDir(filepath.Join(dir, strconv.Itoa(i)), func(path string, d os.DirEntry, err error) error {
+			if err == nil && !d.IsDir() && d.Name() == addr.Object().EncodeToString()+"."+addr.Container().EncodeToString() {
+				b, err := os.ReadFile(path)
+				require.NoError(t, err)
+				b[len(b)-1]++
+				return os.WriteFile(path, b, 0o600)
+			}
+			return err
+		})
+		require.NoError(t, err)
+	}
+}
+
+func putObject(t *testing.T, s *erasure.Storage) (oid.Address, []byte) {
+	obj := blobstortest.NewObject(4096)
+	addr := objectCore.AddressOf(obj)
+
+	raw, err := obj.Marshal()
+	require.NoError(t, err)
+
+	_, err = s.Put(common.PutPrm{Address: addr, Object: obj, RawData: raw})
+	require.NoError(t, err)
+
+	return addr, raw
+}
+
+func TestStorage_LostParts(t *testing.T) {
+	dir := t.TempDir()
+	s := erasure.New(dir, newParts(dir, 5), 2, erasure.WithNoSync(true))
+	require.NoError(t, s.Open(false))
+	require.NoError(t, s.Init())
+	t.Cleanup(func() { require.NoError(t, s.Close()) })
+
+	t.Run("rebuild on read", func(t *testing.T) {
+		addr, raw := putObject(t, s)
+
+		removeParts(t, dir, addr, 0)
+		corruptParts(t, dir, addr, 4)
+
+		b, err := s.GetBytes(addr)
+		require.NoError(t, err)
+		require.Equal(t, raw, b)
+
+		// all parts are restored, so any two of them may be lost again
+		removeParts(t, dir, addr, 0, 1)
+
+		b, err = s.GetBytes(addr)
+		require.NoError(t, err)
+		require.Equal(t, raw, b)
+	})
+
+	t.Run("too many lost parts", func(t *testing.T) {
+		addr, _ := putObject(t, s)
+
+		removeParts(t, dir, addr, 1, 2, 3)
+
+		_, err := s.GetBytes(addr)
+		require.Error(t, err)
+
+		res, err := s.Exists(common.ExistsPrm{Address: addr})
+		require.NoError(t, err)
+		require.False(t, res.Exists)
+	})
+
+	t.Run("repair", func(t *testing.T) {
+		addr, raw := putObject(t, s)
+
+		removeParts(t, dir, addr, 0, 3)
+
+		res, err := s.Repair()
+		require.NoError(t, err)
+		require.NotZero(t, res.Repaired)
+		require.NotZero(t, res.Unrecoverable) // from the previous test
+
+		removeParts(t, dir, addr, 1, 4)
+
+		b, err := s.GetBytes(addr)
+		require.NoError(t, err)
+		require.Equal(t, raw, b)
+	})
+}
@@ -0,0 +1,137 @@
+package erasure
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+)
+
+// Iterate iterates over all stored objects. Each object is visited once
+// regardless of the number of its parts.
+func (s *Storage) Iterate(prm common.IteratePrm) (common.IterateRes, error) {
+	err := s.iterateAddresses(func(addr oid.Address) error {
+		return s.iterateHandle(addr, prm)
+	}, prm.IgnoreErrors)
+
+	return common.IterateRes{}, err
+}
+
+func (s *Storage) iterateHandle(addr oid.Address, prm common.IteratePrm) error {
+	read := func() ([]byte, error) {
+		data, err := s.readObject(addr)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil, logicerr.Wrap(apistatus.ObjectNotFound{})
+			}
+			return nil, err
+		}
+
+		if !prm.DontDecompress {
+			data, err = s.compress.Decompress(data)
+		}
+
+		return data, err
+	}
+
+	if prm.LazyHandler != nil {
+		return prm.LazyHandler(addr, read)
+	}
+
+	data, err := read()
+	if err != nil {
+		if errors.As(err, new(apistatus.ObjectNotFound)) {
+			// removed concurrently
+			return nil
+		}
+		if prm.IgnoreErrors {
+			if prm.ErrorHandler != nil {
+				return prm.ErrorHandler(addr, err)
+			}
+			return nil
+		}
+		return fmt.Errorf("read object %s: %w", addr, err)
+	}
+
+	return prm.Handler(common.IterationElement{
+		Address:    addr,
+		ObjectData: data,
+		StorageID:  []byte{},
+	})
+}
+
+// iterateAddresses calls f for addresses of all stored objects. An object is
+// reported only from the first part directory containing any of its parts,
+// so each object is visited once.
+func (s *Storage) iterateAddresses(f func(oid.Address) error, ignoreErrors bool) error {
+	for i := range s.parts {
+		err := s.iterateDir(s.parts[i], 0, func(addr oid.Address) error {
+			for j := 0; j < i; j++ {
+				ok, err := s.partExists(j, addr)
+				if err == nil && ok {
+					return nil // already visited
+				}
+			}
+
+			return f(addr)
+		})
+		if err != nil {
+			var pe *fs.PathError
+			if ignoreErrors && errors.As(err, &pe) {
+				s.reportPartErr(i, err)
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Storage) iterateDir(dir string, depth uint64, f func(oid.Address) error) error {
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		if depth > 0 && errors.Is(err, fs.ErrNotExist) {
+			return nil // removed concurrently
+		}
+		return err
+	}
+
+	for i := range des {
+		name := des[i].Name()
+
+		if depth < s.depth {
+			if des[i].IsDir() {
+				err = s.iterateDir(filepath.Join(dir, name), depth+1, f)
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if des[i].IsDir() || strings.Contains(name, "#") {
+			// unknown entry or temporary file
+			continue
+		}
+
+		addr, err := addressFromString(name)
+		if err != nil {
+			continue
+		}
+
+		err = f(addr)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,372 @@
+package erasure
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"syscall"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+)
+
+func isNoSpace(err error) bool {
+	return errors.Is(err, syscall.ENOSPC)
+}
+
+// Put splits the object into parts and writes them into part directories.
+// Put succeeds if at least data number of parts are written, remaining parts
+// are restored later by the repair routine.
+//
+// Put returns common.ErrReadOnly if Storage is read-only.
+func (s *Storage) Put(prm common.PutPrm) (common.PutRes, error) {
+	if s.readOnly {
+		return common.PutRes{}, common.ErrReadOnly
+	}
+
+	if !prm.DontCompress {
+		prm.RawData = s.compress.Compress(prm.RawData)
+	}
+
+	if len(prm.RawData) == 0 {
+		return common.PutRes{}, errors.New("empty object data")
+	}
+
+	shards, err := s.enc.Split(prm.RawData)
+	if err != nil {
+		return common.PutRes{}, fmt.Errorf("split data into parts: %w", err)
+	}
+
+	err = s.enc.Encode(shards)
+	if err != nil {
+		return common.PutRes{}, fmt.Errorf("compute parity parts: %w", err)
+	}
+
+	var (
+		written  int
+		firstErr error
+		noSpace  bool
+	)
+
+	for i := range shards {
+		err = s.writePart(i, prm.Address, part{
+			data:    s.dataParts(),
+			parity:  s.parity,
+			index:   i,
+			size:    uint64(len(prm.RawData)),
+			payload: shards[i],
+		})
+		if err != nil {
+			if errors.Is(err, common.ErrNoSpace) {
+				noSpace = true
+			} else {
+				s.reportPartErr(i, err)
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		written++
+	}
+
+	if written < s.dataParts() {
+		// don't leave garbage, object is unreadable anyway
+		for i := range shards {
+			_ = s.removePart(i, prm.Address)
+		}
+
+		if noSpace {
+			return common.PutRes{}, common.ErrNoSpace
+		}
+
+		return common.PutRes{}, fmt.Errorf("write object parts (%d of %d written): %w", written, len(shards), firstErr)
+	}
+
+	return common.PutRes{StorageID: []byte{}}, nil
+}
+
+// readObject reads and decodes object data in a stored (possibly compressed)
+// form. Missing and corrupted parts are reconstructed and, if the Storage is
+// not read-only, written back. Returns fs.ErrNotExist if no part of the object
+// is found.
+func (s *Storage) readObject(addr oid.Address) ([]byte, error) {
+	shards, size, missing, err := s.readParts(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(missing) > 0 {
+		err = s.reconstruct(addr, shards, size, missing)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(int(size))
+
+	err = s.enc.Join(&buf, shards, int(size))
+	if err != nil {
+		return nil, fmt.Errorf("join object parts: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// readParts reads all available parts of the object. Returns shards suitable
+// for the Reed-Solomon decoder, stored data size and indexes of the missing
+// parts.
+func (s *Storage) readParts(addr oid.Address) ([][]byte, uint64, []int, error) {
+	var (
+		shards    = make([][]byte, len(s.parts))
+		missing   []int
+		size      uint64
+		sizeFound bool
+		found     bool
+		lastErr   error
+	)
+
+	for i := range s.parts {
+		p, err := s.readPart(i, addr)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				found = true
+				lastErr = err
+				if !errors.Is(err, errCorruptedPart) {
+					s.reportPartErr(i, err)
+				}
+			}
+
+			missing = append(missing, i)
+			continue
+		}
+
+		found = true
+
+		if sizeFound && p.size != size {
+			// parts of different writes, consider the first one correct
+			missing = append(missing, i)
+			continue
+		}
+
+		shards[i] = p.payload
+		size = p.size
+		sizeFound = true
+	}
+
+	if !found {
+		return nil, 0, nil, fs.ErrNotExist
+	}
+
+	if len(missing) > s.parity {
+		if lastErr == nil {
+			lastErr = errNotEnoughParts
+		}
+		return nil, 0, nil, fmt.Errorf("%w: %d of %d parts are unavailable: %w",
+			errNotEnoughParts, len(missing), len(s.parts), lastErr)
+	}
+
+	return shards, size, missing, nil
+}
+
+// reconstruct restores missing shards and writes them back if possible.
+func (s *Storage) reconstruct(addr oid.Address, shards [][]byte, size uint64, missing []int) error {
+	err := s.enc.Reconstruct(shards)
+	if err != nil {
+		return fmt.Errorf("reconstruct missing parts: %w", err)
+	}
+
+	if s.readOnly {
+		return nil
+	}
+
+	mtx := s.objectLock(addr)
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	// don't resurrect the object removed after its parts have been read
+	if !s.anyPartExists(addr, missing) {
+		return nil
+	}
+
+	for _, i := range missing {
+		err = s.writePart(i, addr, part{
+			data:    s.dataParts(),
+			parity:  s.parity,
+			index:   i,
+			size:    size,
+			payload: shards[i],
+		})
+		if err != nil {
+			// object is still readable, the part will be retried later
+			s.reportPartErr(i, fmt.Errorf("write restored part: %w", err))
+		}
+	}
+
+	return nil
+}
+
+// anyPartExists checks whether any part of the object except the given ones
+// exists.
+func (s *Storage) anyPartExists(addr oid.Address, except []int) bool {
+loop:
+	for i := range s.parts {
+		for _, j := range except {
+			if i == j {
+				continue loop
+			}
+		}
+
+		if ok, _ := s.partExists(i, addr); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetBytes reads object from the Storage by address into memory buffer in a
+// canonical NeoFS binary format. Returns [apistatus.ObjectNotFound] if object
+// is missing.
+func (s *Storage) GetBytes(addr oid.Address) ([]byte, error) {
+	data, err := s.readObject(addr)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, logicerr.Wrap(apistatus.ObjectNotFound{})
+		}
+		return nil, fmt.Errorf("read object %s: %w", addr, err)
+	}
+
+	data, err = s.compress.Decompress(data)
+	if err != nil {
+		return nil, fmt.Errorf("decompress object %s: %w", addr, err)
+	}
+
+	return data, nil
+}
+
+// GetStream reads object header from the Storage by address and opens its
+// payload stream. The object is restored from its parts in memory. Returns
+// [apistatus.ObjectNotFound] if object is missing.
+func (s *Storage) GetStream(addr oid.Address) (*objectSDK.Object, io.ReadCloser, error) {
+	data, err := s.GetBytes(addr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hdr, payload, err := common.ReadHeader(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode object %s: %w", addr, err)
+	}
+
+	return hdr, io.NopCloser(payload), nil
+}
+
+// Get returns an object from the storage by address.
+func (s *Storage) Get(prm common.GetPrm) (common.GetRes, error) {
+	data, err := s.GetBytes(prm.Address)
+	if err != nil {
+		return common.GetRes{}, err
+	}
+
+	obj := objectSDK.New()
+	if err := obj.Unmarshal(data); err != nil {
+		return common.GetRes{}, fmt.Errorf("decode object %s: %w", prm.Address, err)
+	}
+
+	return common.GetRes{Object: obj, RawData: data}, nil
+}
+
+// GetRange implements common.Storage.
+func (s *Storage) GetRange(prm common.GetRangePrm) (common.GetRangeRes, error) {
+	res, err := s.Get(common.GetPrm{Address: prm.Address})
+	if err != nil {
+		return common.GetRangeRes{}, err
+	}
+
+	payload := res.Object.Payload()
+	from := prm.Range.GetOffset()
+	to := from + prm.Range.GetLength()
+
+	if pLen := uint64(len(payload)); to < from || pLen < from || pLen < to {
+		return common.GetRangeRes{}, logicerr.Wrap(apistatus.ObjectOutOfRange{})
+	}
+
+	return common.GetRangeRes{
+		Data: payload[from:to],
+	}, nil
+}
+
+// Exists checks whether enough parts of the object are present to read it.
+func (s *Storage) Exists(prm common.ExistsPrm) (common.ExistsRes, error) {
+	var (
+		n       int
+		lastErr error
+	)
+
+	for i := range s.parts {
+		ok, err := s.partExists(i, prm.Address)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if ok {
+			n++
+		}
+	}
+
+	if n >= s.dataParts() {
+		return common.ExistsRes{Exists: true}, nil
+	}
+
+	if lastErr != nil {
+		return common.ExistsRes{}, fmt.Errorf("check object parts: %w", lastErr)
+	}
+
+	return common.ExistsRes{Exists: false}, nil
+}
+
+// Delete removes all parts of the object.
+func (s *Storage) Delete(prm common.DeletePrm) (common.DeleteRes, error) {
+	if s.readOnly {
+		return common.DeleteRes{}, common.ErrReadOnly
+	}
+
+	var (
+		removed  bool
+		firstErr error
+	)
+
+	mtx := s.objectLock(prm.Address)
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	for i := range s.parts {
+		err := s.removePart(i, prm.Address)
+		if err == nil {
+			removed = true
+			continue
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) && firstErr == nil {
+			firstErr = fmt.Errorf("remove part #%d: %w", i, err)
+		}
+	}
+
+	if firstErr != nil {
+		return common.DeleteRes{}, firstErr
+	}
+
+	if !removed {
+		return common.DeleteRes{}, logicerr.Wrap(apistatus.ObjectNotFound{})
+	}
+
+	return common.DeleteRes{}, nil
+}
@@ -0,0 +1,199 @@
+package erasure
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	"github.com/epicchainlabs/epicchain-node/pkg/util"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+)
+
+// Part file layout:
+//
+//	version     1 byte
+//	data parts  1 byte
+//	parity      1 byte
+//	part index  1 byte
+//	data size   8 bytes, big-endian, size of the (compressed) object
+//	checksum   32 bytes, SHA-256 of the part payload
+//	payload     the rest of the file
+const (
+	partVersion    = 1
+	partHeaderSize = 4 + 8 + sha256.Size
+)
+
+// errCorruptedPart is returned when part file exists but can't be used.
+var errCorruptedPart = errors.New("corrupted part")
+
+// part is a decoded part file.
+type part struct {
+	data, parity, index int
+	size                uint64
+	payload             []byte
+}
+
+func encodePart(p part) []byte {
+	b := make([]byte, partHeaderSize+len(p.payload))
+	b[0] = partVersion
+	b[1] = byte(p.data)
+	b[2] = byte(p.parity)
+	b[3] = byte(p.index)
+	binary.BigEndian.PutUint64(b[4:], p.size)
+	sum := sha256.Sum256(p.payload)
+	copy(b[12:], sum[:])
+	copy(b[partHeaderSize:], p.payload)
+	return b
+}
+
+func decodePart(b []byte) (part, error) {
+	if len(b) < partHeaderSize {
+		return part{}, fmt.Errorf("%w: too short file %d < %d", errCorruptedPart, len(b), partHeaderSize)
+	}
+	if b[0] != partVersion {
+		return part{}, fmt.Errorf("%w: unsupported version %d", errCorruptedPart, b[0])
+	}
+
+	p := part{
+		data:    int(b[1]),
+		parity:  int(b[2]),
+		index:   int(b[3]),
+		size:    binary.BigEndian.Uint64(b[4:]),
+		payload: b[partHeaderSize:],
+	}
+
+	if sum := sha256.Sum256(p.payload); !bytes.Equal(sum[:], b[12:partHeaderSize]) {
+		return part{}, fmt.Errorf("%w: checksum mismatch", errCorruptedPart)
+	}
+
+	return p, nil
+}
+
+func stringifyAddress(addr oid.Address) string {
+	return addr.Object().EncodeToString() + "." + addr.Container().EncodeToString()
+}
+
+func addressFromString(s string) (oid.Address, error) {
+	var addr oid.Address
+
+	ss := strings.SplitN(s, ".", 2)
+	if len(ss) != 2 {
+		return addr, errors.New("invalid address")
+	}
+
+	var obj oid.ID
+	if err := obj.DecodeString(ss[0]); err != nil {
+		return addr, fmt.Errorf("decode object ID from string %q: %w", ss[0], err)
+	}
+
+	var cnr cid.ID
+	if err := cnr.DecodeString(ss[1]); err != nil {
+		return addr, fmt.Errorf("decode container ID from string %q: %w", ss[1], err)
+	}
+
+	addr.SetObject(obj)
+	addr.SetContainer(cnr)
+
+	return addr, nil
+}
+
+// partPath returns path to the file of the i-th part of the object.
+func (s *Storage) partPath(i int, addr oid.Address) string {
+	sAddr := stringifyAddress(addr)
+
+	dirs := make([]string, 0, s.depth+2)
+	dirs = append(dirs, s.parts[i])
+
+	for j := uint64(0); j < s.depth; j++ {
+		dirs = append(dirs, sAddr[j:j+1])
+	}
+
+	return filepath.Join(append(dirs, sAddr)...)
+}
+
+// readPart reads i-th part of the object. Returns fs.ErrNotExist if part file
+// is missing and errCorruptedPart if it can't be used.
+func (s *Storage) readPart(i int, addr oid.Address) (part, error) {
+	b, err := os.ReadFile(s.partPath(i, addr))
+	if err != nil {
+		return part{}, err
+	}
+
+	p, err := decodePart(b)
+	if err != nil {
+		return part{}, err
+	}
+
+	if p.data != s.dataParts() || p.parity != s.parity || p.index != i {
+		return part{}, fmt.Errorf("%w: unexpected layout %d+%d/%d instead of %d+%d/%d",
+			errCorruptedPart, p.data, p.parity, p.index, s.dataParts(), s.parity, i)
+	}
+
+	return p, nil
+}
+
+// partExists checks whether i-th part file of the object exists.
+func (s *Storage) partExists(i int, addr oid.Address) (bool, error) {
+	_, err := os.Stat(s.partPath(i, addr))
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return false, err
+}
+
+// writePart atomically writes i-th part of the object.
+func (s *Storage) writePart(i int, addr oid.Address, p part) error {
+	path := s.partPath(i, addr)
+	dir := filepath.Dir(path)
+
+	if err := util.MkdirAllX(dir, s.perm); err != nil {
+		return fmt.Errorf("mkdir all for %q: %w", dir, err)
+	}
+
+	f, err := os.CreateTemp(dir, filepath.Base(path)+"#*")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+
+	tmpPath := f.Name()
+
+	_, err = f.Write(encodePart(p))
+	if err == nil && !s.noSync {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, s.perm)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		if isNoSpace(err) {
+			return common.ErrNoSpace
+		}
+		return fmt.Errorf("write part file %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// removePart removes i-th part file of the object. Returns fs.ErrNotExist if
+// part file is missing.
+func (s *Storage) removePart(i int, addr oid.Address) error {
+	return os.Remove(s.partPath(i, addr))
+}
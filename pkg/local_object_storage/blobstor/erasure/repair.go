@@ -0,0 +1,89 @@
+package erasure
+
+import (
+	"errors"
+	"io/fs"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"go.uber.org/zap"
+)
+
+// errRepairInterrupted is returned when repair pass is interrupted because
+// Storage is being closed.
+var errRepairInterrupted = errors.New("repair interrupted")
+
+// RepairRes groups the resulting values of Repair operation.
+type RepairRes struct {
+	// Checked is a number of checked objects.
+	Checked uint64
+	// Repaired is a number of objects with restored parts.
+	Repaired uint64
+	// Unrecoverable is a number of objects which lost more than parity
+	// number of parts.
+	Unrecoverable uint64
+}
+
+// Repair checks all parts of all stored objects and restores missing or
+// corrupted ones. Objects that can't be restored are left untouched and
+// counted as unrecoverable.
+//
+// Repair returns common.ErrReadOnly if Storage is read-only.
+func (s *Storage) Repair() (RepairRes, error) {
+	return s.repair(nil)
+}
+
+func (s *Storage) repair(chStop <-chan struct{}) (RepairRes, error) {
+	if s.readOnly {
+		return RepairRes{}, common.ErrReadOnly
+	}
+
+	s.repairMtx.Lock()
+	defer s.repairMtx.Unlock()
+
+	var res RepairRes
+
+	err := s.iterateAddresses(func(addr oid.Address) error {
+		select {
+		case <-chStop:
+			return errRepairInterrupted
+		default:
+		}
+
+		res.Checked++
+
+		shards, size, missing, err := s.readParts(addr)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil // removed concurrently
+			}
+
+			res.Unrecoverable++
+			s.log.Error("unrecoverable erasure-coded object",
+				zap.Stringer("address", addr),
+				zap.Error(err))
+
+			return nil
+		}
+
+		if len(missing) == 0 {
+			return nil
+		}
+
+		err = s.reconstruct(addr, shards, size, missing)
+		if err != nil {
+			res.Unrecoverable++
+			s.log.Error("failed to reconstruct erasure-coded object",
+				zap.Stringer("address", addr),
+				zap.Error(err))
+
+			return nil
+		}
+
+		res.Repaired++
+
+		return nil
+	}, true)
+
+	return res, err
+}
@@ -0,0 +1,47 @@
+package erasure
+
+import (
+	"testing"
+
+	objectCore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/compression"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/internal/blobstortest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_ReconstructRemoved(t *testing.T) {
+	dir := t.TempDir()
+	s := New(dir, []string{dir + "/0", dir + "/1", dir + "/2"}, 1, WithNoSync(true))
+	s.SetCompressor(new(compression.Config))
+	require.NoError(t, s.Open(false))
+	require.NoError(t, s.Init())
+	t.Cleanup(func() { require.NoError(t, s.Close()) })
+
+	obj := blobstortest.NewObject(1024)
+	addr := objectCore.AddressOf(obj)
+
+	raw, err := obj.Marshal()
+	require.NoError(t, err)
+
+	_, err = s.Put(common.PutPrm{Address: addr, RawData: raw, DontCompress: true})
+	require.NoError(t, err)
+
+	require.NoError(t, s.removePart(0, addr))
+
+	// parts are read by the repair routine, then the object is removed
+	shards, size, missing, err := s.readParts(addr)
+	require.NoError(t, err)
+	require.Equal(t, []int{0}, missing)
+
+	_, err = s.Delete(common.DeletePrm{Address: addr})
+	require.NoError(t, err)
+
+	require.NoError(t, s.reconstruct(addr, shards, size, missing))
+
+	for i := range s.parts {
+		ok, err := s.partExists(i, addr)
+		require.NoError(t, err)
+		require.False(t, ok, i)
+	}
+}
@@ -154,7 +154,7 @@ func (t *FSTree) iterate(depth uint64, curPath []string, prm common.IteratePrm)
 			if err != nil && errors.Is(err, fs.ErrNotExist) {
 				continue
 			}
-			if err == nil {
+			if err == nil && !prm.DontDecompress {
 				data, err = t.Decompress(data)
 			}
 			if err != nil {
@@ -346,6 +346,49 @@ func (t *FSTree) GetBytes(addr oid.Address) ([]byte, error) {
 	return dec, nil
 }
 
+// GetStream reads object header from the FSTree by address and opens its
+// payload stream read from the file, so the payload is never kept in memory
+// entirely. Returns [apistatus.ObjectNotFound] if object is missing.
+func (t *FSTree) GetStream(addr oid.Address) (*objectSDK.Object, io.ReadCloser, error) {
+	p := t.treePath(addr)
+
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, logicerr.Wrap(apistatus.ObjectNotFound{})
+		}
+		return nil, nil, fmt.Errorf("open object file %q: %w", p, err)
+	}
+
+	dec, err := t.DecompressReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, nil, fmt.Errorf("decompress object file data %q: %w", p, err)
+	}
+
+	hdr, payload, err := common.ReadHeader(dec)
+	if err != nil {
+		_ = dec.Close()
+		_ = f.Close()
+		return nil, nil, fmt.Errorf("decode object header from file %q: %w", p, err)
+	}
+
+	return hdr, &payloadStream{Reader: payload, dec: dec, f: f}, nil
+}
+
+// payloadStream reads the object payload from the file and closes both the
+// decompressor and the file.
+type payloadStream struct {
+	io.Reader
+
+	dec io.Closer
+	f   *os.File
+}
+
+func (x *payloadStream) Close() error {
+	return errors.Join(x.dec.Close(), x.f.Close())
+}
+
 // GetRange implements common.Storage.
 func (t *FSTree) GetRange(prm common.GetRangePrm) (common.GetRangeRes, error) {
 	res, err := t.Get(common.GetPrm{Address: prm.Address})
@@ -1,8 +1,13 @@
 package fstree
 
 import (
+	"io"
 	"testing"
 
+	"github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/compression"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/internal/blobstortest"
 	oidtest "github.com/epicchainlabs/epicchain-sdk-go/object/id/test"
 	"github.com/stretchr/testify/require"
 )
@@ -14,3 +19,37 @@ func TestAddressToString(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, addr, *actual)
 }
+
+func TestFSTree_GetStream(t *testing.T) {
+	obj := blobstortest.NewObject(64 * 1024)
+	addr := object.AddressOf(obj)
+
+	raw, err := obj.Marshal()
+	require.NoError(t, err)
+
+	for _, codec := range compression.Codecs() {
+		t.Run(codec, func(t *testing.T) {
+			cc := &compression.Config{Enabled: true, Codec: codec}
+			require.NoError(t, cc.Init())
+			t.Cleanup(func() { require.NoError(t, cc.Close()) })
+
+			fst := New(WithPath(t.TempDir()))
+			fst.SetCompressor(cc)
+			require.NoError(t, fst.Open(false))
+			require.NoError(t, fst.Init())
+			t.Cleanup(func() { require.NoError(t, fst.Close()) })
+
+			_, err := fst.Put(common.PutPrm{Address: addr, RawData: raw})
+			require.NoError(t, err)
+
+			hdr, payload, err := fst.GetStream(addr)
+			require.NoError(t, err)
+			require.Equal(t, obj.CutPayload(), hdr)
+
+			b, err := io.ReadAll(payload)
+			require.NoError(t, err)
+			require.NoError(t, payload.Close())
+			require.Equal(t, obj.Payload(), b)
+		})
+	}
+}
@@ -2,10 +2,12 @@ package blobstor
 
 import (
 	"errors"
+	"io"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
 	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
 	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
 )
 
@@ -56,3 +58,26 @@ func (b *BlobStor) GetBytes(addr oid.Address, subStorageID []byte) ([]byte, erro
 	}
 	return b.storage[0].Storage.GetBytes(addr)
 }
+
+// GetStream reads object header from the BlobStor by address and opens its
+// payload stream. The stream must be closed by the caller. Returns
+// [apistatus.ObjectNotFound] if object is missing.
+func (b *BlobStor) GetStream(addr oid.Address, subStorageID []byte) (*objectSDK.Object, io.ReadCloser, error) {
+	b.modeMtx.RLock()
+	defer b.modeMtx.RUnlock()
+
+	if subStorageID == nil {
+		for i := range b.storage {
+			hdr, payload, err := b.storage[i].Storage.GetStream(addr)
+			if err == nil || !errors.As(err, new(apistatus.ObjectNotFound)) {
+				return hdr, payload, err
+			}
+		}
+
+		return nil, nil, logicerr.Wrap(apistatus.ObjectNotFound{})
+	}
+	if len(subStorageID) == 0 {
+		return b.storage[len(b.storage)-1].Storage.GetStream(addr)
+	}
+	return b.storage[0].Storage.GetStream(addr)
+}
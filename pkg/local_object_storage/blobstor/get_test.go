@@ -3,6 +3,7 @@ package blobstor
 import (
 	"bytes"
 	"errors"
+	"io"
 	"testing"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/core/object"
@@ -10,6 +11,7 @@ import (
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/compression"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
 	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
 	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
 	oidtest "github.com/epicchainlabs/epicchain-sdk-go/object/id/test"
 	objecttest "github.com/epicchainlabs/epicchain-sdk-go/object/test"
@@ -67,6 +69,10 @@ func (x *getBytesOnlySubStorage) GetBytes(addr oid.Address) ([]byte, error) {
 	return bytes.Clone(val), nil
 }
 
+func (x *getBytesOnlySubStorage) GetStream(addr oid.Address) (*objectSDK.Object, io.ReadCloser, error) {
+	panic("must not be called")
+}
+
 func TestBlobStor_GetBytes(t *testing.T) {
 	newBlobStorWithStorages := func(ss ...common.Storage) *BlobStor {
 		subs := make([]SubStorage, len(ss))
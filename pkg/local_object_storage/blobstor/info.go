@@ -9,6 +9,11 @@ func (b *BlobStor) DumpInfo() Info {
 	for i := range b.storage {
 		sub[i].Path = b.storage[i].Storage.Path()
 		sub[i].Type = b.storage[i].Storage.Type()
+		if cc := b.storage[i].Compression; cc != nil {
+			sub[i].Codec = cc.CodecName()
+		} else {
+			sub[i].Codec = b.compression.CodecName()
+		}
 	}
 
 	return Info{
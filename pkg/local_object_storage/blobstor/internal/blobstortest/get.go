@@ -1,6 +1,7 @@
 package blobstortest
 
 import (
+	"io"
 	"testing"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
@@ -23,6 +24,8 @@ func TestGet(t *testing.T, cons Constructor, min, max uint64) {
 		require.ErrorAs(t, err, new(apistatus.ObjectNotFound))
 		_, err = s.GetBytes(gPrm.Address)
 		require.ErrorAs(t, err, new(apistatus.ObjectNotFound))
+		_, _, err = s.GetStream(gPrm.Address)
+		require.ErrorAs(t, err, new(apistatus.ObjectNotFound))
 	})
 
 	for i := range objects {
@@ -53,5 +56,14 @@ func TestGet(t *testing.T, cons Constructor, min, max uint64) {
 		b, err := s.GetBytes(objects[i].addr)
 		require.NoError(t, err)
 		require.Equal(t, objects[i].raw, b)
+
+		// Stream.
+		hdr, payload, err := s.GetStream(objects[i].addr)
+		require.NoError(t, err)
+		require.Equal(t, objects[i].obj.CutPayload(), hdr)
+		b, err = io.ReadAll(payload)
+		require.NoError(t, err)
+		require.NoError(t, payload.Close())
+		require.Equal(t, objects[i].obj.Payload(), b)
 	}
 }
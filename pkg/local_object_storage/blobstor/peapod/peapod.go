@@ -6,6 +6,7 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"path/filepath"
 	"sync"
@@ -356,6 +357,23 @@ func (x *Peapod) GetBytes(addr oid.Address) ([]byte, error) {
 	return dec, nil
 }
 
+// GetStream reads object header from the Peapod by address and opens its
+// payload stream. Objects stored in the Peapod are small, so the object is
+// read into memory. Returns [apistatus.ObjectNotFound] if object is missing.
+func (x *Peapod) GetStream(addr oid.Address) (*objectSDK.Object, io.ReadCloser, error) {
+	b, err := x.GetBytes(addr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hdr, payload, err := common.ReadHeader(bytes.NewReader(b))
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode object from binary: %w", err)
+	}
+
+	return hdr, io.NopCloser(payload), nil
+}
+
 // GetRange works like Get but reads specific payload range.
 func (x *Peapod) GetRange(prm common.GetRangePrm) (common.GetRangeRes, error) {
 	// copy-paste from FSTree
@@ -506,17 +524,19 @@ func (x *Peapod) Iterate(prm common.IteratePrm) (common.IterateRes, error) {
 				return fmt.Errorf("decode object address from bucket key: %w", err)
 			}
 
-			v, err = x.compress.Decompress(v)
-			if err != nil {
-				if prm.IgnoreErrors {
-					if prm.ErrorHandler != nil {
-						return prm.ErrorHandler(addr, err)
+			if !prm.DontDecompress {
+				v, err = x.compress.Decompress(v)
+				if err != nil {
+					if prm.IgnoreErrors {
+						if prm.ErrorHandler != nil {
+							return prm.ErrorHandler(addr, err)
+						}
+
+						return nil
 					}
 
-					return nil
+					return fmt.Errorf("decompress value for object '%s': %w", addr, err)
 				}
-
-				return fmt.Errorf("decompress value for object '%s': %w", addr, err)
 			}
 
 			if prm.LazyHandler != nil {
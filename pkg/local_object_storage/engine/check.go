@@ -0,0 +1,234 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
+	"go.uber.org/zap"
+)
+
+// CheckShardPrm groups the parameters of StartShardCheck operation.
+type CheckShardPrm struct {
+	shardIDs []*shard.ID
+	repair   []shard.Problem
+}
+
+// SetShardIDList is an option to set the IDs of the shards to check.
+//
+// Option is required.
+func (p *CheckShardPrm) SetShardIDList(ids []*shard.ID) {
+	p.shardIDs = ids
+}
+
+// SetRepair is an option to fix the problems of the given classes.
+func (p *CheckShardPrm) SetRepair(ps ...shard.Problem) {
+	p.repair = ps
+}
+
+// ShardIssue is an inconsistency found in the shard.
+type ShardIssue struct {
+	shard.Issue
+
+	// ShardID is a string identifier of the shard the issue is found in.
+	ShardID string
+}
+
+// ShardCheckStatus describes the state of the background shard check.
+type ShardCheckStatus struct {
+	running   bool
+	shardIDs  []string
+	startedAt time.Time
+	checked   int
+	issues    []ShardIssue
+	err       error
+}
+
+// Running returns true if the check is in progress.
+func (s ShardCheckStatus) Running() bool {
+	return s.running
+}
+
+// ShardIDs returns string identifiers of the checked shards.
+func (s ShardCheckStatus) ShardIDs() []string {
+	return s.shardIDs
+}
+
+// StartedAt returns the time the last check was started at. Returns zero
+// time if it hasn't been started since the node start.
+func (s ShardCheckStatus) StartedAt() time.Time {
+	return s.startedAt
+}
+
+// Checked returns the number of shards checked completely.
+func (s ShardCheckStatus) Checked() int {
+	return s.checked
+}
+
+// IssueCount returns the number of inconsistencies found so far.
+func (s ShardCheckStatus) IssueCount() int {
+	return len(s.issues)
+}
+
+// Issues returns at most limit inconsistencies found so far starting from
+// the offset one. Zero limit means no limit.
+func (s ShardCheckStatus) Issues(offset, limit int) []ShardIssue {
+	if offset >= len(s.issues) {
+		return nil
+	}
+
+	res := s.issues[offset:]
+	if limit > 0 && limit < len(res) {
+		res = res[:limit]
+	}
+
+	return res
+}
+
+// Error returns the error the last check was aborted with, if any.
+func (s ShardCheckStatus) Error() error {
+	return s.err
+}
+
+// shardCheckState holds the background shard check state of the
+// StorageEngine.
+type shardCheckState struct {
+	mtx    sync.Mutex
+	stopCh chan struct{}
+	doneCh chan struct{}
+	status ShardCheckStatus
+}
+
+var (
+	errShardCheckInProgress = logicerr.New("shard check is already in progress")
+	errShardCheckNotRunning = logicerr.New("shard check is not running")
+)
+
+// StartShardCheck starts checking consistency of the shard metabases with
+// their storages in the background, see [shard.Shard.Check] for details.
+// The shards are checked one by one. Use ShardCheckStatus to track the
+// progress and to get the found issues.
+//
+// Returns an error if the check is already in progress.
+func (e *StorageEngine) StartShardCheck(prm CheckShardPrm) error {
+	e.shardCheck.mtx.Lock()
+	defer e.shardCheck.mtx.Unlock()
+
+	if e.shardCheck.status.running {
+		return errShardCheckInProgress
+	}
+
+	sidList := make([]string, len(prm.shardIDs))
+	for i := range prm.shardIDs {
+		sidList[i] = prm.shardIDs[i].String()
+	}
+
+	shards := make([]*shard.Shard, len(sidList))
+
+	e.mtx.RLock()
+	for i := range sidList {
+		sh, ok := e.shards[sidList[i]]
+		if !ok {
+			e.mtx.RUnlock()
+			return errShardNotFound
+		}
+		shards[i] = sh.Shard
+	}
+	e.mtx.RUnlock()
+
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+
+	e.shardCheck.stopCh = stopCh
+	e.shardCheck.doneCh = doneCh
+	e.shardCheck.status = ShardCheckStatus{
+		running:   true,
+		shardIDs:  sidList,
+		startedAt: time.Now(),
+	}
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		defer close(doneCh)
+
+		err := e.checkShards(shards, prm.repair, stopCh)
+		if errors.Is(err, shard.ErrCheckInterrupted) {
+			e.log.Info("shard check interrupted", zap.Strings("shard_ids", sidList))
+			err = nil
+		} else if err != nil {
+			e.log.Error("shard check failed",
+				zap.Strings("shard_ids", sidList),
+				zap.Error(err))
+		}
+
+		e.shardCheck.mtx.Lock()
+		e.shardCheck.status.running = false
+		e.shardCheck.status.err = err
+		e.shardCheck.mtx.Unlock()
+	}()
+
+	return nil
+}
+
+func (e *StorageEngine) checkShards(shards []*shard.Shard, repair []shard.Problem, stopCh <-chan struct{}) error {
+	for _, sh := range shards {
+		sid := sh.ID().String()
+
+		var prm shard.CheckPrm
+		prm.SetRepair(repair...)
+		prm.SetStopChannel(stopCh)
+		prm.SetIssueHandler(func(i shard.Issue) {
+			e.shardCheck.mtx.Lock()
+			e.shardCheck.status.issues = append(e.shardCheck.status.issues, ShardIssue{Issue: i, ShardID: sid})
+			e.shardCheck.mtx.Unlock()
+		})
+
+		_, err := sh.Check(prm)
+		if err != nil {
+			return err
+		}
+
+		e.shardCheck.mtx.Lock()
+		e.shardCheck.status.checked++
+		e.shardCheck.mtx.Unlock()
+	}
+
+	return nil
+}
+
+// StopShardCheck interrupts the shard check in progress and waits for it
+// to finish. The issues found before the interruption are kept in the
+// status.
+//
+// Returns an error if the check is not running.
+func (e *StorageEngine) StopShardCheck() error {
+	e.shardCheck.mtx.Lock()
+	if !e.shardCheck.status.running {
+		e.shardCheck.mtx.Unlock()
+		return errShardCheckNotRunning
+	}
+
+	stopCh, doneCh := e.shardCheck.stopCh, e.shardCheck.doneCh
+	// concurrent stop calls just wait for the check
+	e.shardCheck.stopCh = nil
+	e.shardCheck.mtx.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+	}
+	<-doneCh
+
+	return nil
+}
+
+// ShardCheckStatus returns the state of the current or the last finished
+// background shard check.
+func (e *StorageEngine) ShardCheckStatus() ShardCheckStatus {
+	e.shardCheck.mtx.Lock()
+	defer e.shardCheck.mtx.Unlock()
+
+	return e.shardCheck.status
+}
@@ -0,0 +1,47 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartShardCheck(t *testing.T) {
+	e, ids, _ := newEngineEvacuate(t, 2, 3)
+
+	require.ErrorIs(t, e.StopShardCheck(), errShardCheckNotRunning)
+	require.True(t, e.ShardCheckStatus().StartedAt().IsZero())
+
+	var prm CheckShardPrm
+	prm.SetShardIDList([]*shard.ID{ids[0], shard.NewIDFromBytes([]byte{1, 2, 3})})
+	require.ErrorIs(t, e.StartShardCheck(prm), errShardNotFound)
+
+	prm.SetShardIDList(ids)
+	require.NoError(t, e.StartShardCheck(prm))
+
+	require.Eventually(t, func() bool {
+		return !e.ShardCheckStatus().Running()
+	}, 10*time.Second, 10*time.Millisecond)
+
+	st := e.ShardCheckStatus()
+	require.NoError(t, st.Error())
+	require.Equal(t, []string{ids[0].String(), ids[1].String()}, st.ShardIDs())
+	require.False(t, st.StartedAt().IsZero())
+	require.Equal(t, 2, st.Checked())
+	require.Zero(t, st.IssueCount())
+	require.Empty(t, st.Issues(0, 10))
+}
+
+func TestShardCheckStatus_Issues(t *testing.T) {
+	st := ShardCheckStatus{issues: make([]ShardIssue, 5)}
+	for i := range st.issues {
+		st.issues[i].ShardID = string(rune('a' + i))
+	}
+
+	require.Equal(t, st.issues, st.Issues(0, 0))
+	require.Equal(t, st.issues[:2], st.Issues(0, 2))
+	require.Equal(t, st.issues[3:], st.Issues(3, 10))
+	require.Empty(t, st.Issues(5, 1))
+}
@@ -19,7 +19,8 @@ type ContainerSizePrm struct {
 
 // ContainerSizeRes resulting values of ContainerSize operation.
 type ContainerSizeRes struct {
-	size uint64
+	size    uint64
+	objects uint64
 }
 
 // ListContainersPrm groups parameters of ListContainers operation.
@@ -40,6 +41,11 @@ func (r ContainerSizeRes) Size() uint64 {
 	return r.size
 }
 
+// Objects returns the number of regular container objects among all shards.
+func (r ContainerSizeRes) Objects() uint64 {
+	return r.objects
+}
+
 // Containers returns a list of identifiers of the containers in which local objects are stored.
 func (r ListContainersRes) Containers() []cid.ID {
 	return r.containers
@@ -88,6 +94,7 @@ func (e *StorageEngine) containerSize(prm ContainerSizePrm) (res ContainerSizeRe
 		}
 
 		res.size += csRes.Size()
+		res.objects += csRes.Objects()
 
 		return false
 	})
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
 	"go.uber.org/zap"
 )
 
@@ -28,8 +30,20 @@ func (e *StorageEngine) open() error {
 	return nil
 }
 
-// Init initializes all StorageEngine's components.
+// Init initializes all StorageEngine's components. Shard rebalance
+// interrupted by the previous shutdown is resumed.
 func (e *StorageEngine) Init() error {
+	err := e.init()
+	if err != nil {
+		return err
+	}
+
+	e.resumeRebalance()
+
+	return nil
+}
+
+func (e *StorageEngine) init() error {
 	e.mtx.Lock()
 	defer e.mtx.Unlock()
 
@@ -59,6 +73,9 @@ var errClosed = errors.New("storage engine is closed")
 func (e *StorageEngine) Close() error {
 	close(e.closeCh)
 	defer e.wg.Wait()
+	e.waitRebalance()
+	e.waitEvacuation()
+	_ = e.StopShardCheck() // fails only if the check is not running
 	return e.setBlockExecErr(errClosed)
 }
 
@@ -159,21 +176,22 @@ func (e *StorageEngine) ResumeExecution() error {
 }
 
 type ReConfiguration struct {
-	errorsThreshold uint32
-	shardPoolSize   uint32
+	errorsThreshold *uint32
+	shardPoolSize   *uint32
 
 	shards map[string][]shard.Option // meta path -> shard opts
+	reopen map[string]struct{}
 }
 
 // SetErrorsThreshold sets a size amount of errors after which
 // shard is moved to read-only mode.
 func (rCfg *ReConfiguration) SetErrorsThreshold(errorsThreshold uint32) {
-	rCfg.errorsThreshold = errorsThreshold
+	rCfg.errorsThreshold = &errorsThreshold
 }
 
 // SetShardPoolSize sets a size of worker pool for each shard.
 func (rCfg *ReConfiguration) SetShardPoolSize(shardPoolSize uint32) {
-	rCfg.shardPoolSize = shardPoolSize
+	rCfg.shardPoolSize = &shardPoolSize
 }
 
 // AddShard adds a shard for the reconfiguration.
@@ -190,24 +208,102 @@ func (rCfg *ReConfiguration) AddShard(id string, opts []shard.Option) {
 	rCfg.shards[id] = opts
 }
 
-// Reload reloads StorageEngine's configuration in runtime.
-func (e *StorageEngine) Reload(rcfg ReConfiguration) error {
+// ReopenShard marks the shard added with AddShard to be closed and opened
+// with the new options instead of being reloaded. It is required for the
+// options that cannot be applied to the working shard.
+func (rCfg *ReConfiguration) ReopenShard(id string) {
+	if rCfg.reopen == nil {
+		rCfg.reopen = make(map[string]struct{})
+	}
+
+	rCfg.reopen[id] = struct{}{}
+}
+
+// ShardChangeType enumerates the changes of the shard set applied by Reload.
+type ShardChangeType uint8
+
+const (
+	// ShardReloaded means the options of the working shard were updated.
+	ShardReloaded ShardChangeType = iota
+	// ShardAdded means the new shard was attached.
+	ShardAdded
+	// ShardReopened means the shard was closed and opened with the new
+	// options.
+	ShardReopened
+	// ShardDetached means the shard missing in the new configuration was
+	// closed and detached.
+	ShardDetached
+)
+
+// String implements fmt.Stringer.
+func (t ShardChangeType) String() string {
+	switch t {
+	case ShardReloaded:
+		return "reloaded"
+	case ShardAdded:
+		return "added"
+	case ShardReopened:
+		return "reopened"
+	case ShardDetached:
+		return "detached"
+	default:
+		return "unknown"
+	}
+}
+
+// ShardChange describes the outcome of the shard change applied by Reload.
+type ShardChange struct {
+	// Type of the change.
+	Type ShardChangeType
+
+	// ConfigID is the shard identifier calculated from the paths used in
+	// blobstor.
+	ConfigID string
+
+	// ID is the shard ID, nil if the shard was not created.
+	ID *shard.ID
+
+	// Err is the error the change failed with, nil on success.
+	Err error
+}
+
+// Reload reloads StorageEngine's configuration in runtime. Shards missing in
+// the new configuration are detached, new ones are attached and the
+// remaining ones are reloaded or reopened. Failure of one change does not
+// prevent the others from being applied. Returns the changes applied to the
+// shards and an error if any of them failed.
+func (e *StorageEngine) Reload(rcfg ReConfiguration) ([]ShardChange, error) {
 	type reloadInfo struct {
-		sh   *shard.Shard
-		opts []shard.Option
+		sh       *shard.Shard
+		configID string
 	}
 
-	e.mtx.RLock()
+	e.mtx.Lock()
 
-	var shardsToRemove []string // shards IDs
-	var shardsToAdd []string    // shard config identifiers (blobstor paths concatenation)
+	if rcfg.errorsThreshold != nil {
+		atomic.StoreUint32(&e.errorsThreshold, *rcfg.errorsThreshold)
+	}
+
+	if rcfg.shardPoolSize != nil && *rcfg.shardPoolSize != e.shardPoolSize {
+		e.shardPoolSize = *rcfg.shardPoolSize
+
+		for _, pool := range e.shardPools {
+			if p, ok := pool.(interface{ Tune(int) }); ok {
+				p.Tune(int(e.shardPoolSize))
+			}
+		}
+	}
+
+	var shardsToRemove []reloadInfo
+	var shardsToAdd []string // shard config identifiers (blobstor paths concatenation)
 	var shardsToReload []reloadInfo
+	var shardsToReopen []reloadInfo
 
 	// mark removed shards for removal
-	for id, sh := range e.shards {
-		_, ok := rcfg.shards[calculateShardID(sh.DumpInfo())]
-		if !ok {
-			shardsToRemove = append(shardsToRemove, id)
+	for _, sh := range e.shards {
+		configID := calculateShardID(sh.DumpInfo())
+		if _, ok := rcfg.shards[configID]; !ok {
+			shardsToRemove = append(shardsToRemove, reloadInfo{sh: sh.Shard, configID: configID})
 		}
 	}
 
@@ -217,10 +313,12 @@ loop:
 			// This calculation should be kept in sync with node
 			// configuration parsing during SIGHUP.
 			if newID == calculateShardID(sh.DumpInfo()) {
-				shardsToReload = append(shardsToReload, reloadInfo{
-					sh:   sh.Shard,
-					opts: rcfg.shards[newID],
-				})
+				info := reloadInfo{sh: sh.Shard, configID: newID}
+				if _, ok := rcfg.reopen[newID]; ok {
+					shardsToReopen = append(shardsToReopen, info)
+				} else {
+					shardsToReload = append(shardsToReload, info)
+				}
 				continue loop
 			}
 		}
@@ -228,46 +326,89 @@ loop:
 		shardsToAdd = append(shardsToAdd, newID)
 	}
 
-	e.mtx.RUnlock()
+	e.mtx.Unlock()
 
-	e.removeShards(shardsToRemove...)
+	var (
+		res  []ShardChange
+		errs []error
+	)
 
-	for _, p := range shardsToReload {
-		err := p.sh.Reload(p.opts...)
-		if err != nil {
-			e.log.Error("could not reload a shard",
-				zap.Stringer("shard id", p.sh.ID()),
-				zap.Error(err))
+	report := func(ch ShardChange) {
+		if ch.Err != nil {
+			e.log.Error("could not apply shard change",
+				zap.Stringer("type", ch.Type),
+				zap.String("config id", ch.ConfigID),
+				zap.Stringer("shard id", ch.ID),
+				zap.Error(ch.Err))
+
+			errs = append(errs, fmt.Errorf("%s %s shard: %w", ch.Type, ch.ConfigID, ch.Err))
 		}
+
+		res = append(res, ch)
 	}
 
-	for _, newID := range shardsToAdd {
-		sh, err := e.createShard(rcfg.shards[newID])
-		if err != nil {
-			return fmt.Errorf("could not add new shard with '%s' metabase path: %w", newID, err)
-		}
+	for _, p := range shardsToRemove {
+		e.removeShards(p.sh.ID().String())
 
-		idStr := sh.ID().String()
+		report(ShardChange{Type: ShardDetached, ConfigID: p.configID, ID: p.sh.ID()})
+	}
 
-		err = sh.Open()
+	for _, p := range shardsToReload {
+		report(ShardChange{
+			Type:     ShardReloaded,
+			ConfigID: p.configID,
+			ID:       p.sh.ID(),
+			Err:      p.sh.Reload(rcfg.shards[p.configID]...),
+		})
+	}
+
+	for _, p := range shardsToReopen {
+		e.removeShards(p.sh.ID().String())
+
+		id, err := e.openShard(rcfg.shards[p.configID])
+		report(ShardChange{Type: ShardReopened, ConfigID: p.configID, ID: id, Err: err})
+	}
+
+	for _, newID := range shardsToAdd {
+		id, err := e.openShard(rcfg.shards[newID])
 		if err == nil {
-			err = sh.Init()
-		}
-		if err != nil {
-			_ = sh.Close()
-			return fmt.Errorf("could not init %s shard: %w", idStr, err)
+			e.log.Info("added new shard", zap.Stringer("id", id))
 		}
 
-		err = e.addShard(sh)
-		if err != nil {
-			_ = sh.Close()
-			return fmt.Errorf("could not add %s shard: %w", idStr, err)
-		}
+		report(ShardChange{Type: ShardAdded, ConfigID: newID, ID: id, Err: err})
+	}
+
+	return res, errors.Join(errs...)
+}
 
-		e.log.Info("added new shard", zap.String("id", idStr))
+// openShard creates, opens and initializes the shard and attaches it to the
+// engine.
+func (e *StorageEngine) openShard(opts []shard.Option) (*shard.ID, error) {
+	sh, err := e.createShard(opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not create shard: %w", err)
 	}
 
-	return nil
+	err = sh.Open()
+	if err == nil {
+		err = sh.Init()
+	}
+	if err != nil {
+		_ = sh.Close()
+		return sh.ID(), fmt.Errorf("could not init shard: %w", err)
+	}
+
+	err = e.addShard(sh)
+	if err != nil {
+		_ = sh.Close()
+		return sh.ID(), fmt.Errorf("could not add shard: %w", err)
+	}
+
+	if e.metrics != nil {
+		e.metrics.SetReadonly(sh.ID().String(), sh.GetMode() != mode.ReadWrite)
+	}
+
+	return sh.ID(), nil
 }
 
 func calculateShardID(info shard.Info) string {
@@ -17,6 +17,7 @@ import (
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/writecache"
 	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
+	"github.com/panjf2000/ants/v2"
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/bbolt"
 	"go.uber.org/zap/zaptest"
@@ -133,9 +134,10 @@ func testEngineFailInitAndReload(t *testing.T, badDir string, errOnAdd bool, s [
 	}
 
 	require.NoError(t, os.Chmod(badDir, os.ModePerm))
-	require.NoError(t, e.Reload(ReConfiguration{
+	_, err = e.Reload(ReConfiguration{
 		shards: map[string][]shard.Option{configID: s},
-	}))
+	})
+	require.NoError(t, err)
 
 	e.mtx.RLock()
 	shardCount := len(e.shards)
@@ -224,12 +226,19 @@ func TestReload(t *testing.T) {
 		}
 
 		rcfg.AddShard(currShards[0], nil) // same path
-		require.NoError(t, e.Reload(rcfg))
+		changes, err := e.Reload(rcfg)
+		require.NoError(t, err)
 
 		// no new paths => no new shards
 		require.Equal(t, shardNum, len(e.shards))
 		require.Equal(t, shardNum, len(e.shardPools))
 
+		require.Len(t, changes, shardNum)
+		for _, ch := range changes {
+			require.Equal(t, ShardReloaded, ch.Type)
+			require.NoError(t, ch.Err)
+		}
+
 		newMeta := filepath.Join(addPath, fmt.Sprintf("%d.metabase", shardNum))
 
 		// add new shard
@@ -237,7 +246,14 @@ func TestReload(t *testing.T) {
 			meta.WithPath(newMeta),
 			meta.WithEpochState(epochState{}),
 		)})
-		require.NoError(t, e.Reload(rcfg))
+		changes, err = e.Reload(rcfg)
+		require.NoError(t, err)
+
+		require.Contains(t, changes, ShardChange{
+			Type:     ShardAdded,
+			ConfigID: newMeta,
+			ID:       changes[len(changes)-1].ID,
+		})
 
 		require.Equal(t, shardNum+1, len(e.shards))
 		require.Equal(t, shardNum+1, len(e.shardPools))
@@ -254,11 +270,72 @@ func TestReload(t *testing.T) {
 			rcfg.AddShard(currShards[i], nil)
 		}
 
-		require.NoError(t, e.Reload(rcfg))
+		changes, err := e.Reload(rcfg)
+		require.NoError(t, err)
 
 		// removed one
 		require.Equal(t, shardNum-1, len(e.shards))
 		require.Equal(t, shardNum-1, len(e.shardPools))
+
+		require.Contains(t, changes, ShardChange{
+			Type:     ShardDetached,
+			ConfigID: currShards[len(currShards)-1],
+			ID:       changes[0].ID,
+		})
+	})
+
+	t.Run("reopen shards", func(t *testing.T) {
+		const shardNum = 2
+		reopenPath := filepath.Join(path, "reopen")
+
+		e, currShards := engineWithShards(t, reopenPath, shardNum)
+
+		ids := make(map[string]struct{}, shardNum)
+		for id := range e.shards {
+			ids[id] = struct{}{}
+		}
+
+		var rcfg ReConfiguration
+		for i, p := range currShards {
+			rcfg.AddShard(p, []shard.Option{
+				shard.WithBlobStorOptions(
+					blobstor.WithStorages(newStorages(filepath.Join(reopenPath, "add", strconv.Itoa(i)), errSmallSize))),
+				shard.WithMetaBaseOptions(
+					meta.WithPath(filepath.Join(reopenPath, "add", fmt.Sprintf("%d.metabase", i))),
+					meta.WithPermissions(0700),
+					meta.WithEpochState(epochState{}),
+				),
+			})
+		}
+		rcfg.ReopenShard(currShards[0])
+		rcfg.SetShardPoolSize(5)
+		rcfg.SetErrorsThreshold(3)
+
+		changes, err := e.Reload(rcfg)
+		require.NoError(t, err)
+		require.Len(t, changes, shardNum)
+
+		types := make(map[ShardChangeType]string)
+		for _, ch := range changes {
+			require.NoError(t, ch.Err)
+			types[ch.Type] = ch.ConfigID
+		}
+		require.Equal(t, map[ShardChangeType]string{
+			ShardReloaded: currShards[1],
+			ShardReopened: currShards[0],
+		}, types)
+
+		// shard ID is persistent
+		require.Len(t, e.shards, shardNum)
+		for id := range e.shards {
+			require.Contains(t, ids, id)
+		}
+
+		require.EqualValues(t, 5, e.shardPoolSize)
+		require.EqualValues(t, 3, e.errorsThreshold)
+		for _, p := range e.shardPools {
+			require.Equal(t, 5, p.(*ants.Pool).Cap())
+		}
 	})
 }
 
@@ -35,6 +35,11 @@ type StorageEngine struct {
 
 		err error
 	}
+
+	rebalance  rebalanceState
+	evacuation evacuationState
+	shardCheck shardCheckState
+	quota      quotaState
 }
 
 type shardWrapper struct {
@@ -166,7 +171,7 @@ func (e *StorageEngine) reportShardErrorWithFlags(
 		zap.String("error", err.Error()),
 	}, fields...)...)
 
-	if e.errorsThreshold == 0 || errCount < e.errorsThreshold {
+	if threshold := atomic.LoadUint32(&e.errorsThreshold); threshold == 0 || errCount < threshold {
 		return
 	}
 
@@ -190,6 +195,16 @@ func (e *StorageEngine) reportShardErrorWithFlags(
 	}
 }
 
+// StateStorage is a persistent key-value storage the StorageEngine saves
+// the state of its background jobs and container quotas to.
+type StateStorage interface {
+	// SetBytes saves the value by the key.
+	SetBytes(key []byte, value []byte) error
+	// Bytes returns the value saved by the key, empty value means
+	// there is none.
+	Bytes(key []byte) ([]byte, error)
+}
+
 // Option represents StorageEngine's constructor option.
 type Option func(*cfg)
 
@@ -202,6 +217,10 @@ type cfg struct {
 
 	shardPoolSize uint32
 
+	rebalanceRate uint32
+
+	stateStorage StateStorage
+
 	containerSource container.Source
 }
 
@@ -259,6 +278,24 @@ func WithErrorThreshold(sz uint32) Option {
 	}
 }
 
+// WithRebalanceRate returns an option to specify the default maximum number
+// of objects moved per second by the shard rebalance. Zero value means no
+// limit.
+func WithRebalanceRate(rate uint32) Option {
+	return func(c *cfg) {
+		c.rebalanceRate = rate
+	}
+}
+
+// WithStateStorage returns an option to specify the storage for the
+// evacuation checkpoints and container quotas. Without it, evacuation
+// progress and quotas are not preserved between restarts.
+func WithStateStorage(s StateStorage) Option {
+	return func(c *cfg) {
+		c.stateStorage = s
+	}
+}
+
 // WithContainersSource returns an option to specify container source.
 func WithContainersSource(cs container.Source) Option {
 	return func(c *cfg) {
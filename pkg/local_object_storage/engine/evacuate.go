@@ -1,12 +1,18 @@
 package engine
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/epicchainlabs/hrw/v2"
 	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
 	"github.com/epicchainlabs/epicchain-node/pkg/util"
 	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
 	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
@@ -46,6 +52,89 @@ func (p EvacuateShardRes) Count() int {
 	return p.count
 }
 
+// EvacuationStatus describes the state of the background shard evacuation.
+type EvacuationStatus struct {
+	running     bool
+	interrupted bool
+	shardIDs    []string
+	startedAt   time.Time
+	total       uint64
+	processed   uint64
+	evacuated   uint64
+	failed      uint64
+	err         error
+}
+
+// Running returns true if the evacuation is in progress.
+func (s EvacuationStatus) Running() bool {
+	return s.running
+}
+
+// Interrupted returns true if the evacuation is not running but has a saved
+// checkpoint, so starting it for the same shards continues from the place
+// it was stopped at.
+func (s EvacuationStatus) Interrupted() bool {
+	return s.interrupted
+}
+
+// ShardIDs returns string identifiers of the evacuated shards.
+func (s EvacuationStatus) ShardIDs() []string {
+	return s.shardIDs
+}
+
+// StartedAt returns the time the last evacuation was started or resumed at.
+// Returns zero time if it hasn't been started since the node start.
+func (s EvacuationStatus) StartedAt() time.Time {
+	return s.startedAt
+}
+
+// Evacuated returns the number of objects moved to other shards or
+// accepted by the fault handler.
+func (s EvacuationStatus) Evacuated() uint64 {
+	return s.evacuated
+}
+
+// Failed returns the number of objects skipped because of read errors.
+func (s EvacuationStatus) Failed() uint64 {
+	return s.failed
+}
+
+// Remaining returns the estimated number of objects left to process.
+func (s EvacuationStatus) Remaining() uint64 {
+	if s.processed >= s.total {
+		return 0
+	}
+	return s.total - s.processed
+}
+
+// Error returns the error the last evacuation was aborted with, if any.
+func (s EvacuationStatus) Error() error {
+	return s.err
+}
+
+var evacuationCheckpointKey = []byte("shard_evacuation")
+
+// evacuationCheckpoint is a persisted progress of the evacuation.
+type evacuationCheckpoint struct {
+	ShardIDs     []string `json:"shard_ids"`
+	IgnoreErrors bool     `json:"ignore_errors"`
+	// Shard is an index of the shard in ShardIDs being evacuated.
+	Shard int `json:"shard"`
+	// Cursor is a marshaled listing cursor within the Shard.
+	Cursor    []byte `json:"cursor,omitempty"`
+	Processed uint64 `json:"processed"`
+	Evacuated uint64 `json:"evacuated"`
+	Failed    uint64 `json:"failed"`
+}
+
+// evacuationState holds the background evacuation state of the StorageEngine.
+type evacuationState struct {
+	mtx    sync.Mutex
+	stopCh chan struct{}
+	doneCh chan struct{}
+	status EvacuationStatus
+}
+
 const defaultEvacuateBatchSize = 100
 
 type pooledShard struct {
@@ -53,11 +142,48 @@ type pooledShard struct {
 	pool util.WorkerPool
 }
 
-var errMustHaveTwoShards = errors.New("must have at least 1 spare shard")
+var (
+	errMustHaveTwoShards = errors.New("must have at least 1 spare shard")
+
+	errEvacuationInProgress = logicerr.New("shard evacuation is already in progress")
+	errEvacuationNotRunning = logicerr.New("shard evacuation is not running")
+	errEvacuationStopped    = errors.New("evacuation stopped")
+)
+
+// evacuationJob is a single evacuation run.
+type evacuationJob struct {
+	prm      EvacuateShardPrm
+	sidList  []string
+	shards   []pooledShard
+	shardMap map[string]*shard.Shard
+
+	// cp is a progress of the job, it's initialized with the checkpoint to
+	// start from.
+	cp evacuationCheckpoint
+
+	// stopCh and closeCh interrupt the job if closed, nil for synchronous
+	// evacuation.
+	stopCh  <-chan struct{}
+	closeCh <-chan struct{}
+	// onObject is called after each processed object, may be nil.
+	onObject func(cp evacuationCheckpoint)
+	// onBatch is called after each processed batch of objects, may be nil.
+	onBatch func(cp evacuationCheckpoint)
+}
 
 // Evacuate moves data from one shard to the others.
 // The shard being moved must be in read-only mode.
 func (e *StorageEngine) Evacuate(prm EvacuateShardPrm) (EvacuateShardRes, error) {
+	job, err := e.newEvacuationJob(prm)
+	if err != nil {
+		return EvacuateShardRes{}, err
+	}
+
+	err = e.evacuate(job)
+	return EvacuateShardRes{count: int(job.cp.Evacuated)}, err
+}
+
+func (e *StorageEngine) newEvacuationJob(prm EvacuateShardPrm) (*evacuationJob, error) {
 	sidList := make([]string, len(prm.shardID))
 	for i := range prm.shardID {
 		sidList[i] = prm.shardID[i].String()
@@ -68,22 +194,20 @@ func (e *StorageEngine) Evacuate(prm EvacuateShardPrm) (EvacuateShardRes, error)
 		sh, ok := e.shards[sidList[i]]
 		if !ok {
 			e.mtx.RUnlock()
-			return EvacuateShardRes{}, errShardNotFound
+			return nil, errShardNotFound
 		}
 
 		if !sh.GetMode().ReadOnly() {
 			e.mtx.RUnlock()
-			return EvacuateShardRes{}, shard.ErrMustBeReadOnly
+			return nil, shard.ErrMustBeReadOnly
 		}
 	}
 
 	if len(e.shards)-len(sidList) < 1 && prm.handler == nil {
 		e.mtx.RUnlock()
-		return EvacuateShardRes{}, errMustHaveTwoShards
+		return nil, errMustHaveTwoShards
 	}
 
-	e.log.Info("started shards evacuation", zap.Strings("shard_ids", sidList))
-
 	// We must have all shards, to have correct information about their
 	// indexes in a sorted slice and set appropriate marks in the metabase.
 	// Evacuated shard is skipped during put.
@@ -105,17 +229,46 @@ func (e *StorageEngine) Evacuate(prm EvacuateShardPrm) (EvacuateShardRes, error)
 		}
 	}
 
+	return &evacuationJob{
+		prm:      prm,
+		sidList:  sidList,
+		shards:   shards,
+		shardMap: shardMap,
+		cp: evacuationCheckpoint{
+			ShardIDs:     sidList,
+			IgnoreErrors: prm.ignoreErrors,
+		},
+	}, nil
+}
+
+func (e *StorageEngine) evacuate(job *evacuationJob) error {
+	e.log.Info("started shards evacuation", zap.Strings("shard_ids", job.sidList))
+
 	var listPrm shard.ListWithCursorPrm
 	listPrm.WithCount(defaultEvacuateBatchSize)
 
-	var res EvacuateShardRes
-
 mainLoop:
-	for n := range sidList {
-		sh := shardMap[sidList[n]]
+	for ; job.cp.Shard < len(job.sidList); job.cp.Shard, job.cp.Cursor = job.cp.Shard+1, nil {
+		sid := job.sidList[job.cp.Shard]
+		sh := job.shardMap[sid]
 
 		var c *meta.Cursor
+		if job.cp.Cursor != nil {
+			c = new(meta.Cursor)
+			if err := c.Unmarshal(job.cp.Cursor); err != nil {
+				return fmt.Errorf("invalid evacuation checkpoint: %w", err)
+			}
+		}
+
 		for {
+			select {
+			case <-job.stopCh:
+				return errEvacuationStopped
+			case <-job.closeCh:
+				return errEvacuationStopped
+			default:
+			}
+
 			listPrm.WithCursor(c)
 
 			// TODO (@fyrchik): #1731 this approach doesn't work in degraded modes
@@ -125,65 +278,290 @@ mainLoop:
 				if errors.Is(err, meta.ErrEndOfListing) || errors.Is(err, shard.ErrDegradedMode) {
 					continue mainLoop
 				}
-				return res, err
+				return err
 			}
 
 			// TODO (@fyrchik): #1731 parallelize the loop
 			lst := listRes.AddressList()
 
-		loop:
 			for i := range lst {
-				addr := lst[i].Address
-				addrHash := hrw.WrapBytes([]byte(addr.EncodeToString()))
-
-				var getPrm shard.GetPrm
-				getPrm.SetAddress(addr)
-
-				getRes, err := sh.Get(getPrm)
+				err = e.evacuateObject(job, sh, sid, lst[i].Address)
 				if err != nil {
-					if prm.ignoreErrors {
-						continue
-					}
-					return res, err
-				}
-
-				hrw.Sort(shards, addrHash)
-				for j := range shards {
-					if _, ok := shardMap[shards[j].ID().String()]; ok {
-						continue
-					}
-					putDone, exists := e.putToShard(shards[j].hashedShard, j, shards[j].pool, addr, PutPrm{obj: getRes.Object()})
-					if putDone || exists {
-						if putDone {
-							e.log.Debug("object is moved to another shard",
-								zap.String("from", sidList[n]),
-								zap.Stringer("to", shards[j].ID()),
-								zap.Stringer("addr", addr))
-
-							res.count++
-						}
-						continue loop
-					}
-				}
-
-				if prm.handler == nil {
-					// Do not check ignoreErrors flag here because
-					// ignoring errors on put make this command kinda useless.
-					return res, fmt.Errorf("%w: %s", errPutShard, lst[i])
+					return err
 				}
 
-				err = prm.handler(addr, getRes.Object())
-				if err != nil {
-					return res, err
+				job.cp.Processed++
+				if job.onObject != nil {
+					job.onObject(job.cp)
 				}
-				res.count++
 			}
 
 			c = listRes.Cursor()
+			job.cp.Cursor = c.Marshal()
+			if job.onBatch != nil {
+				job.onBatch(job.cp)
+			}
 		}
 	}
 
 	e.log.Info("finished shards evacuation",
-		zap.Strings("shard_ids", sidList))
-	return res, nil
+		zap.Strings("shard_ids", job.sidList))
+	return nil
+}
+
+func (e *StorageEngine) evacuateObject(job *evacuationJob, sh *shard.Shard, sid string, addr oid.Address) error {
+	addrHash := hrw.WrapBytes([]byte(addr.EncodeToString()))
+
+	var getPrm shard.GetPrm
+	getPrm.SetAddress(addr)
+	getPrm.SetIOClass(throttle.Background)
+
+	getRes, err := sh.Get(getPrm)
+	if err != nil {
+		if job.prm.ignoreErrors {
+			job.cp.Failed++
+			return nil
+		}
+		return err
+	}
+
+	hrw.Sort(job.shards, addrHash)
+	for j := range job.shards {
+		if _, ok := job.shardMap[job.shards[j].ID().String()]; ok {
+			continue
+		}
+		putDone, exists := e.putToShard(job.shards[j].hashedShard, j, job.shards[j].pool, addr, PutPrm{obj: getRes.Object(), ioClass: throttle.Background})
+		if putDone || exists {
+			if putDone {
+				e.log.Debug("object is moved to another shard",
+					zap.String("from", sid),
+					zap.Stringer("to", job.shards[j].ID()),
+					zap.Stringer("addr", addr))
+
+				job.cp.Evacuated++
+			}
+			return nil
+		}
+	}
+
+	if job.prm.handler == nil {
+		// Do not check ignoreErrors flag here because
+		// ignoring errors on put make this command kinda useless.
+		return fmt.Errorf("%w: %s", errPutShard, addr)
+	}
+
+	err = job.prm.handler(addr, getRes.Object())
+	if err != nil {
+		return err
+	}
+	job.cp.Evacuated++
+
+	return nil
+}
+
+// StartEvacuation starts moving data from the shards to the others in the
+// background. The shards being moved must be in read-only mode. Progress is
+// saved to the storage set with WithStateStorage, so the evacuation of the
+// same shards stopped by StopEvacuation or by restart continues from the
+// saved checkpoint. Use EvacuationStatus to track the progress.
+//
+// Returns an error if evacuation is already in progress.
+func (e *StorageEngine) StartEvacuation(prm EvacuateShardPrm) error {
+	e.evacuation.mtx.Lock()
+	defer e.evacuation.mtx.Unlock()
+
+	if e.evacuation.status.running {
+		return errEvacuationInProgress
+	}
+
+	job, err := e.newEvacuationJob(prm)
+	if err != nil {
+		return err
+	}
+
+	// keep the order stable to match the checkpoint regardless of
+	// the requested one
+	slices.Sort(job.sidList)
+
+	cp, ok, err := e.readEvacuationCheckpoint()
+	if err != nil {
+		return err
+	}
+	if ok && slices.Equal(cp.ShardIDs, job.sidList) && cp.IgnoreErrors == prm.ignoreErrors {
+		e.log.Info("resuming shards evacuation from the checkpoint",
+			zap.Strings("shard_ids", job.sidList),
+			zap.Uint64("processed", cp.Processed))
+		job.cp = cp
+	}
+
+	var total uint64
+	for _, sh := range job.shardMap {
+		c, err := sh.ObjectCounters()
+		if err == nil {
+			total += c.Logic()
+		}
+	}
+
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+
+	job.stopCh = stopCh
+	job.closeCh = e.closeCh
+	job.onObject = func(cp evacuationCheckpoint) {
+		e.evacuation.mtx.Lock()
+		e.evacuation.status.processed = cp.Processed
+		e.evacuation.status.evacuated = cp.Evacuated
+		e.evacuation.status.failed = cp.Failed
+		e.evacuation.mtx.Unlock()
+	}
+	job.onBatch = func(cp evacuationCheckpoint) {
+		err := e.writeEvacuationCheckpoint(&cp)
+		if err != nil {
+			e.log.Warn("could not save evacuation checkpoint", zap.Error(err))
+		}
+	}
+
+	e.evacuation.stopCh = stopCh
+	e.evacuation.doneCh = doneCh
+	e.evacuation.status = EvacuationStatus{
+		running:   true,
+		shardIDs:  job.sidList,
+		startedAt: time.Now(),
+		total:     total,
+		processed: job.cp.Processed,
+		evacuated: job.cp.Evacuated,
+		failed:    job.cp.Failed,
+	}
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		defer close(doneCh)
+
+		err := e.evacuate(job)
+		if err == nil {
+			// done, drop the checkpoint
+			if err := e.writeEvacuationCheckpoint(nil); err != nil {
+				e.log.Warn("could not drop evacuation checkpoint", zap.Error(err))
+			}
+		} else if errors.Is(err, errEvacuationStopped) {
+			e.log.Info("shards evacuation interrupted", zap.Strings("shard_ids", job.sidList))
+			err = nil
+		} else {
+			e.log.Error("shards evacuation failed",
+				zap.Strings("shard_ids", job.sidList),
+				zap.Error(err))
+		}
+
+		e.evacuation.mtx.Lock()
+		e.evacuation.status.running = false
+		e.evacuation.status.interrupted = job.cp.Shard < len(job.sidList) && e.stateStorage != nil
+		e.evacuation.status.err = err
+		e.evacuation.mtx.Unlock()
+	}()
+
+	return nil
+}
+
+// StopEvacuation interrupts the evacuation in progress. Its checkpoint is
+// kept, so the evacuation of the same shards can be continued with
+// StartEvacuation.
+//
+// Returns an error if evacuation is not running.
+func (e *StorageEngine) StopEvacuation() error {
+	e.evacuation.mtx.Lock()
+	if !e.evacuation.status.running {
+		e.evacuation.mtx.Unlock()
+		return errEvacuationNotRunning
+	}
+
+	stopCh, doneCh := e.evacuation.stopCh, e.evacuation.doneCh
+	// concurrent stop calls just wait for the evacuation
+	e.evacuation.stopCh = nil
+	e.evacuation.mtx.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+	}
+	<-doneCh
+
+	return nil
+}
+
+// EvacuationStatus returns the state of the current or the last finished
+// background evacuation. If the evacuation hasn't been started since the
+// node start, the state is restored from the saved checkpoint, if any.
+func (e *StorageEngine) EvacuationStatus() EvacuationStatus {
+	e.evacuation.mtx.Lock()
+	defer e.evacuation.mtx.Unlock()
+
+	if !e.evacuation.status.startedAt.IsZero() {
+		return e.evacuation.status
+	}
+
+	cp, ok, err := e.readEvacuationCheckpoint()
+	if err != nil || !ok {
+		return EvacuationStatus{err: err}
+	}
+
+	return EvacuationStatus{
+		interrupted: true,
+		shardIDs:    cp.ShardIDs,
+		processed:   cp.Processed,
+		evacuated:   cp.Evacuated,
+		failed:      cp.Failed,
+	}
+}
+
+// waitEvacuation waits for the background evacuation to finish if it is
+// running.
+func (e *StorageEngine) waitEvacuation() {
+	e.evacuation.mtx.Lock()
+	doneCh := e.evacuation.doneCh
+	e.evacuation.mtx.Unlock()
+
+	if doneCh != nil {
+		<-doneCh
+	}
+}
+
+func (e *StorageEngine) readEvacuationCheckpoint() (evacuationCheckpoint, bool, error) {
+	var cp evacuationCheckpoint
+
+	if e.stateStorage == nil {
+		return cp, false, nil
+	}
+
+	data, err := e.stateStorage.Bytes(evacuationCheckpointKey)
+	if err != nil {
+		return cp, false, fmt.Errorf("read evacuation checkpoint: %w", err)
+	}
+	if len(data) == 0 {
+		return cp, false, nil
+	}
+
+	err = json.Unmarshal(data, &cp)
+	if err != nil {
+		return cp, false, fmt.Errorf("decode evacuation checkpoint: %w", err)
+	}
+
+	return cp, true, nil
+}
+
+// writeEvacuationCheckpoint saves the checkpoint, nil drops the saved one.
+func (e *StorageEngine) writeEvacuationCheckpoint(cp *evacuationCheckpoint) error {
+	if e.stateStorage == nil {
+		return nil
+	}
+
+	var data = []byte{}
+	if cp != nil {
+		var err error
+		data, err = json.Marshal(cp)
+		if err != nil {
+			return fmt.Errorf("encode evacuation checkpoint: %w", err)
+		}
+	}
+
+	return e.stateStorage.SetBytes(evacuationCheckpointKey, data)
 }
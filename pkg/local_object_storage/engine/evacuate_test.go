@@ -6,7 +6,9 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	objectCore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
@@ -221,3 +223,144 @@ func TestEvacuateNetwork(t *testing.T) {
 		})
 	})
 }
+
+type testStateStorage struct {
+	mtx sync.Mutex
+	m   map[string][]byte
+}
+
+func (s *testStateStorage) SetBytes(key []byte, value []byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.m == nil {
+		s.m = make(map[string][]byte)
+	}
+	s.m[string(key)] = value
+	return nil
+}
+
+func (s *testStateStorage) Bytes(key []byte) ([]byte, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.m[string(key)], nil
+}
+
+func waitEvacuation(t *testing.T, e *StorageEngine) EvacuationStatus {
+	require.Eventually(t, func() bool {
+		return !e.EvacuationStatus().Running()
+	}, 10*time.Second, 10*time.Millisecond)
+
+	return e.EvacuationStatus()
+}
+
+func TestStartEvacuation(t *testing.T) {
+	const objPerShard = 3
+
+	e, ids, objects := newEngineEvacuate(t, 3, objPerShard)
+	e.stateStorage = new(testStateStorage)
+
+	var prm EvacuateShardPrm
+	prm.WithShardIDList(ids[2:3])
+
+	require.ErrorIs(t, e.StartEvacuation(prm), shard.ErrMustBeReadOnly)
+	require.ErrorIs(t, e.StopEvacuation(), errEvacuationNotRunning)
+
+	require.NoError(t, e.shards[ids[2].String()].SetMode(mode.ReadOnly))
+	require.NoError(t, e.StartEvacuation(prm))
+
+	st := waitEvacuation(t, e)
+	require.NoError(t, st.Error())
+	require.False(t, st.Interrupted())
+	require.Equal(t, []string{ids[2].String()}, st.ShardIDs())
+	require.False(t, st.StartedAt().IsZero())
+	require.EqualValues(t, objPerShard, st.Evacuated())
+	require.Zero(t, st.Failed())
+	require.Zero(t, st.Remaining())
+
+	_, ok, err := e.readEvacuationCheckpoint()
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	for i := range objects {
+		var getPrm GetPrm
+		getPrm.WithAddress(objectCore.AddressOf(objects[i]))
+
+		_, err := e.Get(getPrm)
+		require.NoError(t, err)
+	}
+}
+
+func TestStopEvacuation(t *testing.T) {
+	const objNum = 3
+
+	e, ids, _ := newEngineEvacuate(t, 1, objNum)
+	e.stateStorage = new(testStateStorage)
+
+	require.NoError(t, e.shards[ids[0].String()].SetMode(mode.ReadOnly))
+
+	var (
+		calledOnce sync.Once
+		called     = make(chan struct{})
+		release    = make(chan struct{})
+	)
+
+	var prm EvacuateShardPrm
+	prm.WithShardIDList(ids)
+	prm.WithFaultHandler(func(oid.Address, *objectSDK.Object) error {
+		calledOnce.Do(func() { close(called) })
+		<-release
+		return nil
+	})
+
+	require.NoError(t, e.StartEvacuation(prm))
+	require.ErrorIs(t, e.StartEvacuation(prm), errEvacuationInProgress)
+
+	<-called
+
+	stopped := make(chan error, 2)
+	go func() { stopped <- e.StopEvacuation() }()
+
+	// the job checks for the stop signal between the batches only
+	require.Eventually(t, func() bool {
+		e.evacuation.mtx.Lock()
+		defer e.evacuation.mtx.Unlock()
+		return e.evacuation.stopCh == nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// concurrent stop request waits for the same job
+	go func() { stopped <- e.StopEvacuation() }()
+
+	close(release)
+
+	require.NoError(t, <-stopped)
+	if err := <-stopped; err != nil {
+		require.ErrorIs(t, err, errEvacuationNotRunning)
+	}
+
+	st := e.EvacuationStatus()
+	require.False(t, st.Running())
+	require.True(t, st.Interrupted())
+	require.NoError(t, st.Error())
+	require.EqualValues(t, objNum, st.Evacuated())
+
+	cp, ok, err := e.readEvacuationCheckpoint()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []string{ids[0].String()}, cp.ShardIDs)
+	require.EqualValues(t, objNum, cp.Processed)
+	require.NotEmpty(t, cp.Cursor)
+
+	t.Run("resume", func(t *testing.T) {
+		require.NoError(t, e.StartEvacuation(prm))
+
+		st := waitEvacuation(t, e)
+		require.NoError(t, st.Error())
+		require.False(t, st.Interrupted())
+		// objects are not processed again
+		require.EqualValues(t, objNum, st.Evacuated())
+
+		_, ok, err := e.readEvacuationCheckpoint()
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
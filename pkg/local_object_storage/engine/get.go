@@ -2,6 +2,7 @@ package engine
 
 import (
 	"errors"
+	"io"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util"
@@ -185,6 +186,9 @@ func Get(storage *StorageEngine, addr oid.Address) (*objectSDK.Object, error) {
 // GetBytes reads object from the StorageEngine by address into memory buffer in
 // a canonical NeoFS binary format. Returns [apistatus.ObjectNotFound] if object
 // is missing.
+//
+// GetBytes is intended for object replication, the read is throttled by the
+// shard I/O limits as a background operation.
 func (e *StorageEngine) GetBytes(addr oid.Address) ([]byte, error) {
 	var b []byte
 	err := e.execIfNotBlocked(func() error {
@@ -199,3 +203,30 @@ func (e *StorageEngine) GetBytes(addr oid.Address) ([]byte, error) {
 	})
 	return b, err
 }
+
+// GetStream reads object header from local storage by provided address and
+// opens its payload stream, so the payload is not kept in memory entirely. The
+// stream must be closed by the caller.
+//
+// Returns the same errors as Get.
+func (e *StorageEngine) GetStream(addr oid.Address) (*objectSDK.Object, io.ReadCloser, error) {
+	var (
+		sp  shard.GetPrm
+		res shard.GetStreamRes
+	)
+
+	sp.SetAddress(addr)
+
+	err := e.execIfNotBlocked(func() error {
+		return e.get(addr, func(s *shard.Shard, ignoreMetadata bool) (hasMetadata bool, err error) {
+			sp.SetIgnoreMeta(ignoreMetadata)
+			res, err = s.GetStream(sp)
+			return res.HasMeta(), err
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.Header(), res.Payload(), nil
+}
@@ -24,6 +24,11 @@ type MetricRegister interface {
 
 	AddToContainerSize(cnrID string, size int64)
 	AddToPayloadCounter(shardID string, size int64)
+
+	AddThrottledTime(shardID, class string, d time.Duration)
+
+	SetScrubbedObjects(shardID string, v uint64)
+	SetCorruptedObjects(shardID string, v uint64)
 }
 
 func elapsed(addFunc func(d time.Duration)) func() {
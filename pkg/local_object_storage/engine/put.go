@@ -7,6 +7,7 @@ import (
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
 	"github.com/epicchainlabs/epicchain-node/pkg/util"
 	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
 	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
@@ -20,6 +21,10 @@ type PutPrm struct {
 	binSet bool
 	objBin []byte
 	hdrLen int
+
+	// ioClass is the class the write is throttled as, set by the engine
+	// for background data movement.
+	ioClass throttle.Class
 }
 
 // PutRes groups the resulting values of Put operation.
@@ -147,6 +152,8 @@ func (e *StorageEngine) putToShard(sh hashedShard, ind int, pool util.WorkerPool
 			putPrm.SetObjectBinary(prm.objBin, prm.hdrLen)
 		}
 
+		putPrm.SetIOClass(prm.ioClass)
+
 		_, err = sh.Put(putPrm)
 		if err != nil {
 			if errors.Is(err, shard.ErrReadOnlyMode) || errors.Is(err, blobstor.ErrNoPlaceFound) ||
@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/core/container"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+)
+
+var containerQuotasKey = []byte("container_quotas")
+
+// quotaState holds container quotas set by the node operator.
+type quotaState struct {
+	mtx sync.RWMutex
+
+	// loaded is true after the quotas are read from the state storage.
+	loaded bool
+	quotas map[cid.ID]container.Quota
+}
+
+// SetContainerQuota sets the quota of the container data stored by the node.
+// Zero quota removes the limits. Quotas are saved to the storage set with
+// WithStateStorage, without it they are lost on restart.
+//
+// The quota is merged with the one set by the container owner, see
+// CheckContainerQuota.
+func (e *StorageEngine) SetContainerQuota(cnr cid.ID, q container.Quota) error {
+	e.quota.mtx.Lock()
+	defer e.quota.mtx.Unlock()
+
+	if err := e.loadContainerQuotas(); err != nil {
+		return err
+	}
+
+	quotas := make(map[cid.ID]container.Quota, len(e.quota.quotas)+1)
+	for id, cq := range e.quota.quotas {
+		quotas[id] = cq
+	}
+	if q.IsZero() {
+		delete(quotas, cnr)
+	} else {
+		quotas[cnr] = q
+	}
+
+	if err := e.writeContainerQuotas(quotas); err != nil {
+		return err
+	}
+
+	e.quota.quotas = quotas
+
+	return nil
+}
+
+// ContainerQuota returns the quota of the container set with
+// SetContainerQuota. Zero quota means there are no limits.
+func (e *StorageEngine) ContainerQuota(cnr cid.ID) (container.Quota, error) {
+	e.quota.mtx.RLock()
+	if e.quota.loaded {
+		defer e.quota.mtx.RUnlock()
+		return e.quota.quotas[cnr], nil
+	}
+	e.quota.mtx.RUnlock()
+
+	e.quota.mtx.Lock()
+	defer e.quota.mtx.Unlock()
+
+	if err := e.loadContainerQuotas(); err != nil {
+		return container.Quota{}, err
+	}
+
+	return e.quota.quotas[cnr], nil
+}
+
+// CheckContainerQuota checks whether an object with the given payload size can
+// be stored in the container. The quota set with SetContainerQuota is merged
+// with q (usually set by the container owner) taking the strictest limits.
+// Usage is calculated with ContainerSize.
+//
+// Returns container.QuotaExceeded if any hard limit would be exceeded.
+// Returns true if the object is accepted but soft limits are exceeded.
+func (e *StorageEngine) CheckContainerQuota(cnr cid.ID, q container.Quota, size uint64) (bool, error) {
+	local, err := e.ContainerQuota(cnr)
+	if err != nil {
+		return false, err
+	}
+
+	q = q.Merge(local)
+	if q.IsZero() {
+		return false, nil
+	}
+
+	var prm ContainerSizePrm
+	prm.SetContainerID(cnr)
+
+	usage, err := e.ContainerSize(prm)
+	if err != nil {
+		return false, err
+	}
+
+	newSize := usage.Size() + size
+	newObjects := usage.Objects() + 1
+
+	switch {
+	case q.HardSize != 0 && newSize > q.HardSize:
+		return false, container.NewQuotaExceeded(fmt.Sprintf("size limit %d bytes, stored %d bytes, object payload %d bytes",
+			q.HardSize, usage.Size(), size))
+	case q.HardObjects != 0 && newObjects > q.HardObjects:
+		return false, container.NewQuotaExceeded(fmt.Sprintf("objects limit %d, stored %d",
+			q.HardObjects, usage.Objects()))
+	}
+
+	return (q.SoftSize != 0 && newSize > q.SoftSize) ||
+		(q.SoftObjects != 0 && newObjects > q.SoftObjects), nil
+}
+
+// loadContainerQuotas reads the quotas from the state storage if it has not
+// been done yet. e.quota.mtx must be locked.
+func (e *StorageEngine) loadContainerQuotas() error {
+	if e.quota.loaded {
+		return nil
+	}
+
+	quotas := make(map[cid.ID]container.Quota)
+
+	if e.stateStorage != nil {
+		data, err := e.stateStorage.Bytes(containerQuotasKey)
+		if err != nil {
+			return fmt.Errorf("read container quotas: %w", err)
+		}
+
+		if len(data) != 0 {
+			var raw map[string]container.Quota
+			if err := json.Unmarshal(data, &raw); err != nil {
+				return fmt.Errorf("decode container quotas: %w", err)
+			}
+
+			for s, q := range raw {
+				var id cid.ID
+				if err := id.DecodeString(s); err != nil {
+					return fmt.Errorf("decode container quotas: invalid container ID %q: %w", s, err)
+				}
+				quotas[id] = q
+			}
+		}
+	}
+
+	e.quota.quotas = quotas
+	e.quota.loaded = true
+
+	return nil
+}
+
+func (e *StorageEngine) writeContainerQuotas(quotas map[cid.ID]container.Quota) error {
+	if e.stateStorage == nil {
+		return nil
+	}
+
+	raw := make(map[string]container.Quota, len(quotas))
+	for id, q := range quotas {
+		raw[id.EncodeToString()] = q
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("encode container quotas: %w", err)
+	}
+
+	return e.stateStorage.SetBytes(containerQuotasKey, data)
+}
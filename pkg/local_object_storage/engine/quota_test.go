@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/core/container"
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageEngine_ContainerQuota(t *testing.T) {
+	e := testNewEngineWithShardNum(t, 2)
+	t.Cleanup(func() { _ = e.Close() })
+
+	storage := new(testStateStorage)
+	e.stateStorage = storage
+
+	cnr := cidtest.ID()
+
+	for i := 0; i < 3; i++ {
+		obj := generateObjectWithCID(t, cnr)
+		obj.SetPayloadSize(100)
+
+		var prm PutPrm
+		prm.WithObject(obj)
+
+		_, err := e.Put(prm)
+		require.NoError(t, err)
+	}
+
+	var sizePrm ContainerSizePrm
+	sizePrm.SetContainerID(cnr)
+
+	usage, err := e.ContainerSize(sizePrm)
+	require.NoError(t, err)
+	require.EqualValues(t, 300, usage.Size())
+	require.EqualValues(t, 3, usage.Objects())
+
+	soft, err := e.CheckContainerQuota(cnr, container.Quota{}, 100)
+	require.NoError(t, err)
+	require.False(t, soft)
+
+	t.Run("owner quota", func(t *testing.T) {
+		soft, err := e.CheckContainerQuota(cnr, container.Quota{SoftSize: 350, HardSize: 1000}, 100)
+		require.NoError(t, err)
+		require.True(t, soft)
+
+		_, err = e.CheckContainerQuota(cnr, container.Quota{HardObjects: 3}, 1)
+		require.ErrorAs(t, err, new(container.QuotaExceeded))
+
+		// status sent by the object service
+		st := apistatus.ErrorToV2(fmt.Errorf("could not put object: %w", err))
+		require.EqualValues(t, 2054, st.Code())
+
+		// and decoded by the client, it is not mixed up with node failures
+		received := apistatus.ErrorFromV2(st)
+		require.ErrorIs(t, received, apistatus.ErrUnrecognizedStatusV2)
+		require.NotErrorIs(t, received, apistatus.ErrServerInternal)
+		require.Contains(t, received.Error(), "container quota exceeded")
+	})
+
+	t.Run("operator quota", func(t *testing.T) {
+		require.NoError(t, e.SetContainerQuota(cnr, container.Quota{HardSize: 350}))
+
+		q, err := e.ContainerQuota(cnr)
+		require.NoError(t, err)
+		require.Equal(t, container.Quota{HardSize: 350}, q)
+
+		soft, err := e.CheckContainerQuota(cnr, container.Quota{}, 50)
+		require.NoError(t, err)
+		require.False(t, soft)
+
+		// the strictest limit is applied
+		_, err = e.CheckContainerQuota(cnr, container.Quota{HardSize: 1000}, 100)
+		require.ErrorAs(t, err, new(container.QuotaExceeded))
+
+		// quotas are read from the state storage
+		e2 := New()
+		e2.stateStorage = storage
+
+		q, err = e2.ContainerQuota(cnr)
+		require.NoError(t, err)
+		require.Equal(t, container.Quota{HardSize: 350}, q)
+
+		require.NoError(t, e.SetContainerQuota(cnr, container.Quota{}))
+
+		_, err = e.CheckContainerQuota(cnr, container.Quota{}, 100)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,501 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"go.uber.org/zap"
+)
+
+// RebalancePrm groups the parameters of StartRebalance operation.
+type RebalancePrm struct {
+	rate uint32
+}
+
+// SetRate sets the maximum number of objects moved per second. Zero value
+// means the rate configured with WithRebalanceRate.
+func (p *RebalancePrm) SetRate(rate uint32) {
+	p.rate = rate
+}
+
+// RebalanceStatus describes the state of the shard rebalance.
+type RebalanceStatus struct {
+	running     bool
+	rate        uint32
+	startedAt   time.Time
+	shardsTotal int
+	shardsDone  int
+	processed   uint64
+	moved       uint64
+	failed      uint64
+	err         error
+}
+
+// Running returns true if the rebalance is in progress.
+func (s RebalanceStatus) Running() bool {
+	return s.running
+}
+
+// Rate returns the maximum number of objects moved per second, zero
+// means no limit.
+func (s RebalanceStatus) Rate() uint32 {
+	return s.rate
+}
+
+// StartedAt returns the time the last rebalance was started or resumed at.
+// Returns zero time if rebalance has never been started.
+func (s RebalanceStatus) StartedAt() time.Time {
+	return s.startedAt
+}
+
+// ShardsTotal returns the number of shards to be processed.
+func (s RebalanceStatus) ShardsTotal() int {
+	return s.shardsTotal
+}
+
+// ShardsDone returns the number of completely processed shards.
+func (s RebalanceStatus) ShardsDone() int {
+	return s.shardsDone
+}
+
+// Processed returns the number of checked objects.
+func (s RebalanceStatus) Processed() uint64 {
+	return s.processed
+}
+
+// Moved returns the number of objects moved to their HRW-preferred shard.
+func (s RebalanceStatus) Moved() uint64 {
+	return s.moved
+}
+
+// Failed returns the number of objects that could not be moved.
+func (s RebalanceStatus) Failed() uint64 {
+	return s.failed
+}
+
+// Error returns the error the last rebalance was aborted with, if any.
+func (s RebalanceStatus) Error() error {
+	return s.err
+}
+
+// rebalanceState holds the rebalance routine state of the StorageEngine.
+type rebalanceState struct {
+	mtx    sync.Mutex
+	stopCh chan struct{}
+	doneCh chan struct{}
+	status RebalanceStatus
+}
+
+const defaultRebalanceBatchSize = 100
+
+// maxRebalanceRate is the maximum supported rebalance rate, relocations
+// are paced with at least one nanosecond interval.
+const maxRebalanceRate = uint32(time.Second)
+
+var (
+	errRebalanceInProgress = logicerr.New("shard rebalance is already in progress")
+	errRebalanceNotRunning = logicerr.New("shard rebalance is not running")
+	errRebalanceStopped    = errors.New("rebalance stopped")
+	errRebalanceRate       = logicerr.New(fmt.Sprintf("shard rebalance rate must not exceed %d objects per second", maxRebalanceRate))
+)
+
+// StartRebalance starts moving objects to their HRW-preferred shards in the
+// background. Progress of each shard is persisted in its metabase, so the
+// interrupted rebalance is resumed after restart. Use RebalanceStatus to
+// track the progress and StopRebalance to cancel it.
+//
+// Returns an error if rebalance is already in progress or the rate exceeds
+// one object per nanosecond.
+func (e *StorageEngine) StartRebalance(prm RebalancePrm) error {
+	if prm.rate > maxRebalanceRate {
+		return errRebalanceRate
+	}
+
+	e.rebalance.mtx.Lock()
+	defer e.rebalance.mtx.Unlock()
+
+	if e.rebalance.status.running {
+		return errRebalanceInProgress
+	}
+
+	shards := e.rebalanceShards()
+	for _, sh := range shards {
+		err := sh.SetRebalanceCursor(nil)
+		if err != nil {
+			return fmt.Errorf("could not mark shard %s for rebalance: %w", sh.ID(), err)
+		}
+	}
+
+	rate := prm.rate
+	if rate == 0 {
+		rate = e.rebalanceRate
+	}
+
+	e.startRebalance(shards, rate)
+
+	return nil
+}
+
+// resumeRebalance restarts the rebalance interrupted by the shutdown if
+// any shard has a persisted rebalance progress.
+func (e *StorageEngine) resumeRebalance() {
+	e.rebalance.mtx.Lock()
+	defer e.rebalance.mtx.Unlock()
+
+	shards := e.rebalanceShards()
+	for _, sh := range shards {
+		_, ok, err := sh.RebalanceCursor()
+		if err == nil && ok {
+			e.log.Info("resuming interrupted shard rebalance")
+			e.startRebalance(shards, e.rebalanceRate)
+			return
+		}
+	}
+}
+
+// startRebalance runs the rebalance routine. Must be called with
+// rebalance mutex held.
+func (e *StorageEngine) startRebalance(shards []hashedShard, rate uint32) {
+	e.rebalance.stopCh = make(chan struct{})
+	e.rebalance.doneCh = make(chan struct{})
+	e.rebalance.status = RebalanceStatus{
+		running:     true,
+		rate:        rate,
+		startedAt:   time.Now(),
+		shardsTotal: len(shards),
+	}
+
+	e.wg.Add(1)
+	go e.rebalanceLoop(shards, rate, e.rebalance.stopCh, e.rebalance.doneCh)
+}
+
+// StopRebalance cancels the rebalance in progress and drops its persisted
+// progress. Objects already moved stay on their new shards.
+//
+// Returns an error if rebalance is not running.
+func (e *StorageEngine) StopRebalance() error {
+	e.rebalance.mtx.Lock()
+	if !e.rebalance.status.running {
+		e.rebalance.mtx.Unlock()
+		return errRebalanceNotRunning
+	}
+
+	stopCh, doneCh := e.rebalance.stopCh, e.rebalance.doneCh
+	// concurrent stop calls just wait for the rebalance
+	e.rebalance.stopCh = nil
+	e.rebalance.mtx.Unlock()
+
+	if stopCh == nil {
+		<-doneCh
+		return nil
+	}
+
+	close(stopCh)
+	<-doneCh
+
+	for _, sh := range e.rebalanceShards() {
+		err := sh.ResetRebalanceCursor()
+		if err != nil {
+			e.log.Warn("could not reset shard rebalance progress",
+				zap.Stringer("shard_id", sh.ID()),
+				zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// waitRebalance waits for the rebalance routine to finish if it is running.
+func (e *StorageEngine) waitRebalance() {
+	e.rebalance.mtx.Lock()
+	doneCh := e.rebalance.doneCh
+	e.rebalance.mtx.Unlock()
+
+	if doneCh != nil {
+		<-doneCh
+	}
+}
+
+// RebalanceStatus returns the state of the current or the last finished
+// shard rebalance.
+func (e *StorageEngine) RebalanceStatus() RebalanceStatus {
+	e.rebalance.mtx.Lock()
+	defer e.rebalance.mtx.Unlock()
+
+	return e.rebalance.status
+}
+
+// rebalanceShards returns writable shards sorted by ID.
+func (e *StorageEngine) rebalanceShards() []hashedShard {
+	shards := e.unsortedShards()
+
+	res := shards[:0]
+	for _, sh := range shards {
+		if !sh.GetMode().ReadOnly() {
+			res = append(res, sh)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID().String() < res[j].ID().String()
+	})
+
+	return res
+}
+
+func (e *StorageEngine) updateRebalanceStatus(f func(*RebalanceStatus)) {
+	e.rebalance.mtx.Lock()
+	f(&e.rebalance.status)
+	e.rebalance.mtx.Unlock()
+}
+
+// rebalanceLimiter limits the rate of the object relocations and tracks
+// the rebalance interruption.
+type rebalanceLimiter struct {
+	closeCh <-chan struct{}
+	stopCh  <-chan struct{}
+	tick    <-chan time.Time
+}
+
+// stopped returns errRebalanceStopped if the rebalance is interrupted.
+func (l rebalanceLimiter) stopped() error {
+	select {
+	case <-l.closeCh:
+		return errRebalanceStopped
+	case <-l.stopCh:
+		return errRebalanceStopped
+	default:
+		return nil
+	}
+}
+
+// wait blocks until the next relocation is allowed. Returns
+// errRebalanceStopped if the rebalance is interrupted.
+func (l rebalanceLimiter) wait() error {
+	if l.tick == nil {
+		return l.stopped()
+	}
+
+	select {
+	case <-l.closeCh:
+		return errRebalanceStopped
+	case <-l.stopCh:
+		return errRebalanceStopped
+	case <-l.tick:
+		return nil
+	}
+}
+
+func (e *StorageEngine) rebalanceLoop(shards []hashedShard, rate uint32, stopCh, doneCh chan struct{}) {
+	defer e.wg.Done()
+	defer close(doneCh)
+
+	e.log.Info("started shard rebalance", zap.Uint32("rate", rate))
+
+	l := rebalanceLimiter{closeCh: e.closeCh, stopCh: stopCh}
+	if rate > maxRebalanceRate {
+		// configured default is not validated by StartRebalance
+		rate = maxRebalanceRate
+	}
+
+	if rate > 0 {
+		t := time.NewTicker(time.Second / time.Duration(rate))
+		defer t.Stop()
+		l.tick = t.C
+	}
+
+	var err error
+	for _, sh := range shards {
+		err = e.rebalanceShard(sh, l)
+		if err != nil {
+			break
+		}
+
+		e.updateRebalanceStatus(func(s *RebalanceStatus) { s.shardsDone++ })
+	}
+
+	if errors.Is(err, errRebalanceStopped) {
+		e.log.Info("shard rebalance interrupted")
+		err = nil
+	} else if err != nil {
+		e.log.Error("shard rebalance failed", zap.Error(err))
+	} else {
+		e.log.Info("finished shard rebalance")
+	}
+
+	e.updateRebalanceStatus(func(s *RebalanceStatus) {
+		s.running = false
+		s.err = err
+	})
+}
+
+// rebalanceShard moves objects of the shard to their HRW-preferred shards
+// starting from the persisted cursor. The progress is persisted after each
+// batch.
+func (e *StorageEngine) rebalanceShard(sh hashedShard, l rebalanceLimiter) error {
+	c, ok, err := sh.RebalanceCursor()
+	if err != nil {
+		return fmt.Errorf("read rebalance progress of shard %s: %w", sh.ID(), err)
+	}
+	if !ok {
+		return nil // already processed
+	}
+
+	var listPrm shard.ListWithCursorPrm
+	listPrm.WithCount(defaultRebalanceBatchSize)
+
+	for {
+		err = l.stopped()
+		if err != nil {
+			return err
+		}
+
+		listPrm.WithCursor(c)
+
+		listRes, err := sh.ListWithCursor(listPrm)
+		if err != nil {
+			if errors.Is(err, meta.ErrEndOfListing) {
+				break
+			}
+			return fmt.Errorf("list objects of shard %s: %w", sh.ID(), err)
+		}
+
+		for _, a := range listRes.AddressList() {
+			if a.Type != objectSDK.TypeRegular {
+				// system objects affect metabase of the shard they are stored in
+				e.updateRebalanceStatus(func(s *RebalanceStatus) { s.processed++ })
+				continue
+			}
+
+			res, err := e.rebalanceObject(sh, a.Address, l)
+			if err != nil {
+				return err
+			}
+
+			e.updateRebalanceStatus(func(s *RebalanceStatus) {
+				s.processed++
+				switch res {
+				case rebalanceMoved:
+					s.moved++
+				case rebalanceFailed:
+					s.failed++
+				}
+			})
+		}
+
+		c = listRes.Cursor()
+
+		err = sh.SetRebalanceCursor(c)
+		if err != nil {
+			return fmt.Errorf("save rebalance progress of shard %s: %w", sh.ID(), err)
+		}
+	}
+
+	err = sh.ResetRebalanceCursor()
+	if err != nil {
+		return fmt.Errorf("reset rebalance progress of shard %s: %w", sh.ID(), err)
+	}
+
+	return nil
+}
+
+// rebalanceResult is a result of a single object relocation.
+type rebalanceResult int
+
+const (
+	rebalanceSkipped rebalanceResult = iota
+	rebalanceMoved
+	rebalanceFailed
+)
+
+// rebalanceObject moves the object to the first writable shard preceding the
+// current one in HRW order. Returned error aborts the rebalance.
+func (e *StorageEngine) rebalanceObject(from hashedShard, addr oid.Address, l rebalanceLimiter) (rebalanceResult, error) {
+	var (
+		obj    *objectSDK.Object
+		shards = e.sortShardsByWeight(addr)
+	)
+
+	for i, sh := range shards {
+		if sh.ID().String() == from.ID().String() {
+			if obj == nil {
+				return rebalanceSkipped, nil // already in place
+			}
+			break
+		}
+
+		if sh.GetMode().ReadOnly() {
+			continue
+		}
+
+		if obj == nil {
+			err := l.wait()
+			if err != nil {
+				return rebalanceSkipped, err
+			}
+
+			var getPrm shard.GetPrm
+			getPrm.SetAddress(addr)
+			getPrm.SetIOClass(throttle.Background)
+
+			res, err := from.Get(getPrm)
+			if err != nil {
+				if shard.IsErrNotFound(err) || shard.IsErrRemoved(err) {
+					return rebalanceSkipped, nil // removed concurrently
+				}
+
+				e.log.Warn("could not read object for rebalance",
+					zap.Stringer("shard_id", from.ID()),
+					zap.Stringer("addr", addr),
+					zap.Error(err))
+
+				return rebalanceFailed, nil
+			}
+
+			obj = res.Object()
+		}
+
+		e.mtx.RLock()
+		pool, ok := e.shardPools[sh.ID().String()]
+		e.mtx.RUnlock()
+		if !ok {
+			continue // removed concurrently
+		}
+
+		putDone, exists := e.putToShard(sh, i, pool, addr, PutPrm{obj: obj, ioClass: throttle.Background})
+		if !putDone && !exists {
+			continue
+		}
+
+		var delPrm shard.DeletePrm
+		delPrm.SetAddresses(addr)
+		delPrm.SetIOClass(throttle.Background)
+
+		_, err := from.Delete(delPrm)
+		if err != nil {
+			e.log.Warn("could not delete rebalanced object from the source shard",
+				zap.Stringer("shard_id", from.ID()),
+				zap.Stringer("addr", addr),
+				zap.Error(err))
+			return rebalanceFailed, nil
+		}
+
+		e.log.Debug("object is moved to HRW-preferred shard",
+			zap.Stringer("from", from.ID()),
+			zap.Stringer("to", sh.ID()),
+			zap.Stringer("addr", addr))
+
+		return rebalanceMoved, nil
+	}
+
+	return rebalanceFailed, nil
+}
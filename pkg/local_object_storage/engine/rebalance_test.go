@@ -0,0 +1,177 @@
+package engine
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	objectCore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/fstree"
+	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
+	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// newEngineRebalance creates an engine with a single shard storing objNum
+// objects and adds shardNum-1 empty shards to it.
+func newEngineRebalance(t *testing.T, shardNum int, objNum int) (*StorageEngine, []*objectSDK.Object) {
+	dir := t.TempDir()
+
+	e := New(
+		WithLogger(zaptest.NewLogger(t)),
+		WithShardPoolSize(1))
+	t.Cleanup(func() { _ = e.Close() })
+
+	addShard := func(i int) *shard.ID {
+		id, err := e.AddShard(
+			shard.WithLogger(zaptest.NewLogger(t)),
+			shard.WithBlobStorOptions(
+				blobstor.WithStorages([]blobstor.SubStorage{{
+					Storage: fstree.New(
+						fstree.WithPath(filepath.Join(dir, strconv.Itoa(i))),
+						fstree.WithDepth(1)),
+				}})),
+			shard.WithMetaBaseOptions(
+				meta.WithPath(filepath.Join(dir, strconv.Itoa(i)+".metabase")),
+				meta.WithPermissions(0700),
+				meta.WithEpochState(epochState{}),
+			))
+		require.NoError(t, err)
+		return id
+	}
+
+	addShard(0)
+	require.NoError(t, e.Open())
+	require.NoError(t, e.Init())
+
+	objects := make([]*objectSDK.Object, objNum)
+	for i := range objects {
+		objects[i] = generateObjectWithCID(t, cidtest.ID())
+		require.NoError(t, Put(e, objects[i]))
+	}
+
+	for i := 1; i < shardNum; i++ {
+		sh := e.getShard(addShard(i).String())
+		require.NoError(t, sh.Open())
+		require.NoError(t, sh.Init())
+	}
+
+	return e, objects
+}
+
+func waitRebalance(t *testing.T, e *StorageEngine) RebalanceStatus {
+	require.Eventually(t, func() bool {
+		return !e.RebalanceStatus().Running()
+	}, 10*time.Second, 10*time.Millisecond)
+
+	st := e.RebalanceStatus()
+	require.NoError(t, st.Error())
+	return st
+}
+
+func checkRebalanced(t *testing.T, e *StorageEngine, objects []*objectSDK.Object) {
+	for _, obj := range objects {
+		addr := objectCore.AddressOf(obj)
+
+		var existsPrm shard.ExistsPrm
+		existsPrm.SetAddress(addr)
+
+		for i, sh := range e.sortShardsByWeight(addr) {
+			res, err := sh.Exists(existsPrm)
+			require.NoError(t, err)
+			require.Equal(t, i == 0, res.Exists(), "object %s on shard #%d", addr, i)
+		}
+
+		var getPrm GetPrm
+		getPrm.WithAddress(addr)
+
+		_, err := e.Get(getPrm)
+		require.NoError(t, err)
+	}
+}
+
+func TestStorageEngine_Rebalance(t *testing.T) {
+	const objNum = 30
+
+	e, objects := newEngineRebalance(t, 3, objNum)
+
+	require.NoError(t, e.StartRebalance(RebalancePrm{}))
+
+	st := waitRebalance(t, e)
+	// objects moved to the shards processed later are checked again
+	require.GreaterOrEqual(t, st.Processed(), uint64(objNum))
+	require.NotZero(t, st.Moved())
+	require.Zero(t, st.Failed())
+	require.Equal(t, 3, st.ShardsTotal())
+	require.Equal(t, 3, st.ShardsDone())
+
+	checkRebalanced(t, e, objects)
+
+	for _, sh := range e.unsortedShards() {
+		_, ok, err := sh.RebalanceCursor()
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+
+	t.Run("nothing to move", func(t *testing.T) {
+		require.NoError(t, e.StartRebalance(RebalancePrm{}))
+
+		st := waitRebalance(t, e)
+		require.EqualValues(t, objNum, st.Processed())
+		require.Zero(t, st.Moved())
+	})
+}
+
+func TestStorageEngine_RebalanceResume(t *testing.T) {
+	const objNum = 10
+
+	e, objects := newEngineRebalance(t, 2, objNum)
+
+	// simulate the rebalance interrupted by restart
+	for _, sh := range e.unsortedShards() {
+		require.NoError(t, sh.SetRebalanceCursor(nil))
+	}
+
+	e.resumeRebalance()
+
+	waitRebalance(t, e)
+	checkRebalanced(t, e, objects)
+}
+
+func TestStorageEngine_StopRebalance(t *testing.T) {
+	e, _ := newEngineRebalance(t, 2, 10)
+
+	require.ErrorIs(t, e.StopRebalance(), errRebalanceNotRunning)
+
+	var prm RebalancePrm
+	prm.SetRate(maxRebalanceRate + 1)
+	require.ErrorIs(t, e.StartRebalance(prm), errRebalanceRate)
+
+	prm.SetRate(1)
+
+	require.NoError(t, e.StartRebalance(prm))
+	require.ErrorIs(t, e.StartRebalance(prm), errRebalanceInProgress)
+
+	// concurrent stop requests
+	errCh := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() { errCh <- e.StopRebalance() }()
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-errCh; err != nil {
+			require.ErrorIs(t, err, errRebalanceNotRunning)
+		}
+	}
+	require.False(t, e.RebalanceStatus().Running())
+
+	for _, sh := range e.unsortedShards() {
+		_, ok, err := sh.RebalanceCursor()
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+}
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"bytes"
 	"errors"
 
 	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
@@ -14,6 +15,9 @@ import (
 type SelectPrm struct {
 	cnr     cid.ID
 	filters object.SearchFilters
+
+	limit  uint32
+	cursor *oid.ID
 }
 
 // SelectRes groups the resulting values of Select operation.
@@ -31,12 +35,26 @@ func (p *SelectPrm) WithFilters(fs object.SearchFilters) {
 	p.filters = fs
 }
 
+// WithLimit is a Select option to limit the number of the selected objects.
+// Zero means no limit.
+func (p *SelectPrm) WithLimit(n uint32) {
+	p.limit = n
+}
+
+// WithCursor is a Select option to select only the objects with IDs greater
+// than the given one. The last ID of the previous limited Select is the
+// cursor of the next one.
+func (p *SelectPrm) WithCursor(id oid.ID) {
+	p.cursor = &id
+}
+
 // AddressList returns list of addresses of the selected objects.
 func (r SelectRes) AddressList() []oid.Address {
 	return r.addrList
 }
 
 // Select selects the objects from local storage that match select parameters.
+// Objects are ordered by their IDs.
 //
 // Returns any error encountered that did not allow to completely select the objects.
 //
@@ -56,13 +74,16 @@ func (e *StorageEngine) _select(prm SelectPrm) (SelectRes, error) {
 	}
 
 	addrList := make([]oid.Address, 0)
-	uniqueMap := make(map[string]struct{})
 
 	var outError error
 
 	var shPrm shard.SelectPrm
 	shPrm.SetContainerID(prm.cnr)
 	shPrm.SetFilters(prm.filters)
+	shPrm.SetLimit(prm.limit)
+	if prm.cursor != nil {
+		shPrm.SetCursor(*prm.cursor)
+	}
 
 	e.iterateOverUnsortedShards(func(sh hashedShard) (stop bool) {
 		res, err := sh.Select(shPrm)
@@ -75,12 +96,7 @@ func (e *StorageEngine) _select(prm SelectPrm) (SelectRes, error) {
 			return false
 		}
 
-		for _, addr := range res.AddressList() { // save only unique values
-			if _, ok := uniqueMap[addr.EncodeToString()]; !ok {
-				uniqueMap[addr.EncodeToString()] = struct{}{}
-				addrList = append(addrList, addr)
-			}
-		}
+		addrList = mergeSortedAddresses(addrList, res.AddressList(), prm.limit)
 
 		return false
 	})
@@ -90,6 +106,44 @@ func (e *StorageEngine) _select(prm SelectPrm) (SelectRes, error) {
 	}, outError
 }
 
+// mergeSortedAddresses merges two lists of addresses ordered by object IDs
+// into a single ordered one without duplicates and cuts it to the limit if
+// it is not zero.
+func mergeSortedAddresses(a, b []oid.Address, limit uint32) []oid.Address {
+	resCap := len(a) + len(b)
+	if limit > 0 && int(limit) < resCap {
+		resCap = int(limit)
+	}
+
+	res := make([]oid.Address, 0, resCap)
+
+	for (len(a) > 0 || len(b) > 0) && (limit == 0 || len(res) < int(limit)) {
+		var next oid.Address
+
+		switch {
+		case len(b) == 0:
+			next, a = a[0], a[1:]
+		case len(a) == 0:
+			next, b = b[0], b[1:]
+		default:
+			idA, idB := a[0].Object(), b[0].Object()
+
+			switch cmp := bytes.Compare(idA[:], idB[:]); {
+			case cmp < 0:
+				next, a = a[0], a[1:]
+			case cmp > 0:
+				next, b = b[0], b[1:]
+			default:
+				next, a, b = a[0], a[1:], b[1:]
+			}
+		}
+
+		res = append(res, next)
+	}
+
+	return res
+}
+
 // List returns `limit` available physically storage object addresses in engine.
 // If limit is zero, then returns all available object addresses.
 //
@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"bytes"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
+	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectPagination(t *testing.T) {
+	s1 := testNewShard(t, 1)
+	s2 := testNewShard(t, 2)
+	e := testNewEngineWithShards(s1, s2)
+
+	t.Cleanup(func() {
+		e.Close()
+		os.RemoveAll(t.Name())
+	})
+
+	const total = 20
+
+	cnr := cidtest.ID()
+	expected := make([]oid.Address, 0, total)
+
+	for i := 0; i < total; i++ {
+		obj := generateObjectWithCID(t, cnr)
+
+		var prm shard.PutPrm
+		prm.SetObject(obj)
+
+		// objects are spread between the shards, some are stored in both
+		shards := []*shard.Shard{s1, s2}[i%2 : 1+i%2]
+		if i%5 == 0 {
+			shards = []*shard.Shard{s1, s2}
+		}
+
+		for _, sh := range shards {
+			_, err := sh.Put(prm)
+			require.NoError(t, err)
+		}
+
+		expected = append(expected, object.AddressOf(obj))
+	}
+
+	sort.Slice(expected, func(i, j int) bool {
+		idI, idJ := expected[i].Object(), expected[j].Object()
+		return bytes.Compare(idI[:], idJ[:]) < 0
+	})
+
+	var prm SelectPrm
+	prm.WithContainerID(cnr)
+
+	res, err := e.Select(prm)
+	require.NoError(t, err)
+	require.Equal(t, expected, res.AddressList())
+
+	const limit = 3
+	prm.WithLimit(limit)
+
+	var got []oid.Address
+	for {
+		res, err := e.Select(prm)
+		require.NoError(t, err)
+
+		page := res.AddressList()
+		require.LessOrEqual(t, len(page), limit)
+
+		got = append(got, page...)
+		if len(page) < limit {
+			break
+		}
+
+		prm.WithCursor(page[len(page)-1].Object())
+	}
+
+	require.Equal(t, expected, got)
+}
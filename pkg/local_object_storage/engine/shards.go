@@ -1,8 +1,10 @@
 package engine
 
 import (
+	"errors"
 	"fmt"
 	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/epicchainlabs/hrw/v2"
@@ -57,6 +59,18 @@ func (m *metricsWithID) AddToPayloadSize(size int64) {
 	m.mw.AddToPayloadCounter(m.id, size)
 }
 
+func (m *metricsWithID) AddThrottledTime(class string, d time.Duration) {
+	m.mw.AddThrottledTime(m.id, class, d)
+}
+
+func (m *metricsWithID) SetScrubbedObjects(v uint64) {
+	m.mw.SetScrubbedObjects(m.id, v)
+}
+
+func (m *metricsWithID) SetCorruptedObjects(v uint64) {
+	m.mw.SetCorruptedObjects(m.id, v)
+}
+
 // AddShard adds a new shard to the storage engine.
 //
 // Returns any error encountered that did not allow adding a shard.
@@ -144,9 +158,18 @@ func (e *StorageEngine) removeShards(ids ...string) {
 		return
 	}
 
+	e.mtx.Lock()
+	ss := e.unregisterShards(ids)
+	e.mtx.Unlock()
+
+	e.closeRemovedShards(ss)
+}
+
+// unregisterShards removes the shards with the given IDs from the storage
+// engine and returns them. Must be called with e.mtx locked.
+func (e *StorageEngine) unregisterShards(ids []string) []shardWrapper {
 	ss := make([]shardWrapper, 0, len(ids))
 
-	e.mtx.Lock()
 	for _, id := range ids {
 		sh, found := e.shards[id]
 		if !found {
@@ -165,8 +188,11 @@ func (e *StorageEngine) removeShards(ids ...string) {
 		e.log.Info("shard has been removed",
 			zap.String("id", id))
 	}
-	e.mtx.Unlock()
 
+	return ss
+}
+
+func (e *StorageEngine) closeRemovedShards(ss []shardWrapper) {
 	for _, sh := range ss {
 		err := sh.Close()
 		if err != nil {
@@ -178,6 +204,64 @@ func (e *StorageEngine) removeShards(ids ...string) {
 	}
 }
 
+// DetachShards closes the shards with the given IDs and removes them from
+// the storage engine. Objects stored in the detached shards become
+// unavailable, so they should be evacuated beforehand if needed.
+//
+// Returns an error if any of the shards is not found or if no shards would
+// remain in the engine. No shards are detached in this case.
+func (e *StorageEngine) DetachShards(ids []*shard.ID) error {
+	if len(ids) == 0 {
+		return errors.New("no shards to detach")
+	}
+
+	strIDs := make([]string, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+
+	e.mtx.Lock()
+
+	for _, id := range ids {
+		strID := id.String()
+		if _, ok := e.shards[strID]; !ok {
+			e.mtx.Unlock()
+			return fmt.Errorf("%w: %s", errShardNotFound, strID)
+		}
+
+		if _, ok := seen[strID]; !ok {
+			seen[strID] = struct{}{}
+			strIDs = append(strIDs, strID)
+		}
+	}
+
+	if len(strIDs) == len(e.shards) {
+		e.mtx.Unlock()
+		return errors.New("could not detach all shards")
+	}
+
+	ss := e.unregisterShards(strIDs)
+
+	e.mtx.Unlock()
+
+	e.closeRemovedShards(ss)
+
+	return nil
+}
+
+// AttachShard creates the shard with the given options, opens and
+// initializes it and adds it to the storage engine.
+//
+// Returns the ID of the attached shard.
+func (e *StorageEngine) AttachShard(opts ...shard.Option) (*shard.ID, error) {
+	id, err := e.openShard(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	e.log.Info("shard has been attached", zap.Stringer("id", id))
+
+	return id, nil
+}
+
 func generateShardID() (*shard.ID, error) {
 	uid, err := uuid.NewRandom()
 	if err != nil {
@@ -4,6 +4,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
+	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
 	"github.com/stretchr/testify/require"
 )
 
@@ -44,3 +47,40 @@ func TestRemoveShard(t *testing.T) {
 		require.True(t, ok != removed)
 	}
 }
+
+func TestDetachAttachShard(t *testing.T) {
+	const shardNum = 3
+	path := t.TempDir()
+
+	e, _ := engineWithShards(t, path, shardNum)
+	t.Cleanup(func() { _ = e.Close() })
+
+	infos := e.DumpInfo().Shards
+	detached := infos[0]
+
+	require.ErrorIs(t, e.DetachShards([]*shard.ID{shard.NewIDFromBytes([]byte("unknown"))}), errShardNotFound)
+	require.Error(t, e.DetachShards(nil))
+	require.Error(t, e.DetachShards([]*shard.ID{infos[0].ID, infos[1].ID, infos[2].ID}))
+	require.Len(t, e.shards, shardNum)
+
+	require.NoError(t, e.DetachShards([]*shard.ID{detached.ID, detached.ID}))
+	require.Len(t, e.shards, shardNum-1)
+	require.Len(t, e.shardPools, shardNum-1)
+	require.NotContains(t, e.shards, detached.ID.String())
+
+	id, err := e.AttachShard(
+		shard.WithBlobStorOptions(
+			blobstor.WithStorages(newStorages(detached.BlobStorInfo.SubStorages[1].Path, errSmallSize))),
+		shard.WithMetaBaseOptions(
+			meta.WithPath(detached.MetaBaseInfo.Path),
+			meta.WithPermissions(0700),
+			meta.WithEpochState(epochState{}),
+		),
+	)
+	require.NoError(t, err)
+
+	// shard ID is persistent
+	require.Equal(t, detached.ID, id)
+	require.Len(t, e.shards, shardNum)
+	require.Len(t, e.shardPools, shardNum)
+}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"errors"
+	"io"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/pilorama"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
@@ -213,6 +214,68 @@ func (e *StorageEngine) TreeExists(cid cidSDK.ID, treeID string) (bool, error) {
 	return err == nil, err
 }
 
+// TreeExport writes a snapshot of the tree to w. See pilorama.ForestStorage
+// for details.
+func (e *StorageEngine) TreeExport(cid cidSDK.ID, treeID string, w io.Writer) (uint64, error) {
+	index, lst, err := e.getTreeShard(cid, treeID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Errors are not reported, because they are mostly caused by w.
+	return lst[index].TreeExport(cid, treeID, w)
+}
+
+// TreeImport replaces the tree with the snapshot read from r. See
+// pilorama.ForestStorage for details.
+func (e *StorageEngine) TreeImport(cid cidSDK.ID, treeID string, r io.Reader) (uint64, error) {
+	index, lst, err := e.getTreeShard(cid, treeID)
+	if err != nil && !errors.Is(err, pilorama.ErrTreeNotFound) {
+		return 0, err
+	}
+
+	height, err := lst[index].TreeImport(cid, treeID, r)
+	if err != nil {
+		if !errors.Is(err, shard.ErrReadOnlyMode) && err != shard.ErrPiloramaDisabled &&
+			!errors.Is(err, pilorama.ErrInvalidSnapshot) {
+			e.reportShardError(lst[index], "can't perform `TreeImport`", err,
+				zap.Stringer("cid", cid),
+				zap.String("tree", treeID))
+		}
+		return 0, err
+	}
+	return height, nil
+}
+
+// TreeCompact removes operations below the height from the tree log. See
+// pilorama.ForestStorage for details.
+func (e *StorageEngine) TreeCompact(cid cidSDK.ID, treeID string, height uint64) (uint64, error) {
+	index, lst, err := e.getTreeShard(cid, treeID)
+	if err != nil {
+		return 0, err
+	}
+
+	removed, err := lst[index].TreeCompact(cid, treeID, height)
+	if err != nil {
+		if !errors.Is(err, shard.ErrReadOnlyMode) && err != shard.ErrPiloramaDisabled {
+			e.reportShardError(lst[index], "can't perform `TreeCompact`", err,
+				zap.Stringer("cid", cid),
+				zap.String("tree", treeID))
+		}
+		return removed, err
+	}
+	return removed, nil
+}
+
+// TreeLogSize returns the number of operations stored in the tree log.
+func (e *StorageEngine) TreeLogSize(cid cidSDK.ID, treeID string) (uint64, error) {
+	index, lst, err := e.getTreeShard(cid, treeID)
+	if err != nil {
+		return 0, err
+	}
+	return lst[index].TreeLogSize(cid, treeID)
+}
+
 func (e *StorageEngine) getTreeShard(cid cidSDK.ID, treeID string) (int, []hashedShard, error) {
 	lst := e.sortShardsByWeight(cid)
 	for i, sh := range lst {
@@ -0,0 +1,373 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	"go.etcd.io/bbolt"
+)
+
+// Sections of the sorted attribute index. Keys of the index are a section
+// byte followed by the encoded attribute value and the object ID. Numeric
+// values are indexed in both numeric and string sections.
+const (
+	// negative integers: inverted big-endian uint32 magnitude length,
+	// inverted big-endian magnitude
+	sortedIndexNegative = iota
+	// non-negative integers: big-endian uint32 magnitude length, big-endian
+	// magnitude
+	sortedIndexNonNegative
+	// all values as is
+	sortedIndexString
+)
+
+var indexedAttributesKey = []byte("indexed_attributes")
+
+// WithIndexedAttributes returns option to build sorted indexes of the given
+// object attributes. The indexes are used for numeric and prefix SEARCH
+// filters instead of the full scan of the attribute values.
+//
+// Indexes of new attributes are built from the existing objects on Init,
+// indexes of the attributes missing in the list are dropped.
+func WithIndexedAttributes(keys ...string) Option {
+	return func(c *cfg) {
+		c.indexedAttributes = make(map[string]struct{}, len(keys))
+		for i := range keys {
+			c.indexedAttributes[keys[i]] = struct{}{}
+		}
+	}
+}
+
+// IndexedAttributes returns the list of object attributes with sorted indexes.
+func (db *DB) IndexedAttributes() []string {
+	db.modeMtx.RLock()
+	defer db.modeMtx.RUnlock()
+
+	res := make([]string, 0, len(db.sortedIndexes))
+	for k := range db.sortedIndexes {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// isIndexedAttribute checks whether the attribute has sorted index.
+func (db *DB) isIndexedAttribute(key string) bool {
+	_, ok := db.sortedIndexes[key]
+	return ok
+}
+
+// readSortedIndexes returns the set of attributes with sorted indexes stored
+// in the database. It may differ from the configured ones until Init.
+func readSortedIndexes(tx *bbolt.Tx) (map[string]struct{}, error) {
+	var data []byte
+	if b := tx.Bucket(shardInfoBucket); b != nil {
+		data = b.Get(indexedAttributesKey)
+	}
+
+	lst, err := decodeList(data)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode indexed attributes: %w", err)
+	}
+
+	res := make(map[string]struct{}, len(lst))
+	for i := range lst {
+		res[string(lst[i])] = struct{}{}
+	}
+
+	return res, nil
+}
+
+// sortedIndexBucketName returns <CID>_sorted_<attributeKey>.
+func sortedIndexBucketName(cnr cid.ID, attributeKey string, key []byte) []byte {
+	key[0] = sortedAttributePrefix
+	cnr.Encode(key[1:])
+	return append(key[:bucketKeySize], attributeKey...)
+}
+
+// encodeNumericIndexValue encodes n so that the byte order of the results
+// matches the numeric order.
+func encodeNumericIndexValue(n *big.Int) []byte {
+	mag := n.Bytes()
+
+	res := make([]byte, 5+len(mag))
+	binary.BigEndian.PutUint32(res[1:], uint32(len(mag)))
+	copy(res[5:], mag)
+
+	if n.Sign() >= 0 {
+		res[0] = sortedIndexNonNegative
+		return res
+	}
+
+	res[0] = sortedIndexNegative
+	for i := 1; i < len(res); i++ {
+		res[i] = ^res[i]
+	}
+
+	return res
+}
+
+// sortedIndexKeys returns keys of the object with the given attribute value
+// in the sorted attribute index.
+func sortedIndexKeys(val string, objKey []byte) [][]byte {
+	res := make([][]byte, 0, 2)
+
+	strKey := make([]byte, 0, 1+len(val)+len(objKey))
+	strKey = append(strKey, sortedIndexString)
+	strKey = append(strKey, val...)
+	res = append(res, append(strKey, objKey...))
+
+	if n, ok := new(big.Int).SetString(val, 10); ok {
+		res = append(res, append(encodeNumericIndexValue(n), objKey...))
+	}
+
+	return res
+}
+
+// updateSortedIndexes puts or removes the object attributes to/from the
+// sorted indexes of the given attributes.
+func updateSortedIndexes(tx *bbolt.Tx, obj *objectSDK.Object, indexed map[string]struct{}, put bool) error {
+	if len(indexed) == 0 {
+		return nil
+	}
+
+	id, _ := obj.ID()
+	cnr, _ := obj.ContainerID()
+	objKey := objectKey(id, make([]byte, objectKeySize))
+
+	attrs := obj.Attributes()
+	name := make([]byte, bucketKeySize)
+
+	for i := range attrs {
+		if _, ok := indexed[attrs[i].Key()]; !ok {
+			continue
+		}
+
+		name = sortedIndexBucketName(cnr, attrs[i].Key(), name)
+		err := updateSortedIndex(tx, name, sortedIndexKeys(attrs[i].Value(), objKey), put)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func updateSortedIndex(tx *bbolt.Tx, name []byte, keys [][]byte, put bool) error {
+	if !put {
+		bkt := tx.Bucket(name)
+		if bkt == nil {
+			return nil
+		}
+
+		for i := range keys {
+			_ = bkt.Delete(keys[i]) // ignore error, best effort there
+		}
+
+		return nil
+	}
+
+	bkt, err := tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return fmt.Errorf("can't create sorted index %v: %w", name, err)
+	}
+
+	for i := range keys {
+		err = bkt.Put(keys[i], zeroValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// selectFromSortedIndex looks into the sorted attribute index to find the
+// objects matching numeric and prefix filters. Returns false if the filter
+// can't be processed by the index.
+func selectFromSortedIndex(
+	tx *bbolt.Tx,
+	name []byte, // sorted index bucket name
+	f objectSDK.SearchFilter, // filter for operation and value
+	to map[string]int, // resulting cache
+	fNum int, // index of filter
+) bool {
+	var (
+		seek []byte
+		// stop checks whether the iteration is over, skip checks whether
+		// the value does not match the filter within the iterated range
+		stop, skip func(val []byte) bool
+	)
+
+	isNumeric := func(val []byte) bool { return val[0] < sortedIndexString }
+
+	switch op := f.Operation(); op {
+	default:
+		return false
+	case objectSDK.MatchCommonPrefix:
+		seek = append([]byte{sortedIndexString}, f.Value()...)
+		stop = func(val []byte) bool { return !bytes.HasPrefix(val, seek) }
+	case objectSDK.MatchNumGT, objectSDK.MatchNumGE, objectSDK.MatchNumLT, objectSDK.MatchNumLE:
+		n, ok := new(big.Int).SetString(f.Value(), 10)
+		if !ok {
+			return false
+		}
+
+		bound := encodeNumericIndexValue(n)
+
+		switch op {
+		case objectSDK.MatchNumGT:
+			seek = bound
+			stop = func(val []byte) bool { return !isNumeric(val) }
+			skip = func(val []byte) bool { return bytes.Equal(val, bound) }
+		case objectSDK.MatchNumGE:
+			seek = bound
+			stop = func(val []byte) bool { return !isNumeric(val) }
+		case objectSDK.MatchNumLT:
+			seek = []byte{sortedIndexNegative}
+			stop = func(val []byte) bool { return !isNumeric(val) || bytes.Compare(val, bound) >= 0 }
+		case objectSDK.MatchNumLE:
+			seek = []byte{sortedIndexNegative}
+			stop = func(val []byte) bool { return !isNumeric(val) || bytes.Compare(val, bound) > 0 }
+		}
+	}
+
+	bkt := tx.Bucket(name)
+	if bkt == nil {
+		return true
+	}
+
+	c := bkt.Cursor()
+	for k, _ := c.Seek(seek); k != nil; k, _ = c.Next() {
+		if len(k) <= objectKeySize {
+			continue
+		}
+
+		val := k[:len(k)-objectKeySize]
+		if stop(val) {
+			break
+		}
+
+		if skip != nil && skip(val) {
+			continue
+		}
+
+		markAddressInCache(to, fNum, string(k[len(k)-objectKeySize:]))
+	}
+
+	return true
+}
+
+// syncSortedIndexes builds indexes of the attributes that have been added to
+// the indexed ones since the last run and drops indexes of the removed ones.
+func syncSortedIndexes(tx *bbolt.Tx, indexed map[string]struct{}) error {
+	info, err := tx.CreateBucketIfNotExists(shardInfoBucket)
+	if err != nil {
+		return fmt.Errorf("can't create auxiliary bucket: %w", err)
+	}
+
+	stored, err := readSortedIndexes(tx)
+	if err != nil {
+		return err
+	}
+
+	added := make(map[string]struct{})
+	for k := range indexed {
+		if _, ok := stored[k]; !ok {
+			added[k] = struct{}{}
+		}
+	}
+	removed := make(map[string]struct{})
+	for k := range stored {
+		if _, ok := indexed[k]; !ok {
+			removed[k] = struct{}{}
+		}
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	// bucket names can't be changed during the iteration
+	var toDrop, toBuild [][]byte
+
+	err = tx.ForEach(func(name []byte, _ *bbolt.Bucket) error {
+		if len(name) <= bucketKeySize {
+			return nil
+		}
+
+		attr := string(name[bucketKeySize:])
+
+		switch name[0] {
+		case sortedAttributePrefix:
+			if _, ok := removed[attr]; ok {
+				toDrop = append(toDrop, bytes.Clone(name))
+			}
+		case userAttributePrefix:
+			if _, ok := added[attr]; ok {
+				toBuild = append(toBuild, bytes.Clone(name))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range toDrop {
+		err = tx.DeleteBucket(toDrop[i])
+		if err != nil {
+			return fmt.Errorf("can't drop sorted index %v: %w", toDrop[i], err)
+		}
+	}
+
+	for i := range toBuild {
+		err = buildSortedIndex(tx, toBuild[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	list := make([][]byte, 0, len(indexed))
+	for k := range indexed {
+		list = append(list, []byte(k))
+	}
+
+	data, err := encodeList(list)
+	if err != nil {
+		return fmt.Errorf("can't encode indexed attributes: %w", err)
+	}
+
+	return info.Put(indexedAttributesKey, data)
+}
+
+// buildSortedIndex fills the sorted index from the FKBT index of the same
+// attribute.
+func buildSortedIndex(tx *bbolt.Tx, fkbtName []byte) error {
+	fkbtRoot := tx.Bucket(fkbtName)
+
+	name := bytes.Clone(fkbtName)
+	name[0] = sortedAttributePrefix
+
+	bkt, err := tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return fmt.Errorf("can't create sorted index %v: %w", name, err)
+	}
+
+	return fkbtRoot.ForEachBucket(func(val []byte) error {
+		return fkbtRoot.Bucket(val).ForEach(func(objKey, _ []byte) error {
+			for _, k := range sortedIndexKeys(string(val), objKey) {
+				if err := bkt.Put(k, zeroValue); err != nil {
+					return fmt.Errorf("can't put sorted index item: %w", err)
+				}
+			}
+			return nil
+		})
+	})
+}
@@ -0,0 +1,232 @@
+package meta_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+const indexedAttr = "indexed"
+
+var sortedIndexValues = []string{
+	"-300", "-256", "-255", "-1", "0", "1", "9", "10", "11", "255", "256", "300",
+	"007", "+5", strings.Repeat("9", 700), "-" + strings.Repeat("9", 700),
+	"abc", "ab", "10abc", "1.5",
+}
+
+func TestSortedIndexSelect(t *testing.T) {
+	indexed := newDB(t, meta.WithIndexedAttributes(indexedAttr))
+	plain := newDB(t, meta.WithPath(filepath.Join(t.TempDir(), "plain")))
+
+	require.Equal(t, []string{indexedAttr}, indexed.IndexedAttributes())
+	require.Empty(t, plain.IndexedAttributes())
+
+	cnr := cidtest.ID()
+
+	var toDelete []oid.Address
+	for i, val := range sortedIndexValues {
+		obj := generateObjectWithCID(t, cnr)
+		addAttribute(obj, indexedAttr, val)
+		addAttribute(obj, "other", strconv.Itoa(i%2))
+
+		require.NoError(t, putBig(indexed, obj))
+		require.NoError(t, putBig(plain, obj))
+
+		if val == "256" {
+			toDelete = append(toDelete, object.AddressOf(obj))
+		}
+	}
+
+	// object without the attribute
+	obj := generateObjectWithCID(t, cnr)
+	require.NoError(t, putBig(indexed, obj))
+	require.NoError(t, putBig(plain, obj))
+
+	require.NoError(t, metaDelete(indexed, toDelete...))
+	require.NoError(t, metaDelete(plain, toDelete...))
+
+	compare := func(t *testing.T, fs objectSDK.SearchFilters) {
+		exp, err := metaSelect(plain, cnr, fs)
+		require.NoError(t, err)
+
+		res, err := metaSelect(indexed, cnr, fs)
+		require.NoError(t, err)
+		require.ElementsMatch(t, exp, res)
+	}
+
+	for _, op := range allNumOps {
+		for _, bound := range []string{"-1000", "-300", "-256", "-10", "-1", "0", "1", "10", "255", "256", "257", "1000"} {
+			t.Run(fmt.Sprintf("%s %s", op, bound), func(t *testing.T) {
+				compare(t, numQuery(indexedAttr, op, bound))
+
+				fs := numQuery(indexedAttr, op, bound)
+				fs.AddFilter("other", "1", objectSDK.MatchStringEqual)
+				compare(t, fs)
+			})
+		}
+	}
+
+	for _, prefix := range []string{"", "1", "10", "-", "-2", "ab", "abc", "x"} {
+		t.Run("prefix "+prefix, func(t *testing.T) {
+			var fs objectSDK.SearchFilters
+			fs.AddFilter(indexedAttr, prefix, objectSDK.MatchCommonPrefix)
+			compare(t, fs)
+		})
+	}
+
+	t.Run("empty result", func(t *testing.T) {
+		fs := numQuery(indexedAttr, objectSDK.MatchNumGT, "1000")
+		fs.AddFilter("other", "1", objectSDK.MatchStringEqual)
+
+		res, err := metaSelect(indexed, cnr, fs)
+		require.NoError(t, err)
+		require.Empty(t, res)
+	})
+}
+
+func TestSortedIndexSync(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meta")
+	cnr := cidtest.ID()
+
+	open := func(t *testing.T, attrs ...string) *meta.DB {
+		db := meta.New(
+			meta.WithPath(path),
+			meta.WithPermissions(0o600),
+			meta.WithEpochState(epochState{}),
+			meta.WithIndexedAttributes(attrs...),
+		)
+		require.NoError(t, db.Open(false))
+		require.NoError(t, db.Init())
+		return db
+	}
+
+	db := open(t)
+
+	var exp []oid.Address
+	for i := 0; i < 10; i++ {
+		obj := generateObjectWithCID(t, cnr)
+		addAttribute(obj, indexedAttr, strconv.Itoa(i))
+		require.NoError(t, putBig(db, obj))
+
+		if i >= 5 {
+			exp = append(exp, object.AddressOf(obj))
+		}
+	}
+	require.NoError(t, db.Close())
+
+	check := func(t *testing.T, db *meta.DB) {
+		res, err := metaSelect(db, cnr, numQuery(indexedAttr, objectSDK.MatchNumGE, "5"))
+		require.NoError(t, err)
+		require.ElementsMatch(t, exp, res)
+	}
+
+	t.Run("add", func(t *testing.T) {
+		db := open(t, indexedAttr)
+		require.Equal(t, []string{indexedAttr}, db.IndexedAttributes())
+		check(t, db)
+		require.NoError(t, db.Close())
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		// indexes are not synchronized in read-only mode
+		db := meta.New(
+			meta.WithPath(path),
+			meta.WithPermissions(0o600),
+			meta.WithEpochState(epochState{}),
+		)
+		require.NoError(t, db.Open(true))
+		require.Equal(t, []string{indexedAttr}, db.IndexedAttributes())
+		check(t, db)
+		require.NoError(t, db.Close())
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		db := open(t)
+		require.Empty(t, db.IndexedAttributes())
+		check(t, db)
+		require.NoError(t, db.Close())
+	})
+}
+
+func BenchmarkSelectSortedIndex(b *testing.B) {
+	for _, objCount := range []int{10_000, 100_000, 1_000_000} {
+		if testing.Short() && objCount > 10_000 {
+			continue
+		}
+
+		cnr := cidtest.ID()
+		dir := b.TempDir()
+		plain := newBenchDB(b, filepath.Join(dir, "plain"))
+		indexed := newBenchDB(b, filepath.Join(dir, "indexed"), meta.WithIndexedAttributes(indexedAttr))
+
+		populateSortedIndexBench(b, cnr, objCount, plain, indexed)
+
+		for _, db := range []struct {
+			name string
+			db   *meta.DB
+		}{{"plain", plain}, {"indexed", indexed}} {
+			b.Run(fmt.Sprintf("%d objects/%s/num range", objCount, db.name), func(b *testing.B) {
+				fs := numQuery(indexedAttr, objectSDK.MatchNumGE, strconv.Itoa(objCount-100))
+				benchmarkSelect(b, db.db, cnr, fs, 100)
+			})
+			b.Run(fmt.Sprintf("%d objects/%s/common prefix", objCount, db.name), func(b *testing.B) {
+				prefix := strconv.Itoa(objCount / 100)
+				var fs objectSDK.SearchFilters
+				fs.AddFilter(indexedAttr, prefix, objectSDK.MatchCommonPrefix)
+				benchmarkSelect(b, db.db, cnr, fs, 1+10)
+			})
+		}
+	}
+}
+
+func newBenchDB(b *testing.B, path string, opts ...meta.Option) *meta.DB {
+	db := meta.New(append([]meta.Option{
+		meta.WithPath(path),
+		meta.WithPermissions(0o600),
+		meta.WithEpochState(epochState{}),
+		meta.WithBoltDBOptions(&bbolt.Options{NoSync: true}),
+		meta.WithMaxBatchSize(1000),
+	}, opts...)...)
+
+	require.NoError(b, db.Open(false))
+	require.NoError(b, db.Init())
+
+	b.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+// populateSortedIndexBench puts objects with attribute values from 0 to
+// objCount-1 into the given databases.
+func populateSortedIndexBench(b *testing.B, cnr cid.ID, objCount int, dbs ...*meta.DB) {
+	const batch = 100
+
+	errs := make(chan error, batch)
+	for i := 0; i < objCount; i += batch {
+		n := 0
+		for j := i; j < i+batch && j < objCount; j++ {
+			obj := generateObjectWithCID(b, cnr)
+			addAttribute(obj, indexedAttr, strconv.Itoa(j))
+
+			for _, db := range dbs {
+				n++
+				go func(db *meta.DB) { errs <- putBig(db, obj) }(db)
+			}
+		}
+
+		for ; n > 0; n-- {
+			require.NoError(b, <-errs)
+		}
+	}
+}
@@ -0,0 +1,463 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"go.etcd.io/bbolt"
+)
+
+// ChangeType is a type of the logged metabase change.
+type ChangeType byte
+
+const (
+	_ ChangeType = iota
+	// ChangeAdded is logged when a new object is put.
+	ChangeAdded
+	// ChangeInhumed is logged when an object is covered with a tombstone.
+	ChangeInhumed
+	// ChangeGarbage is logged when an object is marked with GC mark.
+	ChangeGarbage
+	// ChangeContainerInhumed is logged when a whole container is marked
+	// as removed.
+	ChangeContainerInhumed
+)
+
+// Change describes a single change of the metabase logged for incremental
+// shard dumps.
+type Change struct {
+	seq  uint64
+	typ  ChangeType
+	addr oid.Address
+	tomb oid.Address
+	cnr  cid.ID
+}
+
+// Seq returns sequence number of the change.
+func (c Change) Seq() uint64 {
+	return c.seq
+}
+
+// Type returns type of the change.
+func (c Change) Type() ChangeType {
+	return c.typ
+}
+
+// Address returns address of the changed object. Not set for
+// ChangeContainerInhumed.
+func (c Change) Address() oid.Address {
+	return c.addr
+}
+
+// Tombstone returns address of the tombstone covering the object. Set for
+// ChangeInhumed only.
+func (c Change) Tombstone() oid.Address {
+	return c.tomb
+}
+
+// Container returns ID of the removed container. Set for
+// ChangeContainerInhumed only.
+func (c Change) Container() cid.ID {
+	return c.cnr
+}
+
+var (
+	// ErrInvalidChangeLogMarker is returned when the change log marker does
+	// not belong to the metabase.
+	ErrInvalidChangeLogMarker = logicerr.New("change log marker is ahead of the metabase changes")
+
+	// ErrChangeLogTruncated is returned when the changes made after the
+	// change log marker have been truncated or have not been logged.
+	ErrChangeLogTruncated = logicerr.New("change log marker is behind the logged metabase changes")
+
+	// ErrChangeLogDisabled is returned when the change log is requested
+	// while it is disabled.
+	ErrChangeLogDisabled = logicerr.New("metabase change log is disabled")
+)
+
+// changeLogStartKey is a shard info key of the marker the change log starts
+// after. Changes made before it are truncated or haven't been logged.
+var changeLogStartKey = []byte("change_log_start")
+
+// changeLogDisabled is a change log start value meaning the log is disabled.
+const changeLogDisabled = math.MaxUint64
+
+// WithChangeLog returns an option to enable logging of the metabase changes
+// required for incremental shard dumps. Disabled by default.
+//
+// If the log is disabled on Init, logged changes are dropped and all the
+// markers given before become invalid.
+func WithChangeLog(enabled bool) Option {
+	return func(c *cfg) {
+		c.changeLog = enabled
+	}
+}
+
+// ChangeLogMarker returns sequence number of the last logged change. Changes
+// made after the marker can be listed with ListChanges.
+//
+// Returns ErrChangeLogDisabled if the change log is disabled.
+func (db *DB) ChangeLogMarker() (uint64, error) {
+	db.modeMtx.RLock()
+	defer db.modeMtx.RUnlock()
+
+	if db.mode.NoMetabase() {
+		return 0, ErrDegradedMode
+	}
+
+	var marker uint64
+	err := db.boltDB.View(func(tx *bbolt.Tx) error {
+		if changeLogStart(tx) == changeLogDisabled {
+			return ErrChangeLogDisabled
+		}
+
+		b := tx.Bucket(changeLogBucketName)
+		if b != nil {
+			marker = b.Sequence()
+		}
+		return nil
+	})
+
+	return marker, err
+}
+
+// TruncateChangeLog drops the changes logged up to the marker inclusive, they
+// are not needed after the dump made with the marker is taken. Changes since
+// the older markers can't be listed after that.
+//
+// Does nothing if the change log is disabled.
+func (db *DB) TruncateChangeLog(marker uint64) error {
+	db.modeMtx.RLock()
+	defer db.modeMtx.RUnlock()
+
+	if db.mode.NoMetabase() {
+		return ErrDegradedMode
+	} else if db.mode.ReadOnly() {
+		return ErrReadOnlyMode
+	}
+
+	return db.boltDB.Update(func(tx *bbolt.Tx) error {
+		start := changeLogStart(tx)
+		if start == changeLogDisabled || marker <= start {
+			return nil
+		}
+
+		b := tx.Bucket(changeLogBucketName)
+		if b == nil || marker > b.Sequence() {
+			return ErrInvalidChangeLogMarker
+		}
+
+		err := truncateChangeLog(b, marker)
+		if err != nil {
+			return err
+		}
+
+		return setChangeLogStart(tx, marker)
+	})
+}
+
+// ListChanges returns up to count changes logged after the since marker in
+// the order they were made. Empty result means there are no more changes.
+//
+// Returns ErrInvalidChangeLogMarker if since is greater than the current
+// ChangeLogMarker, ErrChangeLogTruncated if the changes made after since
+// have been truncated and ErrChangeLogDisabled if the change log is disabled.
+func (db *DB) ListChanges(since uint64, count int) ([]Change, error) {
+	db.modeMtx.RLock()
+	defer db.modeMtx.RUnlock()
+
+	if db.mode.NoMetabase() {
+		return nil, ErrDegradedMode
+	}
+
+	var res []Change
+	err := db.boltDB.View(func(tx *bbolt.Tx) error {
+		start := changeLogStart(tx)
+		if start == changeLogDisabled {
+			return ErrChangeLogDisabled
+		}
+		if since < start {
+			return ErrChangeLogTruncated
+		}
+
+		b := tx.Bucket(changeLogBucketName)
+		if b == nil {
+			if since != 0 {
+				return ErrInvalidChangeLogMarker
+			}
+			return nil
+		}
+
+		if since > b.Sequence() {
+			return ErrInvalidChangeLogMarker
+		}
+
+		var key [8]byte
+		binary.BigEndian.PutUint64(key[:], since+1)
+
+		c := b.Cursor()
+		for k, v := c.Seek(key[:]); k != nil && len(res) < count; k, v = c.Next() {
+			ch, err := changeFromKV(k, v)
+			if err != nil {
+				return fmt.Errorf("invalid change log record: %w", err)
+			}
+			res = append(res, ch)
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+// GarbageContainers returns IDs of the containers marked as removed.
+func (db *DB) GarbageContainers() ([]cid.ID, error) {
+	db.modeMtx.RLock()
+	defer db.modeMtx.RUnlock()
+
+	if db.mode.NoMetabase() {
+		return nil, ErrDegradedMode
+	}
+
+	var res []cid.ID
+	err := db.boltDB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(garbageContainersBucketName)
+		if b == nil {
+			return nil
+		}
+
+		return b.ForEach(func(k, _ []byte) error {
+			var id cid.ID
+			if err := id.Decode(k); err != nil {
+				return fmt.Errorf("parsing raw CID: %w", err)
+			}
+			res = append(res, id)
+			return nil
+		})
+	})
+
+	return res, err
+}
+
+var restoredDumpKey = []byte("restored_dump")
+
+// RestoredDumpMarker returns ID of the shard and the change log marker of the
+// last incremental dump restored to the metabase. Zero marker means nothing has
+// been restored yet.
+func (db *DB) RestoredDumpMarker() ([]byte, uint64, error) {
+	db.modeMtx.RLock()
+	defer db.modeMtx.RUnlock()
+
+	if db.mode.NoMetabase() {
+		return nil, 0, ErrDegradedMode
+	}
+
+	var data []byte
+	err := db.boltDB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(shardInfoBucket)
+		if b != nil {
+			data = bytes.Clone(b.Get(restoredDumpKey))
+		}
+		return nil
+	})
+	if err != nil || len(data) < 8 {
+		return nil, 0, err
+	}
+
+	return data[8:], binary.LittleEndian.Uint64(data), nil
+}
+
+// SetRestoredDumpMarker saves ID of the shard and the change log marker of the
+// incremental dump restored to the metabase.
+func (db *DB) SetRestoredDumpMarker(shardID []byte, marker uint64) error {
+	db.modeMtx.RLock()
+	defer db.modeMtx.RUnlock()
+
+	if db.mode.NoMetabase() {
+		return ErrDegradedMode
+	} else if db.mode.ReadOnly() {
+		return ErrReadOnlyMode
+	}
+
+	data := make([]byte, 8+len(shardID))
+	binary.LittleEndian.PutUint64(data, marker)
+	copy(data[8:], shardID)
+
+	return db.boltDB.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(shardInfoBucket)
+		if err != nil {
+			return err
+		}
+		return b.Put(restoredDumpKey, data)
+	})
+}
+
+// changeLogStart returns the marker the change log starts after.
+func changeLogStart(tx *bbolt.Tx) uint64 {
+	b := tx.Bucket(shardInfoBucket)
+	if b == nil {
+		return 0
+	}
+
+	v := b.Get(changeLogStartKey)
+	if len(v) != 8 {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint64(v)
+}
+
+func setChangeLogStart(tx *bbolt.Tx, start uint64) error {
+	b, err := tx.CreateBucketIfNotExists(shardInfoBucket)
+	if err != nil {
+		return err
+	}
+
+	var v [8]byte
+	binary.LittleEndian.PutUint64(v[:], start)
+
+	return b.Put(changeLogStartKey, v[:])
+}
+
+// truncateChangeLog deletes the changes logged up to the marker inclusive.
+func truncateChangeLog(b *bbolt.Bucket, marker uint64) error {
+	for {
+		k, _ := b.Cursor().First()
+		if k == nil || binary.BigEndian.Uint64(k) > marker {
+			return nil
+		}
+
+		if err := b.Delete(k); err != nil {
+			return fmt.Errorf("could not delete change log record: %w", err)
+		}
+	}
+}
+
+// syncChangeLog enables or disables the change log. Disabled log is dropped
+// since changes made while it is disabled are lost, so the log is restarted
+// after the current marker once enabled.
+func syncChangeLog(tx *bbolt.Tx, enabled bool) error {
+	b, err := tx.CreateBucketIfNotExists(changeLogBucketName)
+	if err != nil {
+		return fmt.Errorf("could not create change log bucket: %w", err)
+	}
+
+	start := changeLogStart(tx)
+
+	if enabled {
+		if start != changeLogDisabled {
+			return nil
+		}
+
+		return setChangeLogStart(tx, b.Sequence())
+	}
+
+	if start == changeLogDisabled {
+		return nil
+	}
+
+	err = truncateChangeLog(b, b.Sequence())
+	if err != nil {
+		return err
+	}
+
+	return setChangeLogStart(tx, changeLogDisabled)
+}
+
+// logObjectChange logs the change of the object if the change log is
+// enabled. Tombstone is used for ChangeInhumed only.
+func (db *DB) logObjectChange(tx *bbolt.Tx, typ ChangeType, addr oid.Address, tomb *oid.Address) error {
+	if !db.changeLog {
+		return nil
+	}
+
+	size := 1 + addressKeySize
+	if tomb != nil {
+		size += addressKeySize
+	}
+
+	v := make([]byte, size)
+	v[0] = byte(typ)
+	addressKey(addr, v[1:])
+	if tomb != nil {
+		addressKey(*tomb, v[1+addressKeySize:])
+	}
+
+	return logChange(tx, v)
+}
+
+// logContainerChange logs the change of the whole container if the change
+// log is enabled.
+func (db *DB) logContainerChange(tx *bbolt.Tx, typ ChangeType, cnr cid.ID) error {
+	if !db.changeLog {
+		return nil
+	}
+
+	v := make([]byte, 1+cidSize)
+	v[0] = byte(typ)
+	cnr.Encode(v[1:])
+
+	return logChange(tx, v)
+}
+
+func logChange(tx *bbolt.Tx, v []byte) error {
+	b, err := tx.CreateBucketIfNotExists(changeLogBucketName)
+	if err != nil {
+		return fmt.Errorf("could not create change log bucket: %w", err)
+	}
+
+	seq, err := b.NextSequence()
+	if err != nil {
+		return fmt.Errorf("could not increment change log sequence: %w", err)
+	}
+
+	var key [8]byte
+	binary.BigEndian.PutUint64(key[:], seq)
+
+	err = b.Put(key[:], v)
+	if err != nil {
+		return fmt.Errorf("could not log change: %w", err)
+	}
+
+	return nil
+}
+
+func changeFromKV(k, v []byte) (Change, error) {
+	var res Change
+
+	if len(k) != 8 || len(v) == 0 {
+		return res, fmt.Errorf("invalid record length: key %d, value %d", len(k), len(v))
+	}
+
+	res.seq = binary.BigEndian.Uint64(k)
+	res.typ = ChangeType(v[0])
+	v = v[1:]
+
+	var err error
+	switch res.typ {
+	case ChangeAdded, ChangeGarbage:
+		if len(v) != addressKeySize {
+			return res, fmt.Errorf("invalid address length %d", len(v))
+		}
+		err = decodeAddressFromKey(&res.addr, v)
+	case ChangeInhumed:
+		if len(v) != 2*addressKeySize {
+			return res, fmt.Errorf("invalid addresses length %d", len(v))
+		}
+		err = decodeAddressFromKey(&res.addr, v[:addressKeySize])
+		if err == nil {
+			err = decodeAddressFromKey(&res.tomb, v[addressKeySize:])
+		}
+	case ChangeContainerInhumed:
+		err = res.cnr.Decode(v)
+	default:
+		return res, fmt.Errorf("unknown change type %d", res.typ)
+	}
+
+	return res, err
+}
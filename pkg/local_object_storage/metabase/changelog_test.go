@@ -0,0 +1,177 @@
+package meta_test
+
+import (
+	"os"
+	"testing"
+
+	objectCore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
+	oidtest "github.com/epicchainlabs/epicchain-sdk-go/object/id/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_ChangeLog(t *testing.T) {
+	db := newDB(t, meta.WithChangeLog(true))
+
+	marker, err := db.ChangeLogMarker()
+	require.NoError(t, err)
+	require.Zero(t, marker)
+
+	changes, err := db.ListChanges(0, 10)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	cnr := cidtest.ID()
+	obj1 := generateObjectWithCID(t, cnr)
+	obj2 := generateObjectWithCID(t, cnr)
+	require.NoError(t, putBig(db, obj1))
+	require.NoError(t, putBig(db, obj2))
+
+	// putting the same object again is not a change
+	require.NoError(t, putBig(db, obj1))
+
+	marker, err = db.ChangeLogMarker()
+	require.NoError(t, err)
+	require.EqualValues(t, 2, marker)
+
+	addr1 := objectCore.AddressOf(obj1)
+	addr2 := objectCore.AddressOf(obj2)
+	tomb := oidtest.Address()
+
+	require.NoError(t, metaInhume(db, addr1, tomb))
+
+	var inhumePrm meta.InhumePrm
+	inhumePrm.SetAddresses(addr2)
+	inhumePrm.SetGCMark()
+	_, err = db.Inhume(inhumePrm)
+	require.NoError(t, err)
+
+	removedCnr := cidtest.ID()
+	_, err = db.InhumeContainer(removedCnr)
+	require.NoError(t, err)
+
+	changes, err = db.ListChanges(marker, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+
+	require.Equal(t, meta.ChangeInhumed, changes[0].Type())
+	require.Equal(t, addr1, changes[0].Address())
+	require.Equal(t, tomb, changes[0].Tombstone())
+	require.Equal(t, meta.ChangeGarbage, changes[1].Type())
+	require.Equal(t, addr2, changes[1].Address())
+	require.Equal(t, meta.ChangeContainerInhumed, changes[2].Type())
+	require.Equal(t, removedCnr, changes[2].Container())
+
+	t.Run("batches", func(t *testing.T) {
+		changes, err := db.ListChanges(0, 2)
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		require.Equal(t, meta.ChangeAdded, changes[0].Type())
+		require.Equal(t, addr1, changes[0].Address())
+		require.EqualValues(t, 1, changes[0].Seq())
+		require.Equal(t, addr2, changes[1].Address())
+
+		changes, err = db.ListChanges(changes[1].Seq(), 10)
+		require.NoError(t, err)
+		require.Len(t, changes, 3)
+	})
+
+	t.Run("marker ahead", func(t *testing.T) {
+		_, err := db.ListChanges(100, 10)
+		require.ErrorIs(t, err, meta.ErrInvalidChangeLogMarker)
+	})
+
+	cnrs, err := db.GarbageContainers()
+	require.NoError(t, err)
+	require.Equal(t, []cid.ID{removedCnr}, cnrs)
+
+	t.Run("truncate", func(t *testing.T) {
+		require.ErrorIs(t, db.TruncateChangeLog(100), meta.ErrInvalidChangeLogMarker)
+		require.NoError(t, db.TruncateChangeLog(marker))
+
+		_, err := db.ListChanges(0, 10)
+		require.ErrorIs(t, err, meta.ErrChangeLogTruncated)
+
+		changes, err := db.ListChanges(marker, 10)
+		require.NoError(t, err)
+		require.Len(t, changes, 3)
+
+		// older markers are no-op
+		require.NoError(t, db.TruncateChangeLog(1))
+
+		changes, err = db.ListChanges(marker, 10)
+		require.NoError(t, err)
+		require.Len(t, changes, 3)
+	})
+}
+
+func TestDB_ChangeLogDisabled(t *testing.T) {
+	path := t.Name()
+	t.Cleanup(func() { _ = os.Remove(path) })
+
+	open := func(enabled bool) *meta.DB {
+		db := meta.New(
+			meta.WithPath(path),
+			meta.WithPermissions(0o600),
+			meta.WithEpochState(epochState{}),
+			meta.WithChangeLog(enabled),
+		)
+		require.NoError(t, db.Open(false))
+		require.NoError(t, db.Init())
+		return db
+	}
+
+	db := open(false)
+	require.NoError(t, putBig(db, generateObject(t)))
+
+	_, err := db.ChangeLogMarker()
+	require.ErrorIs(t, err, meta.ErrChangeLogDisabled)
+	_, err = db.ListChanges(0, 10)
+	require.ErrorIs(t, err, meta.ErrChangeLogDisabled)
+	require.NoError(t, db.Close())
+
+	db = open(true)
+
+	// changes made while the log was disabled are not listed
+	marker, err := db.ChangeLogMarker()
+	require.NoError(t, err)
+	require.Zero(t, marker)
+
+	require.NoError(t, putBig(db, generateObject(t)))
+
+	changes, err := db.ListChanges(marker, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.NoError(t, db.Close())
+
+	// disabling drops the log
+	db = open(false)
+	require.NoError(t, db.Close())
+	db = open(true)
+	defer db.Close()
+
+	marker2, err := db.ChangeLogMarker()
+	require.NoError(t, err)
+	require.Equal(t, changes[0].Seq(), marker2)
+
+	_, err = db.ListChanges(marker, 10)
+	require.ErrorIs(t, err, meta.ErrChangeLogTruncated)
+}
+
+func TestDB_RestoredDumpMarker(t *testing.T) {
+	db := newDB(t)
+
+	id, marker, err := db.RestoredDumpMarker()
+	require.NoError(t, err)
+	require.Nil(t, id)
+	require.Zero(t, marker)
+
+	require.NoError(t, db.SetRestoredDumpMarker([]byte{1, 2, 3}, 42))
+
+	id, marker, err = db.RestoredDumpMarker()
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, id)
+	require.EqualValues(t, 42, marker)
+}
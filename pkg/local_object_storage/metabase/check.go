@@ -0,0 +1,294 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"go.etcd.io/bbolt"
+)
+
+// ObjectRecord describes the metabase record of the physically stored object.
+type ObjectRecord struct {
+	// Stored is true if the metabase has the object header.
+	Stored bool
+	// Removed is true if the object is covered by a tombstone or marked
+	// with GC mark.
+	Removed bool
+	// StorageID is the blobstor descriptor of the object, nil if unknown.
+	StorageID []byte
+}
+
+// ObjectRecord returns the metabase record of the object with the given
+// address. Unlike Exists, it does not check the object status and tells
+// whether the header is stored even for the removed objects.
+func (db *DB) ObjectRecord(addr oid.Address) (ObjectRecord, error) {
+	db.modeMtx.RLock()
+	defer db.modeMtx.RUnlock()
+
+	if db.mode.NoMetabase() {
+		return ObjectRecord{}, ErrDegradedMode
+	}
+
+	var res ObjectRecord
+	err := db.boltDB.View(func(tx *bbolt.Tx) error {
+		key := make([]byte, addressKeySize)
+		res.Removed = inGraveyardWithKey(addressKey(addr, key), tx.Bucket(graveyardBucketName),
+			tx.Bucket(garbageObjectsBucketName), tx.Bucket(garbageContainersBucketName)) != 0
+
+		objKey := objectKey(addr.Object(), key)
+		cnr := addr.Container()
+		name := make([]byte, bucketKeySize)
+
+		for _, bucketName := range []func(cid.ID, []byte) []byte{
+			primaryBucketName,
+			tombstoneBucketName,
+			storageGroupBucketName,
+			bucketNameLockers,
+			linkObjectsBucketName,
+		} {
+			if len(getFromBucket(tx, bucketName(cnr, name), objKey)) != 0 {
+				res.Stored = true
+				break
+			}
+		}
+
+		var err error
+		res.StorageID, err = db.storageID(tx, addr)
+		return err
+	})
+
+	return res, err
+}
+
+// ContainerVolume groups the container size estimations.
+type ContainerVolume struct {
+	// Size is the total payload size of the container regular objects.
+	Size uint64
+	// Objects is the number of the container regular objects.
+	Objects uint64
+}
+
+// ContainerVolumeDrift describes the container with the stored volume
+// estimations that differ from the actual ones.
+type ContainerVolumeDrift struct {
+	Container cid.ID
+	Stored    ContainerVolume
+	Actual    ContainerVolume
+}
+
+// CheckCountersRes groups the resulting values of CheckCounters operation.
+type CheckCountersRes struct {
+	stored, actual ObjectCounters
+	volumes        []ContainerVolumeDrift
+}
+
+// StoredCounters returns the object counters stored in the metabase.
+func (r CheckCountersRes) StoredCounters() ObjectCounters {
+	return r.stored
+}
+
+// ActualCounters returns the object counters calculated from the metabase
+// indexes.
+func (r CheckCountersRes) ActualCounters() ObjectCounters {
+	return r.actual
+}
+
+// ContainerVolumeDrifts returns the containers with the stored volume
+// estimations that differ from the actual ones.
+func (r CheckCountersRes) ContainerVolumeDrifts() []ContainerVolumeDrift {
+	return r.volumes
+}
+
+// CheckCounters compares the object counters and the container volume
+// estimations stored in the metabase with the ones calculated from the
+// object indexes. Use SyncCounters and SyncContainerVolumes to fix them.
+func (db *DB) CheckCounters() (CheckCountersRes, error) {
+	db.modeMtx.RLock()
+	defer db.modeMtx.RUnlock()
+
+	if db.mode.NoMetabase() {
+		return CheckCountersRes{}, ErrDegradedMode
+	}
+
+	var res CheckCountersRes
+	err := db.boltDB.View(func(tx *bbolt.Tx) error {
+		res.stored.phy, res.stored.logic = getCounters(tx)
+
+		var err error
+		res.actual, err = calculateCounters(tx)
+		if err != nil {
+			return err
+		}
+
+		actual, err := calculateContainerVolumes(tx)
+		if err != nil {
+			return err
+		}
+
+		res.volumes = containerVolumeDrifts(tx, actual)
+		return nil
+	})
+
+	return res, err
+}
+
+// SyncContainerVolumes recalculates the container volume estimations from
+// the object indexes.
+func (db *DB) SyncContainerVolumes() error {
+	db.modeMtx.RLock()
+	defer db.modeMtx.RUnlock()
+
+	if db.mode.NoMetabase() {
+		return ErrDegradedMode
+	} else if db.mode.ReadOnly() {
+		return ErrReadOnlyMode
+	}
+
+	return db.boltDB.Update(func(tx *bbolt.Tx) error {
+		actual, err := calculateContainerVolumes(tx)
+		if err != nil {
+			return err
+		}
+
+		bkt := tx.Bucket(containerVolumeBucketName)
+		for _, drift := range containerVolumeDrifts(tx, actual) {
+			key := make([]byte, cidSize)
+			drift.Container.Encode(key)
+
+			if drift.Actual == (ContainerVolume{}) {
+				err = bkt.Delete(key)
+			} else {
+				val := make([]byte, 16)
+				binary.LittleEndian.PutUint64(val, drift.Actual.Size)
+				binary.LittleEndian.PutUint64(val[8:], drift.Actual.Objects)
+				err = bkt.Put(key, val)
+			}
+			if err != nil {
+				return fmt.Errorf("could not update volume of container %s: %w", drift.Container, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// calculateCounters counts all the physically/logically stored objects using
+// internal indexes.
+func calculateCounters(tx *bbolt.Tx) (ObjectCounters, error) {
+	var res ObjectCounters
+	var addr oid.Address
+
+	graveyardBKT := tx.Bucket(graveyardBucketName)
+	garbageObjectsBKT := tx.Bucket(garbageObjectsBucketName)
+	garbageContainersBKT := tx.Bucket(garbageContainersBucketName)
+	key := make([]byte, addressKeySize)
+
+	err := iteratePhyObjects(tx, func(cnr cid.ID, obj oid.ID) error {
+		res.phy++
+
+		addr.SetContainer(cnr)
+		addr.SetObject(obj)
+
+		if inGraveyardWithKey(addressKey(addr, key), graveyardBKT, garbageObjectsBKT, garbageContainersBKT) == 0 {
+			res.logic++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return ObjectCounters{}, fmt.Errorf("could not iterate objects: %w", err)
+	}
+
+	return res, nil
+}
+
+// calculateContainerVolumes sums payload sizes of the available regular
+// objects per container.
+func calculateContainerVolumes(tx *bbolt.Tx) (map[cid.ID]ContainerVolume, error) {
+	res := make(map[cid.ID]ContainerVolume)
+
+	graveyardBKT := tx.Bucket(graveyardBucketName)
+	garbageObjectsBKT := tx.Bucket(garbageObjectsBucketName)
+	garbageContainersBKT := tx.Bucket(garbageContainersBucketName)
+	key := make([]byte, addressKeySize)
+	hdr := objectSDK.New()
+
+	var cnr cid.ID
+
+	err := tx.ForEach(func(name []byte, b *bbolt.Bucket) error {
+		rawCID, prefix := parseContainerIDWithPrefix(&cnr, name)
+		if rawCID == nil || prefix != primaryPrefix || len(name) != bucketKeySize {
+			return nil
+		}
+
+		copy(key, rawCID)
+
+		return b.ForEach(func(k, v []byte) error {
+			if len(k) != objectKeySize {
+				return nil
+			}
+
+			copy(key[cidSize:], k)
+			if inGraveyardWithKey(key, graveyardBKT, garbageObjectsBKT, garbageContainersBKT) != 0 {
+				return nil
+			}
+
+			if err := hdr.Unmarshal(v); err != nil {
+				return fmt.Errorf("could not unmarshal header of the object from container %s: %w", cnr, err)
+			}
+
+			vol := res[cnr]
+			vol.Size += hdr.PayloadSize()
+			vol.Objects++
+			res[cnr] = vol
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not iterate objects: %w", err)
+	}
+
+	return res, nil
+}
+
+// containerVolumeDrifts compares the stored container volume estimations with
+// the actual ones.
+func containerVolumeDrifts(tx *bbolt.Tx, actual map[cid.ID]ContainerVolume) []ContainerVolumeDrift {
+	var res []ContainerVolumeDrift
+
+	stored := make(map[cid.ID]ContainerVolume)
+	if bkt := tx.Bucket(containerVolumeBucketName); bkt != nil {
+		_ = bkt.ForEach(func(k, v []byte) error {
+			var cnr cid.ID
+			if cnr.Decode(k) == nil {
+				stored[cnr] = ContainerVolume{Size: parseContainerSize(v), Objects: parseContainerObjects(v)}
+			}
+
+			return nil
+		})
+	}
+
+	for cnr, vol := range stored {
+		if vol != actual[cnr] {
+			res = append(res, ContainerVolumeDrift{Container: cnr, Stored: vol, Actual: actual[cnr]})
+		}
+	}
+
+	for cnr, vol := range actual {
+		if _, ok := stored[cnr]; !ok {
+			res = append(res, ContainerVolumeDrift{Container: cnr, Actual: vol})
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return bytes.Compare(res[i].Container[:], res[j].Container[:]) < 0
+	})
+
+	return res
+}
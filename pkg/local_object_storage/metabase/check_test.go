@@ -0,0 +1,105 @@
+package meta
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	checksumtest "github.com/epicchainlabs/epicchain-sdk-go/checksum/test"
+	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	oidtest "github.com/epicchainlabs/epicchain-sdk-go/object/id/test"
+	usertest "github.com/epicchainlabs/epicchain-sdk-go/user/test"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+func TestDB_CheckCounters(t *testing.T) {
+	db := New(WithPath(filepath.Join(t.TempDir(), "meta")),
+		WithPermissions(0o600), WithEpochState(epochStateImpl{}))
+	require.NoError(t, db.Open(false))
+	require.NoError(t, db.Init())
+	t.Cleanup(func() { _ = db.Close() })
+
+	cnr := cidtest.ID()
+	addrs := make([]oid.Address, 3)
+	for i := range addrs {
+		obj := objectSDK.New()
+		obj.SetContainerID(cnr)
+		obj.SetID(oidtest.ID())
+		obj.SetPayloadSize(uint64(10 * (i + 1)))
+		owner := usertest.ID(t)
+		obj.SetOwnerID(&owner)
+		obj.SetPayloadChecksum(checksumtest.Checksum())
+
+		var prm PutPrm
+		prm.SetObject(obj)
+		prm.SetStorageID([]byte{byte(i)})
+		_, err := db.Put(prm)
+		require.NoError(t, err)
+
+		addrs[i] = objectcore.AddressOf(obj)
+	}
+
+	var inhumePrm InhumePrm
+	inhumePrm.SetAddresses(addrs[0])
+	inhumePrm.SetGCMark()
+	_, err := db.Inhume(inhumePrm)
+	require.NoError(t, err)
+
+	t.Run("object record", func(t *testing.T) {
+		rec, err := db.ObjectRecord(addrs[0])
+		require.NoError(t, err)
+		require.Equal(t, ObjectRecord{Stored: true, Removed: true, StorageID: []byte{0}}, rec)
+
+		rec, err = db.ObjectRecord(addrs[1])
+		require.NoError(t, err)
+		require.Equal(t, ObjectRecord{Stored: true, StorageID: []byte{1}}, rec)
+
+		var unknown oid.Address
+		unknown.SetContainer(cnr)
+		unknown.SetObject(oidtest.ID())
+		rec, err = db.ObjectRecord(unknown)
+		require.NoError(t, err)
+		require.Equal(t, ObjectRecord{}, rec)
+	})
+
+	res, err := db.CheckCounters()
+	require.NoError(t, err)
+	require.Equal(t, ObjectCounters{phy: 3, logic: 2}, res.ActualCounters())
+	require.Equal(t, res.ActualCounters(), res.StoredCounters())
+	require.Empty(t, res.ContainerVolumeDrifts())
+
+	unknownCnr := cidtest.ID()
+	require.NoError(t, db.boltDB.Update(func(tx *bbolt.Tx) error {
+		data := make([]byte, 8)
+		binary.LittleEndian.PutUint64(data, 10)
+		require.NoError(t, tx.Bucket(shardInfoBucket).Put(objectPhyCounterKey, data))
+
+		require.NoError(t, changeContainerSize(tx, cnr, 5, true))
+		return changeContainerSize(tx, unknownCnr, 1, true)
+	}))
+
+	res, err = db.CheckCounters()
+	require.NoError(t, err)
+	require.Equal(t, ObjectCounters{phy: 10, logic: 2}, res.StoredCounters())
+	require.Equal(t, ObjectCounters{phy: 3, logic: 2}, res.ActualCounters())
+	require.ElementsMatch(t, []ContainerVolumeDrift{
+		{Container: cnr, Stored: ContainerVolume{Size: 55, Objects: 3}, Actual: ContainerVolume{Size: 50, Objects: 2}},
+		{Container: unknownCnr, Stored: ContainerVolume{Size: 1, Objects: 1}},
+	}, res.ContainerVolumeDrifts())
+
+	require.NoError(t, db.SyncCounters())
+	require.NoError(t, db.SyncContainerVolumes())
+
+	res, err = db.CheckCounters()
+	require.NoError(t, err)
+	require.Equal(t, res.ActualCounters(), res.StoredCounters())
+	require.Empty(t, res.ContainerVolumeDrifts())
+
+	size, err := db.ContainerSize(unknownCnr)
+	require.NoError(t, err)
+	require.Zero(t, size)
+}
@@ -62,11 +62,39 @@ func (db *DB) ContainerSize(id cid.ID) (size uint64, err error) {
 }
 
 func (db *DB) containerSize(tx *bbolt.Tx, id cid.ID) (uint64, error) {
+	size, _ := db.containerVolume(tx, id)
+	return size, nil
+}
+
+// ContainerObjects returns the number of regular objects of the container
+// stored in the metabase. The counter is maintained along with the container
+// size and is recounted on the migration from the metabase version 2.
+func (db *DB) ContainerObjects(id cid.ID) (objects uint64, err error) {
+	db.modeMtx.RLock()
+	defer db.modeMtx.RUnlock()
+
+	if db.mode.NoMetabase() {
+		return 0, ErrDegradedMode
+	}
+
+	err = db.boltDB.View(func(tx *bbolt.Tx) error {
+		_, objects = db.containerVolume(tx, id)
+		return nil
+	})
+
+	return objects, err
+}
+
+// containerVolume returns the size and the number of regular objects of the
+// container.
+func (db *DB) containerVolume(tx *bbolt.Tx, id cid.ID) (uint64, uint64) {
 	containerVolume := tx.Bucket(containerVolumeBucketName)
 	key := make([]byte, cidSize)
 	id.Encode(key)
 
-	return parseContainerSize(containerVolume.Get(key)), nil
+	v := containerVolume.Get(key)
+
+	return parseContainerSize(v), parseContainerObjects(v)
 }
 
 func resetContainerSize(tx *bbolt.Tx, cID cid.ID) error {
@@ -74,7 +102,7 @@ func resetContainerSize(tx *bbolt.Tx, cID cid.ID) error {
 	key := make([]byte, cidSize)
 	cID.Encode(key)
 
-	return containerVolume.Put(key, make([]byte, 8))
+	return containerVolume.Put(key, make([]byte, 16))
 }
 
 func parseContainerID(dst *cid.ID, name []byte, ignore map[string]struct{}) bool {
@@ -88,30 +116,49 @@ func parseContainerID(dst *cid.ID, name []byte, ignore map[string]struct{}) bool
 }
 
 func parseContainerSize(v []byte) uint64 {
-	if len(v) == 0 {
+	if len(v) < 8 {
 		return 0
 	}
 
 	return binary.LittleEndian.Uint64(v)
 }
 
+func parseContainerObjects(v []byte) uint64 {
+	if len(v) < 16 {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint64(v[8:])
+}
+
+// changeContainerSize changes the container size by delta and the number of
+// container objects by one.
 func changeContainerSize(tx *bbolt.Tx, id cid.ID, delta uint64, increase bool) error {
 	containerVolume := tx.Bucket(containerVolumeBucketName)
 	key := make([]byte, cidSize)
 	id.Encode(key)
 
-	size := parseContainerSize(containerVolume.Get(key))
+	v := containerVolume.Get(key)
+	size := parseContainerSize(v)
+	objects := parseContainerObjects(v)
 
 	if increase {
 		size += delta
-	} else if size > delta {
-		size -= delta
+		objects++
 	} else {
-		size = 0
+		if size > delta {
+			size -= delta
+		} else {
+			size = 0
+		}
+		if objects > 0 {
+			objects--
+		}
 	}
 
-	buf := make([]byte, 8) // consider using sync.Pool to decrease allocations
+	buf := make([]byte, 16) // consider using sync.Pool to decrease allocations
 	binary.LittleEndian.PutUint64(buf, size)
+	binary.LittleEndian.PutUint64(buf[8:], objects)
 
 	return containerVolume.Put(key, buf)
 }
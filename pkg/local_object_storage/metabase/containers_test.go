@@ -174,13 +174,17 @@ func TestDB_ContainerSize(t *testing.T) {
 		n, err := db.ContainerSize(cnr)
 		require.NoError(t, err)
 		require.Equal(t, volume, int(n))
+
+		n, err = db.ContainerObjects(cnr)
+		require.NoError(t, err)
+		require.EqualValues(t, N, n)
 	}
 
 	t.Run("Inhume", func(t *testing.T) {
 		for cnr, list := range objs {
 			volume := cids[cnr]
 
-			for _, obj := range list {
+			for i, obj := range list {
 				require.NoError(t, metaInhume(
 					db,
 					object.AddressOf(obj),
@@ -192,6 +196,10 @@ func TestDB_ContainerSize(t *testing.T) {
 				n, err := db.ContainerSize(cnr)
 				require.NoError(t, err)
 				require.Equal(t, volume, int(n))
+
+				n, err = db.ContainerObjects(cnr)
+				require.NoError(t, err)
+				require.EqualValues(t, len(list)-i-1, n)
 			}
 		}
 	})
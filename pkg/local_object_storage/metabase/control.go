@@ -67,6 +67,11 @@ func (db *DB) openBolt() error {
 			db.initialized = true
 			err = nil
 		}
+		if err != nil {
+			return err
+		}
+
+		db.sortedIndexes, err = readSortedIndexes(tx)
 		return err
 	})
 }
@@ -107,9 +112,10 @@ func (db *DB) init(reset bool) error {
 		string(garbageContainersBucketName): {},
 		string(shardInfoBucket):             {},
 		string(bucketNameLocked):            {},
+		string(changeLogBucketName):         {},
 	}
 
-	return db.boltDB.Update(func(tx *bbolt.Tx) error {
+	err := db.boltDB.Update(func(tx *bbolt.Tx) error {
 		var err error
 		if !reset {
 			// Normal open, check version and update if not initialized.
@@ -139,6 +145,16 @@ func (db *DB) init(reset bool) error {
 				return fmt.Errorf("could not sync object counter: %w", err)
 			}
 
+			err = syncSortedIndexes(tx, db.indexedAttributes)
+			if err != nil {
+				return fmt.Errorf("could not sync sorted attribute indexes: %w", err)
+			}
+
+			err = syncChangeLog(tx, db.changeLog)
+			if err != nil {
+				return fmt.Errorf("could not sync change log: %w", err)
+			}
+
 			return nil
 		}
 
@@ -152,8 +168,26 @@ func (db *DB) init(reset bool) error {
 		if err != nil {
 			return err
 		}
-		return updateVersion(tx, version)
+
+		err = updateVersion(tx, version)
+		if err != nil {
+			return err
+		}
+
+		err = syncChangeLog(tx, db.changeLog)
+		if err != nil {
+			return fmt.Errorf("could not sync change log: %w", err)
+		}
+
+		return syncSortedIndexes(tx, db.indexedAttributes)
 	})
+	if err != nil {
+		return err
+	}
+
+	db.sortedIndexes = db.indexedAttributes
+
+	return nil
 }
 
 // SyncCounters forces to synchronize the object counters.
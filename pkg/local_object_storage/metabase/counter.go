@@ -4,8 +4,6 @@ import (
 	"encoding/binary"
 	"fmt"
 
-	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
-	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
 	"go.etcd.io/bbolt"
 )
 
@@ -134,35 +132,13 @@ func syncCounter(tx *bbolt.Tx, force bool) error {
 		return nil
 	}
 
-	var addr oid.Address
-	var phyCounter uint64
-	var logicCounter uint64
-
-	graveyardBKT := tx.Bucket(graveyardBucketName)
-	garbageObjectsBKT := tx.Bucket(garbageObjectsBucketName)
-	garbageContainersBKT := tx.Bucket(garbageContainersBucketName)
-	key := make([]byte, addressKeySize)
-
-	err = iteratePhyObjects(tx, func(cnr cid.ID, obj oid.ID) error {
-		phyCounter++
-
-		addr.SetContainer(cnr)
-		addr.SetObject(obj)
-
-		// check if an object is available: not with GCMark
-		// and not covered with a tombstone
-		if inGraveyardWithKey(addressKey(addr, key), graveyardBKT, garbageObjectsBKT, garbageContainersBKT) == 0 {
-			logicCounter++
-		}
-
-		return nil
-	})
+	counters, err := calculateCounters(tx)
 	if err != nil {
-		return fmt.Errorf("could not iterate objects: %w", err)
+		return err
 	}
 
 	data := make([]byte, 8)
-	binary.LittleEndian.PutUint64(data, phyCounter)
+	binary.LittleEndian.PutUint64(data, counters.phy)
 
 	err = b.Put(objectPhyCounterKey, data)
 	if err != nil {
@@ -170,7 +146,7 @@ func syncCounter(tx *bbolt.Tx, force bool) error {
 	}
 
 	data = make([]byte, 8)
-	binary.LittleEndian.PutUint64(data, logicCounter)
+	binary.LittleEndian.PutUint64(data, counters.logic)
 
 	err = b.Put(objectLogicCounterKey, data)
 	if err != nil {
@@ -42,6 +42,9 @@ type DB struct {
 	boltDB *bbolt.DB
 
 	initialized bool
+
+	// attributes with sorted indexes in the database
+	sortedIndexes map[string]struct{}
 }
 
 // Option is an option of DB constructor.
@@ -58,6 +61,10 @@ type cfg struct {
 	log *zap.Logger
 
 	epochState EpochState
+
+	indexedAttributes map[string]struct{}
+
+	changeLog bool
 }
 
 func defaultCfg() *cfg {
@@ -238,6 +238,11 @@ func (db *DB) deleteObject(
 		return fmt.Errorf("can't remove fake bucket tree indexes: %w", err)
 	}
 
+	err = updateSortedIndexes(tx, obj, db.sortedIndexes, false)
+	if err != nil {
+		return fmt.Errorf("can't remove sorted attribute indexes: %w", err)
+	}
+
 	return nil
 }
 
@@ -222,6 +222,15 @@ func (db *DB) Inhume(prm InhumePrm) (res InhumeRes, err error) {
 				return err
 			}
 
+			if prm.tomb != nil {
+				err = db.logObjectChange(tx, ChangeInhumed, prm.target[i], prm.tomb)
+			} else {
+				err = db.logObjectChange(tx, ChangeGarbage, prm.target[i], nil)
+			}
+			if err != nil {
+				return err
+			}
+
 			if prm.lockObjectHandling {
 				// do not perform lock check if
 				// it was already called
@@ -272,6 +281,11 @@ func (db *DB) InhumeContainer(cID cid.ID) (uint64, error) {
 			return fmt.Errorf("put GC mark for container: %w", err)
 		}
 
+		err = db.logContainerChange(tx, ChangeContainerInhumed, cID)
+		if err != nil {
+			return err
+		}
+
 		_, removedAvailable = getCounters(tx)
 
 		err = db.updateCounter(tx, logical, removedAvailable, false)
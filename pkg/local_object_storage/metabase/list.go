@@ -2,6 +2,7 @@ package meta
 
 import (
 	"bytes"
+	"errors"
 
 	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
@@ -22,6 +23,28 @@ type Cursor struct {
 	inBucketOffset []byte
 }
 
+// Marshal encodes the cursor into a binary form which can be decoded back with
+// Unmarshal. It allows to persist listing progress between restarts.
+func (c *Cursor) Marshal() []byte {
+	b := make([]byte, 1+len(c.bucketName)+len(c.inBucketOffset))
+	b[0] = byte(len(c.bucketName))
+	n := copy(b[1:], c.bucketName)
+	copy(b[1+n:], c.inBucketOffset)
+	return b
+}
+
+// Unmarshal decodes the cursor from the binary form produced by Marshal.
+func (c *Cursor) Unmarshal(b []byte) error {
+	if len(b) == 0 || len(b) < 1+int(b[0]) {
+		return errors.New("invalid cursor length")
+	}
+
+	c.bucketName = bytes.Clone(b[1 : 1+b[0]])
+	c.inBucketOffset = bytes.Clone(b[1+b[0]:])
+
+	return nil
+}
+
 // ListPrm contains parameters for ListWithCursor operation.
 type ListPrm struct {
 	count  int
@@ -151,6 +151,11 @@ func (db *DB) put(
 		return fmt.Errorf("can't put fake bucket tree indexes: %w", err)
 	}
 
+	err = updateSortedIndexes(tx, obj, db.sortedIndexes, true)
+	if err != nil {
+		return fmt.Errorf("can't put sorted attribute indexes: %w", err)
+	}
+
 	// update container volume size estimation
 	if obj.Type() == objectSDK.TypeRegular && !isParent {
 		err = changeContainerSize(tx, cnr, obj.PayloadSize(), true)
@@ -171,6 +176,11 @@ func (db *DB) put(
 		if err != nil {
 			return fmt.Errorf("could not increase logical object counter: %w", err)
 		}
+
+		err = db.logObjectChange(tx, ChangeAdded, objectCore.AddressOf(obj), nil)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
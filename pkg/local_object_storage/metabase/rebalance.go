@@ -0,0 +1,86 @@
+package meta
+
+import (
+	"bytes"
+	"fmt"
+
+	"go.etcd.io/bbolt"
+)
+
+var rebalanceKey = []byte("rebalance")
+
+// ReadRebalanceCursor reads the shard rebalance progress stored with
+// WriteRebalanceCursor. The second return value is false if there is no
+// rebalance in progress. Nil cursor with true means that the rebalance has been
+// started but no object has been processed yet.
+func (db *DB) ReadRebalanceCursor() (*Cursor, bool, error) {
+	db.modeMtx.RLock()
+	defer db.modeMtx.RUnlock()
+
+	if db.mode.NoMetabase() {
+		return nil, false, ErrDegradedMode
+	}
+
+	var data []byte
+	err := db.boltDB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(shardInfoBucket)
+		if b != nil {
+			data = bytes.Clone(b.Get(rebalanceKey))
+		}
+		return nil
+	})
+	if err != nil || data == nil {
+		return nil, false, err
+	}
+
+	if len(data) == 0 {
+		return nil, true, nil
+	}
+
+	c := new(Cursor)
+	if err := c.Unmarshal(data); err != nil {
+		return nil, false, fmt.Errorf("decode rebalance cursor: %w", err)
+	}
+
+	return c, true, nil
+}
+
+// WriteRebalanceCursor stores the shard rebalance progress. Nil cursor marks
+// the rebalance as started from the beginning.
+func (db *DB) WriteRebalanceCursor(c *Cursor) error {
+	var data = []byte{}
+	if c != nil {
+		data = c.Marshal()
+	}
+
+	return db.updateShardInfo(func(b *bbolt.Bucket) error {
+		return b.Put(rebalanceKey, data)
+	})
+}
+
+// ResetRebalanceCursor removes the shard rebalance progress, so that
+// ReadRebalanceCursor reports no rebalance in progress.
+func (db *DB) ResetRebalanceCursor() error {
+	return db.updateShardInfo(func(b *bbolt.Bucket) error {
+		return b.Delete(rebalanceKey)
+	})
+}
+
+func (db *DB) updateShardInfo(f func(*bbolt.Bucket) error) error {
+	db.modeMtx.RLock()
+	defer db.modeMtx.RUnlock()
+
+	if db.mode.NoMetabase() {
+		return ErrDegradedMode
+	} else if db.mode.ReadOnly() {
+		return ErrReadOnlyMode
+	}
+
+	return db.boltDB.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(shardInfoBucket)
+		if err != nil {
+			return err
+		}
+		return f(b)
+	})
+}
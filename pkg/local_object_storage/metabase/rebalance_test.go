@@ -0,0 +1,58 @@
+package meta_test
+
+import (
+	"testing"
+
+	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_RebalanceCursor(t *testing.T) {
+	db := newDB(t)
+
+	_, ok, err := db.ReadRebalanceCursor()
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, db.WriteRebalanceCursor(nil))
+
+	c, ok, err := db.ReadRebalanceCursor()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Nil(t, c)
+
+	const total = 5
+	cnr := cidtest.ID()
+	for i := 0; i < total; i++ {
+		obj := generateObjectWithCID(t, cnr)
+		require.NoError(t, putBig(db, obj))
+	}
+
+	var prm meta.ListPrm
+	prm.SetCount(2)
+
+	res, err := db.ListWithCursor(prm)
+	require.NoError(t, err)
+	require.Len(t, res.AddressList(), 2)
+
+	require.NoError(t, db.WriteRebalanceCursor(res.Cursor()))
+
+	c, ok, err = db.ReadRebalanceCursor()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// listing continues from the restored cursor
+	prm.SetCount(total)
+	prm.SetCursor(c)
+
+	res, err = db.ListWithCursor(prm)
+	require.NoError(t, err)
+	require.Len(t, res.AddressList(), total-2)
+
+	require.NoError(t, db.ResetRebalanceCursor())
+
+	_, ok, err = db.ReadRebalanceCursor()
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -0,0 +1,99 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go.etcd.io/bbolt"
+)
+
+var scrubKey = []byte("scrub")
+
+// scrubStateHeaderSize is a size of the encoded ScrubState counters.
+const scrubStateHeaderSize = 3 * 8
+
+// ScrubState describes the progress of the background integrity scrubbing of
+// the shard objects.
+type ScrubState struct {
+	// Cursor of the last checked object, nil at the beginning of the pass.
+	Cursor *Cursor
+	// Checked is a number of objects checked in the current pass.
+	Checked uint64
+	// Corrupted is a number of corrupted objects found in all passes.
+	Corrupted uint64
+	// Passes is a number of completed passes.
+	Passes uint64
+}
+
+func (s ScrubState) marshal() []byte {
+	b := make([]byte, scrubStateHeaderSize)
+	binary.LittleEndian.PutUint64(b, s.Checked)
+	binary.LittleEndian.PutUint64(b[8:], s.Corrupted)
+	binary.LittleEndian.PutUint64(b[16:], s.Passes)
+
+	if s.Cursor != nil {
+		b = append(b, s.Cursor.Marshal()...)
+	}
+
+	return b
+}
+
+func (s *ScrubState) unmarshal(b []byte) error {
+	if len(b) < scrubStateHeaderSize {
+		return errors.New("invalid scrub state length")
+	}
+
+	s.Checked = binary.LittleEndian.Uint64(b)
+	s.Corrupted = binary.LittleEndian.Uint64(b[8:])
+	s.Passes = binary.LittleEndian.Uint64(b[16:])
+	s.Cursor = nil
+
+	if len(b) > scrubStateHeaderSize {
+		s.Cursor = new(Cursor)
+		if err := s.Cursor.Unmarshal(b[scrubStateHeaderSize:]); err != nil {
+			return fmt.Errorf("decode cursor: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ReadScrubState reads the shard scrubbing progress stored with
+// WriteScrubState. Zero state is returned if the scrubbing has never been
+// run.
+func (db *DB) ReadScrubState() (ScrubState, error) {
+	db.modeMtx.RLock()
+	defer db.modeMtx.RUnlock()
+
+	if db.mode.NoMetabase() {
+		return ScrubState{}, ErrDegradedMode
+	}
+
+	var data []byte
+	err := db.boltDB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(shardInfoBucket)
+		if b != nil {
+			data = bytes.Clone(b.Get(scrubKey))
+		}
+		return nil
+	})
+	if err != nil || data == nil {
+		return ScrubState{}, err
+	}
+
+	var s ScrubState
+	if err := s.unmarshal(data); err != nil {
+		return ScrubState{}, fmt.Errorf("decode scrub state: %w", err)
+	}
+
+	return s, nil
+}
+
+// WriteScrubState stores the shard scrubbing progress.
+func (db *DB) WriteScrubState(s ScrubState) error {
+	return db.updateShardInfo(func(b *bbolt.Bucket) error {
+		return b.Put(scrubKey, s.marshal())
+	})
+}
@@ -0,0 +1,56 @@
+package meta_test
+
+import (
+	"testing"
+
+	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_ScrubState(t *testing.T) {
+	db := newDB(t)
+
+	s, err := db.ReadScrubState()
+	require.NoError(t, err)
+	require.Zero(t, s)
+
+	cnr := cidtest.ID()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, putBig(db, generateObjectWithCID(t, cnr)))
+	}
+
+	var prm meta.ListPrm
+	prm.SetCount(2)
+
+	res, err := db.ListWithCursor(prm)
+	require.NoError(t, err)
+
+	exp := meta.ScrubState{
+		Cursor:    res.Cursor(),
+		Checked:   2,
+		Corrupted: 1,
+		Passes:    3,
+	}
+	require.NoError(t, db.WriteScrubState(exp))
+
+	s, err = db.ReadScrubState()
+	require.NoError(t, err)
+	require.Equal(t, exp, s)
+
+	// listing continues from the restored cursor
+	prm.SetCursor(s.Cursor)
+
+	res, err = db.ListWithCursor(prm)
+	require.NoError(t, err)
+	require.Len(t, res.AddressList(), 1)
+
+	exp.Cursor = nil
+	exp.Checked = 0
+	exp.Passes++
+	require.NoError(t, db.WriteScrubState(exp))
+
+	s, err = db.ReadScrubState()
+	require.NoError(t, err)
+	require.Equal(t, exp, s)
+}
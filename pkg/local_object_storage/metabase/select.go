@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -35,6 +36,9 @@ type (
 type SelectPrm struct {
 	cnr     cid.ID
 	filters object.SearchFilters
+
+	limit  uint32
+	cursor *oid.ID
 }
 
 // SelectRes groups the resulting values of Select operation.
@@ -52,12 +56,26 @@ func (p *SelectPrm) SetFilters(fs object.SearchFilters) {
 	p.filters = fs
 }
 
+// SetLimit is a Select option to limit the number of the selected objects.
+// Zero means no limit.
+func (p *SelectPrm) SetLimit(n uint32) {
+	p.limit = n
+}
+
+// SetCursor is a Select option to select only the objects following the given
+// one. Objects are ordered by their IDs, so the last ID of the previous
+// limited Select is the cursor of the next one.
+func (p *SelectPrm) SetCursor(id oid.ID) {
+	p.cursor = &id
+}
+
 // AddressList returns list of addresses of the selected objects.
 func (r SelectRes) AddressList() []oid.Address {
 	return r.addrList
 }
 
-// Select returns list of addresses of objects that match search filters.
+// Select returns list of addresses of objects that match search filters
+// ordered by object IDs.
 //
 // Only creation epoch, payload size, user attributes and unknown system ones
 // are allowed with numeric operators. Values of numeric filters must be base-10
@@ -79,13 +97,13 @@ func (db *DB) Select(prm SelectPrm) (res SelectRes, err error) {
 	currEpoch := db.epochState.CurrentEpoch()
 
 	return res, db.boltDB.View(func(tx *bbolt.Tx) error {
-		res.addrList, err = db.selectObjects(tx, prm.cnr, prm.filters, currEpoch)
+		res.addrList, err = db.selectObjects(tx, prm.cnr, prm.filters, currEpoch, prm.cursor, prm.limit)
 
 		return err
 	})
 }
 
-func (db *DB) selectObjects(tx *bbolt.Tx, cnr cid.ID, fs object.SearchFilters, currEpoch uint64) ([]oid.Address, error) {
+func (db *DB) selectObjects(tx *bbolt.Tx, cnr cid.ID, fs object.SearchFilters, currEpoch uint64, cursor *oid.ID, limit uint32) ([]oid.Address, error) {
 	group, err := groupFilters(fs)
 	if err != nil {
 		return nil, err
@@ -108,18 +126,45 @@ func (db *DB) selectObjects(tx *bbolt.Tx, cnr cid.ID, fs object.SearchFilters, c
 
 		db.selectAll(tx, cnr, mAddr)
 	} else {
+		db.sortFastFilters(group.fastFilters)
+
 		for i := range group.fastFilters {
 			db.selectFastFilter(tx, cnr, group.fastFilters[i], mAddr, i)
+
+			if len(mAddr) == 0 {
+				break // nothing can match the rest of the filters
+			}
 		}
 	}
 
-	res := make([]oid.Address, 0, len(mAddr))
-
+	matched := make([]string, 0, len(mAddr))
 	for a, ind := range mAddr {
 		if ind != expLen {
 			continue // ignore objects with unmatched fast filters
 		}
 
+		if cursor != nil && a <= string(cursor[:]) {
+			continue // ignore objects of the previous pages
+		}
+
+		matched = append(matched, a)
+	}
+
+	// keys are the binary IDs, so the order of the strings is the order of IDs
+	sort.Strings(matched)
+
+	resCap := len(matched)
+	if limit > 0 && int(limit) < resCap {
+		resCap = int(limit)
+	}
+
+	res := make([]oid.Address, 0, resCap)
+
+	for _, a := range matched {
+		if limit > 0 && len(res) == int(limit) {
+			break
+		}
+
 		var id oid.ID
 		err = id.Decode([]byte(a))
 		if err != nil {
@@ -212,6 +257,11 @@ func (db *DB) selectFastFilter(
 		selectAllFromBucket(tx, bucketNameLockers(cnr, bucketName), to, fNum)
 		selectAllFromBucket(tx, linkObjectsBucketName(cnr, bucketName), to, fNum)
 	default: // user attribute
+		if db.isIndexedAttribute(f.Header()) &&
+			selectFromSortedIndex(tx, sortedIndexBucketName(cnr, f.Header(), bucketName), f, to, fNum) {
+			return
+		}
+
 		bucketName := attributeBucketName(cnr, f.Header(), bucketName)
 
 		if f.Operation() == object.MatchNotPresent {
@@ -222,6 +272,46 @@ func (db *DB) selectFastFilter(
 	}
 }
 
+// Costs of the fast filters processing.
+const (
+	// filter is processed by the direct lookup of the value
+	filterCostLookup = iota
+	// filter is processed by the iteration over the matching part of the
+	// sorted attribute index
+	filterCostRange
+	// filter is processed by the iteration over all values of the attribute
+	// or all container objects
+	filterCostScan
+)
+
+// filterCost estimates the cost of the fast filter processing.
+func (db *DB) filterCost(f object.SearchFilter) int {
+	switch f.Header() {
+	case object.FilterType, object.FilterRoot, object.FilterPhysical:
+		return filterCostScan
+	}
+
+	switch op := f.Operation(); op {
+	case object.MatchStringEqual:
+		return filterCostLookup
+	case object.MatchCommonPrefix, object.MatchNumGT, object.MatchNumGE, object.MatchNumLT, object.MatchNumLE:
+		if db.isIndexedAttribute(f.Header()) {
+			return filterCostRange
+		}
+	}
+
+	return filterCostScan
+}
+
+// sortFastFilters orders fast filters from the cheapest to the most expensive
+// ones, so the first filters narrow down the set of objects to check, and the
+// processing stops as soon as nothing matches.
+func (db *DB) sortFastFilters(fs object.SearchFilters) {
+	sort.SliceStable(fs, func(i, j int) bool {
+		return db.filterCost(fs[i]) < db.filterCost(fs[j])
+	})
+}
+
 var mBucketNaming = map[string][]func(cid.ID, []byte) []byte{
 	object.TypeRegular.EncodeToString():      {primaryBucketName, parentBucketName},
 	object.TypeTombstone.EncodeToString():    {tombstoneBucketName},
@@ -1,10 +1,12 @@
 package meta_test
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
 	"math"
 	"math/big"
+	"sort"
 	"strconv"
 	"testing"
 
@@ -1126,3 +1128,58 @@ func TestSelectNotFilledBucket(t *testing.T) {
 	fs.AddObjectIDFilter(objectSDK.MatchStringNotEqual, oID1)
 	testSelect(t, db, cnr, fs, object.AddressOf(raw2))
 }
+
+func TestSelectPagination(t *testing.T) {
+	db := newDB(t)
+	cnr := cidtest.ID()
+
+	const total = 20
+
+	var expected []oid.Address
+	for i := 0; i < total; i++ {
+		obj := generateObjectWithCID(t, cnr)
+		addAttribute(obj, "parity", strconv.Itoa(i%2))
+		require.NoError(t, putBig(db, obj))
+
+		if i%2 == 0 {
+			expected = append(expected, object.AddressOf(obj))
+		}
+	}
+
+	sort.Slice(expected, func(i, j int) bool {
+		idI, idJ := expected[i].Object(), expected[j].Object()
+		return bytes.Compare(idI[:], idJ[:]) < 0
+	})
+
+	fs := objectSDK.SearchFilters{}
+	fs.AddFilter("parity", "0", objectSDK.MatchStringEqual)
+
+	var prm meta.SelectPrm
+	prm.SetContainerID(cnr)
+	prm.SetFilters(fs)
+
+	res, err := db.Select(prm)
+	require.NoError(t, err)
+	require.Equal(t, expected, res.AddressList())
+
+	const limit = 3
+	prm.SetLimit(limit)
+
+	var got []oid.Address
+	for {
+		res, err := db.Select(prm)
+		require.NoError(t, err)
+
+		page := res.AddressList()
+		require.LessOrEqual(t, len(page), limit)
+
+		got = append(got, page...)
+		if len(page) < limit {
+			break
+		}
+
+		prm.SetCursor(page[len(page)-1].Object())
+	}
+
+	require.Equal(t, expected, got)
+}
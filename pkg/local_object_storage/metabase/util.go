@@ -25,6 +25,7 @@ var (
 	garbageContainersBucketName = []byte{garbageContainersPrefix}
 	toMoveItBucketName          = []byte{toMoveItPrefix}
 	containerVolumeBucketName   = []byte{containerVolumePrefix}
+	changeLogBucketName         = []byte{changeLogPrefix}
 
 	zeroValue = []byte{0xFF}
 )
@@ -130,6 +131,18 @@ const (
 	//  Key: first object ID
 	//  Value: list of object IDs
 	firstObjectIDPrefix
+
+	// changeLogPrefix is used for the bucket logging object additions and
+	// removals for incremental shard dumps.
+	//  Key: change sequence number as big-endian uint64
+	//  Value: change type byte followed by the change-specific data
+	changeLogPrefix
+
+	// sortedAttributePrefix is used for prefixing sorted index buckets of the
+	// object attributes configured with WithIndexedAttributes.
+	//  Key: section byte, encoded attribute value, object ID
+	//  Value: dummy value
+	sortedAttributePrefix
 )
 
 const (
@@ -1,15 +1,18 @@
 package meta
 
 import (
+	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
 	"go.etcd.io/bbolt"
 )
 
 // version contains current metabase version.
-const version = 2
+const version = 3
 
 var versionKey = []byte("version")
 
@@ -18,6 +21,12 @@ var versionKey = []byte("version")
 // the current code version.
 var ErrOutdatedVersion = logicerr.New("invalid version, resynchronization is required")
 
+// migrations contains functions upgrading the metabase of the key version to
+// the next one.
+var migrations = map[uint64]func(*bbolt.Tx) error{
+	2: migrateFrom2Version,
+}
+
 func checkVersion(tx *bbolt.Tx, initialized bool) error {
 	var knownVersion bool
 
@@ -29,7 +38,10 @@ func checkVersion(tx *bbolt.Tx, initialized bool) error {
 
 			stored := binary.LittleEndian.Uint64(data)
 			if stored != version {
-				return fmt.Errorf("%w: expected=%d, stored=%d", ErrOutdatedVersion, version, stored)
+				err := migrate(tx, stored)
+				if err != nil {
+					return fmt.Errorf("%w: expected=%d, stored=%d: %w", ErrOutdatedVersion, version, stored, err)
+				}
 			}
 		}
 	}
@@ -59,3 +71,99 @@ func updateVersion(tx *bbolt.Tx, version uint64) error {
 	}
 	return b.Put(versionKey, data)
 }
+
+// migrate upgrades the metabase of the stored version to the current one.
+func migrate(tx *bbolt.Tx, stored uint64) error {
+	if stored > version {
+		return errors.New("metabase is newer than the node")
+	}
+
+	for v := stored; v < version; v++ {
+		m, ok := migrations[v]
+		if !ok {
+			return fmt.Errorf("no migration from version %d", v)
+		}
+
+		err := m(tx)
+		if err != nil {
+			return fmt.Errorf("migration from version %d: %w", v, err)
+		}
+
+		err = updateVersion(tx, v+1)
+		if err != nil {
+			return fmt.Errorf("can't update version to %d: %w", v+1, err)
+		}
+	}
+
+	return nil
+}
+
+// migrateFrom2Version adds the empty list of the indexed attributes, their
+// sorted indexes are built on Init according to the configuration, and
+// recounts container objects stored along with the container volumes.
+func migrateFrom2Version(tx *bbolt.Tx) error {
+	b, err := tx.CreateBucketIfNotExists(shardInfoBucket)
+	if err != nil {
+		return fmt.Errorf("can't create auxiliary bucket: %w", err)
+	}
+
+	data, err := encodeList(nil)
+	if err != nil {
+		return err
+	}
+
+	err = b.Put(indexedAttributesKey, data)
+	if err != nil {
+		return err
+	}
+
+	return recountContainerObjects(tx)
+}
+
+// recountContainerObjects walks the primary indexes and stores the number of
+// available regular objects after the container size in the container volume
+// values. Sizes are kept as is.
+func recountContainerObjects(tx *bbolt.Tx) error {
+	b := tx.Bucket(containerVolumeBucketName)
+	if b == nil {
+		return nil
+	}
+
+	actual, err := calculateContainerVolumes(tx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		cnr     cid.ID
+		keys    [][]byte
+		updated [][]byte
+	)
+
+	err = b.ForEach(func(k, v []byte) error {
+		if cnr.Decode(k) != nil {
+			return nil
+		}
+
+		val := make([]byte, 16)
+		binary.LittleEndian.PutUint64(val, parseContainerSize(v))
+		binary.LittleEndian.PutUint64(val[8:], actual[cnr].Objects)
+
+		keys = append(keys, bytes.Clone(k))
+		updated = append(updated, val)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not iterate container volumes: %w", err)
+	}
+
+	for i := range keys {
+		err = b.Put(keys[i], updated[i])
+		if err != nil {
+			return fmt.Errorf("could not update container volume: %w", err)
+		}
+	}
+
+	return nil
+}
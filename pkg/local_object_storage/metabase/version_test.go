@@ -7,6 +7,13 @@ import (
 	"path/filepath"
 	"testing"
 
+	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	checksumtest "github.com/epicchainlabs/epicchain-sdk-go/checksum/test"
+	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	oidtest "github.com/epicchainlabs/epicchain-sdk-go/object/id/test"
+	usertest "github.com/epicchainlabs/epicchain-sdk-go/user/test"
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/bbolt"
 )
@@ -65,6 +72,72 @@ func TestVersion(t *testing.T) {
 		check(t, db)
 		require.NoError(t, db.Close())
 	})
+	t.Run("migration from 2", func(t *testing.T) {
+		db := newDB(t)
+		require.NoError(t, db.Open(false))
+		require.NoError(t, db.Init())
+
+		cnr := cidtest.ID()
+		addrs := make([]oid.Address, 3)
+		for i := range addrs {
+			obj := objectSDK.New()
+			obj.SetContainerID(cnr)
+			obj.SetID(oidtest.ID())
+			obj.SetPayloadSize(10)
+			owner := usertest.ID(t)
+			obj.SetOwnerID(&owner)
+			obj.SetPayloadChecksum(checksumtest.Checksum())
+
+			var prm PutPrm
+			prm.SetObject(obj)
+			_, err := db.Put(prm)
+			require.NoError(t, err)
+
+			addrs[i] = objectcore.AddressOf(obj)
+		}
+
+		var inhumePrm InhumePrm
+		inhumePrm.SetAddresses(addrs[0])
+		inhumePrm.SetGCMark()
+		_, err := db.Inhume(inhumePrm)
+		require.NoError(t, err)
+
+		require.NoError(t, db.boltDB.Update(func(tx *bbolt.Tx) error {
+			if err := tx.Bucket(shardInfoBucket).Delete(indexedAttributesKey); err != nil {
+				return err
+			}
+
+			// version 2 stores the container size only
+			key := make([]byte, cidSize)
+			cnr.Encode(key)
+			size := make([]byte, 8)
+			binary.LittleEndian.PutUint64(size, 30)
+			if err := tx.Bucket(containerVolumeBucketName).Put(key, size); err != nil {
+				return err
+			}
+
+			return updateVersion(tx, 2)
+		}))
+		require.NoError(t, db.Close())
+
+		require.NoError(t, db.Open(false))
+		require.NoError(t, db.Init())
+		check(t, db)
+		require.NoError(t, db.boltDB.View(func(tx *bbolt.Tx) error {
+			if tx.Bucket(shardInfoBucket).Get(indexedAttributesKey) == nil {
+				return errors.New("indexed attributes are not stored")
+			}
+			return nil
+		}))
+
+		size, err := db.ContainerSize(cnr)
+		require.NoError(t, err)
+		require.EqualValues(t, 30, size)
+		objects, err := db.ContainerObjects(cnr)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, objects)
+		require.NoError(t, db.Close())
+	})
 	t.Run("invalid version", func(t *testing.T) {
 		db := newDB(t)
 		require.NoError(t, db.Open(false))
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	gio "io"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -35,6 +36,9 @@ type boltForest struct {
 var (
 	dataBucket = []byte{0}
 	logBucket  = []byte{1}
+
+	heightKey = []byte{'h'}
+	clockKey  = []byte{'l'}
 )
 
 // ErrDegradedMode is returned when pilorama is in a degraded mode.
@@ -59,7 +63,9 @@ var ErrReadOnlyMode = logicerr.New("pilorama is in a read-only mode")
 // - 'p' + node (id) -> parent (id),
 // - 'm' + node (id) -> serialized meta,
 // - 'c' + parent (id) + child (id) -> 0/1,
-// - 'i' + 0 + attrKey + 0 + attrValue + 0 + parent (id) + node (id) -> 0/1 (1 for automatically created nodes).
+// - 'i' + 0 + attrKey + 0 + attrValue + 0 + parent (id) + node (id) -> 0/1 (1 for automatically created nodes),
+// - 'h' -> log height of the compacted log,
+// - 'l' -> log height of the imported snapshot.
 func NewBoltForest(opts ...Option) ForestStorage {
 	b := boltForest{
 		cfg: cfg{
@@ -164,7 +170,7 @@ func (t *boltForest) TreeMove(d CIDDescriptor, treeID string, m *Move) (*LogMove
 			return err
 		}
 
-		lm.Time = t.getLatestTimestamp(bLog, d.Position, d.Size)
+		lm.Time = t.getLatestTimestamp(bLog, bTree, d.Position, d.Size)
 		if lm.Child == RootID {
 			lm.Child = t.findSpareID(bTree)
 		}
@@ -225,7 +231,7 @@ func (t *boltForest) TreeAddByPath(d CIDDescriptor, treeID string, attr string,
 			return err
 		}
 
-		ts := t.getLatestTimestamp(bLog, d.Position, d.Size)
+		ts := t.getLatestTimestamp(bLog, bTree, d.Position, d.Size)
 		lm = make([]LogMove, len(path)-i+1)
 		for j := i; j < len(path); j++ {
 			lm[j-i] = Move{
@@ -261,7 +267,13 @@ func (t *boltForest) TreeAddByPath(d CIDDescriptor, treeID string, attr string,
 
 // getLatestTimestamp returns timestamp for a new operation which is guaranteed to be bigger than
 // all timestamps corresponding to already stored operations.
-func (t *boltForest) getLatestTimestamp(bLog *bbolt.Bucket, pos, size int) uint64 {
+func (t *boltForest) getLatestTimestamp(bLog, bTree *bbolt.Bucket, pos, size int) uint64 {
+	return nextTimestamp(t.getLogHeight(bLog, bTree), uint64(pos), uint64(size))
+}
+
+// getLogHeight returns timestamp of the last stored operation taking
+// the imported snapshot and the log compaction into account.
+func (t *boltForest) getLogHeight(bLog, bTree *bbolt.Bucket) uint64 {
 	var ts uint64
 
 	c := bLog.Cursor()
@@ -269,7 +281,24 @@ func (t *boltForest) getLatestTimestamp(bLog *bbolt.Bucket, pos, size int) uint6
 	if len(key) != 0 {
 		ts = binary.BigEndian.Uint64(key)
 	}
-	return nextTimestamp(ts, uint64(pos), uint64(size))
+	if base := t.getLogBase(bTree); base > ts {
+		ts = base
+	}
+	return ts
+}
+
+// getLogBase returns the maximum of the imported snapshot clock and the
+// compaction height, the log has no operations below it.
+func (t *boltForest) getLogBase(bTree *bbolt.Bucket) uint64 {
+	var base uint64
+	for _, k := range [][]byte{heightKey, clockKey} {
+		if data := bTree.Get(k); len(data) == 8 {
+			if h := binary.LittleEndian.Uint64(data); h > base {
+				base = h
+			}
+		}
+	}
+	return base
 }
 
 // findSpareID returns random unused ID.
@@ -301,24 +330,29 @@ func (t *boltForest) TreeApply(d CIDDescriptor, treeID string, m *Move, backgrou
 		return ErrReadOnlyMode
 	}
 
-	if backgroundSync {
-		var seen bool
-		err := t.db.View(func(tx *bbolt.Tx) error {
-			treeRoot := tx.Bucket(bucketName(d.CID, treeID))
-			if treeRoot == nil {
-				return nil
-			}
+	var skip bool
+	err := t.db.View(func(tx *bbolt.Tx) error {
+		treeRoot := tx.Bucket(bucketName(d.CID, treeID))
+		if treeRoot == nil {
+			return nil
+		}
 
-			b := treeRoot.Bucket(logBucket)
+		// Operations up to the imported snapshot clock or the compaction
+		// height can't be applied in order since the log below is missing.
+		if base := t.getLogBase(treeRoot.Bucket(dataBucket)); base != 0 && m.Time <= base {
+			skip = true
+			return nil
+		}
 
+		if backgroundSync {
 			var logKey [8]byte
 			binary.BigEndian.PutUint64(logKey[:], m.Time)
-			seen = b.Get(logKey[:]) != nil
-			return nil
-		})
-		if err != nil || seen {
-			return err
+			skip = treeRoot.Bucket(logBucket).Get(logKey[:]) != nil
 		}
+		return nil
+	})
+	if err != nil || skip {
+		return err
 	}
 
 	if t.db.MaxBatchSize == 1 {
@@ -806,6 +840,195 @@ func (t *boltForest) TreeDrop(cid cidSDK.ID, treeID string) error {
 	})
 }
 
+// TreeExport implements the pilorama.ForestStorage interface.
+func (t *boltForest) TreeExport(cid cidSDK.ID, treeID string, w gio.Writer) (uint64, error) {
+	t.modeMtx.RLock()
+	defer t.modeMtx.RUnlock()
+
+	if t.mode.NoMetabase() {
+		return 0, ErrDegradedMode
+	}
+
+	var height uint64
+
+	err := t.db.View(func(tx *bbolt.Tx) error {
+		treeRoot := tx.Bucket(bucketName(cid, treeID))
+		if treeRoot == nil {
+			return ErrTreeNotFound
+		}
+
+		b := treeRoot.Bucket(dataBucket)
+		height = t.getLogHeight(treeRoot.Bucket(logBucket), b)
+
+		sw := newSnapshotWriter(w, height)
+		c := b.Cursor()
+		for k, _ := c.Seek([]byte{'s'}); len(k) == 9 && k[0] == 's'; k, _ = c.Next() {
+			parent, ts, rawMeta, _ := t.getState(b, k)
+			err := sw.writeNode(snapshotNode{
+				Child:     binary.LittleEndian.Uint64(k[1:]),
+				Parent:    parent,
+				Timestamp: ts,
+				RawMeta:   rawMeta,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return sw.close()
+	})
+
+	return height, err
+}
+
+// TreeImport implements the pilorama.ForestStorage interface.
+func (t *boltForest) TreeImport(cid cidSDK.ID, treeID string, r gio.Reader) (uint64, error) {
+	t.modeMtx.RLock()
+	defer t.modeMtx.RUnlock()
+
+	if t.mode.NoMetabase() {
+		return 0, ErrDegradedMode
+	} else if t.mode.ReadOnly() {
+		return 0, ErrReadOnlyMode
+	}
+
+	sr, err := newSnapshotReader(r)
+	if err != nil {
+		return 0, err
+	}
+
+	// Batch can execute the function multiple times, so reading from r
+	// is only possible within a regular transaction.
+	fullID := bucketName(cid, treeID)
+	return sr.height, t.db.Update(func(tx *bbolt.Tx) error {
+		err := tx.DeleteBucket(fullID)
+		if err != nil && !errors.Is(err, bbolt.ErrBucketNotFound) {
+			return err
+		}
+
+		_, bTree, err := t.getTreeBuckets(tx, fullID)
+		if err != nil {
+			return err
+		}
+
+		var n snapshotNode
+		var meta Meta
+		key := make([]byte, 17)
+		for {
+			more, err := sr.next(&n)
+			if err != nil {
+				return err
+			} else if !more {
+				break
+			}
+
+			if err := meta.FromBytes(n.RawMeta); err != nil {
+				return fmt.Errorf("%w: invalid meta of node %d: %v", ErrInvalidSnapshot, n.Child, err)
+			}
+			if err := t.addNode(bTree, key, n.Child, n.Parent, n.Timestamp, meta, n.RawMeta); err != nil {
+				return err
+			}
+		}
+
+		// The log of the imported tree is empty, so operations up to the
+		// snapshot height are skipped by TreeApply.
+		height := make([]byte, 8)
+		binary.LittleEndian.PutUint64(height, sr.height)
+		return bTree.Put(clockKey, height)
+	})
+}
+
+// compactBatchSize is a maximum number of log operations removed in a single
+// transaction by TreeCompact.
+const compactBatchSize = 10000
+
+// TreeCompact implements the pilorama.ForestStorage interface.
+func (t *boltForest) TreeCompact(cid cidSDK.ID, treeID string, height uint64) (uint64, error) {
+	t.modeMtx.RLock()
+	defer t.modeMtx.RUnlock()
+
+	if t.mode.NoMetabase() {
+		return 0, ErrDegradedMode
+	} else if t.mode.ReadOnly() {
+		return 0, ErrReadOnlyMode
+	}
+
+	fullID := bucketName(cid, treeID)
+
+	var removed uint64
+	for {
+		var n int
+		err := t.db.Update(func(tx *bbolt.Tx) error {
+			treeRoot := tx.Bucket(fullID)
+			if treeRoot == nil {
+				return ErrTreeNotFound
+			}
+
+			bLog := treeRoot.Bucket(logBucket)
+			bTree := treeRoot.Bucket(dataBucket)
+
+			var last Timestamp
+			var key [9]byte
+			c := bLog.Cursor()
+			for k, _ := c.First(); len(k) == 8 && n < compactBatchSize; k, _ = c.First() {
+				ts := binary.BigEndian.Uint64(k)
+				if ts >= height {
+					break
+				}
+
+				// Old state is needed only to undo the operation.
+				if err := bTree.Delete(oldKey(key[:], ts)); err != nil {
+					return err
+				}
+				if err := bLog.Delete(k); err != nil {
+					return err
+				}
+				last = ts
+				n++
+			}
+
+			if n == 0 {
+				return nil
+			}
+
+			data := bTree.Get(heightKey)
+			if len(data) == 8 && binary.LittleEndian.Uint64(data) >= last {
+				return nil
+			}
+
+			h := make([]byte, 8)
+			binary.LittleEndian.PutUint64(h, last)
+			return bTree.Put(heightKey, h)
+		})
+		removed += uint64(n)
+		if err != nil || n < compactBatchSize {
+			return removed, err
+		}
+	}
+}
+
+// TreeLogSize implements the pilorama.ForestStorage interface.
+func (t *boltForest) TreeLogSize(cid cidSDK.ID, treeID string) (uint64, error) {
+	t.modeMtx.RLock()
+	defer t.modeMtx.RUnlock()
+
+	if t.mode.NoMetabase() {
+		return 0, ErrDegradedMode
+	}
+
+	var size uint64
+	err := t.db.View(func(tx *bbolt.Tx) error {
+		treeRoot := tx.Bucket(bucketName(cid, treeID))
+		if treeRoot == nil {
+			return ErrTreeNotFound
+		}
+
+		size = uint64(treeRoot.Bucket(logBucket).Stats().KeyN)
+		return nil
+	})
+
+	return size, err
+}
+
 func (t *boltForest) getPathPrefix(bTree *bbolt.Bucket, attr string, path []string) (int, Node, error) {
 	c := bTree.Cursor()
 
@@ -1,6 +1,8 @@
 package pilorama
 
 import (
+	"fmt"
+	"io"
 	"sort"
 	"strings"
 
@@ -105,6 +107,11 @@ func (f *memoryForest) TreeApply(d CIDDescriptor, treeID string, op *Move, _ boo
 		f.treeMap[fullID] = s
 	}
 
+	if s.height != 0 && op.Time <= s.height {
+		// no log to apply the operation in order
+		return nil
+	}
+
 	return s.Apply(op)
 }
 
@@ -226,3 +233,98 @@ func (f *memoryForest) TreeExists(cid cidSDK.ID, treeID string) (bool, error) {
 	_, ok := f.treeMap[fullID]
 	return ok, nil
 }
+
+// TreeExport implements the pilorama.ForestStorage interface.
+func (f *memoryForest) TreeExport(cid cidSDK.ID, treeID string, w io.Writer) (uint64, error) {
+	fullID := cid.String() + "/" + treeID
+	s, ok := f.treeMap[fullID]
+	if !ok {
+		return 0, ErrTreeNotFound
+	}
+
+	nodes := make([]Node, 0, len(s.infoMap))
+	for n := range s.infoMap {
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+
+	height := s.logHeight()
+	sw := newSnapshotWriter(w, height)
+	for _, n := range nodes {
+		info := s.infoMap[n]
+		err := sw.writeNode(snapshotNode{
+			Child:     n,
+			Parent:    info.Parent,
+			Timestamp: info.Meta.Time,
+			RawMeta:   info.Meta.Bytes(),
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return height, sw.close()
+}
+
+// TreeImport implements the pilorama.ForestStorage interface.
+func (f *memoryForest) TreeImport(cid cidSDK.ID, treeID string, r io.Reader) (uint64, error) {
+	sr, err := newSnapshotReader(r)
+	if err != nil {
+		return 0, err
+	}
+
+	s := newState()
+	s.height = sr.height
+
+	var n snapshotNode
+	for {
+		more, err := sr.next(&n)
+		if err != nil {
+			return 0, err
+		} else if !more {
+			break
+		}
+
+		var info nodeInfo
+		if err := info.Meta.FromBytes(n.RawMeta); err != nil {
+			return 0, fmt.Errorf("%w: invalid meta of node %d: %v", ErrInvalidSnapshot, n.Child, err)
+		}
+		info.Parent = n.Parent
+		s.infoMap[n.Child] = info
+		s.childMap[n.Parent] = append(s.childMap[n.Parent], n.Child)
+	}
+
+	f.treeMap[cid.String()+"/"+treeID] = s
+	return sr.height, nil
+}
+
+// TreeCompact implements the pilorama.ForestStorage interface.
+func (f *memoryForest) TreeCompact(cid cidSDK.ID, treeID string, height uint64) (uint64, error) {
+	fullID := cid.String() + "/" + treeID
+	s, ok := f.treeMap[fullID]
+	if !ok {
+		return 0, ErrTreeNotFound
+	}
+
+	n := sort.Search(len(s.operations), func(i int) bool {
+		return s.operations[i].Time >= height
+	})
+	if n == 0 {
+		return 0, nil
+	}
+
+	if last := s.operations[n-1].Time; last > s.height {
+		s.height = last
+	}
+	s.operations = append([]move(nil), s.operations[n:]...)
+	return uint64(n), nil
+}
+
+// TreeLogSize implements the pilorama.ForestStorage interface.
+func (f *memoryForest) TreeLogSize(cid cidSDK.ID, treeID string) (uint64, error) {
+	fullID := cid.String() + "/" + treeID
+	s, ok := f.treeMap[fullID]
+	if !ok {
+		return 0, ErrTreeNotFound
+	}
+	return uint64(len(s.operations)), nil
+}
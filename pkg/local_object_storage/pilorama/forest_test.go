@@ -1,6 +1,7 @@
 package pilorama
 
 import (
+	"bytes"
 	"fmt"
 	"math/rand"
 	"os"
@@ -1035,3 +1036,176 @@ func testTreeGetTrees(t *testing.T, s Forest) {
 		require.ElementsMatch(t, treeIDs[cid], trees)
 	}
 }
+
+func TestForest_TreeExportImport(t *testing.T) {
+	for i := range providers {
+		for j := range providers {
+			t.Run(providers[i].name+"/"+providers[j].name, func(t *testing.T) {
+				testForestTreeExportImport(t, providers[i].construct, providers[j].construct)
+			})
+		}
+	}
+}
+
+func testForestTreeExportImport(t *testing.T, expConstructor, impConstructor func(t testing.TB, _ ...Option) Forest) {
+	const (
+		nodeCount = 5
+		opCount   = 20
+	)
+
+	ops := prepareRandomTree(nodeCount, opCount)
+
+	cid := cidtest.ID()
+	d := CIDDescriptor{cid, 0, 1}
+	treeID := "version"
+
+	expected := expConstructor(t).(ForestStorage)
+	for i := range ops {
+		require.NoError(t, expected.TreeApply(d, treeID, &ops[i], false))
+	}
+
+	var buf bytes.Buffer
+	t.Run("missing tree", func(t *testing.T) {
+		_, err := expected.TreeExport(cid, "missing", &buf)
+		require.ErrorIs(t, err, ErrTreeNotFound)
+	})
+
+	buf.Reset()
+	height, err := expected.TreeExport(cid, treeID, &buf)
+	require.NoError(t, err)
+	require.Equal(t, ops[len(ops)-1].Time, height)
+
+	actual := impConstructor(t).(ForestStorage)
+	t.Run("invalid snapshot", func(t *testing.T) {
+		_, err := actual.TreeImport(cid, treeID, bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+		require.ErrorIs(t, err, ErrInvalidSnapshot)
+	})
+
+	// Existing tree is replaced.
+	_, err = actual.TreeMove(d, treeID, &Move{Parent: RootID, Child: 100500})
+	require.NoError(t, err)
+
+	h, err := actual.TreeImport(cid, treeID, &buf)
+	require.NoError(t, err)
+	require.Equal(t, height, h)
+
+	for i := uint64(0); i < nodeCount+12; i++ {
+		expectedMeta, expectedParent, err := expected.TreeGetMeta(cid, treeID, i)
+		require.NoError(t, err)
+		actualMeta, actualParent, err := actual.TreeGetMeta(cid, treeID, i)
+		require.NoError(t, err)
+		require.Equal(t, expectedParent, actualParent, "node id: %d", i)
+		require.Equal(t, expectedMeta, actualMeta, "node id: %d", i)
+
+		expectedChildren, err := expected.TreeGetChildren(cid, treeID, i)
+		require.NoError(t, err)
+		actualChildren, err := actual.TreeGetChildren(cid, treeID, i)
+		require.NoError(t, err)
+		require.ElementsMatch(t, expectedChildren, actualChildren, "node id: %d", i)
+	}
+
+	m, _, err := actual.TreeGetMeta(cid, treeID, 100500)
+	require.NoError(t, err)
+	require.Equal(t, Meta{}, m)
+	children, err := actual.TreeGetChildren(cid, treeID, 100500)
+	require.NoError(t, err)
+	require.Empty(t, children)
+
+	t.Run("new operations", func(t *testing.T) {
+		lm, err := actual.TreeMove(d, treeID, &Move{Parent: RootID, Child: 1})
+		require.NoError(t, err)
+		require.Greater(t, lm.Time, height)
+
+		op, err := actual.TreeGetOpLog(cid, treeID, 0)
+		require.NoError(t, err)
+		require.Equal(t, lm.Time, op.Time)
+		require.Equal(t, lm.Child, op.Child)
+	})
+
+	t.Run("operations below the snapshot height", func(t *testing.T) {
+		// there is no log to apply them in order
+		for _, background := range []bool{true, false} {
+			m := &Move{Parent: RootID, Child: 100501, Meta: Meta{Time: nodeCount + 2}}
+			require.Less(t, m.Time, height)
+			require.NoError(t, actual.TreeApply(d, treeID, m, background))
+
+			children, err := actual.TreeGetChildren(cid, treeID, RootID)
+			require.NoError(t, err)
+			require.NotContains(t, children, m.Child)
+		}
+	})
+}
+
+func TestForest_TreeCompact(t *testing.T) {
+	for i := range providers {
+		t.Run(providers[i].name, func(t *testing.T) {
+			testForestTreeCompact(t, providers[i].construct)
+		})
+	}
+}
+
+func testForestTreeCompact(t *testing.T, constructor func(t testing.TB, _ ...Option) Forest) {
+	const (
+		nodeCount = 5
+		opCount   = 20
+		delayed   = 20
+	)
+
+	ops := prepareRandomTree(nodeCount, opCount)
+
+	cid := cidtest.ID()
+	d := CIDDescriptor{cid, 0, 1}
+	treeID := "version"
+
+	expected := constructor(t).(ForestStorage)
+	actual := constructor(t).(ForestStorage)
+	for i := range ops {
+		if i != delayed {
+			require.NoError(t, expected.TreeApply(d, treeID, &ops[i], false))
+			require.NoError(t, actual.TreeApply(d, treeID, &ops[i], false))
+		}
+	}
+
+	_, err := actual.TreeCompact(cid, "missing", 1)
+	require.ErrorIs(t, err, ErrTreeNotFound)
+
+	height := ops[15].Time
+	removed, err := actual.TreeCompact(cid, treeID, height)
+	require.NoError(t, err)
+	require.EqualValues(t, 15, removed)
+
+	size, err := actual.TreeLogSize(cid, treeID)
+	require.NoError(t, err)
+	require.EqualValues(t, len(ops)-1-15, size)
+
+	lm, err := actual.TreeGetOpLog(cid, treeID, 0)
+	require.NoError(t, err)
+	require.Equal(t, height, lm.Time)
+
+	// Operations above the compaction height can still arrive out of order.
+	require.NoError(t, expected.TreeApply(d, treeID, &ops[delayed], false))
+	require.NoError(t, actual.TreeApply(d, treeID, &ops[delayed], false))
+
+	for i := uint64(0); i < nodeCount+12; i++ {
+		expectedMeta, expectedParent, err := expected.TreeGetMeta(cid, treeID, i)
+		require.NoError(t, err)
+		actualMeta, actualParent, err := actual.TreeGetMeta(cid, treeID, i)
+		require.NoError(t, err)
+		require.Equal(t, expectedParent, actualParent, "node id: %d", i)
+		require.Equal(t, expectedMeta, actualMeta, "node id: %d", i)
+	}
+
+	t.Run("new operations", func(t *testing.T) {
+		removed, err := actual.TreeCompact(cid, treeID, ops[len(ops)-1].Time+1)
+		require.NoError(t, err)
+		require.EqualValues(t, len(ops)-15, removed)
+
+		size, err := actual.TreeLogSize(cid, treeID)
+		require.NoError(t, err)
+		require.Zero(t, size)
+
+		lm, err := actual.TreeMove(d, treeID, &Move{Parent: RootID, Child: 1})
+		require.NoError(t, err)
+		require.Greater(t, lm.Time, ops[len(ops)-1].Time)
+	})
+}
@@ -15,6 +15,8 @@ type move struct {
 // state represents state being replicated.
 type state struct {
 	operations []move
+	// height is a log height of the imported snapshot or the compacted log.
+	height Timestamp
 	tree
 }
 
@@ -127,10 +129,15 @@ func (s *state) removeChild(child, parent Node) {
 }
 
 func (s *state) timestamp(pos, size int) Timestamp {
-	if len(s.operations) == 0 {
-		return nextTimestamp(0, uint64(pos), uint64(size))
+	return nextTimestamp(s.logHeight(), uint64(pos), uint64(size))
+}
+
+// logHeight returns timestamp of the last applied operation.
+func (s *state) logHeight() Timestamp {
+	if len(s.operations) == 0 || s.operations[len(s.operations)-1].Time < s.height {
+		return s.height
 	}
-	return nextTimestamp(s.operations[len(s.operations)-1].Time, uint64(pos), uint64(size))
+	return s.operations[len(s.operations)-1].Time
 }
 
 func (s *state) findSpareID() Node {
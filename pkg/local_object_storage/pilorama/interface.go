@@ -1,6 +1,8 @@
 package pilorama
 
 import (
+	"io"
+
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
 	cidSDK "github.com/epicchainlabs/epicchain-sdk-go/container/id"
@@ -54,6 +56,27 @@ type ForestStorage interface {
 	Close() error
 	SetMode(m mode.Mode) error
 	Forest
+	// TreeExport writes a snapshot of the current tree state to w. The snapshot
+	// contains all tree nodes and the log height: operations below the height
+	// are already reflected in the snapshot. Returns the log height.
+	// Should return ErrTreeNotFound if the tree is not found.
+	TreeExport(cid cidSDK.ID, treeID string, w io.Writer) (uint64, error)
+	// TreeImport replaces the tree with the snapshot read from r. The operation
+	// log of the imported tree is empty, new operations are applied on top of
+	// the snapshot state and timestamped above its log height. Operations up to
+	// the height can't be applied in order and are skipped by TreeApply like
+	// the ones below the compaction height. Returns the log height of the
+	// snapshot.
+	TreeImport(cid cidSDK.ID, treeID string, r io.Reader) (uint64, error)
+	// TreeCompact removes operations below the height from the tree log.
+	// The height must be agreed by all container nodes: operations below it
+	// can't be applied to the tree after the compaction. Returns the number
+	// of removed operations.
+	// Should return ErrTreeNotFound if the tree is not found.
+	TreeCompact(cid cidSDK.ID, treeID string, height uint64) (uint64, error)
+	// TreeLogSize returns the number of operations stored in the tree log.
+	// Should return ErrTreeNotFound if the tree is not found.
+	TreeLogSize(cid cidSDK.ID, treeID string) (uint64, error)
 }
 
 const (
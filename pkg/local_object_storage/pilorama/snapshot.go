@@ -0,0 +1,96 @@
+package pilorama
+
+import (
+	"fmt"
+	"io"
+
+	nio "github.com/epicchainlabs/epicchain-go/pkg/io"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
+)
+
+// snapshotVersion is a version of the tree snapshot format.
+//
+// Snapshot layout:
+// - version (1 byte),
+// - log height (8-byte little-endian),
+// - node records, each prefixed with 1,
+// - single 0 byte terminating the snapshot.
+//
+// Node record consists of the node ID, parent ID and the timestamp of the
+// first node appearance (all 8-byte little-endian) followed by the serialized
+// meta (var-bytes).
+const snapshotVersion = 1
+
+// maxSnapshotMetaSize is a maximum size of the node meta in a snapshot.
+const maxSnapshotMetaSize = 64 * 1024 * 1024
+
+// ErrInvalidSnapshot is returned when the tree snapshot can't be decoded.
+var ErrInvalidSnapshot = logicerr.New("invalid tree snapshot")
+
+// snapshotNode is a single node record of the tree snapshot.
+type snapshotNode struct {
+	Child     Node
+	Parent    Node
+	Timestamp Timestamp
+	RawMeta   []byte
+}
+
+// snapshotWriter encodes tree snapshot to the underlying writer.
+type snapshotWriter struct {
+	w *nio.BinWriter
+}
+
+func newSnapshotWriter(w io.Writer, height Timestamp) *snapshotWriter {
+	bw := nio.NewBinWriterFromIO(w)
+	bw.WriteB(snapshotVersion)
+	bw.WriteU64LE(height)
+	return &snapshotWriter{w: bw}
+}
+
+func (s *snapshotWriter) writeNode(n snapshotNode) error {
+	s.w.WriteBool(true)
+	s.w.WriteU64LE(n.Child)
+	s.w.WriteU64LE(n.Parent)
+	s.w.WriteU64LE(n.Timestamp)
+	s.w.WriteVarBytes(n.RawMeta)
+	return s.w.Err
+}
+
+func (s *snapshotWriter) close() error {
+	s.w.WriteBool(false)
+	return s.w.Err
+}
+
+// snapshotReader decodes tree snapshot from the underlying reader.
+type snapshotReader struct {
+	r      *nio.BinReader
+	height Timestamp
+}
+
+func newSnapshotReader(r io.Reader) (*snapshotReader, error) {
+	br := nio.NewBinReaderFromIO(r)
+	version := br.ReadB()
+	height := br.ReadU64LE()
+	if br.Err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, br.Err)
+	}
+	if version != snapshotVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, version)
+	}
+	return &snapshotReader{r: br, height: height}, nil
+}
+
+// next reads the next node record. Returns false if there are no more nodes.
+func (s *snapshotReader) next(n *snapshotNode) (bool, error) {
+	more := s.r.ReadBool()
+	if s.r.Err == nil && more {
+		n.Child = s.r.ReadU64LE()
+		n.Parent = s.r.ReadU64LE()
+		n.Timestamp = s.r.ReadU64LE()
+		n.RawMeta = s.r.ReadVarBytes(maxSnapshotMetaSize)
+	}
+	if s.r.Err != nil {
+		return false, fmt.Errorf("%w: %v", ErrInvalidSnapshot, s.r.Err)
+	}
+	return more, nil
+}
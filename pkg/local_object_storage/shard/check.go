@@ -0,0 +1,615 @@
+package shard
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"go.uber.org/zap"
+)
+
+// Problem is a class of inconsistencies between the metabase and the
+// storages of the shard.
+type Problem uint8
+
+const (
+	_ Problem = iota
+
+	// ProblemOrphanBlob is an object stored in the blobstor without the
+	// metabase record.
+	ProblemOrphanBlob
+
+	// ProblemMissingPayload is an object with the metabase record stored
+	// neither in the blobstor nor in the write-cache.
+	ProblemMissingPayload
+
+	// ProblemWrongStorageID is an object with the metabase storage ID
+	// pointing to the blobstor sub-storage that does not have it.
+	ProblemWrongStorageID
+
+	// ProblemContainerVolume is a container with the size estimations that
+	// do not match its objects.
+	ProblemContainerVolume
+
+	// ProblemObjectCounters is the object counters that do not match the
+	// metabase objects.
+	ProblemObjectCounters
+)
+
+var problemNames = map[Problem]string{
+	ProblemOrphanBlob:      "orphan_blob",
+	ProblemMissingPayload:  "missing_payload",
+	ProblemWrongStorageID:  "wrong_storage_id",
+	ProblemContainerVolume: "container_volume",
+	ProblemObjectCounters:  "object_counters",
+}
+
+// Problems returns all the problem classes Check looks for.
+func Problems() []Problem {
+	return []Problem{
+		ProblemOrphanBlob,
+		ProblemMissingPayload,
+		ProblemWrongStorageID,
+		ProblemContainerVolume,
+		ProblemObjectCounters,
+	}
+}
+
+// String returns the problem class name.
+func (p Problem) String() string {
+	if name, ok := problemNames[p]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// ParseProblem parses the problem class from its name.
+func ParseProblem(s string) (Problem, error) {
+	for p, name := range problemNames {
+		if name == s {
+			return p, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown problem class %q", s)
+}
+
+// Issue describes a single inconsistency found by Check.
+type Issue struct {
+	Problem Problem
+
+	// Container is set for all the problems except the object counters.
+	Container cid.ID
+	// Object is set for the object-level problems only.
+	Object oid.ID
+
+	// Stored is the metabase value and Actual is the one it should be
+	// according to the shard content. Storage IDs are hex-encoded, container
+	// volume and counters are key=value lists. Empty for the orphan blobs and
+	// the missing payloads.
+	Stored, Actual string
+
+	// Repaired is true if the issue was fixed.
+	Repaired bool
+}
+
+// ErrCheckInterrupted is returned by Check stopped with the channel passed
+// to SetStopChannel.
+var ErrCheckInterrupted = logicerr.New("consistency check is interrupted")
+
+// CheckPrm groups the parameters of Check operation.
+type CheckPrm struct {
+	repair  map[Problem]struct{}
+	stopCh  <-chan struct{}
+	handler func(Issue)
+}
+
+// CheckRes groups the resulting values of Check operation.
+type CheckRes struct {
+	issues []Issue
+}
+
+// SetRepair is a Check option to fix the problems of the given classes.
+// Requires the shard to be writable.
+func (p *CheckPrm) SetRepair(ps ...Problem) {
+	p.repair = make(map[Problem]struct{}, len(ps))
+	for i := range ps {
+		p.repair[ps[i]] = struct{}{}
+	}
+}
+
+// SetStopChannel is a Check option to interrupt the check when the channel
+// is closed.
+func (p *CheckPrm) SetStopChannel(ch <-chan struct{}) {
+	p.stopCh = ch
+}
+
+// SetIssueHandler is a Check option to pass the found issues to f as soon as
+// they are found instead of collecting them in CheckRes.
+func (p *CheckPrm) SetIssueHandler(f func(Issue)) {
+	p.handler = f
+}
+
+// Issues returns the inconsistencies found by Check. Empty if the issue
+// handler is set.
+func (r CheckRes) Issues() []Issue {
+	return r.issues
+}
+
+// Check compares the metabase with the blobstor and the write-cache content
+// and reports the inconsistencies found:
+//   - orphan blobs: the objects with no metabase records are put back to the
+//     metabase or dropped from the blobstor if they are already removed;
+//   - missing payloads: the metabase records are deleted;
+//   - wrong storage IDs: the metabase storage IDs are updated;
+//   - container volume and object counters: the metabase values are
+//     recalculated from the object indexes.
+//
+// The problems are fixed only for the classes passed to SetRepair, others
+// are just reported. Objects being written or flushed concurrently may be
+// reported falsely, so the shard is expected to be not in use for repairing.
+//
+// The shard mode lock is taken for every checked object or batch of
+// objects, not for the whole check, so the shard mode can be changed while
+// the check is in progress. The check is aborted with ErrDegradedMode if the
+// shard loses its metabase and with ErrReadOnlyMode if the repair is
+// requested for the read-only shard. Returns ErrCheckInterrupted if the
+// channel passed to SetStopChannel is closed.
+func (s *Shard) Check(prm CheckPrm) (CheckRes, error) {
+	c := checker{s: s, repair: prm.repair, stopCh: prm.stopCh, handler: prm.handler}
+
+	err := c.lock()
+	if err != nil {
+		return CheckRes{}, err
+	}
+	s.m.RUnlock()
+
+	err = c.checkBlobs()
+	if err != nil {
+		return CheckRes{}, err
+	}
+
+	err = c.checkMetabase()
+	if err != nil {
+		return CheckRes{}, err
+	}
+
+	err = c.checkCounters()
+	if err != nil {
+		return CheckRes{}, err
+	}
+
+	return CheckRes{issues: c.issues}, nil
+}
+
+type checker struct {
+	s       *Shard
+	repair  map[Problem]struct{}
+	stopCh  <-chan struct{}
+	handler func(Issue)
+	issues  []Issue
+}
+
+// errCheckModeChange is returned when the shard mode is being changed while
+// the blobstor is iterated over.
+var errCheckModeChange = logicerr.New("consistency check is aborted by the shard mode change")
+
+// lock takes the shard mode lock for a single step of the check and makes
+// sure the shard mode still allows the check. The caller must release the
+// lock if no error is returned.
+func (c *checker) lock() error {
+	select {
+	case <-c.stopCh:
+		return ErrCheckInterrupted
+	default:
+	}
+
+	c.s.m.RLock()
+
+	err := c.checkMode()
+	if err != nil {
+		c.s.m.RUnlock()
+	}
+
+	return err
+}
+
+// tryLock is lock for the steps made inside the blobstor iteration. The
+// iteration blocks the blobstor mode changes, so the shard mode lock is not
+// waited for there: it fails with errCheckModeChange if the shard mode is
+// being changed.
+func (c *checker) tryLock() error {
+	select {
+	case <-c.stopCh:
+		return ErrCheckInterrupted
+	default:
+	}
+
+	if !c.s.m.TryRLock() {
+		return errCheckModeChange
+	}
+
+	err := c.checkMode()
+	if err != nil {
+		c.s.m.RUnlock()
+	}
+
+	return err
+}
+
+func (c *checker) checkMode() error {
+	if c.s.info.Mode.NoMetabase() {
+		return ErrDegradedMode
+	} else if len(c.repair) != 0 && c.s.info.Mode.ReadOnly() {
+		return ErrReadOnlyMode
+	}
+
+	return nil
+}
+
+// lockRepair is lock for the step changing the shard content, it waits for
+// the shard I/O limits first.
+func (c *checker) lockRepair(n uint64) error {
+	err := c.s.throttle(throttle.Background, throttle.Write, n, 0)
+	if err != nil {
+		return err
+	}
+
+	return c.lock()
+}
+
+func (c *checker) needRepair(p Problem) bool {
+	_, ok := c.repair[p]
+	return ok
+}
+
+func (c *checker) report(p Problem, addr oid.Address, stored, actual string, repaired bool) {
+	c.add(Issue{
+		Problem:   p,
+		Container: addr.Container(),
+		Object:    addr.Object(),
+		Stored:    stored,
+		Actual:    actual,
+		Repaired:  repaired,
+	})
+}
+
+func (c *checker) add(i Issue) {
+	if c.handler != nil {
+		c.handler(i)
+		return
+	}
+
+	c.issues = append(c.issues, i)
+}
+
+// blobRecord is a blobstor object with inconsistent metabase record.
+type blobRecord struct {
+	addr      oid.Address
+	storageID []byte
+	removed   bool
+}
+
+// checkBlobs looks for the blobstor objects with missing or inconsistent
+// metabase records.
+func (c *checker) checkBlobs() error {
+	var (
+		orphans, wrongIDs []blobRecord
+		// iterErr is the first handler error, the blobstor ignores the
+		// handler errors with IgnoreErrors and goes on to the next
+		// sub-storage
+		iterErr error
+	)
+
+	var iterPrm common.IteratePrm
+	iterPrm.IgnoreErrors = true
+	iterPrm.ErrorHandler = func(addr oid.Address, err error) error {
+		c.s.log.Warn("error occurred during the consistency check iteration",
+			zap.Stringer("address", addr),
+			zap.String("err", err.Error()))
+		return nil
+	}
+	iterPrm.Handler = func(elem common.IterationElement) error {
+		if iterErr != nil {
+			return iterErr
+		}
+
+		iterErr = c.tryLock()
+		if iterErr != nil {
+			return iterErr
+		}
+		defer c.s.m.RUnlock()
+
+		rec, err := c.s.metaBase.ObjectRecord(elem.Address)
+		if err != nil {
+			return fmt.Errorf("could not get metabase record of %s: %w", elem.Address, err)
+		}
+
+		if !rec.Stored {
+			orphans = append(orphans, blobRecord{addr: elem.Address, storageID: elem.StorageID, removed: rec.Removed})
+			return nil
+		}
+
+		// nil storage ID means the object is looked for in all the
+		// sub-storages, e.g. it was put to the write-cache
+		if rec.StorageID == nil || bytes.Equal(rec.StorageID, elem.StorageID) {
+			return nil
+		}
+
+		// the object may be stored in several sub-storages
+		res, err := c.s.blobStor.Exists(common.ExistsPrm{Address: elem.Address, StorageID: rec.StorageID})
+		if err == nil && res.Exists {
+			return nil
+		}
+
+		wrongIDs = append(wrongIDs, blobRecord{addr: elem.Address, storageID: elem.StorageID})
+		return nil
+	}
+
+	_, err := c.s.blobStor.Iterate(iterPrm)
+	if iterErr != nil {
+		return iterErr
+	} else if err != nil {
+		return fmt.Errorf("could not iterate over blobstor: %w", err)
+	}
+
+	repair := c.needRepair(ProblemOrphanBlob)
+	for _, orphan := range orphans {
+		var err error
+		if repair {
+			err = c.lockRepair(1)
+			if err != nil {
+				return err
+			}
+
+			err = c.repairOrphan(orphan)
+			c.s.m.RUnlock()
+			if err != nil {
+				c.s.log.Warn("could not repair orphan blob",
+					zap.Stringer("address", orphan.addr),
+					zap.Error(err))
+			}
+		}
+
+		c.report(ProblemOrphanBlob, orphan.addr, "", "", repair && err == nil)
+	}
+
+	repair = c.needRepair(ProblemWrongStorageID)
+	for _, wrong := range wrongIDs {
+		err := c.lock()
+		if err != nil {
+			return err
+		}
+
+		rec, err := c.s.metaBase.ObjectRecord(wrong.addr)
+		if err != nil {
+			c.s.m.RUnlock()
+			return fmt.Errorf("could not get metabase record of %s: %w", wrong.addr, err)
+		}
+
+		if repair {
+			var prm meta.UpdateStorageIDPrm
+			prm.SetAddress(wrong.addr)
+			prm.SetStorageID(wrong.storageID)
+
+			_, err = c.s.metaBase.UpdateStorageID(prm)
+			if err != nil {
+				c.s.log.Warn("could not repair storage ID",
+					zap.Stringer("address", wrong.addr),
+					zap.Error(err))
+			}
+		}
+		c.s.m.RUnlock()
+
+		c.report(ProblemWrongStorageID, wrong.addr,
+			hex.EncodeToString(rec.StorageID), hex.EncodeToString(wrong.storageID), repair && err == nil)
+	}
+
+	return nil
+}
+
+// repairOrphan restores the metabase record of the orphan blob or drops the
+// blob if the object has already been removed.
+func (c *checker) repairOrphan(orphan blobRecord) error {
+	if orphan.removed {
+		_, err := c.s.blobStor.Delete(common.DeletePrm{Address: orphan.addr, StorageID: orphan.storageID})
+		return err
+	}
+
+	res, err := c.s.blobStor.Get(common.GetPrm{Address: orphan.addr, StorageID: orphan.storageID})
+	if err != nil {
+		return fmt.Errorf("could not read object: %w", err)
+	}
+
+	return c.s.restoreMetaRecord(res.Object, orphan.storageID)
+}
+
+// checkMetabase looks for the metabase records of the objects stored neither
+// in the blobstor nor in the write-cache.
+func (c *checker) checkMetabase() error {
+	const batchSize = 1000
+
+	var missing []oid.Address
+
+	var listPrm meta.ListPrm
+	listPrm.SetCount(batchSize)
+
+	for {
+		cursor, err := c.checkMetabaseBatch(listPrm, &missing)
+		if err != nil {
+			if errors.Is(err, meta.ErrEndOfListing) {
+				break
+			}
+
+			return err
+		}
+
+		listPrm.SetCursor(cursor)
+	}
+
+	repair := c.needRepair(ProblemMissingPayload)
+	for _, addr := range missing {
+		var err error
+		if repair {
+			err = c.lockRepair(1)
+			if err != nil {
+				return err
+			}
+
+			var prm DeletePrm
+			prm.SetAddresses(addr)
+
+			_, err = c.s.delete(prm)
+			c.s.m.RUnlock()
+			if err != nil {
+				c.s.log.Warn("could not delete metabase record of missing object",
+					zap.Stringer("address", addr),
+					zap.Error(err))
+			}
+		}
+
+		c.report(ProblemMissingPayload, addr, "", "", repair && err == nil)
+	}
+
+	return nil
+}
+
+// checkMetabaseBatch checks a single batch of the metabase objects under the
+// shard mode lock and returns the cursor to the next one.
+func (c *checker) checkMetabaseBatch(prm meta.ListPrm, missing *[]oid.Address) (*meta.Cursor, error) {
+	err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer c.s.m.RUnlock()
+
+	res, err := c.s.metaBase.ListWithCursor(prm)
+	if err != nil {
+		if errors.Is(err, meta.ErrEndOfListing) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("could not list metabase objects: %w", err)
+	}
+
+	for _, obj := range res.AddressList() {
+		ok, err := c.s.hasPayload(obj.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			*missing = append(*missing, obj.Address)
+		}
+	}
+
+	return res.Cursor(), nil
+}
+
+// hasPayload checks whether the object is stored in the write-cache or in
+// the blobstor. The write-cache is checked first, so the objects being
+// flushed are not missed.
+func (s *Shard) hasPayload(addr oid.Address) (bool, error) {
+	if s.hasWriteCache() {
+		_, err := s.writeCache.Head(addr)
+		if err == nil {
+			return true, nil
+		} else if !IsErrNotFound(err) {
+			return false, fmt.Errorf("could not check %s in write-cache: %w", addr, err)
+		}
+	}
+
+	res, err := s.blobStor.Exists(common.ExistsPrm{Address: addr})
+	if err != nil {
+		return false, fmt.Errorf("could not check %s in blobstor: %w", addr, err)
+	}
+
+	return res.Exists, nil
+}
+
+// checkCounters compares the metabase counters with the actual ones.
+func (c *checker) checkCounters() error {
+	issues, err := c.checkCountersLocked()
+	if err != nil {
+		return err
+	}
+
+	// the issues are passed to the handler outside the mode lock
+	for i := range issues {
+		c.add(issues[i])
+	}
+
+	return nil
+}
+
+func (c *checker) checkCountersLocked() ([]Issue, error) {
+	err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer c.s.m.RUnlock()
+
+	res, err := c.s.metaBase.CheckCounters()
+	if err != nil {
+		return nil, fmt.Errorf("could not check metabase counters: %w", err)
+	}
+
+	var issues []Issue
+
+	drifts := res.ContainerVolumeDrifts()
+	if len(drifts) != 0 {
+		repair := c.needRepair(ProblemContainerVolume)
+		if repair {
+			err = c.s.metaBase.SyncContainerVolumes()
+			if err != nil {
+				c.s.log.Warn("could not repair container volumes", zap.Error(err))
+			}
+		}
+
+		for _, drift := range drifts {
+			issues = append(issues, Issue{
+				Problem:   ProblemContainerVolume,
+				Container: drift.Container,
+				Stored:    formatContainerVolume(drift.Stored),
+				Actual:    formatContainerVolume(drift.Actual),
+				Repaired:  repair && err == nil,
+			})
+		}
+	}
+
+	stored, actual := res.StoredCounters(), res.ActualCounters()
+	if stored != actual {
+		repair := c.needRepair(ProblemObjectCounters)
+		if repair {
+			err = c.s.metaBase.SyncCounters()
+			if err != nil {
+				c.s.log.Warn("could not repair object counters", zap.Error(err))
+			}
+		}
+
+		issues = append(issues, Issue{
+			Problem:  ProblemObjectCounters,
+			Stored:   formatObjectCounters(stored),
+			Actual:   formatObjectCounters(actual),
+			Repaired: repair && err == nil,
+		})
+	}
+
+	return issues, nil
+}
+
+func formatContainerVolume(v meta.ContainerVolume) string {
+	return fmt.Sprintf("size=%d,objects=%d", v.Size, v.Objects)
+}
+
+func formatObjectCounters(c meta.ObjectCounters) string {
+	return fmt.Sprintf("phy=%d,logic=%d", c.Phy(), c.Logic())
+}
@@ -0,0 +1,185 @@
+package shard
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/fstree"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/peapod"
+	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/pilorama"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	objecttest "github.com/epicchainlabs/epicchain-sdk-go/object/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestShard_Check(t *testing.T) {
+	dir := t.TempDir()
+
+	sh := New(
+		WithLogger(zaptest.NewLogger(t)),
+		WithBlobStorOptions(blobstor.WithStorages([]blobstor.SubStorage{
+			{
+				Storage: peapod.New(filepath.Join(dir, "peapod.db"), 0o600, 10*time.Millisecond),
+				Policy: func(_ *objectSDK.Object, data []byte) bool {
+					return len(data) <= 1<<20
+				},
+			},
+			{
+				Storage: fstree.New(fstree.WithPath(filepath.Join(dir, "blob"))),
+			},
+		})),
+		WithPiloramaOptions(pilorama.WithPath(filepath.Join(dir, "pilorama"))),
+		WithMetaBaseOptions(meta.WithPath(filepath.Join(dir, "meta")), meta.WithEpochState(epochState{})))
+	require.NoError(t, sh.Open())
+	require.NoError(t, sh.Init())
+	t.Cleanup(func() { require.NoError(t, sh.Close()) })
+
+	newObject := func() *objectSDK.Object {
+		obj := objecttest.Object(t)
+		obj.SetType(objectSDK.TypeRegular)
+		obj.SetPayload([]byte{0, 1, 2, 3, 4, 5})
+		return &obj
+	}
+	put := func() oid.Address {
+		obj := newObject()
+
+		var putPrm PutPrm
+		putPrm.SetObject(obj)
+		_, err := sh.Put(putPrm)
+		require.NoError(t, err)
+
+		return object.AddressOf(obj)
+	}
+
+	_ = put()
+
+	missing := put()
+	_, err := sh.blobStor.Delete(common.DeletePrm{Address: missing})
+	require.NoError(t, err)
+
+	wrongID := put()
+	var updPrm meta.UpdateStorageIDPrm
+	updPrm.SetAddress(wrongID)
+	updPrm.SetStorageID([]byte{})
+	_, err = sh.metaBase.UpdateStorageID(updPrm)
+	require.NoError(t, err)
+
+	orphanObj := newObject()
+	_, err = sh.blobStor.Put(common.PutPrm{Object: orphanObj})
+	require.NoError(t, err)
+	orphan := object.AddressOf(orphanObj)
+
+	issueOf := func(p Problem, addr oid.Address, repaired bool) Issue {
+		return Issue{Problem: p, Container: addr.Container(), Object: addr.Object(), Repaired: repaired}
+	}
+	wrongIDIssue := issueOf(ProblemWrongStorageID, wrongID, false)
+	wrongIDIssue.Actual = hex.EncodeToString([]byte("peapod"))
+
+	res, err := sh.Check(CheckPrm{})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Issue{
+		issueOf(ProblemOrphanBlob, orphan, false),
+		issueOf(ProblemMissingPayload, missing, false),
+		wrongIDIssue,
+	}, res.Issues())
+
+	t.Run("issue handler", func(t *testing.T) {
+		var issues []Issue
+
+		var prm CheckPrm
+		prm.SetIssueHandler(func(i Issue) { issues = append(issues, i) })
+		res, err := sh.Check(prm)
+		require.NoError(t, err)
+		require.Empty(t, res.Issues())
+		require.ElementsMatch(t, []Issue{
+			issueOf(ProblemOrphanBlob, orphan, false),
+			issueOf(ProblemMissingPayload, missing, false),
+			wrongIDIssue,
+		}, issues)
+	})
+
+	t.Run("interrupted", func(t *testing.T) {
+		stopCh := make(chan struct{})
+		close(stopCh)
+
+		var prm CheckPrm
+		prm.SetStopChannel(stopCh)
+		_, err := sh.Check(prm)
+		require.ErrorIs(t, err, ErrCheckInterrupted)
+	})
+
+	t.Run("mode change", func(t *testing.T) {
+		// the check does not hold the mode lock between the steps
+		var prm CheckPrm
+		prm.SetIssueHandler(func(Issue) {
+			require.NoError(t, sh.SetMode(mode.ReadOnly))
+		})
+		t.Cleanup(func() { require.NoError(t, sh.SetMode(mode.ReadWrite)) })
+
+		_, err := sh.Check(prm)
+		require.NoError(t, err)
+		require.Equal(t, mode.ReadOnly, sh.GetMode())
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		require.NoError(t, sh.SetMode(mode.ReadOnly))
+		t.Cleanup(func() { require.NoError(t, sh.SetMode(mode.ReadWrite)) })
+
+		var prm CheckPrm
+		prm.SetRepair(ProblemOrphanBlob)
+		_, err := sh.Check(prm)
+		require.ErrorIs(t, err, ErrReadOnlyMode)
+	})
+
+	var prm CheckPrm
+	prm.SetRepair(Problems()...)
+	res, err = sh.Check(prm)
+	require.NoError(t, err)
+
+	wrongIDIssue.Repaired = true
+	require.Subset(t, res.Issues(), []Issue{
+		issueOf(ProblemOrphanBlob, orphan, true),
+		issueOf(ProblemMissingPayload, missing, true),
+		wrongIDIssue,
+	})
+	for _, issue := range res.Issues() {
+		require.True(t, issue.Repaired, issue.Problem)
+	}
+
+	res, err = sh.Check(CheckPrm{})
+	require.NoError(t, err)
+	require.Empty(t, res.Issues())
+
+	for _, addr := range []oid.Address{orphan, wrongID} {
+		var getPrm GetPrm
+		getPrm.SetAddress(addr)
+		_, err = sh.Get(getPrm)
+		require.NoError(t, err)
+	}
+
+	var getPrm GetPrm
+	getPrm.SetAddress(missing)
+	_, err = sh.Get(getPrm)
+	require.ErrorAs(t, err, new(apistatus.ObjectNotFound))
+}
+
+func TestParseProblem(t *testing.T) {
+	for _, p := range Problems() {
+		parsed, err := ParseProblem(p.String())
+		require.NoError(t, err)
+		require.Equal(t, p, parsed)
+	}
+
+	_, err := ParseProblem("unknown")
+	require.Error(t, err)
+}
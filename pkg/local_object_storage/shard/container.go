@@ -12,7 +12,8 @@ type ContainerSizePrm struct {
 }
 
 type ContainerSizeRes struct {
-	size uint64
+	size    uint64
+	objects uint64
 }
 
 func (p *ContainerSizePrm) SetContainerID(cnr cid.ID) {
@@ -23,6 +24,11 @@ func (r ContainerSizeRes) Size() uint64 {
 	return r.size
 }
 
+// Objects returns the number of regular objects of the container.
+func (r ContainerSizeRes) Objects() uint64 {
+	return r.objects
+}
+
 func (s *Shard) ContainerSize(prm ContainerSizePrm) (ContainerSizeRes, error) {
 	s.m.RLock()
 	defer s.m.RUnlock()
@@ -36,8 +42,14 @@ func (s *Shard) ContainerSize(prm ContainerSizePrm) (ContainerSizeRes, error) {
 		return ContainerSizeRes{}, fmt.Errorf("could not get container size: %w", err)
 	}
 
+	objects, err := s.metaBase.ContainerObjects(prm.cnr)
+	if err != nil {
+		return ContainerSizeRes{}, fmt.Errorf("could not get container objects number: %w", err)
+	}
+
 	return ContainerSizeRes{
-		size: size,
+		size:    size,
+		objects: objects,
 	}, nil
 }
 
@@ -1,6 +1,7 @@
 package shard
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -38,6 +39,11 @@ func (s *Shard) handleMetabaseFailure(stage string, err error) error {
 
 // Open opens all Shard's components.
 func (s *Shard) Open() error {
+	if s.ioCtx.Err() != nil {
+		// reopened after Close
+		s.ioCtx, s.ioCancel = context.WithCancel(context.Background())
+	}
+
 	components := []interface{ Open(bool) error }{
 		s.blobStor, s.metaBase,
 	}
@@ -153,6 +159,8 @@ func (s *Shard) Init() error {
 
 	s.gc.init()
 
+	s.startScrubber()
+
 	return nil
 }
 
@@ -172,70 +180,76 @@ func (s *Shard) refillMetabase() error {
 			return nil
 		}
 
-		//nolint: exhaustive
-		switch obj.Type() {
-		case objectSDK.TypeTombstone:
-			tombstone := objectSDK.NewTombstone()
+		return s.restoreMetaRecord(obj, descriptor)
+	})
+	if err != nil {
+		return fmt.Errorf("could not put objects to the meta: %w", err)
+	}
 
-			if err := tombstone.Unmarshal(obj.Payload()); err != nil {
-				return fmt.Errorf("could not unmarshal tombstone content: %w", err)
-			}
+	err = s.metaBase.SyncCounters()
+	if err != nil {
+		return fmt.Errorf("could not sync object counters: %w", err)
+	}
 
-			tombAddr := object.AddressOf(obj)
-			memberIDs := tombstone.Members()
-			tombMembers := make([]oid.Address, 0, len(memberIDs))
+	return nil
+}
 
-			for i := range memberIDs {
-				a := tombAddr
-				a.SetObject(memberIDs[i])
+// restoreMetaRecord puts the object stored in the blobstor to the metabase
+// along with the tombstone and lock relations it carries.
+func (s *Shard) restoreMetaRecord(obj *objectSDK.Object, storageID []byte) error {
+	//nolint: exhaustive
+	switch obj.Type() {
+	case objectSDK.TypeTombstone:
+		tombstone := objectSDK.NewTombstone()
 
-				tombMembers = append(tombMembers, a)
-			}
+		if err := tombstone.Unmarshal(obj.Payload()); err != nil {
+			return fmt.Errorf("could not unmarshal tombstone content: %w", err)
+		}
 
-			var inhumePrm meta.InhumePrm
+		tombAddr := object.AddressOf(obj)
+		memberIDs := tombstone.Members()
+		tombMembers := make([]oid.Address, 0, len(memberIDs))
 
-			inhumePrm.SetTombstoneAddress(tombAddr)
-			inhumePrm.SetAddresses(tombMembers...)
+		for i := range memberIDs {
+			a := tombAddr
+			a.SetObject(memberIDs[i])
 
-			_, err = s.metaBase.Inhume(inhumePrm)
-			if err != nil {
-				return fmt.Errorf("could not inhume objects: %w", err)
-			}
-		case objectSDK.TypeLock:
-			var lock objectSDK.Lock
-			if err := lock.Unmarshal(obj.Payload()); err != nil {
-				return fmt.Errorf("could not unmarshal lock content: %w", err)
-			}
+			tombMembers = append(tombMembers, a)
+		}
 
-			locked := make([]oid.ID, lock.NumberOfMembers())
-			lock.ReadMembers(locked)
+		var inhumePrm meta.InhumePrm
 
-			cnr, _ := obj.ContainerID()
-			id, _ := obj.ID()
-			err = s.metaBase.Lock(cnr, id, locked)
-			if err != nil {
-				return fmt.Errorf("could not lock objects: %w", err)
-			}
+		inhumePrm.SetTombstoneAddress(tombAddr)
+		inhumePrm.SetAddresses(tombMembers...)
+
+		_, err := s.metaBase.Inhume(inhumePrm)
+		if err != nil {
+			return fmt.Errorf("could not inhume objects: %w", err)
+		}
+	case objectSDK.TypeLock:
+		var lock objectSDK.Lock
+		if err := lock.Unmarshal(obj.Payload()); err != nil {
+			return fmt.Errorf("could not unmarshal lock content: %w", err)
 		}
 
-		var mPrm meta.PutPrm
-		mPrm.SetObject(obj)
-		mPrm.SetStorageID(descriptor)
+		locked := make([]oid.ID, lock.NumberOfMembers())
+		lock.ReadMembers(locked)
 
-		_, err := s.metaBase.Put(mPrm)
-		if err != nil && !meta.IsErrRemoved(err) && !errors.Is(err, meta.ErrObjectIsExpired) {
-			return err
+		cnr, _ := obj.ContainerID()
+		id, _ := obj.ID()
+		err := s.metaBase.Lock(cnr, id, locked)
+		if err != nil {
+			return fmt.Errorf("could not lock objects: %w", err)
 		}
-
-		return nil
-	})
-	if err != nil {
-		return fmt.Errorf("could not put objects to the meta: %w", err)
 	}
 
-	err = s.metaBase.SyncCounters()
-	if err != nil {
-		return fmt.Errorf("could not sync object counters: %w", err)
+	var mPrm meta.PutPrm
+	mPrm.SetObject(obj)
+	mPrm.SetStorageID(storageID)
+
+	_, err := s.metaBase.Put(mPrm)
+	if err != nil && !meta.IsErrRemoved(err) && !errors.Is(err, meta.ErrObjectIsExpired) {
+		return err
 	}
 
 	return nil
@@ -243,6 +257,9 @@ func (s *Shard) refillMetabase() error {
 
 // Close releases all Shard's components.
 func (s *Shard) Close() error {
+	s.ioCancel()
+	s.stopScrubber()
+
 	components := []interface{ Close() error }{}
 
 	if s.pilorama != nil {
@@ -284,6 +301,13 @@ func (s *Shard) Reload(opts ...Option) error {
 	s.m.Lock()
 	defer s.m.Unlock()
 
+	s.ioLimiter.SetLimits(c.ioLimits)
+	s.scrub.setConfig(c.scrubCfg)
+
+	if s.hasWriteCache() {
+		s.writeCache.Reload(c.writeCacheOpts...)
+	}
+
 	ok, err := s.metaBase.Reload(c.metaOpts...)
 	if err != nil {
 		if errors.Is(err, meta.ErrDegradedMode) {
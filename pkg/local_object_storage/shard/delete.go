@@ -5,6 +5,7 @@ import (
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
 	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/writecache"
 	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
 	"go.uber.org/zap"
@@ -14,6 +15,7 @@ import (
 type DeletePrm struct {
 	addr              []oid.Address
 	skipNotFoundError bool
+	ioClass           throttle.Class
 }
 
 // DeleteRes groups the resulting values of Delete operation.
@@ -32,9 +34,19 @@ func (p *DeletePrm) SkipNotFoundError() {
 	p.skipNotFoundError = true
 }
 
+// SetIOClass is a Delete option to set the class the removal is throttled as,
+// throttle.Client by default.
+func (p *DeletePrm) SetIOClass(c throttle.Class) {
+	p.ioClass = c
+}
+
 // Delete removes data from the shard's writeCache, metaBase and
 // blobStor.
 func (s *Shard) Delete(prm DeletePrm) (DeleteRes, error) {
+	if err := s.throttle(prm.ioClass, throttle.Write, uint64(len(prm.addr)), 0); err != nil {
+		return DeleteRes{}, err
+	}
+
 	s.m.RLock()
 	defer s.m.RUnlock()
 
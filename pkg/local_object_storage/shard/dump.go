@@ -17,6 +17,8 @@ type DumpPrm struct {
 	path         string
 	stream       io.Writer
 	ignoreErrors bool
+	incremental  bool
+	since        uint64
 }
 
 // WithPath is an Dump option to set the destination path.
@@ -36,9 +38,20 @@ func (p *DumpPrm) WithIgnoreErrors(ignore bool) {
 	p.ignoreErrors = ignore
 }
 
+// WithIncremental is a Dump option to write only the objects added and
+// removed after the marker of the previous incremental dump. The dump is
+// written in the versioned format with a manifest and checksums. Zero marker
+// dumps the whole shard as a base for the following incremental dumps.
+func (p *DumpPrm) WithIncremental(since uint64) {
+	p.incremental = true
+	p.since = since
+}
+
 // DumpRes groups the result fields of Dump operation.
 type DumpRes struct {
-	count int
+	count   int
+	inhumed int
+	marker  uint64
 }
 
 // Count return amount of object written.
@@ -46,6 +59,18 @@ func (r DumpRes) Count() int {
 	return r.count
 }
 
+// InhumeCount returns amount of object removal records written to the
+// incremental dump.
+func (r DumpRes) InhumeCount() int {
+	return r.inhumed
+}
+
+// Marker returns the marker to make the next incremental dump since. It is
+// set for incremental dumps only.
+func (r DumpRes) Marker() uint64 {
+	return r.marker
+}
+
 var ErrMustBeReadOnly = logicerr.New("shard must be in read-only mode")
 
 // Dump dumps all objects from the shard to a file or stream.
@@ -70,6 +95,14 @@ func (s *Shard) Dump(prm DumpPrm) (DumpRes, error) {
 		w = f
 	}
 
+	if prm.incremental {
+		res, err := s.dumpIncremental(w, prm)
+		if err == nil {
+			s.setDumpedChanges(res.marker)
+		}
+		return res, err
+	}
+
 	_, err := w.Write(dumpMagic)
 	if err != nil {
 		return DumpRes{}, err
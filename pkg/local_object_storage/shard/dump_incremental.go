@@ -0,0 +1,617 @@
+package shard
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/writecache"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	"github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"go.uber.org/zap"
+)
+
+// Incremental dump format:
+//
+//	magic | version | manifest record | object and inhume records... | checksum record
+//
+// Every record is encoded as its type byte, little-endian uint32 payload size,
+// little-endian uint32 CRC32 (IEEE) of the payload and the payload itself.
+// Manifest and inhume payloads are JSON, object payload is the binary object.
+// Checksum record contains the number of the records and SHA256 of the dump
+// preceding it.
+var incrementalDumpMagic = []byte("NEOI")
+
+const incrementalDumpVersion = 1
+
+const (
+	_ = iota
+	dumpRecordManifest
+	dumpRecordObject
+	dumpRecordInhume
+	dumpRecordChecksum
+)
+
+const (
+	dumpInhumeTombstone = "tombstone"
+	dumpInhumeGarbage   = "garbage"
+	dumpInhumeContainer = "container"
+)
+
+// changeLogBatchSize is a number of metabase changes read at once during
+// incremental dump.
+const changeLogBatchSize = 1000
+
+var (
+	// ErrDumpChainBroken is returned when the incremental dump being
+	// restored does not continue the previously restored one.
+	ErrDumpChainBroken = logicerr.New("incremental dump does not continue the restored one")
+	// ErrInvalidDumpChecksum is returned when the dump data doesn't match its
+	// checksum.
+	ErrInvalidDumpChecksum = logicerr.New("invalid dump checksum")
+)
+
+type dumpManifest struct {
+	Version   int    `json:"version"`
+	ShardID   string `json:"shard_id"`
+	Since     uint64 `json:"since"`
+	Marker    uint64 `json:"marker"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type dumpInhume struct {
+	Type      string `json:"type"`
+	Address   string `json:"address,omitempty"`
+	Tombstone string `json:"tombstone,omitempty"`
+	Container string `json:"container,omitempty"`
+}
+
+type dumpChecksum struct {
+	Objects int    `json:"objects"`
+	Inhumed int    `json:"inhumed"`
+	SHA256  string `json:"sha256"`
+}
+
+type dumpWriter struct {
+	w io.Writer
+	h hash.Hash
+}
+
+func newDumpWriter(w io.Writer) *dumpWriter {
+	h := sha256.New()
+	return &dumpWriter{w: io.MultiWriter(w, h), h: h}
+}
+
+func (w *dumpWriter) write(typ byte, payload []byte) error {
+	var hdr [9]byte
+	hdr[0] = typ
+	binary.LittleEndian.PutUint32(hdr[1:], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(hdr[5:], crc32.ChecksumIEEE(payload))
+
+	if _, err := w.w.Write(hdr[:]); err != nil {
+		return err
+	}
+	_, err := w.w.Write(payload)
+	return err
+}
+
+func (w *dumpWriter) writeJSON(typ byte, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return w.write(typ, data)
+}
+
+type dumpReader struct {
+	r io.Reader
+	h hash.Hash
+	// sum is a checksum of the data preceding the last read record.
+	sum []byte
+}
+
+func newDumpReader(r io.Reader) *dumpReader {
+	h := sha256.New()
+	return &dumpReader{r: io.TeeReader(r, h), h: h}
+}
+
+// read reads the next record. Returns io.EOF if there are no more records.
+func (r *dumpReader) read() (byte, []byte, error) {
+	r.sum = r.h.Sum(nil)
+
+	var hdr [9]byte
+	if _, err := io.ReadFull(r.r, hdr[:]); err != nil {
+		return 0, nil, err
+	}
+
+	payload := make([]byte, binary.LittleEndian.Uint32(hdr[1:]))
+	if _, err := io.ReadFull(r.r, payload); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, nil, err
+	}
+
+	if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(hdr[5:]) {
+		return hdr[0], nil, fmt.Errorf("%w: record CRC mismatch", ErrInvalidDumpChecksum)
+	}
+
+	return hdr[0], payload, nil
+}
+
+// setDumpedChanges remembers the marker of the incremental dump. The dump is
+// made in read-only mode, so the change log is truncated by
+// truncateDumpedChanges after the mode is switched.
+func (s *Shard) setDumpedChanges(marker uint64) {
+	for {
+		cur := s.dumpedChanges.Load()
+		if marker <= cur || s.dumpedChanges.CompareAndSwap(cur, marker) {
+			return
+		}
+	}
+}
+
+// truncateDumpedChanges drops the changes that have been dumped already
+// from the metabase change log. Must be called in read-write mode.
+func (s *Shard) truncateDumpedChanges() {
+	marker := s.dumpedChanges.Swap(0)
+	if marker == 0 {
+		return
+	}
+
+	err := s.metaBase.TruncateChangeLog(marker)
+	if err != nil {
+		s.log.Warn("could not truncate metabase change log",
+			zap.Uint64("marker", marker),
+			zap.Error(err))
+	}
+}
+
+// dumpIncremental writes the objects added and inhumed after prm.since marker.
+// Zero marker means the whole shard.
+func (s *Shard) dumpIncremental(w io.Writer, prm DumpPrm) (DumpRes, error) {
+	if s.info.Mode.NoMetabase() {
+		return DumpRes{}, ErrDegradedMode
+	}
+
+	marker, err := s.metaBase.ChangeLogMarker()
+	if err != nil {
+		return DumpRes{}, fmt.Errorf("read change log marker: %w", err)
+	}
+	if prm.since > marker {
+		return DumpRes{}, meta.ErrInvalidChangeLogMarker
+	}
+
+	if _, err := w.Write(append(bytes.Clone(incrementalDumpMagic), incrementalDumpVersion)); err != nil {
+		return DumpRes{}, err
+	}
+
+	var shardID string
+	if s.info.ID != nil {
+		shardID = s.info.ID.String()
+	}
+
+	dw := newDumpWriter(w)
+
+	err = dw.writeJSON(dumpRecordManifest, dumpManifest{
+		Version:   incrementalDumpVersion,
+		ShardID:   shardID,
+		Since:     prm.since,
+		Marker:    marker,
+		CreatedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return DumpRes{}, err
+	}
+
+	res := DumpRes{marker: marker}
+
+	writeObject := func(data []byte) error {
+		if err := dw.write(dumpRecordObject, data); err != nil {
+			return err
+		}
+		res.count++
+		return nil
+	}
+	writeInhume := func(rec dumpInhume) error {
+		if err := dw.writeJSON(dumpRecordInhume, rec); err != nil {
+			return err
+		}
+		res.inhumed++
+		return nil
+	}
+
+	if prm.since == 0 {
+		err = s.dumpAll(prm.ignoreErrors, writeObject, writeInhume)
+	} else {
+		err = s.dumpChanges(prm.since, marker, prm.ignoreErrors, writeObject, writeInhume)
+	}
+	if err != nil {
+		return DumpRes{}, err
+	}
+
+	err = dw.writeJSON(dumpRecordChecksum, dumpChecksum{
+		Objects: res.count,
+		Inhumed: res.inhumed,
+		SHA256:  hex.EncodeToString(dw.h.Sum(nil)),
+	})
+	if err != nil {
+		return DumpRes{}, err
+	}
+
+	return res, nil
+}
+
+// dumpAll writes all the objects stored in the shard and all removal marks.
+func (s *Shard) dumpAll(ignoreErrors bool, writeObject func([]byte) error, writeInhume func(dumpInhume) error) error {
+	if s.hasWriteCache() {
+		var iterPrm writecache.IterationPrm
+
+		iterPrm.WithIgnoreErrors(ignoreErrors)
+		iterPrm.WithHandler(writeObject)
+
+		err := s.writeCache.Iterate(iterPrm)
+		if err != nil {
+			return err
+		}
+	}
+
+	var pi common.IteratePrm
+	pi.IgnoreErrors = ignoreErrors
+	pi.Handler = func(elem common.IterationElement) error {
+		return writeObject(elem.ObjectData)
+	}
+
+	if _, err := s.blobStor.Iterate(pi); err != nil {
+		return err
+	}
+
+	var graveyardPrm meta.GraveyardIterationPrm
+	graveyardPrm.SetHandler(func(o meta.TombstonedObject) error {
+		return writeInhume(dumpInhume{
+			Type:      dumpInhumeTombstone,
+			Address:   o.Address().EncodeToString(),
+			Tombstone: o.Tombstone().EncodeToString(),
+		})
+	})
+
+	if err := s.metaBase.IterateOverGraveyard(graveyardPrm); err != nil {
+		return fmt.Errorf("iterate over graveyard: %w", err)
+	}
+
+	var garbagePrm meta.GarbageIterationPrm
+	garbagePrm.SetHandler(func(o meta.GarbageObject) error {
+		return writeInhume(dumpInhume{
+			Type:    dumpInhumeGarbage,
+			Address: o.Address().EncodeToString(),
+		})
+	})
+
+	if err := s.metaBase.IterateOverGarbage(garbagePrm); err != nil {
+		return fmt.Errorf("iterate over garbage: %w", err)
+	}
+
+	cnrs, err := s.metaBase.GarbageContainers()
+	if err != nil {
+		return fmt.Errorf("list removed containers: %w", err)
+	}
+
+	for i := range cnrs {
+		err := writeInhume(dumpInhume{
+			Type:      dumpInhumeContainer,
+			Container: cnrs[i].EncodeToString(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dumpChanges writes the objects added and removed in (since, marker] range
+// of the metabase change log.
+func (s *Shard) dumpChanges(since, marker uint64, ignoreErrors bool, writeObject func([]byte) error, writeInhume func(dumpInhume) error) error {
+	for since < marker {
+		changes, err := s.metaBase.ListChanges(since, changeLogBatchSize)
+		if err != nil {
+			return fmt.Errorf("list changes: %w", err)
+		}
+		if len(changes) == 0 {
+			break
+		}
+
+		for _, ch := range changes {
+			if ch.Seq() > marker {
+				return nil
+			}
+
+			switch ch.Type() {
+			case meta.ChangeAdded:
+				addr := ch.Address()
+
+				// the object can be inhumed later, so metabase is not checked
+				var data []byte
+				_, err = s.fetchObjectData(addr, true, func(bs *blobstor.BlobStor, subStorageID []byte) error {
+					var err error
+					data, err = bs.GetBytes(addr, subStorageID)
+					return err
+				}, func(w writecache.Cache) error {
+					var err error
+					data, err = w.GetBytes(addr)
+					return err
+				})
+				if err != nil {
+					if IsErrNotFound(err) || ignoreErrors {
+						// already deleted physically
+						continue
+					}
+					return fmt.Errorf("read object %s: %w", addr, err)
+				}
+
+				err = writeObject(data)
+			case meta.ChangeInhumed:
+				err = writeInhume(dumpInhume{
+					Type:      dumpInhumeTombstone,
+					Address:   ch.Address().EncodeToString(),
+					Tombstone: ch.Tombstone().EncodeToString(),
+				})
+			case meta.ChangeGarbage:
+				err = writeInhume(dumpInhume{
+					Type:    dumpInhumeGarbage,
+					Address: ch.Address().EncodeToString(),
+				})
+			case meta.ChangeContainerInhumed:
+				err = writeInhume(dumpInhume{
+					Type:      dumpInhumeContainer,
+					Container: ch.Container().EncodeToString(),
+				})
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		since = changes[len(changes)-1].Seq()
+	}
+
+	return nil
+}
+
+// restoreIncremental restores the dump written by dumpIncremental, magic
+// is expected to be read already. The whole dump is verified before any
+// record is applied, so a truncated or corrupted dump does not leave the
+// shard restored partially. Non-seekable streams are staged to a temporary
+// file for that.
+func (s *Shard) restoreIncremental(r io.Reader, prm RestorePrm) (RestoreRes, error) {
+	rs, ok := r.(io.ReadSeeker)
+	if !ok {
+		f, err := os.CreateTemp("", "shard-restore-*")
+		if err != nil {
+			return RestoreRes{}, fmt.Errorf("create staging file: %w", err)
+		}
+		defer func() {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}()
+
+		if _, err := io.Copy(f, r); err != nil {
+			return RestoreRes{}, fmt.Errorf("stage dump: %w", err)
+		}
+
+		rs = f
+		if _, err := rs.Seek(0, io.SeekStart); err != nil {
+			return RestoreRes{}, err
+		}
+	}
+
+	start, err := rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return RestoreRes{}, err
+	}
+
+	manifest, _, err := readIncrementalDump(rs, prm.ignoreErrors, nil)
+	if err != nil {
+		return RestoreRes{}, err
+	}
+
+	if manifest.Since != 0 {
+		shardID, marker, err := s.metaBase.RestoredDumpMarker()
+		if err != nil {
+			return RestoreRes{}, fmt.Errorf("read restored dump marker: %w", err)
+		}
+		if string(shardID) != manifest.ShardID || marker != manifest.Since {
+			return RestoreRes{}, fmt.Errorf("%w: dump of %s shard since %d, restored %s shard up to %d",
+				ErrDumpChainBroken, manifest.ShardID, manifest.Since, shardID, marker)
+		}
+	}
+
+	if _, err := rs.Seek(start, io.SeekStart); err != nil {
+		return RestoreRes{}, err
+	}
+
+	var (
+		res    RestoreRes
+		putPrm PutPrm
+	)
+
+	_, skipped, err := readIncrementalDump(rs, prm.ignoreErrors, func(typ byte, data []byte) error {
+		switch typ {
+		case dumpRecordObject:
+			obj := object.New()
+			err := obj.Unmarshal(data)
+			if err != nil {
+				if prm.ignoreErrors {
+					res.failed++
+					return nil
+				}
+				return err
+			}
+
+			putPrm.SetObject(obj)
+			_, err = s.Put(putPrm)
+			if err != nil && !IsErrObjectExpired(err) && !IsErrRemoved(err) {
+				return err
+			}
+
+			res.count++
+		case dumpRecordInhume:
+			err := s.restoreInhume(data)
+			if err != nil {
+				if prm.ignoreErrors {
+					res.failed++
+					return nil
+				}
+				return err
+			}
+
+			res.inhumed++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return RestoreRes{}, err
+	}
+
+	res.failed += skipped
+
+	err = s.metaBase.SetRestoredDumpMarker([]byte(manifest.ShardID), manifest.Marker)
+	if err != nil {
+		return RestoreRes{}, fmt.Errorf("save restored dump marker: %w", err)
+	}
+
+	return res, nil
+}
+
+// readIncrementalDump reads the dump written by dumpIncremental, magic is
+// expected to be read already. Object and inhume records are passed to the
+// handler if it is set. Returns the dump manifest and the number of records
+// skipped because of the checksum mismatch.
+func readIncrementalDump(r io.Reader, ignoreErrors bool, handler func(typ byte, data []byte) error) (dumpManifest, int, error) {
+	var manifest dumpManifest
+
+	var v [1]byte
+	if _, err := io.ReadFull(r, v[:]); err != nil {
+		return manifest, 0, err
+	}
+	if v[0] != incrementalDumpVersion {
+		return manifest, 0, fmt.Errorf("%w: unsupported incremental dump version %d", ErrInvalidMagic, v[0])
+	}
+
+	dr := newDumpReader(r)
+
+	typ, data, err := dr.read()
+	if err != nil {
+		return manifest, 0, fmt.Errorf("read manifest: %w", err)
+	}
+	if typ != dumpRecordManifest {
+		return manifest, 0, fmt.Errorf("unexpected record type %d instead of the manifest", typ)
+	}
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, 0, fmt.Errorf("decode manifest: %w", err)
+	}
+
+	var (
+		objects     int
+		inhumed     int
+		failed      int
+		checksumRec *dumpChecksum
+	)
+
+	for checksumRec == nil {
+		typ, data, err := dr.read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			if errors.Is(err, ErrInvalidDumpChecksum) && ignoreErrors && typ != dumpRecordChecksum {
+				failed++
+				continue
+			}
+			return manifest, 0, err
+		}
+
+		switch typ {
+		case dumpRecordObject:
+			objects++
+		case dumpRecordInhume:
+			inhumed++
+		case dumpRecordChecksum:
+			checksumRec = new(dumpChecksum)
+			if err := json.Unmarshal(data, checksumRec); err != nil {
+				return manifest, 0, fmt.Errorf("decode checksum: %w", err)
+			}
+			continue
+		default:
+			return manifest, 0, fmt.Errorf("unknown record type %d", typ)
+		}
+
+		if handler != nil {
+			if err := handler(typ, data); err != nil {
+				return manifest, 0, err
+			}
+		}
+	}
+
+	if checksumRec.SHA256 != hex.EncodeToString(dr.sum) ||
+		checksumRec.Objects != objects || checksumRec.Inhumed != inhumed {
+		return manifest, 0, ErrInvalidDumpChecksum
+	}
+
+	return manifest, failed, nil
+}
+
+func (s *Shard) restoreInhume(data []byte) error {
+	var rec dumpInhume
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return fmt.Errorf("decode inhume record: %w", err)
+	}
+
+	if rec.Type == dumpInhumeContainer {
+		var cnr cid.ID
+		if err := cnr.DecodeString(rec.Container); err != nil {
+			return fmt.Errorf("decode container ID: %w", err)
+		}
+		return s.InhumeContainer(cnr)
+	}
+
+	var addr oid.Address
+	if err := addr.DecodeString(rec.Address); err != nil {
+		return fmt.Errorf("decode object address: %w", err)
+	}
+
+	var inhumePrm InhumePrm
+	switch rec.Type {
+	case dumpInhumeTombstone:
+		var tomb oid.Address
+		if err := tomb.DecodeString(rec.Tombstone); err != nil {
+			return fmt.Errorf("decode tombstone address: %w", err)
+		}
+		inhumePrm.InhumeByTomb(tomb, addr)
+	case dumpInhumeGarbage:
+		inhumePrm.MarkAsGarbage(addr)
+		// the mark has been accepted by the dumped shard already
+		inhumePrm.ForceRemoval()
+	default:
+		return fmt.Errorf("unknown inhume record type %q", rec.Type)
+	}
+
+	_, err := s.Inhume(inhumePrm)
+	return err
+}
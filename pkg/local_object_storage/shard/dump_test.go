@@ -15,6 +15,7 @@ import (
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/fstree"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/peapod"
+	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/writecache"
@@ -398,3 +399,168 @@ func TestDumpIgnoreErrors(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, objCount, res.Count())
 }
+
+func TestIncrementalDump(t *testing.T) {
+	shPath := t.TempDir()
+	sh := newCustomShard(t, shPath, false, nil, nil, shard.WithMetaBaseOptions(
+		meta.WithPath(filepath.Join(shPath, "meta")),
+		meta.WithEpochState(epochState{}),
+		meta.WithChangeLog(true),
+	))
+	defer releaseShard(sh, t)
+
+	putObjects := func(n int) []*objectSDK.Object {
+		objects := make([]*objectSDK.Object, n)
+		for i := range objects {
+			objects[i] = generateObjectWithCID(t, cidtest.ID())
+
+			var prm shard.PutPrm
+			prm.SetObject(objects[i])
+			_, err := sh.Put(prm)
+			require.NoError(t, err)
+		}
+		return objects
+	}
+
+	dump := func(path string, since uint64) shard.DumpRes {
+		require.NoError(t, sh.SetMode(mode.ReadOnly))
+		defer func() { require.NoError(t, sh.SetMode(mode.ReadWrite)) }()
+
+		var prm shard.DumpPrm
+		prm.WithPath(path)
+		prm.WithIncremental(since)
+
+		res, err := sh.Dump(prm)
+		require.NoError(t, err)
+		return res
+	}
+
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "base")
+	incPath := filepath.Join(dir, "inc")
+
+	baseObjects := putObjects(3)
+
+	baseRes := dump(basePath, 0)
+	require.Equal(t, len(baseObjects), baseRes.Count())
+	require.Zero(t, baseRes.InhumeCount())
+
+	newObjects := putObjects(2)
+
+	var inhumePrm shard.InhumePrm
+	inhumePrm.InhumeByTomb(objecttest.Address(), object.AddressOf(baseObjects[0]))
+	_, err := sh.Inhume(inhumePrm)
+	require.NoError(t, err)
+
+	inhumePrm.MarkAsGarbage(object.AddressOf(baseObjects[1]))
+	_, err = sh.Inhume(inhumePrm)
+	require.NoError(t, err)
+
+	incRes := dump(incPath, baseRes.Marker())
+	require.Equal(t, len(newObjects), incRes.Count())
+	require.Equal(t, 2, incRes.InhumeCount())
+	require.Greater(t, incRes.Marker(), baseRes.Marker())
+
+	t.Run("marker ahead", func(t *testing.T) {
+		require.NoError(t, sh.SetMode(mode.ReadOnly))
+		defer func() { require.NoError(t, sh.SetMode(mode.ReadWrite)) }()
+
+		var prm shard.DumpPrm
+		prm.WithPath(filepath.Join(dir, "invalid"))
+		prm.WithIncremental(incRes.Marker() + 1)
+
+		_, err := sh.Dump(prm)
+		require.ErrorIs(t, err, meta.ErrInvalidChangeLogMarker)
+	})
+
+	t.Run("change log truncated", func(t *testing.T) {
+		require.NoError(t, sh.SetMode(mode.ReadOnly))
+		defer func() { require.NoError(t, sh.SetMode(mode.ReadWrite)) }()
+
+		var prm shard.DumpPrm
+		prm.WithPath(filepath.Join(dir, "truncated"))
+		prm.WithIncremental(baseRes.Marker())
+
+		_, err := sh.Dump(prm)
+		require.ErrorIs(t, err, meta.ErrChangeLogTruncated)
+	})
+
+	restore := func(sh *shard.Shard, path string) (shard.RestoreRes, error) {
+		var prm shard.RestorePrm
+		prm.WithPath(path)
+		return sh.Restore(prm)
+	}
+
+	t.Run("broken chain", func(t *testing.T) {
+		sh := newCustomShard(t, filepath.Join(t.TempDir(), "broken"), false, nil, nil)
+		defer releaseShard(sh, t)
+
+		_, err := restore(sh, incPath)
+		require.ErrorIs(t, err, shard.ErrDumpChainBroken)
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		sh := newCustomShard(t, filepath.Join(t.TempDir(), "corrupted"), false, nil, nil)
+		defer releaseShard(sh, t)
+
+		data, err := os.ReadFile(basePath)
+		require.NoError(t, err)
+		data[len(data)/2] ^= 0xFF
+
+		path := filepath.Join(t.TempDir(), "corrupted")
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+
+		_, err = restore(sh, path)
+		require.ErrorIs(t, err, shard.ErrInvalidDumpChecksum)
+
+		// checksum of the whole dump is corrupted
+		data[len(data)/2] ^= 0xFF
+		data[len(data)-1] ^= 0xFF
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+
+		_, err = restore(sh, path)
+		require.ErrorIs(t, err, shard.ErrInvalidDumpChecksum)
+
+		var prm shard.RestorePrm
+		prm.WithStream(struct{ io.Reader }{bytes.NewReader(data)}) // not seekable
+		_, err = sh.Restore(prm)
+		require.ErrorIs(t, err, shard.ErrInvalidDumpChecksum)
+
+		// nothing is restored partially
+		var getPrm shard.GetPrm
+		for _, obj := range baseObjects {
+			getPrm.SetAddress(object.AddressOf(obj))
+			_, err := sh.Get(getPrm)
+			require.Error(t, err)
+		}
+	})
+
+	restored := newCustomShard(t, filepath.Join(t.TempDir(), "restored"), false, nil, nil)
+	defer releaseShard(restored, t)
+
+	res, err := restore(restored, basePath)
+	require.NoError(t, err)
+	require.Equal(t, len(baseObjects), res.Count())
+
+	res, err = restore(restored, incPath)
+	require.NoError(t, err)
+	require.Equal(t, len(newObjects), res.Count())
+	require.Equal(t, 2, res.InhumeCount())
+
+	_, err = restore(restored, incPath)
+	require.ErrorIs(t, err, shard.ErrDumpChainBroken)
+
+	var getPrm shard.GetPrm
+	for _, obj := range append(baseObjects[2:], newObjects...) {
+		getPrm.SetAddress(object.AddressOf(obj))
+		res, err := restored.Get(getPrm)
+		require.NoError(t, err)
+		require.Equal(t, obj, res.Object())
+	}
+
+	for _, obj := range baseObjects[:2] {
+		getPrm.SetAddress(object.AddressOf(obj))
+		_, err := restored.Get(getPrm)
+		require.Error(t, err)
+	}
+}
@@ -7,7 +7,9 @@ import (
 
 	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
 	"github.com/epicchainlabs/epicchain-node/pkg/util"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
 	"github.com/epicchainlabs/epicchain-sdk-go/object"
 	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
 	"go.uber.org/zap"
@@ -186,28 +188,30 @@ func (gc *gc) stop() {
 // with GC-marked graves.
 // Does nothing if shard is in "read-only" mode.
 func (s *Shard) removeGarbage() {
-	s.m.RLock()
-	defer s.m.RUnlock()
+	gObjs, gContainers, ok := s.getGarbage()
+	if !ok {
+		return
+	}
 
-	if s.info.Mode != mode.ReadWrite {
+	// wait for the limits before the mode lock is taken
+	if err := s.throttle(throttle.Background, throttle.Write, uint64(len(gObjs)), 0); err != nil {
 		return
 	}
 
-	gObjs, gContainers, err := s.metaBase.GetGarbage(s.rmBatchSize)
-	if err != nil {
-		s.log.Warn("fetching garbage objects",
-			zap.Error(err),
-		)
+	s.m.RLock()
+	defer s.m.RUnlock()
 
+	if s.info.Mode != mode.ReadWrite {
 		return
 	}
 
 	var deletePrm DeletePrm
 	deletePrm.SetAddresses(gObjs...)
 	deletePrm.skipNotFoundError = true
+	deletePrm.ioClass = throttle.Background
 
 	// delete accumulated objects
-	_, err = s.delete(deletePrm)
+	_, err := s.delete(deletePrm)
 	if err != nil {
 		s.log.Warn("could not delete the objects",
 			zap.String("error", err.Error()),
@@ -229,6 +233,28 @@ func (s *Shard) removeGarbage() {
 	}
 }
 
+// getGarbage returns a batch of the objects and containers marked as garbage.
+// Returns false if the shard is not in read-write mode or the metabase fails.
+func (s *Shard) getGarbage() ([]oid.Address, []cid.ID, bool) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	if s.info.Mode != mode.ReadWrite {
+		return nil, nil, false
+	}
+
+	gObjs, gContainers, err := s.metaBase.GetGarbage(s.rmBatchSize)
+	if err != nil {
+		s.log.Warn("fetching garbage objects",
+			zap.Error(err),
+		)
+
+		return nil, nil, false
+	}
+
+	return gObjs, gContainers, true
+}
+
 func (s *Shard) collectExpiredObjects(ctx context.Context, e Event) {
 	expired, err := s.getExpiredObjects(ctx, e.(newEpoch).epoch, func(typ object.Type) bool {
 		return typ != object.TypeTombstone && typ != object.TypeLock
@@ -2,10 +2,12 @@ package shard
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
 	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/writecache"
 	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
@@ -18,6 +20,7 @@ import (
 type GetPrm struct {
 	addr     oid.Address
 	skipMeta bool
+	ioClass  throttle.Class
 }
 
 // GetRes groups the resulting values of Get operation.
@@ -39,6 +42,12 @@ func (p *GetPrm) SetIgnoreMeta(ignore bool) {
 	p.skipMeta = ignore
 }
 
+// SetIOClass is a Get option to set the class the read is throttled as,
+// throttle.Client by default.
+func (p *GetPrm) SetIOClass(c throttle.Class) {
+	p.ioClass = c
+}
+
 // Object returns the requested object.
 func (r GetRes) Object() *objectSDK.Object {
 	return r.obj
@@ -58,6 +67,12 @@ func (r GetRes) HasMeta() bool {
 // Returns an error of type apistatus.ObjectAlreadyRemoved if the requested object has been marked as removed in shard.
 // Returns the object.ErrObjectIsExpired if the object is presented but already expired.
 func (s *Shard) Get(prm GetPrm) (GetRes, error) {
+	// the size is unknown until the object is read, so the read is
+	// admitted by the number of operations and charged after
+	if err := s.throttleRead(prm.ioClass, prm.addr, prm.skipMeta, 0); err != nil {
+		return GetRes{}, err
+	}
+
 	s.m.RLock()
 	defer s.m.RUnlock()
 
@@ -88,6 +103,68 @@ func (s *Shard) Get(prm GetPrm) (GetRes, error) {
 	skipMeta := prm.skipMeta || s.info.Mode.NoMetabase()
 	var err error
 	res.hasMeta, err = s.fetchObjectData(prm.addr, skipMeta, cb, wc)
+	if err == nil {
+		s.chargeRead(prm.ioClass, res.obj.PayloadSize())
+	}
+
+	return res, err
+}
+
+// GetStreamRes groups the resulting values of GetStream operation.
+type GetStreamRes struct {
+	hdr     *objectSDK.Object
+	payload io.ReadCloser
+	hasMeta bool
+}
+
+// Header returns header of the requested object without payload.
+func (r GetStreamRes) Header() *objectSDK.Object {
+	return r.hdr
+}
+
+// Payload returns payload stream of the requested object. It must be closed
+// by the caller.
+func (r GetStreamRes) Payload() io.ReadCloser {
+	return r.payload
+}
+
+// HasMeta returns true if info about the object was found in the metabase.
+func (r GetStreamRes) HasMeta() bool {
+	return r.hasMeta
+}
+
+// GetStream works like Get but returns object header and opens the payload
+// stream instead of reading the payload into memory.
+//
+// Returns the same errors as Get.
+func (s *Shard) GetStream(prm GetPrm) (GetStreamRes, error) {
+	if err := s.throttleRead(prm.ioClass, prm.addr, prm.skipMeta, 0); err != nil {
+		return GetStreamRes{}, err
+	}
+
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	var res GetStreamRes
+
+	cb := func(stor *blobstor.BlobStor, id []byte) error {
+		var err error
+		res.hdr, res.payload, err = stor.GetStream(prm.addr, id)
+		return err
+	}
+
+	wc := func(c writecache.Cache) error {
+		var err error
+		res.hdr, res.payload, err = c.GetStream(prm.addr)
+		return err
+	}
+
+	skipMeta := prm.skipMeta || s.info.Mode.NoMetabase()
+	var err error
+	res.hasMeta, err = s.fetchObjectData(prm.addr, skipMeta, cb, wc)
+	if err == nil {
+		s.chargeRead(prm.ioClass, res.hdr.PayloadSize())
+	}
 
 	return res, err
 }
@@ -169,6 +246,9 @@ func (s *Shard) fetchObjectData(addr oid.Address, skipMeta bool,
 // GetBytes reads object from the Shard by address into memory buffer in a
 // canonical NeoFS binary format. Returns [apistatus.ObjectNotFound] if object
 // is missing.
+//
+// GetBytes serves object replication, so the read is throttled as
+// throttle.Background.
 func (s *Shard) GetBytes(addr oid.Address) ([]byte, error) {
 	b, _, err := s.getBytesWithMetadataLookup(addr, true)
 	return b, err
@@ -182,6 +262,10 @@ func (s *Shard) GetBytesWithMetadataLookup(addr oid.Address) ([]byte, bool, erro
 }
 
 func (s *Shard) getBytesWithMetadataLookup(addr oid.Address, skipMeta bool) ([]byte, bool, error) {
+	if err := s.throttleRead(throttle.Background, addr, skipMeta, 0); err != nil {
+		return nil, false, err
+	}
+
 	s.m.RLock()
 	defer s.m.RUnlock()
 
@@ -195,5 +279,8 @@ func (s *Shard) getBytesWithMetadataLookup(addr oid.Address, skipMeta bool) ([]b
 		b, err = w.GetBytes(addr)
 		return err
 	})
+	if err == nil {
+		s.chargeRead(throttle.Background, uint64(len(b)))
+	}
 	return b, hasMeta, err
 }
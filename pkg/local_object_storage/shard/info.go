@@ -30,9 +30,27 @@ type Info struct {
 
 	// PiloramaInfo contains information about trees stored on this shard.
 	PiloramaInfo pilorama.Info
+
+	// Scrub contains the progress of the background integrity scrubbing.
+	Scrub ScrubStatus
 }
 
 // DumpInfo returns information about the Shard.
 func (s *Shard) DumpInfo() Info {
-	return s.info
+	info := s.info
+	info.Scrub = s.ScrubStatus()
+
+	return info
+}
+
+// ObjectCounters returns object counters tracked by the shard's metabase.
+func (s *Shard) ObjectCounters() (meta.ObjectCounters, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	if s.info.Mode.NoMetabase() {
+		return meta.ObjectCounters{}, ErrDegradedMode
+	}
+
+	return s.metaBase.ObjectCounters()
 }
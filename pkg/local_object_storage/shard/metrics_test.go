@@ -3,6 +3,7 @@ package shard_test
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
@@ -66,6 +67,12 @@ func (m *metricsStore) AddToPayloadSize(size int64) {
 	m.payloadSize += size
 }
 
+func (m metricsStore) AddThrottledTime(string, time.Duration) {}
+
+func (m metricsStore) SetScrubbedObjects(uint64) {}
+
+func (m metricsStore) SetCorruptedObjects(uint64) {}
+
 const physical = "phy"
 const logical = "logic"
 const readonly = "readonly"
@@ -62,6 +62,9 @@ func (s *Shard) setMode(m mode.Mode) error {
 	}
 
 	s.info.Mode = m
+	if m == mode.ReadWrite {
+		s.truncateDumpedChanges()
+	}
 	if s.metricsWriter != nil {
 		s.metricsWriter.SetReadonly(s.info.Mode != mode.ReadWrite)
 	}
@@ -6,6 +6,7 @@ import (
 	objectCore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
 	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
 	"github.com/epicchainlabs/epicchain-sdk-go/object"
 	"go.uber.org/zap"
 )
@@ -17,6 +18,8 @@ type PutPrm struct {
 	binSet bool
 	objBin []byte
 	hdrLen int
+
+	ioClass throttle.Class
 }
 
 // PutRes groups the resulting values of Put operation.
@@ -37,6 +40,12 @@ func (p *PutPrm) SetObjectBinary(objBin []byte, hdrLen int) {
 	p.hdrLen = hdrLen
 }
 
+// SetIOClass is a Put option to set the class the write is throttled as,
+// throttle.Client by default.
+func (p *PutPrm) SetIOClass(c throttle.Class) {
+	p.ioClass = c
+}
+
 // Put saves the object in shard.
 //
 // Returns any error encountered that
@@ -44,14 +53,6 @@ func (p *PutPrm) SetObjectBinary(objBin []byte, hdrLen int) {
 //
 // Returns ErrReadOnlyMode error if shard is in "read-only" mode.
 func (s *Shard) Put(prm PutPrm) (PutRes, error) {
-	s.m.RLock()
-	defer s.m.RUnlock()
-
-	m := s.info.Mode
-	if m.ReadOnly() {
-		return PutRes{}, ErrReadOnlyMode
-	}
-
 	var data []byte
 	var err error
 	if prm.binSet {
@@ -66,6 +67,28 @@ func (s *Shard) Put(prm PutPrm) (PutRes, error) {
 		//  reuse already encoded header.
 	}
 
+	// writes rejected by the mode are not throttled, the mode is checked
+	// again under the lock since it may change while waiting
+	s.m.RLock()
+	readOnly := s.info.Mode.ReadOnly()
+	s.m.RUnlock()
+
+	if readOnly {
+		return PutRes{}, ErrReadOnlyMode
+	}
+
+	if err := s.throttle(prm.ioClass, throttle.Write, 1, uint64(len(data))); err != nil {
+		return PutRes{}, err
+	}
+
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	m := s.info.Mode
+	if m.ReadOnly() {
+		return PutRes{}, ErrReadOnlyMode
+	}
+
 	var putPrm common.PutPrm // form Put parameters
 	putPrm.Object = prm.obj
 	putPrm.RawData = data
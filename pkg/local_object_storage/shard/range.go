@@ -3,6 +3,7 @@ package shard
 import (
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/writecache"
 	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
@@ -19,6 +20,8 @@ type RngPrm struct {
 	addr oid.Address
 
 	skipMeta bool
+
+	ioClass throttle.Class
 }
 
 // RngRes groups the resulting values of GetRange operation.
@@ -45,6 +48,12 @@ func (p *RngPrm) SetIgnoreMeta(ignore bool) {
 	p.skipMeta = ignore
 }
 
+// SetIOClass is a GetRange option to set the class the read is throttled as,
+// throttle.Client by default.
+func (p *RngPrm) SetIOClass(c throttle.Class) {
+	p.ioClass = c
+}
+
 // Object returns the requested object part.
 //
 // Instance payload contains the requested range of the original object.
@@ -67,6 +76,10 @@ func (r RngRes) HasMeta() bool {
 // Returns an error of type apistatus.ObjectAlreadyRemoved if the requested object has been marked as removed in shard.
 // Returns the object.ErrObjectIsExpired if the object is presented but already expired.
 func (s *Shard) GetRange(prm RngPrm) (RngRes, error) {
+	if err := s.throttleRead(prm.ioClass, prm.addr, prm.skipMeta, prm.ln); err != nil {
+		return RngRes{}, err
+	}
+
 	s.m.RLock()
 	defer s.m.RUnlock()
 
@@ -0,0 +1,46 @@
+package shard
+
+// RebalanceCursor returns the stored progress of the shard rebalance. The
+// second return value is false if there is no rebalance in progress.
+func (s *Shard) RebalanceCursor() (*Cursor, bool, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	if s.info.Mode.NoMetabase() {
+		return nil, false, ErrDegradedMode
+	}
+
+	return s.metaBase.ReadRebalanceCursor()
+}
+
+// SetRebalanceCursor stores the progress of the shard rebalance, so that
+// it can be resumed after restart. Nil cursor marks the rebalance as started
+// from the beginning.
+func (s *Shard) SetRebalanceCursor(c *Cursor) error {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	m := s.info.Mode
+	if m.ReadOnly() {
+		return ErrReadOnlyMode
+	} else if m.NoMetabase() {
+		return ErrDegradedMode
+	}
+
+	return s.metaBase.WriteRebalanceCursor(c)
+}
+
+// ResetRebalanceCursor drops the stored progress of the shard rebalance.
+func (s *Shard) ResetRebalanceCursor() error {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	m := s.info.Mode
+	if m.ReadOnly() {
+		return ErrReadOnlyMode
+	} else if m.NoMetabase() {
+		return ErrDegradedMode
+	}
+
+	return s.metaBase.ResetRebalanceCursor()
+}
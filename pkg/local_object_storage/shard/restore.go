@@ -40,8 +40,9 @@ func (p *RestorePrm) WithIgnoreErrors(ignore bool) {
 
 // RestoreRes groups the result fields of Restore operation.
 type RestoreRes struct {
-	count  int
-	failed int
+	count   int
+	inhumed int
+	failed  int
 }
 
 // Count return amount of object written.
@@ -49,12 +50,20 @@ func (r RestoreRes) Count() int {
 	return r.count
 }
 
+// InhumeCount returns amount of object removals restored from the
+// incremental dump.
+func (r RestoreRes) InhumeCount() int {
+	return r.inhumed
+}
+
 // FailCount return amount of object skipped.
 func (r RestoreRes) FailCount() int {
 	return r.failed
 }
 
-// Restore restores objects from the dump prepared by Dump.
+// Restore restores objects from the dump prepared by Dump. Incremental dumps
+// must be restored in the order they were made starting from the one made
+// since zero marker, ErrDumpChainBroken is returned otherwise.
 //
 // Returns any error encountered.
 func (s *Shard) Restore(prm RestorePrm) (RestoreRes, error) {
@@ -79,6 +88,9 @@ func (s *Shard) Restore(prm RestorePrm) (RestoreRes, error) {
 
 	var m [4]byte
 	_, _ = io.ReadFull(r, m[:])
+	if bytes.Equal(m[:], incrementalDumpMagic) {
+		return s.restoreIncremental(r, prm)
+	}
 	if !bytes.Equal(m[:], dumpMagic) {
 		return RestoreRes{}, ErrInvalidMagic
 	}
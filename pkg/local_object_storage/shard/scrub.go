@@ -0,0 +1,542 @@
+package shard
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"go.uber.org/zap"
+)
+
+// IntegrityValidator checks stored objects for damage.
+type IntegrityValidator interface {
+	// ValidateIntegrity must return an error if the object is damaged.
+	ValidateIntegrity(*objectSDK.Object) error
+}
+
+// HealthyCopySource provides copies of the objects stored on the other
+// container nodes.
+type HealthyCopySource interface {
+	// HealthyCopy must return the object with the given address received
+	// from another node.
+	HealthyCopy(oid.Address) (*objectSDK.Object, error)
+}
+
+// DefaultScrubInterval is the default pause between the complete scrubbing
+// passes over the shard objects.
+const DefaultScrubInterval = 24 * time.Hour
+
+const (
+	// scrubBatchSize is a number of objects checked between the progress saves.
+	scrubBatchSize = 100
+
+	// scrubRetryInterval is a pause before the next scrubbing attempt when
+	// the shard can not be scrubbed at the moment.
+	scrubRetryInterval = time.Minute
+)
+
+// ScrubConfig describes the background integrity scrubbing of the shard
+// objects.
+type ScrubConfig struct {
+	// Rate is the maximum number of bytes of the objects checked per second.
+	// Zero disables the scrubbing.
+	Rate uint64
+
+	// Interval is the pause between the complete passes over the shard
+	// objects. Zero means DefaultScrubInterval.
+	Interval time.Duration
+}
+
+// ScrubStatus describes the progress of the shard scrubbing.
+type ScrubStatus struct {
+	// Enabled is true if the scrubbing is configured.
+	Enabled bool
+
+	// Checked is a number of objects checked in the current pass.
+	Checked uint64
+
+	// Total is a number of objects stored in the shard at the beginning of
+	// the current pass.
+	Total uint64
+
+	// Corrupted is a number of corrupted objects found in all passes.
+	Corrupted uint64
+
+	// Passes is a number of completed passes.
+	Passes uint64
+}
+
+// WithScrubConfig returns option to set the background integrity scrubbing
+// of the shard objects. The scrubbing is done only if IntegrityValidator is
+// set with WithIntegrityValidator.
+//
+// The option is applied on Reload.
+func WithScrubConfig(sc ScrubConfig) Option {
+	return func(c *cfg) {
+		c.scrubCfg = sc
+	}
+}
+
+// WithIntegrityValidator returns option to set the validator of the objects
+// checked by the background scrubbing.
+func WithIntegrityValidator(v IntegrityValidator) Option {
+	return func(c *cfg) {
+		c.integrityValidator = v
+	}
+}
+
+// WithHealthyCopySource returns option to set the source of the healthy
+// copies the corrupted objects are repaired with.
+func WithHealthyCopySource(src HealthyCopySource) Option {
+	return func(c *cfg) {
+		c.healthyCopySource = src
+	}
+}
+
+// scrubber runs the background integrity scrubbing of the shard objects.
+// Corrupted objects are overwritten with their healthy copies received from
+// other container nodes. Objects that can't be repaired are kept as is and
+// checked again in the next pass, they are never removed by the scrubber
+// since they may be locked or be the last copies in the network.
+type scrubber struct {
+	mtx sync.Mutex
+
+	cfg    ScrubConfig
+	state  meta.ScrubState
+	total  uint64
+	loaded bool
+
+	// limiter limits the scrubbing rate.
+	limiter *throttle.Limiter
+
+	// reconfigured wakes the scrubber up on configuration changes.
+	reconfigured chan struct{}
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newScrubber(c ScrubConfig) *scrubber {
+	return &scrubber{
+		cfg:          c,
+		limiter:      throttle.New(throttle.Limits{ReadBandwidth: c.Rate}, nil),
+		reconfigured: make(chan struct{}, 1),
+	}
+}
+
+func (x *scrubber) config() ScrubConfig {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	return x.cfg
+}
+
+func (x *scrubber) setConfig(c ScrubConfig) {
+	x.mtx.Lock()
+	x.cfg = c
+	x.mtx.Unlock()
+
+	x.limiter.SetLimits(throttle.Limits{ReadBandwidth: c.Rate})
+
+	select {
+	case x.reconfigured <- struct{}{}:
+	default:
+	}
+}
+
+// wait blocks for d or until the scrubber is stopped or reconfigured.
+// Returns false if the scrubber is stopped.
+func (x *scrubber) wait(d time.Duration) bool {
+	var tc <-chan time.Time
+	if d > 0 {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		tc = t.C
+	}
+
+	select {
+	case <-x.stop:
+		return false
+	case <-x.reconfigured:
+		return true
+	case <-tc:
+		return true
+	}
+}
+
+// startScrubber starts the background scrubbing of the shard objects.
+func (s *Shard) startScrubber() {
+	s.scrub.mtx.Lock()
+	s.scrub.loaded = false
+	s.scrub.mtx.Unlock()
+
+	s.scrub.stop = make(chan struct{})
+	s.scrub.wg.Add(1)
+
+	go s.runScrubber()
+}
+
+// stopScrubber stops the background scrubbing and waits for it to finish.
+func (s *Shard) stopScrubber() {
+	if s.scrub.stop == nil {
+		return
+	}
+
+	close(s.scrub.stop)
+	s.scrub.wg.Wait()
+
+	s.scrub.stop = nil
+}
+
+func (s *Shard) runScrubber() {
+	defer s.scrub.wg.Done()
+
+	for {
+		select {
+		case <-s.scrub.stop:
+			return
+		default:
+		}
+
+		c := s.scrub.config()
+		if c.Rate == 0 || s.integrityValidator == nil {
+			if !s.scrub.wait(0) {
+				return
+			}
+			continue
+		}
+
+		finished, err := s.scrubBatch()
+		if err != nil {
+			s.log.Debug("could not scrub shard objects", zap.Error(err))
+
+			if !s.scrub.wait(scrubRetryInterval) {
+				return
+			}
+			continue
+		}
+
+		if finished {
+			interval := c.Interval
+			if interval <= 0 {
+				interval = DefaultScrubInterval
+			}
+
+			s.log.Info("shard scrubbing pass finished", zap.Stringer("next", interval))
+
+			if !s.scrub.wait(interval) {
+				return
+			}
+		}
+	}
+}
+
+// errScrubUnavailable is returned when the shard mode does not allow
+// scrubbing.
+var errScrubUnavailable = errors.New("shard is not in read-write mode")
+
+// scrubBatch checks the next batch of the shard objects and saves the
+// progress. Returns true if the pass has been finished.
+func (s *Shard) scrubBatch() (bool, error) {
+	state, addrs, err := s.listScrubBatch()
+	if err != nil {
+		return false, err
+	}
+
+	if addrs == nil {
+		state.Cursor = nil
+		state.Checked = 0
+		state.Passes++
+
+		return true, s.saveScrubState(state, true)
+	}
+
+	var corrupted uint64
+
+	for i := range addrs {
+		size, err := s.scrubObject(addrs[i])
+		if err != nil {
+			corrupted++
+
+			s.log.Warn("corrupted object found by scrubbing",
+				zap.Stringer("address", addrs[i]),
+				zap.Error(err))
+
+			err = s.repairObject(addrs[i])
+			if err != nil {
+				s.log.Warn("could not repair corrupted object",
+					zap.Stringer("address", addrs[i]),
+					zap.Error(err))
+			} else {
+				s.log.Info("corrupted object repaired with a healthy copy",
+					zap.Stringer("address", addrs[i]))
+			}
+		}
+
+		if !s.scrub.limiter.Wait(s.scrub.stop, throttle.Client, throttle.Read, 1, size) ||
+			!s.ioLimiter.Wait(s.scrub.stop, throttle.Background, throttle.Read, 1, size) {
+			// progress of the checked objects is lost, they are checked again after restart
+			return false, nil
+		}
+	}
+
+	state.Checked += uint64(len(addrs))
+	state.Corrupted += corrupted
+
+	return false, s.saveScrubState(state, false)
+}
+
+// listScrubBatch returns the current scrubbing state and the next batch of
+// objects to check. Nil list is returned at the end of the pass.
+func (s *Shard) listScrubBatch() (meta.ScrubState, []oid.Address, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	if s.info.Mode != mode.ReadWrite {
+		return meta.ScrubState{}, nil, errScrubUnavailable
+	}
+
+	s.scrub.mtx.Lock()
+	loaded := s.scrub.loaded
+	state := s.scrub.state
+	s.scrub.mtx.Unlock()
+
+	if !loaded {
+		var err error
+
+		state, err = s.metaBase.ReadScrubState()
+		if err != nil {
+			return meta.ScrubState{}, nil, fmt.Errorf("read scrub state: %w", err)
+		}
+
+		s.scrub.mtx.Lock()
+		s.scrub.state = state
+		s.scrub.loaded = true
+		s.scrub.mtx.Unlock()
+
+		s.setScrubMetrics(state)
+	}
+
+	if state.Cursor == nil {
+		cc, err := s.metaBase.ObjectCounters()
+		if err != nil {
+			return meta.ScrubState{}, nil, fmt.Errorf("read object counters: %w", err)
+		}
+
+		s.scrub.mtx.Lock()
+		s.scrub.total = cc.Phy()
+		s.scrub.mtx.Unlock()
+	}
+
+	var prm meta.ListPrm
+	prm.SetCount(scrubBatchSize)
+	prm.SetCursor(state.Cursor)
+
+	res, err := s.metaBase.ListWithCursor(prm)
+	if err != nil {
+		if errors.Is(err, meta.ErrEndOfListing) {
+			return state, nil, nil
+		}
+		return meta.ScrubState{}, nil, fmt.Errorf("list objects: %w", err)
+	}
+
+	list := res.AddressList()
+	addrs := make([]oid.Address, len(list))
+
+	for i := range list {
+		addrs[i] = list[i].Address
+	}
+
+	state.Cursor = res.Cursor()
+
+	return state, addrs, nil
+}
+
+// scrubObject reads the object from the BLOB storage and checks its
+// integrity. Returns the size of the read data and a non-nil error if the
+// object is corrupted. Objects that can't be read are not reported as
+// corrupted.
+func (s *Shard) scrubObject(addr oid.Address) (uint64, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	var mPrm meta.StorageIDPrm
+	mPrm.SetAddress(addr)
+
+	mRes, err := s.metaBase.StorageID(mPrm)
+	if err != nil {
+		s.log.Debug("could not get storage ID of the scrubbed object",
+			zap.Stringer("address", addr), zap.Error(err))
+		return 0, nil
+	}
+
+	storageID := mRes.StorageID()
+	if storageID == nil {
+		storageID = emptyStorageID
+	}
+
+	b, err := s.blobStor.GetBytes(addr, storageID)
+	if err != nil {
+		if !IsErrNotFound(err) {
+			s.reportErrorFunc(s.ID().String(), "could not read the scrubbed object", err)
+		}
+		return 0, nil
+	}
+
+	obj := objectSDK.New()
+
+	err = obj.Unmarshal(b)
+	if err != nil {
+		return uint64(len(b)), fmt.Errorf("decode object: %w", err)
+	}
+
+	if id, ok := obj.ID(); !ok || id != addr.Object() {
+		return uint64(len(b)), errors.New("object ID mismatch")
+	}
+
+	if cnr, ok := obj.ContainerID(); !ok || cnr != addr.Container() {
+		return uint64(len(b)), errors.New("container ID mismatch")
+	}
+
+	return uint64(len(b)), s.integrityValidator.ValidateIntegrity(obj)
+}
+
+var (
+	errNoHealthyCopySource = errors.New("healthy copy source is not set")
+	errScrubStopped        = errors.New("scrubbing is stopped")
+)
+
+// repairObject overwrites the corrupted object with a healthy copy received
+// from another container node. The metabase record of the object is kept,
+// only its storage ID is updated if the copy is stored in another place.
+func (s *Shard) repairObject(addr oid.Address) error {
+	if s.healthyCopySource == nil {
+		return errNoHealthyCopySource
+	}
+
+	obj, err := s.healthyCopySource.HealthyCopy(addr)
+	if err != nil {
+		return fmt.Errorf("get healthy copy: %w", err)
+	}
+
+	if id, ok := obj.ID(); !ok || id != addr.Object() {
+		return errors.New("healthy copy object ID mismatch")
+	}
+
+	if cnr, ok := obj.ContainerID(); !ok || cnr != addr.Container() {
+		return errors.New("healthy copy container ID mismatch")
+	}
+
+	err = s.integrityValidator.ValidateIntegrity(obj)
+	if err != nil {
+		return fmt.Errorf("invalid healthy copy: %w", err)
+	}
+
+	data, err := obj.Marshal()
+	if err != nil {
+		return fmt.Errorf("encode healthy copy: %w", err)
+	}
+
+	if !s.ioLimiter.Wait(s.scrub.stop, throttle.Background, throttle.Write, 1, uint64(len(data))) {
+		return errScrubStopped
+	}
+
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	if s.info.Mode != mode.ReadWrite {
+		return errScrubUnavailable
+	}
+
+	var mPrm meta.StorageIDPrm
+	mPrm.SetAddress(addr)
+
+	mRes, err := s.metaBase.StorageID(mPrm)
+	if err != nil {
+		return fmt.Errorf("get storage ID: %w", err)
+	}
+
+	oldID := mRes.StorageID()
+	if oldID == nil {
+		oldID = emptyStorageID
+	}
+
+	// objects are immutable, so storages may keep the existing data on put,
+	// the corrupted copy has no value and is dropped first
+	_, err = s.blobStor.Delete(common.DeletePrm{Address: addr, StorageID: oldID})
+	if err != nil && !IsErrNotFound(err) {
+		return fmt.Errorf("remove corrupted copy from BLOB storage: %w", err)
+	}
+
+	res, err := s.blobStor.Put(common.PutPrm{Address: addr, Object: obj, RawData: data})
+	if err != nil {
+		return fmt.Errorf("put healthy copy to BLOB storage: %w", err)
+	}
+
+	if bytes.Equal(res.StorageID, oldID) {
+		return nil
+	}
+
+	var uPrm meta.UpdateStorageIDPrm
+	uPrm.SetAddress(addr)
+	uPrm.SetStorageID(res.StorageID)
+
+	_, err = s.metaBase.UpdateStorageID(uPrm)
+	if err != nil {
+		return fmt.Errorf("update storage ID: %w", err)
+	}
+
+	return nil
+}
+
+// saveScrubState stores the scrubbing progress in the metabase. Total number
+// of objects is counted again when the next pass begins.
+func (s *Shard) saveScrubState(state meta.ScrubState, passFinished bool) error {
+	s.m.RLock()
+	err := s.metaBase.WriteScrubState(state)
+	s.m.RUnlock()
+
+	if err != nil {
+		return fmt.Errorf("write scrub state: %w", err)
+	}
+
+	s.scrub.mtx.Lock()
+	s.scrub.state = state
+	if passFinished {
+		s.scrub.total = 0
+	}
+	s.scrub.mtx.Unlock()
+
+	s.setScrubMetrics(state)
+
+	return nil
+}
+
+// ScrubStatus returns the progress of the shard scrubbing.
+func (s *Shard) ScrubStatus() ScrubStatus {
+	s.scrub.mtx.Lock()
+	defer s.scrub.mtx.Unlock()
+
+	return ScrubStatus{
+		Enabled:   s.scrub.cfg.Rate > 0 && s.integrityValidator != nil,
+		Checked:   s.scrub.state.Checked,
+		Total:     s.scrub.total,
+		Corrupted: s.scrub.state.Corrupted,
+		Passes:    s.scrub.state.Passes,
+	}
+}
+
+func (s *Shard) setScrubMetrics(state meta.ScrubState) {
+	if s.cfg.metricsWriter != nil {
+		s.cfg.metricsWriter.SetScrubbedObjects(state.Checked)
+		s.cfg.metricsWriter.SetCorruptedObjects(state.Corrupted)
+	}
+}
@@ -0,0 +1,134 @@
+package shard
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/fstree"
+	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	objecttest "github.com/epicchainlabs/epicchain-sdk-go/object/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type checksumValidator struct{}
+
+func (checksumValidator) ValidateIntegrity(obj *objectSDK.Object) error {
+	return obj.VerifyPayloadChecksum()
+}
+
+type testHealthyCopySource map[oid.Address]*objectSDK.Object
+
+func (x testHealthyCopySource) HealthyCopy(addr oid.Address) (*objectSDK.Object, error) {
+	obj, ok := x[addr]
+	if !ok {
+		return nil, apistatus.ObjectNotFound{}
+	}
+	return obj, nil
+}
+
+func TestShard_Scrub(t *testing.T) {
+	dir := t.TempDir()
+	copies := make(testHealthyCopySource)
+
+	sh := New(
+		WithLogger(zaptest.NewLogger(t)),
+		WithBlobStorOptions(blobstor.WithStorages([]blobstor.SubStorage{
+			{Storage: fstree.New(fstree.WithPath(filepath.Join(dir, "blob")))},
+		})),
+		WithMetaBaseOptions(meta.WithPath(filepath.Join(dir, "meta")), meta.WithEpochState(epochState{})),
+		WithIntegrityValidator(checksumValidator{}),
+		WithHealthyCopySource(copies))
+	require.NoError(t, sh.Open())
+	require.NoError(t, sh.Init())
+	t.Cleanup(func() { require.NoError(t, sh.Close()) })
+
+	require.Equal(t, ScrubStatus{}, sh.ScrubStatus())
+
+	put := func() *objectSDK.Object {
+		obj := objecttest.Object(t)
+		obj.SetType(objectSDK.TypeRegular)
+		obj.SetPayload([]byte{0, 1, 2, 3, 4, 5})
+		obj.CalculateAndSetPayloadChecksum()
+
+		var putPrm PutPrm
+		putPrm.SetObject(&obj)
+		_, err := sh.Put(putPrm)
+		require.NoError(t, err)
+
+		return &obj
+	}
+
+	overwrite := func(addr oid.Address, data []byte) {
+		_, err := sh.blobStor.Delete(common.DeletePrm{Address: addr})
+		require.NoError(t, err)
+		_, err = sh.blobStor.Put(common.PutPrm{Address: addr, RawData: data})
+		require.NoError(t, err)
+	}
+
+	healthy := object.AddressOf(put())
+
+	damagedObj := put()
+	damaged := object.AddressOf(damagedObj)
+	copies[damaged] = damagedObj
+
+	damagedCopy := objectSDK.New()
+	data, err := damagedObj.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, damagedCopy.Unmarshal(data))
+	damagedCopy.Payload()[0]++
+	data, err = damagedCopy.Marshal()
+	require.NoError(t, err)
+	overwrite(damaged, data)
+
+	// there is no healthy copy of this one, so it is kept as is
+	garbage := object.AddressOf(put())
+	overwrite(garbage, []byte("garbage"))
+
+	require.NoError(t, sh.Reload(WithScrubConfig(ScrubConfig{Rate: 1 << 20, Interval: time.Hour})))
+
+	require.Eventually(t, func() bool {
+		return sh.ScrubStatus().Passes == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, ScrubStatus{
+		Enabled:   true,
+		Corrupted: 2,
+		Passes:    1,
+	}, sh.ScrubStatus())
+	require.Equal(t, sh.ScrubStatus(), sh.DumpInfo().Scrub)
+
+	get := func(addr oid.Address) (*objectSDK.Object, error) {
+		var getPrm GetPrm
+		getPrm.SetAddress(addr)
+		res, err := sh.Get(getPrm)
+		return res.Object(), err
+	}
+
+	_, err = get(healthy)
+	require.NoError(t, err)
+
+	repaired, err := get(damaged)
+	require.NoError(t, err)
+	require.Equal(t, damagedObj.Payload(), repaired.Payload())
+
+	_, err = get(garbage)
+	require.Error(t, err)
+
+	var existsPrm ExistsPrm
+	existsPrm.SetAddress(garbage)
+	exists, err := sh.Exists(existsPrm)
+	require.NoError(t, err)
+	require.True(t, exists.Exists())
+
+	state, err := sh.metaBase.ReadScrubState()
+	require.NoError(t, err)
+	require.Equal(t, meta.ScrubState{Corrupted: 2, Passes: 1}, state)
+}
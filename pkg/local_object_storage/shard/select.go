@@ -13,6 +13,9 @@ import (
 type SelectPrm struct {
 	cnr     cid.ID
 	filters object.SearchFilters
+
+	limit  uint32
+	cursor *oid.ID
 }
 
 // SelectRes groups the resulting values of Select operation.
@@ -30,12 +33,25 @@ func (p *SelectPrm) SetFilters(fs object.SearchFilters) {
 	p.filters = fs
 }
 
+// SetLimit is a Select option to limit the number of the selected objects.
+// Zero means no limit.
+func (p *SelectPrm) SetLimit(n uint32) {
+	p.limit = n
+}
+
+// SetCursor is a Select option to select only the objects with IDs greater
+// than the given one.
+func (p *SelectPrm) SetCursor(id oid.ID) {
+	p.cursor = &id
+}
+
 // AddressList returns list of addresses of the selected objects.
 func (r SelectRes) AddressList() []oid.Address {
 	return r.addrList
 }
 
-// Select selects the objects from shard that match select parameters.
+// Select selects the objects from shard that match select parameters
+// ordered by object IDs.
 //
 // Returns any error encountered that
 // did not allow to completely select the objects.
@@ -52,6 +68,10 @@ func (s *Shard) Select(prm SelectPrm) (SelectRes, error) {
 	var selectPrm meta.SelectPrm
 	selectPrm.SetFilters(prm.filters)
 	selectPrm.SetContainerID(prm.cnr)
+	selectPrm.SetLimit(prm.limit)
+	if prm.cursor != nil {
+		selectPrm.SetCursor(*prm.cursor)
+	}
 
 	mRes, err := s.metaBase.Select(selectPrm)
 	if err != nil {
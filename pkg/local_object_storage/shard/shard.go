@@ -3,12 +3,14 @@ package shard
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
 	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/pilorama"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/writecache"
 	"github.com/epicchainlabs/epicchain-node/pkg/util"
 	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
@@ -29,7 +31,20 @@ type Shard struct {
 
 	metaBase *meta.DB
 
+	ioLimiter *throttle.Limiter
+
+	// ioCtx is canceled on Close to interrupt the operations waiting for
+	// the I/O limits.
+	ioCtx    context.Context
+	ioCancel context.CancelFunc
+
+	scrub *scrubber
+
 	tsSource TombstoneSource
+
+	// dumpedChanges is the marker of the last incremental dump, the change
+	// log is truncated up to it once the shard is writable again.
+	dumpedChanges *atomic.Uint64
 }
 
 // Option represents Shard's constructor option.
@@ -69,6 +84,15 @@ type MetricsWriter interface {
 	SetShardID(id string)
 	// SetReadonly must set shard readonly state.
 	SetReadonly(readonly bool)
+	// AddThrottledTime must add the time operations of the given class
+	// have waited for the shard I/O limits.
+	AddThrottledTime(class string, d time.Duration)
+	// SetScrubbedObjects must set the number of objects checked in the
+	// current scrubbing pass.
+	SetScrubbedObjects(v uint64)
+	// SetCorruptedObjects must set the number of corrupted objects found by
+	// the scrubbing.
+	SetCorruptedObjects(v uint64)
 }
 
 type cfg struct {
@@ -90,6 +114,14 @@ type cfg struct {
 
 	piloramaOpts []pilorama.Option
 
+	ioLimits throttle.Limits
+
+	scrubCfg ScrubConfig
+
+	integrityValidator IntegrityValidator
+
+	healthyCopySource HealthyCopySource
+
 	log *zap.Logger
 
 	gcCfg gcCfg
@@ -132,8 +164,14 @@ func New(opts ...Option) *Shard {
 		blobStor: bs,
 		metaBase: mb,
 		tsSource: c.tsSource,
+
+		dumpedChanges: new(atomic.Uint64),
 	}
 
+	s.ioLimiter = throttle.New(c.ioLimits, s.addThrottledTime)
+	s.ioCtx, s.ioCancel = context.WithCancel(context.Background())
+	s.scrub = newScrubber(c.scrubCfg)
+
 	reportFunc := func(msg string, err error) {
 		s.reportErrorFunc(s.ID().String(), msg, err)
 	}
@@ -145,6 +183,7 @@ func New(opts ...Option) *Shard {
 			append(c.writeCacheOpts,
 				writecache.WithReportErrorFunc(reportFunc),
 				writecache.WithBlobstor(bs),
+				writecache.WithIOLimiter(s.ioLimiter),
 				writecache.WithMetabase(mb))...)
 	}
 
@@ -0,0 +1,82 @@
+package shard
+
+import (
+	"time"
+
+	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+)
+
+// WithIOLimits returns option to set limits of the shard I/O. Operations
+// exceeding the limits are delayed, background ones are delayed first.
+//
+// The option is applied on Reload.
+func WithIOLimits(l throttle.Limits) Option {
+	return func(c *cfg) {
+		c.ioLimits = l
+	}
+}
+
+// IOLimits returns the current limits of the shard I/O.
+func (s *Shard) IOLimits() throttle.Limits {
+	return s.ioLimiter.Limits()
+}
+
+// errShardClosed is returned when the shard is closed while the operation
+// waits for the I/O limits.
+var errShardClosed = logicerr.New("shard is closed")
+
+// throttle waits until n operations of the given class and kind transferring
+// size bytes are allowed by the shard I/O limits. It must be called before
+// the shard mode lock is taken, so waiting operations do not block mode
+// changes and closing. Returns errShardClosed if the shard is closed while
+// waiting.
+func (s *Shard) throttle(class throttle.Class, op throttle.Op, n, size uint64) error {
+	if !s.ioLimiter.Wait(s.ioCtx.Done(), class, op, n, size) {
+		return errShardClosed
+	}
+	return nil
+}
+
+// throttleRead works like throttle for the object read but checks the
+// metabase first, so the lookups of the objects missing in the shard (e.g.
+// stored in the other shards) are not throttled. The metabase is not checked
+// if skipMeta is set.
+func (s *Shard) throttleRead(class throttle.Class, addr oid.Address, skipMeta bool, size uint64) error {
+	if !skipMeta {
+		s.m.RLock()
+		noMeta := s.info.Mode.NoMetabase()
+		var (
+			res meta.ExistsRes
+			err error
+		)
+		if !noMeta {
+			var prm meta.ExistsPrm
+			prm.SetAddress(addr)
+
+			res, err = s.metaBase.Exists(prm)
+		}
+		s.m.RUnlock()
+
+		if !noMeta && (err != nil || !res.Exists()) {
+			// the read fails with the same metabase result
+			return nil
+		}
+	}
+
+	return s.throttle(class, throttle.Read, 1, size)
+}
+
+// chargeRead accounts size bytes read by the operation admitted by throttle
+// before the size was known.
+func (s *Shard) chargeRead(class throttle.Class, size uint64) {
+	s.ioLimiter.Charge(class, throttle.Read, 0, size)
+}
+
+func (s *Shard) addThrottledTime(class throttle.Class, d time.Duration) {
+	if s.cfg.metricsWriter != nil {
+		s.cfg.metricsWriter.AddThrottledTime(class.String(), d)
+	}
+}
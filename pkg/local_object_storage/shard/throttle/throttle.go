@@ -0,0 +1,225 @@
+package throttle
+
+import (
+	"sync"
+	"time"
+)
+
+// Class is a class of shard I/O operations.
+type Class uint8
+
+const (
+	// Client is a class of operations serving client requests. It is the
+	// default one.
+	Client Class = iota
+
+	// Background is a class of operations issued by the node itself:
+	// replication, garbage collection, write-cache flushing, evacuation, etc.
+	// They are throttled before the client ones.
+	Background
+)
+
+// String implements fmt.Stringer.
+func (c Class) String() string {
+	switch c {
+	case Client:
+		return "client"
+	case Background:
+		return "background"
+	default:
+		return "unknown"
+	}
+}
+
+// Op is a kind of I/O operation.
+type Op uint8
+
+const (
+	// Read is a kind of operations reading object data.
+	Read Op = iota
+	// Write is a kind of operations writing or removing object data.
+	Write
+)
+
+// DefaultBackgroundShare is the default share of limits available to
+// background operations.
+const DefaultBackgroundShare = 0.5
+
+// burstWindow is the period unused limits are accumulated for.
+const burstWindow = time.Second
+
+// Limits describes shard I/O limits. Zero value of any limit means no limit.
+type Limits struct {
+	// ReadIOPS is the maximum number of read operations per second.
+	ReadIOPS uint64
+	// WriteIOPS is the maximum number of write operations per second.
+	WriteIOPS uint64
+	// ReadBandwidth is the maximum number of bytes read per second.
+	ReadBandwidth uint64
+	// WriteBandwidth is the maximum number of bytes written per second.
+	WriteBandwidth uint64
+	// BackgroundShare is the share of the limits, from 0 to 1, available to
+	// background operations. Client operations may use the whole limits,
+	// so background operations can not take more than the share of the
+	// shard bandwidth from the clients. Zero means DefaultBackgroundShare.
+	BackgroundShare float64
+}
+
+// IsZero checks whether l sets no limits.
+func (l Limits) IsZero() bool {
+	return l.ReadIOPS == 0 && l.WriteIOPS == 0 && l.ReadBandwidth == 0 && l.WriteBandwidth == 0
+}
+
+// bucket is a token bucket with the capacity of burstWindow of its rate
+// implemented as a virtual clock: next is the time the bucket gets empty.
+type bucket struct {
+	rate float64
+	next time.Time
+}
+
+// reserve takes n tokens from the bucket and returns the time to wait
+// before they are available. Zero n waits for the tokens taken in advance
+// by Limiter.Charge.
+func (b *bucket) reserve(now time.Time, n uint64) time.Duration {
+	if b.rate == 0 {
+		return 0
+	}
+
+	if start := now.Add(-burstWindow); b.next.Before(start) {
+		b.next = start
+	}
+
+	b.next = b.next.Add(time.Duration(float64(n) / b.rate * float64(time.Second)))
+
+	return b.next.Sub(now)
+}
+
+// opBuckets limits operations of a single kind.
+type opBuckets struct {
+	ops, bytes bucket
+}
+
+func (b *opBuckets) reserve(now time.Time, n, size uint64) time.Duration {
+	wait := b.ops.reserve(now, n)
+	if w := b.bytes.reserve(now, size); w > wait {
+		wait = w
+	}
+	return wait
+}
+
+func (b *opBuckets) setRates(ops, bytes, share float64) {
+	b.ops.rate = ops * share
+	b.bytes.rate = bytes * share
+}
+
+// Limiter throttles shard I/O operations according to Limits. Operations of
+// all classes share the limits, background operations are additionally
+// limited by the Limits.BackgroundShare of them.
+//
+// Nil Limiter does not limit anything.
+type Limiter struct {
+	mtx sync.Mutex
+
+	limits Limits
+
+	all        [2]opBuckets
+	background [2]opBuckets
+
+	onWait func(Class, time.Duration)
+}
+
+// New returns Limiter with the given limits. If onWait is not nil, it is
+// called with the time every throttled operation has waited for.
+func New(l Limits, onWait func(Class, time.Duration)) *Limiter {
+	lim := &Limiter{onWait: onWait}
+	lim.SetLimits(l)
+	return lim
+}
+
+// SetLimits changes the limits. Operations waiting at the moment are not
+// affected.
+func (l *Limiter) SetLimits(lim Limits) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.limits = lim
+
+	share := lim.BackgroundShare
+	if share <= 0 || share > 1 {
+		share = DefaultBackgroundShare
+	}
+
+	l.all[Read].setRates(float64(lim.ReadIOPS), float64(lim.ReadBandwidth), 1)
+	l.all[Write].setRates(float64(lim.WriteIOPS), float64(lim.WriteBandwidth), 1)
+	l.background[Read].setRates(float64(lim.ReadIOPS), float64(lim.ReadBandwidth), share)
+	l.background[Write].setRates(float64(lim.WriteIOPS), float64(lim.WriteBandwidth), share)
+}
+
+// Limits returns the current limits.
+func (l *Limiter) Limits() Limits {
+	if l == nil {
+		return Limits{}
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	return l.limits
+}
+
+// Wait blocks until n operations of the given class and kind transferring
+// size bytes in total are allowed by the limits. Returns false if done is
+// closed while waiting, nil done is never closed.
+func (l *Limiter) Wait(done <-chan struct{}, class Class, op Op, n, size uint64) bool {
+	if l == nil {
+		return true
+	}
+
+	wait := l.reserve(time.Now(), class, op, n, size)
+	if wait <= 0 {
+		return true
+	}
+
+	if l.onWait != nil {
+		defer func(start time.Time) { l.onWait(class, time.Since(start)) }(time.Now())
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return true
+	case <-done:
+		return false
+	}
+}
+
+// Charge accounts n operations of the given class and kind that have
+// transferred size bytes in total without waiting, the following operations
+// are delayed instead. It is used when the size is known after the I/O only.
+func (l *Limiter) Charge(class Class, op Op, n, size uint64) {
+	if l == nil {
+		return
+	}
+
+	l.reserve(time.Now(), class, op, n, size)
+}
+
+func (l *Limiter) reserve(now time.Time, class Class, op Op, n, size uint64) time.Duration {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.limits.IsZero() {
+		return 0
+	}
+
+	wait := l.all[op].reserve(now, n, size)
+	if class == Background {
+		if w := l.background[op].reserve(now, n, size); w > wait {
+			wait = w
+		}
+	}
+
+	return wait
+}
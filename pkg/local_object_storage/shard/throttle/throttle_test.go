@@ -0,0 +1,101 @@
+package throttle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_reserve(t *testing.T) {
+	now := time.Now()
+
+	t.Run("no limits", func(t *testing.T) {
+		l := New(Limits{}, nil)
+		for i := 0; i < 100; i++ {
+			require.Zero(t, l.reserve(now, Client, Write, 1, 1<<20))
+		}
+	})
+
+	t.Run("iops", func(t *testing.T) {
+		l := New(Limits{ReadIOPS: 10}, nil)
+
+		// a second of unused limits is available at once
+		for i := 0; i < 10; i++ {
+			require.LessOrEqual(t, l.reserve(now, Client, Read, 1, 0), time.Duration(0))
+		}
+		require.Equal(t, 100*time.Millisecond, l.reserve(now, Client, Read, 1, 0))
+
+		// writes are not limited
+		require.Zero(t, l.reserve(now, Client, Write, 100, 0))
+	})
+
+	t.Run("bandwidth", func(t *testing.T) {
+		l := New(Limits{WriteBandwidth: 1 << 20}, nil)
+
+		require.LessOrEqual(t, l.reserve(now, Client, Write, 1, 1<<20), time.Duration(0))
+		require.Equal(t, time.Second, l.reserve(now, Client, Write, 1, 1<<20))
+	})
+
+	t.Run("background", func(t *testing.T) {
+		l := New(Limits{WriteIOPS: 10, BackgroundShare: 0.5}, nil)
+
+		for i := 0; i < 5; i++ {
+			require.LessOrEqual(t, l.reserve(now, Background, Write, 1, 0), time.Duration(0))
+		}
+		require.Equal(t, 200*time.Millisecond, l.reserve(now, Background, Write, 1, 0))
+
+		// clients still have the rest of the limits
+		for i := 0; i < 4; i++ {
+			require.LessOrEqual(t, l.reserve(now, Client, Write, 1, 0), time.Duration(0))
+		}
+		require.Equal(t, 100*time.Millisecond, l.reserve(now, Client, Write, 1, 0))
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		l := New(Limits{ReadIOPS: 1}, nil)
+
+		require.LessOrEqual(t, l.reserve(now, Client, Read, 1, 0), time.Duration(0))
+		require.Equal(t, time.Second, l.reserve(now, Client, Read, 1, 0))
+
+		l.SetLimits(Limits{})
+		require.Zero(t, l.reserve(now, Client, Read, 1, 0))
+	})
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	var l *Limiter
+	require.True(t, l.Wait(nil, Client, Read, 1, 1))
+
+	var waited []Class
+	l = New(Limits{WriteIOPS: 2}, func(c Class, d time.Duration) {
+		require.Positive(t, d)
+		waited = append(waited, c)
+	})
+
+	require.True(t, l.Wait(nil, Background, Write, 1, 0))
+	require.Empty(t, waited)
+
+	done := make(chan struct{})
+	close(done)
+
+	require.False(t, l.Wait(done, Background, Write, 10, 0))
+	require.Equal(t, []Class{Background}, waited)
+}
+
+func TestLimiter_Charge(t *testing.T) {
+	var l *Limiter
+	l.Charge(Client, Read, 1, 1)
+
+	l = New(Limits{ReadBandwidth: 1 << 20}, nil)
+
+	// the size is unknown before the read
+	require.True(t, l.Wait(nil, Client, Read, 1, 0))
+	l.Charge(Client, Read, 0, 3<<20)
+
+	// the following reads wait for the charged bytes
+	require.Greater(t, l.reserve(time.Now(), Client, Read, 1, 0), time.Second)
+
+	// writes are not affected
+	require.Zero(t, l.reserve(time.Now(), Client, Write, 1, 1<<20))
+}
@@ -0,0 +1,77 @@
+package shard_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	oidtest "github.com/epicchainlabs/epicchain-sdk-go/object/id/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShard_Throttle(t *testing.T) {
+	sh := newCustomShard(t, t.TempDir(), false, nil, nil,
+		shard.WithIOLimits(throttle.Limits{WriteIOPS: 1}))
+
+	addrs := make([]oid.Address, 1000)
+	for i := range addrs {
+		addrs[i] = oidtest.Address()
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		var prm shard.DeletePrm
+		prm.SetAddresses(addrs...)
+
+		_, err := sh.Delete(prm)
+		errCh <- err
+	}()
+
+	// let the removal start waiting for the limits
+	time.Sleep(50 * time.Millisecond)
+
+	// waiting operations do not block the mode changes
+	require.NoError(t, sh.SetMode(mode.ReadOnly))
+
+	// and are interrupted on close
+	require.NoError(t, sh.Close())
+
+	select {
+	case err := <-errCh:
+		require.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("throttled operation is not interrupted by shard close")
+	}
+}
+
+func TestShard_ThrottleRejected(t *testing.T) {
+	sh := newCustomShard(t, t.TempDir(), false, nil, nil,
+		shard.WithIOLimits(throttle.Limits{ReadIOPS: 1, WriteIOPS: 1}))
+	t.Cleanup(func() { _ = sh.Close() })
+
+	start := time.Now()
+
+	// objects missing in the metabase are not read
+	for i := 0; i < 10; i++ {
+		var prm shard.GetPrm
+		prm.SetAddress(oidtest.Address())
+
+		_, err := sh.Get(prm)
+		require.True(t, shard.IsErrNotFound(err), err)
+	}
+
+	// writes in the read-only mode are not made
+	require.NoError(t, sh.SetMode(mode.ReadOnly))
+	for i := 0; i < 10; i++ {
+		var prm shard.PutPrm
+		prm.SetObject(generateObject(t))
+
+		_, err := sh.Put(prm)
+		require.ErrorIs(t, err, shard.ErrReadOnlyMode)
+	}
+
+	require.Less(t, time.Since(start), time.Second)
+}
@@ -1,6 +1,8 @@
 package shard
 
 import (
+	"io"
+
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/pilorama"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
 	cidSDK "github.com/epicchainlabs/epicchain-sdk-go/container/id"
@@ -111,3 +113,49 @@ func (s *Shard) TreeExists(cid cidSDK.ID, treeID string) (bool, error) {
 	}
 	return s.pilorama.TreeExists(cid, treeID)
 }
+
+// TreeExport implements the pilorama.ForestStorage interface.
+func (s *Shard) TreeExport(cid cidSDK.ID, treeID string, w io.Writer) (uint64, error) {
+	if s.pilorama == nil {
+		return 0, ErrPiloramaDisabled
+	}
+	return s.pilorama.TreeExport(cid, treeID, w)
+}
+
+// TreeImport implements the pilorama.ForestStorage interface.
+func (s *Shard) TreeImport(cid cidSDK.ID, treeID string, r io.Reader) (uint64, error) {
+	if s.pilorama == nil {
+		return 0, ErrPiloramaDisabled
+	}
+
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	if s.info.Mode.ReadOnly() {
+		return 0, ErrReadOnlyMode
+	}
+	return s.pilorama.TreeImport(cid, treeID, r)
+}
+
+// TreeCompact implements the pilorama.ForestStorage interface.
+func (s *Shard) TreeCompact(cid cidSDK.ID, treeID string, height uint64) (uint64, error) {
+	if s.pilorama == nil {
+		return 0, ErrPiloramaDisabled
+	}
+
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	if s.info.Mode.ReadOnly() {
+		return 0, ErrReadOnlyMode
+	}
+	return s.pilorama.TreeCompact(cid, treeID, height)
+}
+
+// TreeLogSize implements the pilorama.ForestStorage interface.
+func (s *Shard) TreeLogSize(cid cidSDK.ID, treeID string) (uint64, error) {
+	if s.pilorama == nil {
+		return 0, ErrPiloramaDisabled
+	}
+	return s.pilorama.TreeLogSize(cid, treeID)
+}
@@ -26,8 +26,15 @@ const (
 	defaultFlushWorkersCount = 20
 	// defaultFlushInterval is default time interval between successive flushes.
 	defaultFlushInterval = time.Second
+	// bigObjectsFlushInterval is time interval between successive flushes of
+	// the big objects.
+	bigObjectsFlushInterval = defaultFlushInterval * 10
 )
 
+// errFlushInterrupted is returned when the background flush is interrupted
+// by closing the write-cache.
+var errFlushInterrupted = errors.New("flush interrupted")
+
 // runFlushLoop starts background workers which periodically flush objects to the blobstor.
 func (c *cache) runFlushLoop() {
 	for i := 0; i < c.workersCount; i++ {
@@ -48,7 +55,9 @@ func (c *cache) runFlushLoop() {
 		for {
 			select {
 			case <-tt.C:
-				c.flushDB()
+				if c.flushAllowed(time.Now()) {
+					c.flushDB()
+				}
 				tt.Reset(defaultFlushInterval)
 			case <-c.closeCh:
 				return
@@ -60,6 +69,7 @@ func (c *cache) runFlushLoop() {
 func (c *cache) flushDB() {
 	var lastKey []byte
 	var m []objectInfo
+	start := time.Now()
 	for {
 		select {
 		case <-c.closeCh:
@@ -129,6 +139,7 @@ func (c *cache) flushDB() {
 
 		if count == 0 {
 			c.modeMtx.RUnlock()
+			c.flushPassDone(false, start)
 			break
 		}
 
@@ -143,17 +154,24 @@ func (c *cache) flushDB() {
 func (c *cache) flushBigObjects() {
 	defer c.wg.Done()
 
-	tick := time.NewTicker(defaultFlushInterval * 10)
+	tick := time.NewTicker(bigObjectsFlushInterval)
 	for {
 		select {
 		case <-tick.C:
+			if !c.flushAllowed(time.Now()) {
+				break
+			}
+
 			c.modeMtx.RLock()
 			if c.readOnly() {
 				c.modeMtx.RUnlock()
 				break
 			}
 
-			_ = c.flushFSTree(true)
+			start := time.Now()
+			if c.flushFSTree(true, true) == nil {
+				c.flushPassDone(true, start)
+			}
 
 			c.modeMtx.RUnlock()
 		case <-c.closeCh:
@@ -172,7 +190,9 @@ func (c *cache) reportFlushError(msg string, addr string, err error) {
 	}
 }
 
-func (c *cache) flushFSTree(ignoreErrors bool) error {
+// flushFSTree flushes big objects to the main storage. If throttle is set,
+// flush speed is limited according to the flush policy.
+func (c *cache) flushFSTree(ignoreErrors, throttle bool) error {
 	var prm common.IteratePrm
 	prm.IgnoreErrors = ignoreErrors
 	prm.LazyHandler = func(addr oid.Address, f func() ([]byte, error)) error {
@@ -207,8 +227,13 @@ func (c *cache) flushFSTree(ignoreErrors bool) error {
 			return err
 		}
 
+		if throttle && !c.throttleFlush(uint64(len(data))) {
+			return errFlushInterrupted
+		}
+
 		err = c.flushObject(&obj, data)
 		if err != nil {
+			c.markPending()
 			if ignoreErrors {
 				return nil
 			}
@@ -238,10 +263,18 @@ func (c *cache) flushWorker(_ int) {
 			return
 		}
 
+		if !c.throttleFlush(obj.PayloadSize()) {
+			return
+		}
+
 		err := c.flushObject(obj, nil)
-		if err == nil {
-			c.flushed.Add(objectCore.AddressOf(obj).EncodeToString(), true)
+		if err != nil {
+			// retried by the next pass, MaxAge trigger included
+			c.markPending()
+			continue
 		}
+
+		c.flushed.Add(objectCore.AddressOf(obj).EncodeToString(), true)
 	}
 }
 
@@ -286,7 +319,7 @@ func (c *cache) Flush(ignoreErrors bool) error {
 }
 
 func (c *cache) flush(ignoreErrors bool) error {
-	if err := c.flushFSTree(ignoreErrors); err != nil {
+	if err := c.flushFSTree(ignoreErrors, false); err != nil {
 		return err
 	}
 
@@ -2,6 +2,7 @@ package writecache
 
 import (
 	"bytes"
+	"io"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/util/logicerr"
@@ -86,3 +87,31 @@ func (c *cache) GetBytes(addr oid.Address) ([]byte, error) {
 	c.flushed.Get(saddr)
 	return b, nil
 }
+
+// GetStream reads object header from write-cache and opens its payload stream.
+// Small objects are read into memory, payload of big ones is read from the
+// file.
+//
+// Returns an error of type apistatus.ObjectNotFound if the requested object is missing in write-cache.
+func (c *cache) GetStream(addr oid.Address) (*objectSDK.Object, io.ReadCloser, error) {
+	saddr := addr.EncodeToString()
+	b, err := get(c.db, []byte(saddr))
+	if err == nil {
+		c.flushed.Get(saddr)
+
+		hdr, payload, err := common.ReadHeader(bytes.NewReader(b))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return hdr, io.NopCloser(payload), nil
+	}
+
+	hdr, payload, err := c.fsTree.GetStream(addr)
+	if err != nil {
+		return nil, nil, logicerr.Wrap(apistatus.ObjectNotFound{})
+	}
+
+	c.flushed.Get(saddr)
+	return hdr, payload, nil
+}
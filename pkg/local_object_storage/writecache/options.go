@@ -6,6 +6,7 @@ import (
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
 	meta "github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/metabase"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
 	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
 	"go.uber.org/zap"
 )
@@ -54,6 +55,10 @@ type options struct {
 	noSync bool
 	// reportError is the function called when encountering disk errors in background workers.
 	reportError func(string, error)
+	// flushPolicy describes when objects are flushed to the main storage.
+	flushPolicy FlushPolicy
+	// ioLimiter throttles writes to the main storage.
+	ioLimiter *throttle.Limiter
 }
 
 // WithLogger sets logger.
@@ -151,3 +156,19 @@ func WithReportErrorFunc(f func(string, error)) Option {
 		o.reportError = f
 	}
 }
+
+// WithFlushPolicy sets policy of the background flushing to the main storage.
+// The policy can be changed with Reload.
+func WithFlushPolicy(p FlushPolicy) Option {
+	return func(o *options) {
+		o.flushPolicy = p
+	}
+}
+
+// WithIOLimiter sets limiter of the shard I/O. Flushing to the main storage
+// is throttled as a background operation.
+func WithIOLimiter(l *throttle.Limiter) Option {
+	return func(o *options) {
+		o.ioLimiter = l
+	}
+}
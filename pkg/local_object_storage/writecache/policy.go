@@ -0,0 +1,242 @@
+package writecache
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/throttle"
+)
+
+// FlushPolicy describes when the write-cache flushes objects to the main
+// storage. Zero value means that objects are flushed as soon as possible.
+//
+// If at least one trigger (MaxAge, FillRatio or Windows) is set, background
+// flushing is done only when any of them fires.
+type FlushPolicy struct {
+	// MaxAge is the maximum time objects are kept in the write-cache without
+	// being flushed. Zero disables the trigger.
+	MaxAge time.Duration
+	// FillRatio is the write-cache fill ratio in the (0, 1] range starting
+	// the flush. Zero disables the trigger.
+	FillRatio float64
+	// Windows are the daily maintenance time windows objects are flushed in.
+	Windows []FlushWindow
+	// RateLimit is the maximum background flush speed in bytes per second.
+	// Zero means no limit.
+	RateLimit uint64
+}
+
+// hasTriggers checks whether flushing is restricted by the policy triggers.
+func (p FlushPolicy) hasTriggers() bool {
+	return p.MaxAge > 0 || p.FillRatio > 0 || len(p.Windows) > 0
+}
+
+// FlushWindow is a daily time window. Start and End are offsets from the
+// midnight of the local time, End less than Start means the window spans
+// midnight.
+type FlushWindow struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseFlushWindow parses time window in the "HH:MM-HH:MM" format.
+func ParseFlushWindow(s string) (FlushWindow, error) {
+	var w FlushWindow
+
+	start, end, ok := strings.Cut(s, "-")
+	if !ok {
+		return w, fmt.Errorf("invalid time window %q: missing '-' separator", s)
+	}
+
+	var err error
+	if w.Start, err = parseDayTime(start); err != nil {
+		return w, fmt.Errorf("invalid time window %q: %w", s, err)
+	}
+	if w.End, err = parseDayTime(end); err != nil {
+		return w, fmt.Errorf("invalid time window %q: %w", s, err)
+	}
+
+	return w, nil
+}
+
+func parseDayTime(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// String returns window in the "HH:MM-HH:MM" format.
+func (w FlushWindow) String() string {
+	return formatDayTime(w.Start) + "-" + formatDayTime(w.End)
+}
+
+func formatDayTime(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// contains checks whether t belongs to the window.
+func (w FlushWindow) contains(t time.Time) bool {
+	y, m, d := t.Date()
+	offset := t.Sub(time.Date(y, m, d, 0, 0, 0, 0, t.Location()))
+
+	if w.Start <= w.End {
+		return w.Start <= offset && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// FlushPolicy returns the current flush policy of the write-cache.
+func (c *cache) FlushPolicy() FlushPolicy {
+	c.policyMtx.RLock()
+	defer c.policyMtx.RUnlock()
+
+	return c.flushPolicy
+}
+
+// Reload applies the reloadable options to the write-cache. Currently, only
+// the flush policy is updated, other options are ignored.
+func (c *cache) Reload(opts ...Option) {
+	var o options
+	for i := range opts {
+		opts[i](&o)
+	}
+
+	c.policyMtx.Lock()
+	c.flushPolicy = o.flushPolicy
+	c.policyMtx.Unlock()
+}
+
+// flushAllowed checks whether background flush can be done at the given time
+// according to the flush policy. Once any trigger fires, flushing is allowed
+// for the big objects flush interval, so both small and big objects are
+// flushed. MaxAge trigger keeps firing until the flush passes of both kinds
+// cover the pending objects, see flushPassDone.
+func (c *cache) flushAllowed(now time.Time) bool {
+	p := c.FlushPolicy()
+	if !p.hasTriggers() {
+		return true
+	}
+
+	if c.policyTriggered(p, now) {
+		c.flushUntil.Store(now.Add(bigObjectsFlushInterval).UnixNano())
+		return true
+	}
+
+	return now.UnixNano() < c.flushUntil.Load()
+}
+
+func (c *cache) policyTriggered(p FlushPolicy, now time.Time) bool {
+	for i := range p.Windows {
+		if p.Windows[i].contains(now) {
+			return true
+		}
+	}
+
+	if p.FillRatio > 0 && float64(c.estimateCacheSize()) >= p.FillRatio*float64(c.maxCacheSize) {
+		return true
+	}
+
+	if p.MaxAge > 0 {
+		if since := c.pendingSince.Load(); since != 0 && now.Sub(time.Unix(0, since)) >= p.MaxAge {
+			return true
+		}
+	}
+
+	return false
+}
+
+// markPending remembers the time of the object put to the write-cache or
+// failed to be flushed from it.
+func (c *cache) markPending() {
+	now := time.Now().UnixNano()
+
+	c.pendingMtx.Lock()
+	c.lastPut = now
+	c.pendingSince.CompareAndSwap(0, now)
+	c.pendingMtx.Unlock()
+}
+
+// flushPassDone records the background flush pass of the small or big
+// objects started at the given time and completed without interruption.
+// Objects put before the start of the last passes of both kinds are flushed,
+// so the pending time is moved to it, or cleared if there were no puts since.
+func (c *cache) flushPassDone(big bool, start time.Time) {
+	c.pendingMtx.Lock()
+	defer c.pendingMtx.Unlock()
+
+	if big {
+		c.bigFlushedAt = start.UnixNano()
+	} else {
+		c.smallFlushedAt = start.UnixNano()
+	}
+
+	covered := c.smallFlushedAt
+	if c.bigFlushedAt < covered {
+		covered = c.bigFlushedAt
+	}
+
+	if since := c.pendingSince.Load(); since == 0 || since >= covered {
+		return
+	}
+
+	if c.lastPut >= covered {
+		c.pendingSince.Store(covered)
+	} else {
+		c.pendingSince.Store(0)
+	}
+}
+
+// flushLimiter limits the background flush speed.
+type flushLimiter struct {
+	mtx  sync.Mutex
+	next time.Time
+}
+
+// reserve reserves sz bytes at the given rate and returns the time to wait
+// before they can be flushed.
+func (l *flushLimiter) reserve(now time.Time, sz, rate uint64) time.Duration {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.next.Before(now) {
+		l.next = now
+	}
+
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(time.Duration(float64(sz) / float64(rate) * float64(time.Second)))
+
+	return wait
+}
+
+// throttleFlush waits until sz bytes can be flushed according to the flush
+// policy rate limit and the shard I/O limits. Returns false if the write-cache
+// was closed while waiting.
+func (c *cache) throttleFlush(sz uint64) bool {
+	if !c.ioLimiter.Wait(c.closeCh, throttle.Background, throttle.Write, 1, sz) {
+		return false
+	}
+
+	rate := c.FlushPolicy().RateLimit
+	if rate == 0 {
+		return true
+	}
+
+	wait := c.limiter.reserve(time.Now(), sz, rate)
+	if wait <= 0 {
+		return true
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return true
+	case <-c.closeCh:
+		return false
+	}
+}
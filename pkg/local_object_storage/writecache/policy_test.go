@@ -0,0 +1,92 @@
+package writecache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFlushWindow(t *testing.T) {
+	w, err := ParseFlushWindow("01:30-05:00")
+	require.NoError(t, err)
+	require.Equal(t, FlushWindow{Start: time.Hour + 30*time.Minute, End: 5 * time.Hour}, w)
+	require.Equal(t, "01:30-05:00", w.String())
+
+	for _, s := range []string{"", "01:30", "1:30-", "25:00-01:00", "01:00-02:60"} {
+		_, err := ParseFlushWindow(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestFlushWindow_Contains(t *testing.T) {
+	at := func(h, m int) time.Time {
+		return time.Date(2024, 1, 1, h, m, 0, 0, time.Local)
+	}
+
+	w := FlushWindow{Start: time.Hour, End: 5 * time.Hour}
+	require.False(t, w.contains(at(0, 59)))
+	require.True(t, w.contains(at(1, 0)))
+	require.True(t, w.contains(at(4, 59)))
+	require.False(t, w.contains(at(5, 0)))
+
+	w = FlushWindow{Start: 22 * time.Hour, End: 2 * time.Hour}
+	require.True(t, w.contains(at(23, 0)))
+	require.True(t, w.contains(at(1, 0)))
+	require.False(t, w.contains(at(12, 0)))
+}
+
+func TestCache_FlushAllowed(t *testing.T) {
+	c := New(WithMaxCacheSize(100 * defaultSmallObjectSize)).(*cache)
+
+	now := time.Now()
+	require.True(t, c.flushAllowed(now), "empty policy must allow flushing")
+
+	c.Reload(WithFlushPolicy(FlushPolicy{MaxAge: time.Minute}))
+	require.Equal(t, FlushPolicy{MaxAge: time.Minute}, c.DumpInfo().FlushPolicy)
+	require.False(t, c.flushAllowed(now), "nothing was put")
+
+	pending := now.Add(-2 * time.Minute)
+	c.pendingSince.Store(pending.UnixNano())
+	c.lastPut = pending.UnixNano()
+	require.True(t, c.flushAllowed(now))
+	require.True(t, c.flushAllowed(now.Add(bigObjectsFlushInterval/2)), "flush is allowed for some time after the trigger")
+	require.True(t, c.flushAllowed(now.Add(bigObjectsFlushInterval)), "objects are not flushed yet")
+
+	// big objects are not flushed yet
+	c.flushPassDone(false, now)
+	require.Equal(t, pending.UnixNano(), c.pendingSince.Load())
+	require.True(t, c.flushAllowed(now.Add(bigObjectsFlushInterval)))
+
+	// objects put during the pass are pending since its start
+	c.lastPut = now.Add(time.Second).UnixNano()
+	c.flushPassDone(true, now)
+	require.Equal(t, now.UnixNano(), c.pendingSince.Load())
+	require.False(t, c.flushAllowed(now.Add(3*bigObjectsFlushInterval)))
+
+	later := now.Add(2 * time.Second)
+	c.flushPassDone(false, later)
+	c.flushPassDone(true, later)
+	require.Zero(t, c.pendingSince.Load())
+	require.False(t, c.flushAllowed(now.Add(2*time.Minute)), "all objects are flushed")
+
+	now = now.Add(time.Hour)
+	c.Reload(WithFlushPolicy(FlushPolicy{FillRatio: 0.5}))
+	c.objCounters.cDB.Store(49)
+	require.False(t, c.flushAllowed(now))
+	c.objCounters.cDB.Store(50)
+	require.True(t, c.flushAllowed(now))
+
+	c.Reload()
+	require.Equal(t, FlushPolicy{}, c.DumpInfo().FlushPolicy)
+}
+
+func TestFlushLimiter(t *testing.T) {
+	var l flushLimiter
+
+	now := time.Now()
+	require.Zero(t, l.reserve(now, 1000, 1000))
+	require.Equal(t, time.Second, l.reserve(now, 500, 1000))
+	require.Equal(t, 1500*time.Millisecond, l.reserve(now, 1, 1000))
+	require.Zero(t, l.reserve(now.Add(time.Minute), 1, 1000))
+}
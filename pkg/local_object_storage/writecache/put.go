@@ -59,6 +59,7 @@ func (c *cache) putSmall(obj objectInfo) error {
 			storagelog.OpField("db PUT"),
 		)
 		c.objCounters.IncDB()
+		c.markPending()
 	}
 	return err
 }
@@ -81,6 +82,7 @@ func (c *cache) putBig(addr string, prm common.PutPrm) error {
 		c.mtx.Unlock()
 	}
 	c.objCounters.IncFS()
+	c.markPending()
 	storagelog.Write(c.log,
 		storagelog.AddressField(addr),
 		storagelog.StorageTypeField(wcStorageType),
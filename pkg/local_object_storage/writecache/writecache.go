@@ -1,7 +1,9 @@
 package writecache
 
 import (
+	"io"
 	"sync"
+	"sync/atomic"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/common"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor/fstree"
@@ -16,6 +18,8 @@ import (
 type Info struct {
 	// Full path to the write-cache.
 	Path string
+	// Flush policy in use.
+	FlushPolicy FlushPolicy
 }
 
 // Cache represents write-cache for objects.
@@ -25,6 +29,10 @@ type Cache interface {
 	// canonical NeoFS binary format. Returns [apistatus.ObjectNotFound] if object
 	// is missing.
 	GetBytes(oid.Address) ([]byte, error)
+	// GetStream reads object header from the Cache by address and opens its
+	// payload stream. The stream must be closed by the caller. Returns
+	// [apistatus.ObjectNotFound] if object is missing.
+	GetStream(oid.Address) (*object.Object, io.ReadCloser, error)
 	Head(oid.Address) (*object.Object, error)
 	// Delete removes object referenced by the given oid.Address from the
 	// Cache. Returns any error encountered that prevented the object to be
@@ -39,6 +47,9 @@ type Cache interface {
 	SetLogger(*zap.Logger)
 	DumpInfo() Info
 	Flush(bool) error
+	// Reload applies the reloadable options to the Cache. Currently, only
+	// the flush policy is updated.
+	Reload(...Option)
 
 	Init() error
 	Open(readOnly bool) error
@@ -65,6 +76,24 @@ type cache struct {
 	closeCh chan struct{}
 	// wg is a wait group for flush workers.
 	wg sync.WaitGroup
+	// policyMtx protects flushPolicy.
+	policyMtx sync.RWMutex
+	// pendingMtx synchronizes updates of pendingSince along with the put
+	// and the flush pass times.
+	pendingMtx sync.Mutex
+	// pendingSince is the time (Unix nanoseconds) of the first object put
+	// that may be not flushed yet, zero if all objects are flushed.
+	pendingSince atomic.Int64
+	// lastPut is the time (Unix nanoseconds) of the last object put.
+	lastPut int64
+	// smallFlushedAt and bigFlushedAt are the start times (Unix nanoseconds)
+	// of the last completed background flush passes of small and big objects.
+	smallFlushedAt, bigFlushedAt int64
+	// flushUntil is the time (Unix nanoseconds) background flushing is
+	// allowed until after the last flush policy trigger.
+	flushUntil atomic.Int64
+	// limiter limits the background flush speed.
+	limiter flushLimiter
 	// store contains underlying database.
 	store
 	// fsTree contains big files stored directly on file-system.
@@ -128,7 +157,8 @@ func (c *cache) SetLogger(l *zap.Logger) {
 
 func (c *cache) DumpInfo() Info {
 	return Info{
-		Path: c.path,
+		Path:        c.path,
+		FlushPolicy: c.FlushPolicy(),
 	}
 }
 
@@ -149,6 +179,9 @@ func (c *cache) Open(readOnly bool) error {
 // Init runs necessary services. No-op in read-only mode.
 func (c *cache) Init() error {
 	if !c.db.IsReadOnly() {
+		if c.estimateCacheSize() > 0 {
+			c.markPending()
+		}
 		c.initFlushMarks()
 		c.runFlushLoop()
 	}
@@ -22,10 +22,19 @@ type (
 
 		containerSize prometheus.GaugeVec
 		payloadSize   prometheus.GaugeVec
+
+		throttledTime prometheus.CounterVec
+
+		scrubbedObjects  prometheus.GaugeVec
+		corruptedObjects prometheus.GaugeVec
 	}
 )
 
-const engineSubsystem = "engine"
+const (
+	engineSubsystem = "engine"
+
+	ioClassLabelKey = "class"
+)
 
 func newEngineMetrics() engineMetrics {
 	var (
@@ -121,6 +130,27 @@ func newEngineMetrics() engineMetrics {
 			Name:      "payload_size",
 			Help:      "Accumulated size of all objects in a shard",
 		}, []string{shardIDLabelKey})
+
+		throttledTime = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: storageNodeNameSpace,
+			Subsystem: engineSubsystem,
+			Name:      "throttled_time",
+			Help:      "Total time in seconds shard operations waited for the I/O limits",
+		}, []string{shardIDLabelKey, ioClassLabelKey})
+
+		scrubbedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: storageNodeNameSpace,
+			Subsystem: engineSubsystem,
+			Name:      "scrubbed_objects",
+			Help:      "Number of objects checked in the current scrubbing pass of a shard",
+		}, []string{shardIDLabelKey})
+
+		corruptedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: storageNodeNameSpace,
+			Subsystem: engineSubsystem,
+			Name:      "corrupted_objects",
+			Help:      "Number of corrupted objects found by the scrubbing of a shard",
+		}, []string{shardIDLabelKey})
 	)
 
 	return engineMetrics{
@@ -137,6 +167,9 @@ func newEngineMetrics() engineMetrics {
 		listObjectsDuration:           listObjectsDuration,
 		containerSize:                 *containerSize,
 		payloadSize:                   *payloadSize,
+		throttledTime:                 *throttledTime,
+		scrubbedObjects:               *scrubbedObjects,
+		corruptedObjects:              *corruptedObjects,
 	}
 }
 
@@ -154,6 +187,9 @@ func (m engineMetrics) register() {
 	prometheus.MustRegister(m.listObjectsDuration)
 	prometheus.MustRegister(m.containerSize)
 	prometheus.MustRegister(m.payloadSize)
+	prometheus.MustRegister(m.throttledTime)
+	prometheus.MustRegister(m.scrubbedObjects)
+	prometheus.MustRegister(m.corruptedObjects)
 }
 
 func (m engineMetrics) AddListContainersDuration(d time.Duration) {
@@ -211,3 +247,18 @@ func (m engineMetrics) AddToContainerSize(cnrID string, size int64) {
 func (m engineMetrics) AddToPayloadCounter(shardID string, size int64) {
 	m.payloadSize.With(prometheus.Labels{shardIDLabelKey: shardID}).Add(float64(size))
 }
+
+func (m engineMetrics) AddThrottledTime(shardID, class string, d time.Duration) {
+	m.throttledTime.With(prometheus.Labels{
+		shardIDLabelKey: shardID,
+		ioClassLabelKey: class,
+	}).Add(d.Seconds())
+}
+
+func (m engineMetrics) SetScrubbedObjects(shardID string, v uint64) {
+	m.scrubbedObjects.With(prometheus.Labels{shardIDLabelKey: shardID}).Set(float64(v))
+}
+
+func (m engineMetrics) SetCorruptedObjects(shardID string, v uint64) {
+	m.corruptedObjects.With(prometheus.Labels{shardIDLabelKey: shardID}).Set(float64(v))
+}
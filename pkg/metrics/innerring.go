@@ -8,6 +8,7 @@ const innerRingNameSpace = "neofs_ir"
 
 // InnerRingServiceMetrics contains metrics collected by inner ring.
 type InnerRingServiceMetrics struct {
+	morphMetrics
 	epoch prometheus.Gauge
 }
 
@@ -23,8 +24,12 @@ func NewInnerRingMetrics(version string) InnerRingServiceMetrics {
 	})
 	prometheus.MustRegister(epoch)
 
+	morph := newMorphMetrics(innerRingNameSpace)
+	morph.register()
+
 	return InnerRingServiceMetrics{
-		epoch: epoch,
+		morphMetrics: morph,
+		epoch:        epoch,
 	}
 }
 
@@ -10,6 +10,10 @@ type NodeMetrics struct {
 	objectServiceMetrics
 	engineMetrics
 	stateMetrics
+	treeServiceMetrics
+	policerMetrics
+	rateLimitMetrics
+	morphMetrics
 	epoch prometheus.Gauge
 }
 
@@ -25,6 +29,18 @@ func NewNodeMetrics(version string) *NodeMetrics {
 	state := newStateMetrics()
 	state.register()
 
+	tree := newTreeServiceMetrics()
+	tree.register()
+
+	policer := newPolicerMetrics()
+	policer.register()
+
+	rateLimit := newRateLimitMetrics()
+	rateLimit.register()
+
+	morph := newMorphMetrics(storageNodeNameSpace)
+	morph.register()
+
 	epoch := prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: storageNodeNameSpace,
 		Subsystem: stateSubsystem,
@@ -37,6 +53,10 @@ func NewNodeMetrics(version string) *NodeMetrics {
 		objectServiceMetrics: objectService,
 		engineMetrics:        engine,
 		stateMetrics:         state,
+		treeServiceMetrics:   tree,
+		policerMetrics:       policer,
+		rateLimitMetrics:     rateLimit,
+		morphMetrics:         morph,
 		epoch:                epoch,
 	}
 }
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	morphSubsystem = "morph"
+
+	endpointLabelKey = "endpoint"
+)
+
+type morphMetrics struct {
+	endpointHeight  *prometheus.GaugeVec
+	endpointLatency *prometheus.GaugeVec
+	endpointScore   *prometheus.GaugeVec
+	endpointErrors  *prometheus.CounterVec
+	activeEndpoint  *prometheus.GaugeVec
+	switches        *prometheus.CounterVec
+}
+
+func newMorphMetrics(namespace string) morphMetrics {
+	return morphMetrics{
+		endpointHeight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: morphSubsystem,
+			Name:      "endpoint_height",
+			Help:      "Last known block height of the morph chain RPC endpoint",
+		}, []string{endpointLabelKey}),
+		endpointLatency: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: morphSubsystem,
+			Name:      "endpoint_latency_seconds",
+			Help:      "Smoothed health check latency of the morph chain RPC endpoint",
+		}, []string{endpointLabelKey}),
+		endpointScore: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: morphSubsystem,
+			Name:      "endpoint_score",
+			Help:      "Health score of the morph chain RPC endpoint, lower is better",
+		}, []string{endpointLabelKey}),
+		endpointErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: morphSubsystem,
+			Name:      "endpoint_errors",
+			Help:      "Number of failed health checks of the morph chain RPC endpoint",
+		}, []string{endpointLabelKey}),
+		activeEndpoint: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: morphSubsystem,
+			Name:      "active_endpoint",
+			Help:      "Morph chain RPC endpoint the node is connected to (1) or not (0)",
+		}, []string{endpointLabelKey}),
+		switches: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: morphSubsystem,
+			Name:      "endpoint_switches",
+			Help:      "Number of switches to the morph chain RPC endpoint",
+		}, []string{endpointLabelKey}),
+	}
+}
+
+func (m morphMetrics) register() {
+	prometheus.MustRegister(m.endpointHeight)
+	prometheus.MustRegister(m.endpointLatency)
+	prometheus.MustRegister(m.endpointScore)
+	prometheus.MustRegister(m.endpointErrors)
+	prometheus.MustRegister(m.activeEndpoint)
+	prometheus.MustRegister(m.switches)
+}
+
+func (m morphMetrics) SetMorphEndpointHeight(endpoint string, height uint32) {
+	m.endpointHeight.With(prometheus.Labels{endpointLabelKey: endpoint}).Set(float64(height))
+}
+
+func (m morphMetrics) SetMorphEndpointLatency(endpoint string, latency time.Duration) {
+	m.endpointLatency.With(prometheus.Labels{endpointLabelKey: endpoint}).Set(latency.Seconds())
+}
+
+func (m morphMetrics) SetMorphEndpointScore(endpoint string, score float64) {
+	m.endpointScore.With(prometheus.Labels{endpointLabelKey: endpoint}).Set(score)
+}
+
+func (m morphMetrics) IncMorphEndpointErrors(endpoint string) {
+	m.endpointErrors.With(prometheus.Labels{endpointLabelKey: endpoint}).Inc()
+}
+
+func (m morphMetrics) SetMorphEndpointActive(endpoint string, active bool) {
+	var v float64
+	if active {
+		v = 1
+	}
+
+	m.activeEndpoint.With(prometheus.Labels{endpointLabelKey: endpoint}).Set(v)
+}
+
+func (m morphMetrics) IncMorphEndpointSwitches(endpoint string) {
+	m.switches.With(prometheus.Labels{endpointLabelKey: endpoint}).Inc()
+}
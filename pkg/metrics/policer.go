@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	policerSubsystem = "policer"
+
+	deficitLabelKey = "deficit"
+)
+
+type policerMetrics struct {
+	queueDepth *prometheus.GaugeVec
+}
+
+func newPolicerMetrics() policerMetrics {
+	return policerMetrics{
+		queueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: storageNodeNameSpace,
+			Subsystem: policerSubsystem,
+			Name:      "queue_depth",
+			Help:      "Number of objects waiting for the prioritized policy check by the estimated number of lost replicas",
+		}, []string{deficitLabelKey}),
+	}
+}
+
+func (m policerMetrics) register() {
+	prometheus.MustRegister(m.queueDepth)
+}
+
+func (m policerMetrics) SetPolicerQueueDepth(deficit uint32, depth uint64) {
+	m.queueDepth.With(prometheus.Labels{
+		deficitLabelKey: strconv.FormatUint(uint64(deficit), 10),
+	}).Set(float64(depth))
+}
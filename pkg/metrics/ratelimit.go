@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const limitClassLabelKey = "class"
+
+type rateLimitMetrics struct {
+	limitedRequests *prometheus.CounterVec
+}
+
+func newRateLimitMetrics() rateLimitMetrics {
+	return rateLimitMetrics{
+		limitedRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: storageNodeNameSpace,
+			Subsystem: objectSubsystem,
+			Name:      "rate_limited_requests",
+			Help:      "Number of object requests rejected by the rate limits by the limited key class",
+		}, []string{limitClassLabelKey}),
+	}
+}
+
+func (m rateLimitMetrics) register() {
+	prometheus.MustRegister(m.limitedRequests)
+}
+
+func (m rateLimitMetrics) IncRateLimitedRequests(class string) {
+	m.limitedRequests.With(prometheus.Labels{
+		limitClassLabelKey: class,
+	}).Inc()
+}
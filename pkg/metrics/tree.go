@@ -0,0 +1,35 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	treeSubsystem = "treeservice"
+
+	treeIDLabelKey = "tree"
+)
+
+type treeServiceMetrics struct {
+	logSize *prometheus.GaugeVec
+}
+
+func newTreeServiceMetrics() treeServiceMetrics {
+	return treeServiceMetrics{
+		logSize: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: storageNodeNameSpace,
+			Subsystem: treeSubsystem,
+			Name:      "log_size",
+			Help:      "Number of operations stored in the tree log",
+		}, []string{containerIDLabelKey, treeIDLabelKey}),
+	}
+}
+
+func (m treeServiceMetrics) register() {
+	prometheus.MustRegister(m.logSize)
+}
+
+func (m treeServiceMetrics) SetTreeLogSize(cnrID, treeID string, size uint64) {
+	m.logSize.With(prometheus.Labels{
+		containerIDLabelKey: cnrID,
+		treeIDLabelKey:      treeID,
+	}).Set(float64(size))
+}
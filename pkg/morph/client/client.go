@@ -68,6 +68,15 @@ type Client struct {
 
 	endpoints []string
 
+	// endpoint the client is connected to
+	endpoint string
+
+	// health of the endpoints, nil if health checks are disabled
+	health *healthTracker
+
+	// endpoints to switch to proactively
+	switchChan chan string
+
 	// switchLock protects endpoints, inactive, and subscription-related fields.
 	// It is taken exclusively during endpoint switch and locked in shared mode
 	// on every normal call.
@@ -546,6 +555,69 @@ func (c *Client) TxHeight(h util.Uint256) (res uint32, err error) {
 	return c.client.GetTransactionHeight(h)
 }
 
+// BlockNotifications returns the notifications emitted during the successful
+// executions of the block with the specified index, in the order they have
+// been emitted: OnPersist, transactions, PostPersist.
+func (c *Client) BlockNotifications(index uint32) ([]*state.ContainedNotificationEvent, error) {
+	c.switchLock.RLock()
+	defer c.switchLock.RUnlock()
+
+	if c.inactive {
+		return nil, ErrConnectionLost
+	}
+
+	b, err := c.client.GetBlockByIndex(index)
+	if err != nil {
+		return nil, fmt.Errorf("get block %d: %w", index, err)
+	}
+
+	blockLog, err := c.client.GetApplicationLog(b.Hash(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("get application log of block %d: %w", index, err)
+	}
+
+	var res []*state.ContainedNotificationEvent
+
+	appendEvents := func(container util.Uint256, exec state.Execution) {
+		if !exec.VMState.HasFlag(vmstate.Halt) {
+			return
+		}
+
+		for i := range exec.Events {
+			res = append(res, &state.ContainedNotificationEvent{
+				Container:         container,
+				NotificationEvent: exec.Events[i],
+			})
+		}
+	}
+
+	appendBlockEvents := func(trig trigger.Type) {
+		for _, exec := range blockLog.Executions {
+			if exec.Trigger == trig {
+				appendEvents(b.Hash(), exec)
+			}
+		}
+	}
+
+	appendBlockEvents(trigger.OnPersist)
+
+	trig := trigger.Application
+	for _, tx := range b.Transactions {
+		txLog, err := c.client.GetApplicationLog(tx.Hash(), &trig)
+		if err != nil {
+			return nil, fmt.Errorf("get application log of transaction %s: %w", tx.Hash().StringLE(), err)
+		}
+
+		for _, exec := range txLog.Executions {
+			appendEvents(tx.Hash(), exec)
+		}
+	}
+
+	appendBlockEvents(trigger.PostPersist)
+
+	return res, nil
+}
+
 // NeoFSAlphabetList returns keys that stored in NeoFS Alphabet role. Main chain
 // stores alphabet node keys of inner ring there, however the sidechain stores both
 // alphabet and non alphabet node keys of inner ring.
@@ -51,11 +51,19 @@ type cfg struct {
 
 	reconnectionRetries int
 	reconnectionDelay   time.Duration
+
+	healthCheckInterval time.Duration
+	switchChecks        int
+
+	metrics Metrics
 }
 
 const (
 	defaultDialTimeout  = 5 * time.Second
 	defaultWaitInterval = 500 * time.Millisecond
+
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultSwitchChecks        = 3
 )
 
 func defaultConfig() *cfg {
@@ -69,6 +77,8 @@ func defaultConfig() *cfg {
 		},
 		reconnectionDelay:   5 * time.Second,
 		reconnectionRetries: 5,
+		healthCheckInterval: defaultHealthCheckInterval,
+		switchChecks:        defaultSwitchChecks,
 	}
 }
 
@@ -89,6 +99,8 @@ var ErrStaleNodes = errors.New("RPC nodes are not yet up to date")
 //   - blockchain network type: netmode.PrivNet;
 //   - signer with the CalledByEntry scope;
 //   - wait interval: 500ms;
+//   - endpoints health check interval: 10s;
+//   - number of health checks to switch to the healthier endpoint: 3;
 //   - logger: &zap.Logger{Logger: zap.L()}.
 //
 // If desired option satisfies the default value, it can be omitted.
@@ -118,6 +130,7 @@ func New(key *keys.PrivateKey, opts ...Option) (*Client, error) {
 		cfg:        *cfg,
 		switchLock: &sync.RWMutex{},
 		closeChan:  make(chan struct{}),
+		switchChan: make(chan string, 1),
 		subs: subscriptions{
 			notifyChan:             make(chan *state.ContainedNotificationEvent),
 			blockChan:              make(chan *block.Block),
@@ -160,12 +173,19 @@ func New(key *keys.PrivateKey, opts ...Option) (*Client, error) {
 		}
 
 		cli.endpoints = cfg.endpoints
+		if cfg.healthCheckInterval > 0 {
+			cli.health = newHealthTracker(cli.endpoints, cfg.switchChecks)
+		}
+
 		for _, e := range cli.endpoints {
 			cli.client, act, err = cli.newCli(e)
 			if err != nil {
 				cli.logger.Warn("Neo RPC connection failure", zap.String("endpoint", e), zap.Error(err))
 				continue
 			}
+
+			cli.setEndpoint(e)
+			break
 		}
 
 		if err != nil {
@@ -191,6 +211,10 @@ func New(key *keys.PrivateKey, opts ...Option) (*Client, error) {
 	go cli.routeNotifications()
 	go cli.closeWaiter()
 
+	if cli.health != nil {
+		go cli.healthLoop()
+	}
+
 	return cli, nil
 }
 
@@ -353,6 +377,38 @@ func WithConnSwitchCallback(cb Callback) Option {
 	}
 }
 
+// WithHealthCheckInterval returns a client constructor option that
+// specifies the interval between health checks of the endpoints provided
+// via [WithEndpoints]. The health of the endpoint is scored by its block
+// height lag, latency and error rate. Non-positive value disables health
+// checks and the proactive switches to the healthier endpoints.
+//
+// Has no effect if WithSingleClient is provided.
+func WithHealthCheckInterval(d time.Duration) Option {
+	return func(c *cfg) {
+		c.healthCheckInterval = d
+	}
+}
+
+// WithSwitchChecksNumber returns a client constructor option that specifies
+// the number of consecutive health checks the endpoint must be healthier in
+// than the current one to be switched to. Ignores non-positive values.
+func WithSwitchChecksNumber(n int) Option {
+	return func(c *cfg) {
+		if n > 0 {
+			c.switchChecks = n
+		}
+	}
+}
+
+// WithMetrics returns a client constructor option that specifies the
+// component for the RPC endpoints statistics.
+func WithMetrics(m Metrics) Option {
+	return func(c *cfg) {
+		c.metrics = m
+	}
+}
+
 // WithMinRequiredBlockHeight returns a client constructor
 // option that specifies a minimal chain height that is
 // considered as acceptable. [New] returns [ErrStaleNodes]
@@ -0,0 +1,260 @@
+package client
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Endpoint health score weights: each block of the lag behind the highest
+// known endpoint, each scoreLatencyUnit of the request latency and each
+// scoreErrorRateUnit of the failed checks share add one point to the score.
+// Lower score is better.
+const (
+	scoreLatencyUnit   = 100 * time.Millisecond
+	scoreErrorRateUnit = 0.1
+
+	// scoreHysteresis is a score margin the candidate endpoint must be
+	// better than the current one by to be switched to.
+	scoreHysteresis = 2
+
+	// healthSmoothing is a weight of the latest check in the exponentially
+	// weighted moving averages of the latency and the error rate.
+	healthSmoothing = 0.3
+)
+
+// Metrics collects the statistics of the RPC endpoints.
+type Metrics interface {
+	// SetMorphEndpointHeight sets the last known block height of the endpoint.
+	SetMorphEndpointHeight(endpoint string, height uint32)
+
+	// SetMorphEndpointLatency sets the smoothed health check latency of the
+	// endpoint.
+	SetMorphEndpointLatency(endpoint string, latency time.Duration)
+
+	// SetMorphEndpointScore sets the health score of the endpoint, lower is
+	// better.
+	SetMorphEndpointScore(endpoint string, score float64)
+
+	// IncMorphEndpointErrors increases the number of the failed health checks
+	// of the endpoint.
+	IncMorphEndpointErrors(endpoint string)
+
+	// SetMorphEndpointActive marks whether the Client is connected to the
+	// endpoint.
+	SetMorphEndpointActive(endpoint string, active bool)
+
+	// IncMorphEndpointSwitches increases the number of the switches to the
+	// RPC endpoint.
+	IncMorphEndpointSwitches(endpoint string)
+}
+
+// endpointHealth is a result of the endpoint health checks.
+type endpointHealth struct {
+	checked bool
+	alive   bool
+
+	height  uint32
+	latency time.Duration
+	errRate float64
+}
+
+// score returns the endpoint health score relative to the highest known
+// block. Lower is better, unreachable endpoint has infinite score and the
+// endpoint that has never been checked has zero score.
+func (h endpointHealth) score(maxHeight uint32) float64 {
+	if !h.checked {
+		return 0
+	}
+
+	if !h.alive {
+		return math.Inf(1)
+	}
+
+	var lag uint32
+	if maxHeight > h.height {
+		lag = maxHeight - h.height
+	}
+
+	return float64(lag) +
+		float64(h.latency)/float64(scoreLatencyUnit) +
+		h.errRate/scoreErrorRateUnit
+}
+
+// healthTracker accumulates the results of the endpoint health checks and
+// chooses the endpoint to switch to.
+type healthTracker struct {
+	mtx sync.Mutex
+
+	// endpoints in the order of decreasing priority
+	endpoints []string
+	stats     map[string]*endpointHealth
+
+	// number of consecutive checks the candidate must be better in
+	switchChecks int
+
+	candidate      string
+	candidateTimes int
+}
+
+func newHealthTracker(endpoints []string, switchChecks int) *healthTracker {
+	stats := make(map[string]*endpointHealth, len(endpoints))
+	for _, e := range endpoints {
+		stats[e] = new(endpointHealth)
+	}
+
+	return &healthTracker{
+		endpoints:    endpoints,
+		stats:        stats,
+		switchChecks: switchChecks,
+	}
+}
+
+// update saves the result of the endpoint health check.
+func (t *healthTracker) update(endpoint string, height uint32, latency time.Duration, err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	h, ok := t.stats[endpoint]
+	if !ok {
+		return
+	}
+
+	var failed float64
+	if err != nil {
+		failed = 1
+	}
+
+	if !h.checked {
+		h.checked = true
+		h.errRate = failed
+		h.latency = latency
+	} else {
+		h.errRate = healthSmoothing*failed + (1-healthSmoothing)*h.errRate
+		if err == nil {
+			h.latency = time.Duration(healthSmoothing*float64(latency) + (1-healthSmoothing)*float64(h.latency))
+		}
+	}
+
+	h.alive = err == nil
+	if err == nil {
+		h.height = height
+	}
+}
+
+func (t *healthTracker) maxHeight() uint32 {
+	var res uint32
+	for _, h := range t.stats {
+		if h.alive && h.height > res {
+			res = h.height
+		}
+	}
+	return res
+}
+
+// scores returns the health scores of all the endpoints.
+func (t *healthTracker) scores() map[string]float64 {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	maxHeight := t.maxHeight()
+
+	res := make(map[string]float64, len(t.stats))
+	for e, h := range t.stats {
+		res[e] = h.score(maxHeight)
+	}
+
+	return res
+}
+
+// latency returns the smoothed health check latency of the endpoint.
+func (t *healthTracker) latency(endpoint string) time.Duration {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if h, ok := t.stats[endpoint]; ok {
+		return h.latency
+	}
+
+	return 0
+}
+
+// ordered returns the endpoints sorted by the health score, endpoints with
+// the same score keep the priority order.
+func (t *healthTracker) ordered() []string {
+	scores := t.scores()
+
+	res := make([]string, len(t.endpoints))
+	copy(res, t.endpoints)
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return scores[res[i]] < scores[res[j]]
+	})
+
+	return res
+}
+
+// nextSwitch returns the endpoint the Client connected to the current one
+// should switch to. The endpoint is returned only after it has been the best
+// candidate for the configured number of consecutive checks. The candidate
+// must either have the score better by scoreHysteresis or have a higher
+// priority and the score worse by less than scoreHysteresis, so the switches
+// back and forth do not alternate.
+func (t *healthTracker) nextSwitch(current string) (string, bool) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	maxHeight := t.maxHeight()
+
+	cur, ok := t.stats[current]
+	if !ok {
+		t.candidate, t.candidateTimes = "", 0
+		return "", false
+	}
+
+	curScore := cur.score(maxHeight)
+	curPriority := len(t.endpoints)
+	for i := range t.endpoints {
+		if t.endpoints[i] == current {
+			curPriority = i
+			break
+		}
+	}
+
+	var (
+		best      string
+		bestScore = math.Inf(1)
+	)
+
+	for i, e := range t.endpoints {
+		if e == current || !t.stats[e].checked || !t.stats[e].alive {
+			continue
+		}
+
+		s := t.stats[e].score(maxHeight)
+		if s+scoreHysteresis < curScore || (i < curPriority && s < curScore+scoreHysteresis) {
+			if best == "" || s < bestScore {
+				best, bestScore = e, s
+			}
+		}
+	}
+
+	if best == "" {
+		t.candidate, t.candidateTimes = "", 0
+		return "", false
+	}
+
+	if best != t.candidate {
+		t.candidate, t.candidateTimes = best, 0
+	}
+
+	t.candidateTimes++
+	if t.candidateTimes < t.switchChecks {
+		return "", false
+	}
+
+	t.candidate, t.candidateTimes = "", 0
+
+	return best, true
+}
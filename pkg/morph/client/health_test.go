@@ -0,0 +1,126 @@
+package client
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointHealth_Score(t *testing.T) {
+	require.Zero(t, endpointHealth{}.score(100))
+	require.True(t, math.IsInf(endpointHealth{checked: true}.score(100), 1))
+
+	h := endpointHealth{
+		checked: true,
+		alive:   true,
+		height:  95,
+		latency: 200 * time.Millisecond,
+		errRate: 0.2,
+	}
+	require.InDelta(t, 5+2+2, h.score(100), 1e-9)
+	require.InDelta(t, 2+2, h.score(90), 1e-9)
+}
+
+func TestHealthTracker(t *testing.T) {
+	const a, b, c = "a", "b", "c"
+	errAny := errors.New("any error")
+
+	newTracker := func() *healthTracker {
+		tr := newHealthTracker([]string{a, b, c}, 2)
+		for _, e := range []string{a, b, c} {
+			tr.update(e, 100, time.Millisecond, nil)
+		}
+		return tr
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		tr := newTracker()
+
+		for i := 0; i < 5; i++ {
+			_, ok := tr.nextSwitch(a)
+			require.False(t, ok)
+		}
+		require.Equal(t, []string{a, b, c}, tr.ordered())
+	})
+
+	t.Run("lagging", func(t *testing.T) {
+		tr := newTracker()
+		tr.update(b, 110, time.Millisecond, nil)
+		tr.update(c, 110, time.Millisecond, nil)
+
+		_, ok := tr.nextSwitch(a)
+		require.False(t, ok) // hysteresis
+
+		e, ok := tr.nextSwitch(a)
+		require.True(t, ok)
+		require.Equal(t, b, e)
+
+		require.Equal(t, []string{b, c, a}, tr.ordered())
+	})
+
+	t.Run("small lag", func(t *testing.T) {
+		tr := newTracker()
+		tr.update(b, 101, time.Millisecond, nil)
+
+		for i := 0; i < 5; i++ {
+			_, ok := tr.nextSwitch(a)
+			require.False(t, ok)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		tr := newTracker()
+		tr.update(a, 0, time.Millisecond, errAny)
+		tr.update(b, 0, time.Millisecond, errAny)
+
+		_, ok := tr.nextSwitch(a)
+		require.False(t, ok)
+
+		e, ok := tr.nextSwitch(a)
+		require.True(t, ok)
+		require.Equal(t, c, e)
+
+		require.Equal(t, []string{c, a, b}, tr.ordered())
+	})
+
+	t.Run("interrupted", func(t *testing.T) {
+		tr := newTracker()
+		tr.update(b, 110, time.Millisecond, nil)
+		tr.update(c, 110, time.Millisecond, nil)
+
+		_, ok := tr.nextSwitch(a)
+		require.False(t, ok)
+
+		tr.update(a, 110, time.Millisecond, nil)
+		_, ok = tr.nextSwitch(a)
+		require.False(t, ok)
+
+		tr.update(b, 120, time.Millisecond, nil)
+		_, ok = tr.nextSwitch(a)
+		require.False(t, ok)
+	})
+
+	t.Run("back to priority", func(t *testing.T) {
+		tr := newTracker()
+
+		_, ok := tr.nextSwitch(c)
+		require.False(t, ok)
+
+		e, ok := tr.nextSwitch(c)
+		require.True(t, ok)
+		require.Equal(t, a, e)
+
+		// higher priority node is lagging a bit, but not enough to switch
+		// back and forth
+		tr = newTracker()
+		tr.update(c, 103, time.Millisecond, nil)
+
+		for i := 0; i < 5; i++ {
+			_, ok = tr.nextSwitch(c)
+			require.False(t, ok)
+		}
+	})
+}
@@ -1,8 +1,13 @@
 package client
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/epicchainlabs/epicchain-go/pkg/core/block"
+	"github.com/epicchainlabs/epicchain-go/pkg/core/state"
+	"github.com/epicchainlabs/epicchain-go/pkg/neorpc/result"
+	"github.com/epicchainlabs/epicchain-go/pkg/rpcclient"
 	"go.uber.org/zap"
 )
 
@@ -48,8 +53,14 @@ func (c *Client) SwitchRPC() bool {
 func (c *Client) switchRPC() bool {
 	c.client.Close()
 
-	// Iterate endpoints in the order of decreasing priority.
-	for _, e := range c.endpoints {
+	// Iterate endpoints in the order of decreasing health score and
+	// priority, so lagging and unreachable nodes are tried last.
+	endpoints := c.endpoints
+	if c.health != nil {
+		endpoints = c.health.ordered()
+	}
+
+	for _, e := range endpoints {
 		cli, act, err := c.newCli(e)
 		if err != nil {
 			c.logger.Warn("could not establish connection to the switched RPC node",
@@ -67,12 +78,239 @@ func (c *Client) switchRPC() bool {
 
 		c.client = cli
 		c.setActor(act)
+		c.setEndpoint(e)
 
 		return true
 	}
 	return false
 }
 
+// setEndpoint saves the endpoint the Client is connected to.
+func (c *Client) setEndpoint(e string) {
+	prev := c.endpoint
+	c.endpoint = e
+
+	if c.cfg.metrics != nil {
+		if prev != "" {
+			c.cfg.metrics.SetMorphEndpointActive(prev, false)
+			c.cfg.metrics.IncMorphEndpointSwitches(e)
+		}
+		c.cfg.metrics.SetMorphEndpointActive(e, true)
+	}
+}
+
+// switchTo connects the Client to the given healthy endpoint before closing
+// the current connection. The endpoint is not switched to if it is behind
+// the current one. Returns true if the Client has been switched.
+func (c *Client) switchTo(endpoint string) bool {
+	c.switchLock.Lock()
+
+	if c.inactive || c.endpoint == endpoint {
+		c.switchLock.Unlock()
+		return false
+	}
+
+	cli, act, err := c.newCli(endpoint)
+	if err != nil {
+		c.switchLock.Unlock()
+
+		c.logger.Warn("could not establish connection to the healthier RPC node",
+			zap.String("endpoint", endpoint),
+			zap.Error(err),
+		)
+
+		return false
+	}
+
+	curHeight, curErr := c.rpcActor.GetBlockCount()
+	newHeight, err := act.GetBlockCount()
+	if err == nil && curErr == nil && newHeight < curHeight {
+		err = fmt.Errorf("%w: node is at %d height, current one is at %d", ErrStaleNodes, newHeight, curHeight)
+	}
+	if err != nil {
+		c.switchLock.Unlock()
+		cli.Close()
+
+		c.logger.Warn("healthier RPC node is not suitable to switch to",
+			zap.String("endpoint", endpoint),
+			zap.Error(err),
+		)
+
+		return false
+	}
+
+	old := c.client
+	prev := c.endpoint
+
+	c.cache.invalidate()
+	c.client = cli
+	c.setActor(act)
+	c.setEndpoint(endpoint)
+
+	c.switchLock.Unlock()
+
+	// the old client can be blocked sending notifications to the channels
+	// nobody reads from anymore, so they are drained until it is closed
+	closeAndDrain(old, c.subs.curNotifyChan, c.subs.curBlockChan, c.subs.curNotaryChan)
+
+	c.logger.Info("switched to the healthier RPC node",
+		zap.String("previous", prev),
+		zap.String("endpoint", endpoint))
+
+	if c.cfg.rpcSwitchCb != nil {
+		c.cfg.rpcSwitchCb()
+	}
+
+	return true
+}
+
+// closeAndDrain closes the WS client in the background and reads the given
+// notification channels until it is closed.
+func closeAndDrain(cli *rpcclient.WSClient, notifCh <-chan *state.ContainedNotificationEvent,
+	blCh <-chan *block.Block, notaryCh <-chan *result.NotaryRequestEvent) {
+	done := make(chan struct{})
+
+	go func() {
+		cli.Close()
+		close(done)
+	}()
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case _, ok := <-notifCh:
+				if !ok {
+					notifCh = nil
+				}
+			case _, ok := <-blCh:
+				if !ok {
+					blCh = nil
+				}
+			case _, ok := <-notaryCh:
+				if !ok {
+					notaryCh = nil
+				}
+			}
+		}
+	}()
+}
+
+// healthLoop periodically checks the health of all the endpoints and
+// requests the switch to the healthier one.
+func (c *Client) healthLoop() {
+	t := time.NewTicker(c.cfg.healthCheckInterval)
+	defer t.Stop()
+
+	// connections to the endpoints the Client is not connected to
+	probes := make(map[string]*rpcclient.WSClient)
+	defer func() {
+		for _, cli := range probes {
+			cli.Close()
+		}
+	}()
+
+	for {
+		select {
+		case <-c.cfg.ctx.Done():
+			return
+		case <-c.closeChan:
+			return
+		case <-t.C:
+		}
+
+		c.checkHealth(probes)
+	}
+}
+
+func (c *Client) checkHealth(probes map[string]*rpcclient.WSClient) {
+	c.switchLock.RLock()
+	current, inactive := c.endpoint, c.inactive
+	c.switchLock.RUnlock()
+
+	if inactive {
+		return
+	}
+
+	for _, e := range c.endpoints {
+		var (
+			height uint32
+			err    error
+			start  = time.Now()
+		)
+
+		if e == current {
+			height, err = c.BlockCount()
+		} else {
+			height, err = c.probeHeight(probes, e)
+		}
+
+		c.health.update(e, height, time.Since(start), err)
+
+		if err != nil {
+			c.logger.Debug("RPC node health check failed",
+				zap.String("endpoint", e),
+				zap.Error(err))
+		}
+
+		if c.cfg.metrics != nil {
+			if err != nil {
+				c.cfg.metrics.IncMorphEndpointErrors(e)
+			} else {
+				c.cfg.metrics.SetMorphEndpointHeight(e, height)
+			}
+		}
+	}
+
+	if c.cfg.metrics != nil {
+		for e, score := range c.health.scores() {
+			c.cfg.metrics.SetMorphEndpointScore(e, score)
+			c.cfg.metrics.SetMorphEndpointLatency(e, c.health.latency(e))
+		}
+	}
+
+	if e, ok := c.health.nextSwitch(current); ok {
+		c.logger.Info("healthier RPC node found, switching",
+			zap.String("current", current),
+			zap.String("endpoint", e))
+
+		select {
+		case c.switchChan <- e:
+		default:
+		}
+	}
+}
+
+// probeHeight requests the block height from the endpoint using the cached
+// connection.
+func (c *Client) probeHeight(probes map[string]*rpcclient.WSClient, endpoint string) (uint32, error) {
+	cli, ok := probes[endpoint]
+	if !ok {
+		var err error
+
+		cli, err = rpcclient.NewWS(c.cfg.ctx, endpoint, rpcclient.WSOptions{
+			Options: rpcclient.Options{
+				DialTimeout:    c.cfg.dialTimeout,
+				RequestTimeout: c.cfg.dialTimeout,
+			},
+		})
+		if err != nil {
+			return 0, fmt.Errorf("WS client creation: %w", err)
+		}
+
+		probes[endpoint] = cli
+	}
+
+	height, err := cli.GetBlockCount()
+	if err != nil {
+		cli.Close()
+		delete(probes, endpoint)
+	}
+
+	return height, err
+}
+
 func (c *Client) closeWaiter() {
 	select {
 	case <-c.cfg.ctx.Done():
@@ -260,6 +260,18 @@ routeloop:
 			if !ok {
 				connLost = true
 			}
+		case e := <-c.switchChan:
+			if restoreInProgress || !c.switchTo(e) {
+				continue
+			}
+
+			c.subs.Lock()
+			c.subs.curNotifyChan = make(chan *state.ContainedNotificationEvent)
+			c.subs.curBlockChan = make(chan *block.Block)
+			c.subs.curNotaryChan = make(chan *result.NotaryRequestEvent)
+			go c.restoreSubscriptions(c.subs.curNotifyChan, c.subs.curBlockChan, c.subs.curNotaryChan, restoreCh)
+			c.subs.Unlock()
+			restoreInProgress = true
 		}
 		if connLost {
 			if !restoreInProgress {
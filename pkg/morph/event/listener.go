@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/epicchainlabs/epicchain-go/pkg/core/state"
 	"github.com/epicchainlabs/epicchain-go/pkg/neorpc/result"
@@ -88,6 +89,13 @@ type ListenerParams struct {
 	Client *client.Client
 
 	WorkerPoolCapacity int
+
+	// BlockHeightStorage keeps the height of the last processed block. If
+	// set, the notifications are not received live but fetched for each new
+	// block and handled in the order of the blocks, the blocks missed while
+	// the listener has been stopped or disconnected are handled first.
+	// Optional.
+	BlockHeightStorage BlockHeightStorage
 }
 
 type listener struct {
@@ -113,6 +121,11 @@ type listener struct {
 	blockHandlers []BlockHandler
 
 	pool *ants.Pool
+
+	heightStorage      BlockHeightStorage
+	blockNotifications func(uint32) ([]*state.ContainedNotificationEvent, error)
+	receivedBlock      atomic.Uint32
+	newBlock           chan struct{}
 }
 
 const newListenerFailMsg = "could not instantiate Listener"
@@ -161,6 +174,10 @@ func (l *listener) listen(ctx context.Context) error {
 
 	subErrCh := make(chan error)
 
+	if l.heightStorage != nil {
+		go l.blockLoop(ctx)
+	}
+
 	go l.subscribe(subErrCh)
 
 	return l.listenLoop(ctx, subErrCh)
@@ -186,13 +203,17 @@ func (l *listener) subscribe(errCh chan error) {
 	}
 	l.mtx.RUnlock()
 
-	err := l.cli.ReceiveExecutionNotifications(hashes)
-	if err != nil {
-		errCh <- fmt.Errorf("could not subscribe for notifications: %w", err)
-		return
+	var err error
+
+	// notifications are fetched for each received block otherwise
+	if l.heightStorage == nil {
+		if err = l.cli.ReceiveExecutionNotifications(hashes); err != nil {
+			errCh <- fmt.Errorf("could not subscribe for notifications: %w", err)
+			return
+		}
 	}
 
-	if len(l.blockHandlers) > 0 {
+	if len(l.blockHandlers) > 0 || l.heightStorage != nil {
 		if err = l.cli.ReceiveBlocks(); err != nil {
 			errCh <- fmt.Errorf("could not subscribe for blocks: %w", err)
 			return
@@ -255,6 +276,10 @@ loop:
 				break loop
 			}
 
+			if l.heightStorage != nil {
+				l.blockReceived(b.Index)
+			}
+
 			if err := l.pool.Submit(func() {
 				for i := range l.blockHandlers {
 					l.blockHandlers[i](b)
@@ -594,11 +619,19 @@ func NewListener(p ListenerParams) (Listener, error) {
 		return nil, fmt.Errorf("could not init worker pool: %w", err)
 	}
 
-	return &listener{
+	l := &listener{
 		notificationParsers:  make(map[scriptHashWithType]NotificationParser),
 		notificationHandlers: make(map[scriptHashWithType][]Handler),
 		log:                  p.Logger,
 		cli:                  p.Client,
 		pool:                 pool,
-	}, nil
+	}
+
+	if p.BlockHeightStorage != nil {
+		l.heightStorage = p.BlockHeightStorage
+		l.blockNotifications = p.Client.BlockNotifications
+		l.newBlock = make(chan struct{}, 1)
+	}
+
+	return l, nil
 }
@@ -0,0 +1,117 @@
+package event
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// BlockHeightStorage is a persistent storage of the height of the last block
+// processed by the Listener.
+type BlockHeightStorage interface {
+	// LastProcessedBlock returns the saved block height. Zero means there is
+	// no saved height.
+	LastProcessedBlock() (uint32, error)
+
+	// SetLastProcessedBlock saves the block height.
+	SetLastProcessedBlock(uint32) error
+}
+
+// blockReceived passes the index of the new block to the blockLoop.
+func (l *listener) blockReceived(index uint32) {
+	for {
+		// keep the highest index since the client may switch to the
+		// lagging RPC node
+		cur := l.receivedBlock.Load()
+		if index <= cur || l.receivedBlock.CompareAndSwap(cur, index) {
+			break
+		}
+	}
+
+	select {
+	case l.newBlock <- struct{}{}:
+	default:
+	}
+}
+
+// blockLoop handles the notifications of the received blocks one by one in
+// the order of the blocks. The notifications are fetched from the chain, so
+// the blocks missed while the listener has been stopped or the client has
+// lost the connection are handled the same way before the received one. The
+// height is saved after all notifications of the block have been handled,
+// so each block is handled once and the next start continues from the
+// following block.
+func (l *listener) blockLoop(ctx context.Context) {
+	last, err := l.heightStorage.LastProcessedBlock()
+	if err != nil {
+		l.log.Warn("could not read the last processed block, missed notifications will not be replayed",
+			zap.Error(err),
+		)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-l.newBlock:
+		}
+
+		cur := l.receivedBlock.Load()
+		if last == 0 && cur > 0 {
+			// nothing has been processed yet, start from the received block
+			last = cur - 1
+		}
+
+		if cur <= last {
+			continue
+		}
+
+		if cur > last+1 {
+			l.log.Info("replaying notifications of the missed blocks",
+				zap.Uint32("from", last+1),
+				zap.Uint32("to", cur-1),
+			)
+		}
+
+		last = l.handleBlocks(ctx, last+1, cur)
+	}
+}
+
+// handleBlocks handles the notifications of the blocks in [from, to] range
+// and saves the height after each block. Returns the index of the last
+// handled block, the rest ones are handled on the next received block.
+func (l *listener) handleBlocks(ctx context.Context, from, to uint32) uint32 {
+	for i := from; i <= to; i++ {
+		if ctx.Err() != nil {
+			return i - 1
+		}
+
+		evs, err := l.blockNotifications(i)
+		if err != nil {
+			l.log.Warn("could not get notifications of the block",
+				zap.Uint32("index", i),
+				zap.Error(err),
+			)
+
+			return i - 1
+		}
+
+		for _, ev := range evs {
+			l.parseAndHandleNotification(ev)
+		}
+
+		l.saveHeight(i)
+	}
+
+	return to
+}
+
+func (l *listener) saveHeight(index uint32) {
+	err := l.heightStorage.SetLastProcessedBlock(index)
+	if err != nil {
+		l.log.Warn("can't update persistent state",
+			zap.Uint32("block_index", index),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,215 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-go/pkg/core/state"
+	"github.com/epicchainlabs/epicchain-go/pkg/util"
+	"github.com/epicchainlabs/epicchain-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testEvent int64
+
+func (testEvent) MorphEvent() {}
+
+type testHeightStorage struct {
+	mtx    sync.Mutex
+	height uint32
+}
+
+func (s *testHeightStorage) LastProcessedBlock() (uint32, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.height, nil
+}
+
+func (s *testHeightStorage) SetLastProcessedBlock(h uint32) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.height = h
+	return nil
+}
+
+// testChain is a chain with a single notification in each block.
+type testChain struct {
+	mtx     sync.Mutex
+	failAt  uint32
+	fetched []uint32
+	handled []int64
+}
+
+var testContract = util.Uint160{1, 2, 3}
+
+func testNotification(i uint32) *state.ContainedNotificationEvent {
+	return &state.ContainedNotificationEvent{
+		Container: util.Uint256{byte(i)},
+		NotificationEvent: state.NotificationEvent{
+			ScriptHash: testContract,
+			Name:       "Test",
+			Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make(i)}),
+		},
+	}
+}
+
+func (c *testChain) blockNotifications(i uint32) ([]*state.ContainedNotificationEvent, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.fetched = append(c.fetched, i)
+	if i == c.failAt {
+		return nil, errors.New("any error")
+	}
+
+	return []*state.ContainedNotificationEvent{testNotification(i)}, nil
+}
+
+func (c *testChain) setFailAt(i uint32) {
+	c.mtx.Lock()
+	c.failAt = i
+	c.mtx.Unlock()
+}
+
+// reset returns fetched blocks and handled events and forgets them.
+func (c *testChain) reset() ([]uint32, []int64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	fetched, handled := c.fetched, c.handled
+	c.fetched, c.handled = nil, nil
+
+	return fetched, handled
+}
+
+func newReplayListener(t *testing.T, height uint32) (*listener, *testChain, *testHeightStorage) {
+	chain := new(testChain)
+	storage := &testHeightStorage{height: height}
+
+	l := &listener{
+		notificationParsers:  make(map[scriptHashWithType]NotificationParser),
+		notificationHandlers: make(map[scriptHashWithType][]Handler),
+		log:                  zap.NewNop(),
+		heightStorage:        storage,
+		blockNotifications:   chain.blockNotifications,
+		newBlock:             make(chan struct{}, 1),
+	}
+
+	var pi NotificationParserInfo
+	pi.SetScriptHash(testContract)
+	pi.SetType(TypeFromString("Test"))
+	pi.SetParser(func(e *state.ContainedNotificationEvent) (Event, error) {
+		n, err := e.Item.Value().([]stackitem.Item)[0].TryInteger()
+		if err != nil {
+			return nil, err
+		}
+		return testEvent(n.Int64()), nil
+	})
+	l.SetNotificationParser(pi)
+
+	var hi NotificationHandlerInfo
+	hi.SetScriptHash(testContract)
+	hi.SetType(TypeFromString("Test"))
+	hi.SetHandler(func(e Event) {
+		chain.mtx.Lock()
+		chain.handled = append(chain.handled, int64(e.(testEvent)))
+		chain.mtx.Unlock()
+	})
+	l.RegisterNotificationHandler(hi)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go l.blockLoop(ctx)
+
+	return l, chain, storage
+}
+
+func requireHeight(t *testing.T, s *testHeightStorage, h uint32) {
+	require.Eventually(t, func() bool {
+		cur, _ := s.LastProcessedBlock()
+		return cur == h
+	}, time.Second, time.Millisecond)
+}
+
+func TestListener_Replay(t *testing.T) {
+	t.Run("first start", func(t *testing.T) {
+		l, chain, storage := newReplayListener(t, 0)
+
+		l.blockReceived(10)
+		requireHeight(t, storage, 10)
+
+		fetched, handled := chain.reset()
+		require.Equal(t, []uint32{10}, fetched)
+		require.Equal(t, []int64{10}, handled)
+	})
+
+	t.Run("restart", func(t *testing.T) {
+		l, chain, storage := newReplayListener(t, 10)
+
+		l.blockReceived(13)
+		requireHeight(t, storage, 13)
+
+		// the last processed block is not handled again
+		fetched, handled := chain.reset()
+		require.Equal(t, []uint32{11, 12, 13}, fetched)
+		require.Equal(t, []int64{11, 12, 13}, handled)
+
+		l.blockReceived(14)
+		requireHeight(t, storage, 14)
+
+		fetched, handled = chain.reset()
+		require.Equal(t, []uint32{14}, fetched)
+		require.Equal(t, []int64{14}, handled)
+
+		// lagging RPC node
+		l.blockReceived(12)
+		l.blockReceived(15)
+		requireHeight(t, storage, 15)
+
+		fetched, handled = chain.reset()
+		require.Equal(t, []uint32{15}, fetched)
+		require.Equal(t, []int64{15}, handled)
+	})
+
+	t.Run("no new blocks on restart", func(t *testing.T) {
+		l, chain, storage := newReplayListener(t, 10)
+
+		l.blockReceived(10)
+		l.blockReceived(11)
+		requireHeight(t, storage, 11)
+
+		fetched, handled := chain.reset()
+		require.Equal(t, []uint32{11}, fetched)
+		require.Equal(t, []int64{11}, handled)
+	})
+
+	t.Run("reconnection", func(t *testing.T) {
+		l, chain, storage := newReplayListener(t, 0)
+
+		l.blockReceived(10)
+		requireHeight(t, storage, 10)
+
+		chain.setFailAt(12)
+
+		l.blockReceived(14)
+		requireHeight(t, storage, 11)
+
+		fetched, handled := chain.reset()
+		require.Equal(t, []uint32{10, 11, 12}, fetched)
+		require.Equal(t, []int64{10, 11}, handled)
+
+		chain.setFailAt(0)
+
+		l.blockReceived(15)
+		requireHeight(t, storage, 15)
+
+		fetched, handled = chain.reset()
+		require.Equal(t, []uint32{12, 13, 14, 15}, fetched)
+		require.Equal(t, []int64{12, 13, 14, 15}, handled)
+	})
+}
@@ -200,3 +200,291 @@ func (w *flushCacheResponseWrapper) FromGRPCMessage(m grpc.Message) error {
 	w.FlushCacheResponse = r
 	return nil
 }
+
+type startShardRebalanceResponseWrapper struct {
+	*StartShardRebalanceResponse
+}
+
+func (w *startShardRebalanceResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.StartShardRebalanceResponse
+}
+
+func (w *startShardRebalanceResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*StartShardRebalanceResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*StartShardRebalanceResponse)(nil))
+	}
+
+	w.StartShardRebalanceResponse = r
+	return nil
+}
+
+type getShardRebalanceStatusResponseWrapper struct {
+	*GetShardRebalanceStatusResponse
+}
+
+func (w *getShardRebalanceStatusResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.GetShardRebalanceStatusResponse
+}
+
+func (w *getShardRebalanceStatusResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*GetShardRebalanceStatusResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*GetShardRebalanceStatusResponse)(nil))
+	}
+
+	w.GetShardRebalanceStatusResponse = r
+	return nil
+}
+
+type stopShardRebalanceResponseWrapper struct {
+	*StopShardRebalanceResponse
+}
+
+func (w *stopShardRebalanceResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.StopShardRebalanceResponse
+}
+
+func (w *stopShardRebalanceResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*StopShardRebalanceResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*StopShardRebalanceResponse)(nil))
+	}
+
+	w.StopShardRebalanceResponse = r
+	return nil
+}
+
+type startShardEvacuationResponseWrapper struct {
+	*StartShardEvacuationResponse
+}
+
+func (w *startShardEvacuationResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.StartShardEvacuationResponse
+}
+
+func (w *startShardEvacuationResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*StartShardEvacuationResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*StartShardEvacuationResponse)(nil))
+	}
+
+	w.StartShardEvacuationResponse = r
+	return nil
+}
+
+type getShardEvacuationStatusResponseWrapper struct {
+	*GetShardEvacuationStatusResponse
+}
+
+func (w *getShardEvacuationStatusResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.GetShardEvacuationStatusResponse
+}
+
+func (w *getShardEvacuationStatusResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*GetShardEvacuationStatusResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*GetShardEvacuationStatusResponse)(nil))
+	}
+
+	w.GetShardEvacuationStatusResponse = r
+	return nil
+}
+
+type stopShardEvacuationResponseWrapper struct {
+	*StopShardEvacuationResponse
+}
+
+func (w *stopShardEvacuationResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.StopShardEvacuationResponse
+}
+
+func (w *stopShardEvacuationResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*StopShardEvacuationResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*StopShardEvacuationResponse)(nil))
+	}
+
+	w.StopShardEvacuationResponse = r
+	return nil
+}
+
+type compactTreeResponseWrapper struct {
+	*CompactTreeResponse
+}
+
+func (w *compactTreeResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.CompactTreeResponse
+}
+
+func (w *compactTreeResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*CompactTreeResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*CompactTreeResponse)(nil))
+	}
+
+	w.CompactTreeResponse = r
+	return nil
+}
+
+type setContainerQuotaResponseWrapper struct {
+	*SetContainerQuotaResponse
+}
+
+func (w *setContainerQuotaResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.SetContainerQuotaResponse
+}
+
+func (w *setContainerQuotaResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*SetContainerQuotaResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*SetContainerQuotaResponse)(nil))
+	}
+
+	w.SetContainerQuotaResponse = r
+	return nil
+}
+
+type getContainerUsageResponseWrapper struct {
+	*GetContainerUsageResponse
+}
+
+func (w *getContainerUsageResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.GetContainerUsageResponse
+}
+
+func (w *getContainerUsageResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*GetContainerUsageResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*GetContainerUsageResponse)(nil))
+	}
+
+	w.GetContainerUsageResponse = r
+	return nil
+}
+
+type startShardCheckResponseWrapper struct {
+	*StartShardCheckResponse
+}
+
+func (w *startShardCheckResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.StartShardCheckResponse
+}
+
+func (w *startShardCheckResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*StartShardCheckResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*StartShardCheckResponse)(nil))
+	}
+
+	w.StartShardCheckResponse = r
+	return nil
+}
+
+type getShardCheckStatusResponseWrapper struct {
+	*GetShardCheckStatusResponse
+}
+
+func (w *getShardCheckStatusResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.GetShardCheckStatusResponse
+}
+
+func (w *getShardCheckStatusResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*GetShardCheckStatusResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*GetShardCheckStatusResponse)(nil))
+	}
+
+	w.GetShardCheckStatusResponse = r
+	return nil
+}
+
+type stopShardCheckResponseWrapper struct {
+	*StopShardCheckResponse
+}
+
+func (w *stopShardCheckResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.StopShardCheckResponse
+}
+
+func (w *stopShardCheckResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*StopShardCheckResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*StopShardCheckResponse)(nil))
+	}
+
+	w.StopShardCheckResponse = r
+	return nil
+}
+
+type listReplicationTasksResponseWrapper struct {
+	*ListReplicationTasksResponse
+}
+
+func (w *listReplicationTasksResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.ListReplicationTasksResponse
+}
+
+func (w *listReplicationTasksResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*ListReplicationTasksResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*ListReplicationTasksResponse)(nil))
+	}
+
+	w.ListReplicationTasksResponse = r
+	return nil
+}
+
+type getReloadStatusResponseWrapper struct {
+	*GetReloadStatusResponse
+}
+
+func (w *getReloadStatusResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.GetReloadStatusResponse
+}
+
+func (w *getReloadStatusResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*GetReloadStatusResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*GetReloadStatusResponse)(nil))
+	}
+
+	w.GetReloadStatusResponse = r
+	return nil
+}
+
+type detachShardsResponseWrapper struct {
+	*DetachShardsResponse
+}
+
+func (w *detachShardsResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.DetachShardsResponse
+}
+
+func (w *detachShardsResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*DetachShardsResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*DetachShardsResponse)(nil))
+	}
+
+	w.DetachShardsResponse = r
+	return nil
+}
+
+type attachShardResponseWrapper struct {
+	*AttachShardResponse
+}
+
+func (w *attachShardResponseWrapper) ToGRPCMessage() grpc.Message {
+	return w.AttachShardResponse
+}
+
+func (w *attachShardResponseWrapper) FromGRPCMessage(m grpc.Message) error {
+	r, ok := m.(*AttachShardResponse)
+	if !ok {
+		return message.NewUnexpectedMessageType(m, (*AttachShardResponse)(nil))
+	}
+
+	w.AttachShardResponse = r
+	return nil
+}
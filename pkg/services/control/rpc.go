@@ -8,16 +8,32 @@ import (
 const serviceName = "control.ControlService"
 
 const (
-	rpcHealthCheck     = "HealthCheck"
-	rpcSetNetmapStatus = "SetNetmapStatus"
-	rpcDropObjects     = "DropObjects"
-	rpcListShards      = "ListShards"
-	rpcSetShardMode    = "SetShardMode"
-	rpcDumpShard       = "DumpShard"
-	rpcRestoreShard    = "RestoreShard"
-	rpcSynchronizeTree = "SynchronizeTree"
-	rpcEvacuateShard   = "EvacuateShard"
-	rpcFlushCache      = "FlushCache"
+	rpcHealthCheck              = "HealthCheck"
+	rpcSetNetmapStatus          = "SetNetmapStatus"
+	rpcDropObjects              = "DropObjects"
+	rpcListShards               = "ListShards"
+	rpcSetShardMode             = "SetShardMode"
+	rpcDumpShard                = "DumpShard"
+	rpcRestoreShard             = "RestoreShard"
+	rpcSynchronizeTree          = "SynchronizeTree"
+	rpcEvacuateShard            = "EvacuateShard"
+	rpcFlushCache               = "FlushCache"
+	rpcStartShardRebalance      = "StartShardRebalance"
+	rpcGetShardRebalanceStatus  = "GetShardRebalanceStatus"
+	rpcStopShardRebalance       = "StopShardRebalance"
+	rpcStartShardEvacuation     = "StartShardEvacuation"
+	rpcGetShardEvacuationStatus = "GetShardEvacuationStatus"
+	rpcStopShardEvacuation      = "StopShardEvacuation"
+	rpcCompactTree              = "CompactTree"
+	rpcSetContainerQuota        = "SetContainerQuota"
+	rpcGetContainerUsage        = "GetContainerUsage"
+	rpcStartShardCheck          = "StartShardCheck"
+	rpcGetShardCheckStatus      = "GetShardCheckStatus"
+	rpcStopShardCheck           = "StopShardCheck"
+	rpcListReplicationTasks     = "ListReplicationTasks"
+	rpcGetReloadStatus          = "GetReloadStatus"
+	rpcDetachShards             = "DetachShards"
+	rpcAttachShard              = "AttachShard"
 )
 
 // HealthCheck executes ControlService.HealthCheck RPC.
@@ -191,3 +207,211 @@ func FlushCache(cli *client.Client, req *FlushCacheRequest, opts ...client.CallO
 
 	return wResp.FlushCacheResponse, nil
 }
+
+// StartShardRebalance executes ControlService.StartShardRebalance RPC.
+func StartShardRebalance(cli *client.Client, req *StartShardRebalanceRequest, opts ...client.CallOption) (*StartShardRebalanceResponse, error) {
+	wResp := &startShardRebalanceResponseWrapper{new(StartShardRebalanceResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcStartShardRebalance), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.StartShardRebalanceResponse, nil
+}
+
+// GetShardRebalanceStatus executes ControlService.GetShardRebalanceStatus RPC.
+func GetShardRebalanceStatus(cli *client.Client, req *GetShardRebalanceStatusRequest, opts ...client.CallOption) (*GetShardRebalanceStatusResponse, error) {
+	wResp := &getShardRebalanceStatusResponseWrapper{new(GetShardRebalanceStatusResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcGetShardRebalanceStatus), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.GetShardRebalanceStatusResponse, nil
+}
+
+// StopShardRebalance executes ControlService.StopShardRebalance RPC.
+func StopShardRebalance(cli *client.Client, req *StopShardRebalanceRequest, opts ...client.CallOption) (*StopShardRebalanceResponse, error) {
+	wResp := &stopShardRebalanceResponseWrapper{new(StopShardRebalanceResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcStopShardRebalance), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.StopShardRebalanceResponse, nil
+}
+
+// StartShardEvacuation executes ControlService.StartShardEvacuation RPC.
+func StartShardEvacuation(cli *client.Client, req *StartShardEvacuationRequest, opts ...client.CallOption) (*StartShardEvacuationResponse, error) {
+	wResp := &startShardEvacuationResponseWrapper{new(StartShardEvacuationResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcStartShardEvacuation), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.StartShardEvacuationResponse, nil
+}
+
+// GetShardEvacuationStatus executes ControlService.GetShardEvacuationStatus RPC.
+func GetShardEvacuationStatus(cli *client.Client, req *GetShardEvacuationStatusRequest, opts ...client.CallOption) (*GetShardEvacuationStatusResponse, error) {
+	wResp := &getShardEvacuationStatusResponseWrapper{new(GetShardEvacuationStatusResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcGetShardEvacuationStatus), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.GetShardEvacuationStatusResponse, nil
+}
+
+// StopShardEvacuation executes ControlService.StopShardEvacuation RPC.
+func StopShardEvacuation(cli *client.Client, req *StopShardEvacuationRequest, opts ...client.CallOption) (*StopShardEvacuationResponse, error) {
+	wResp := &stopShardEvacuationResponseWrapper{new(StopShardEvacuationResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcStopShardEvacuation), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.StopShardEvacuationResponse, nil
+}
+
+// CompactTree executes ControlService.CompactTree RPC.
+func CompactTree(cli *client.Client, req *CompactTreeRequest, opts ...client.CallOption) (*CompactTreeResponse, error) {
+	wResp := &compactTreeResponseWrapper{new(CompactTreeResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcCompactTree), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.CompactTreeResponse, nil
+}
+
+// SetContainerQuota executes ControlService.SetContainerQuota RPC.
+func SetContainerQuota(cli *client.Client, req *SetContainerQuotaRequest, opts ...client.CallOption) (*SetContainerQuotaResponse, error) {
+	wResp := &setContainerQuotaResponseWrapper{new(SetContainerQuotaResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcSetContainerQuota), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.SetContainerQuotaResponse, nil
+}
+
+// GetContainerUsage executes ControlService.GetContainerUsage RPC.
+func GetContainerUsage(cli *client.Client, req *GetContainerUsageRequest, opts ...client.CallOption) (*GetContainerUsageResponse, error) {
+	wResp := &getContainerUsageResponseWrapper{new(GetContainerUsageResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcGetContainerUsage), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.GetContainerUsageResponse, nil
+}
+
+// StartShardCheck executes ControlService.StartShardCheck RPC.
+func StartShardCheck(cli *client.Client, req *StartShardCheckRequest, opts ...client.CallOption) (*StartShardCheckResponse, error) {
+	wResp := &startShardCheckResponseWrapper{new(StartShardCheckResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcStartShardCheck), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.StartShardCheckResponse, nil
+}
+
+// GetShardCheckStatus executes ControlService.GetShardCheckStatus RPC.
+func GetShardCheckStatus(cli *client.Client, req *GetShardCheckStatusRequest, opts ...client.CallOption) (*GetShardCheckStatusResponse, error) {
+	wResp := &getShardCheckStatusResponseWrapper{new(GetShardCheckStatusResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcGetShardCheckStatus), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.GetShardCheckStatusResponse, nil
+}
+
+// StopShardCheck executes ControlService.StopShardCheck RPC.
+func StopShardCheck(cli *client.Client, req *StopShardCheckRequest, opts ...client.CallOption) (*StopShardCheckResponse, error) {
+	wResp := &stopShardCheckResponseWrapper{new(StopShardCheckResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcStopShardCheck), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.StopShardCheckResponse, nil
+}
+
+// ListReplicationTasks executes ControlService.ListReplicationTasks RPC.
+func ListReplicationTasks(cli *client.Client, req *ListReplicationTasksRequest, opts ...client.CallOption) (*ListReplicationTasksResponse, error) {
+	wResp := &listReplicationTasksResponseWrapper{new(ListReplicationTasksResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcListReplicationTasks), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.ListReplicationTasksResponse, nil
+}
+
+// GetReloadStatus executes ControlService.GetReloadStatus RPC.
+func GetReloadStatus(cli *client.Client, req *GetReloadStatusRequest, opts ...client.CallOption) (*GetReloadStatusResponse, error) {
+	wResp := &getReloadStatusResponseWrapper{new(GetReloadStatusResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcGetReloadStatus), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.GetReloadStatusResponse, nil
+}
+
+// DetachShards executes ControlService.DetachShards RPC.
+func DetachShards(cli *client.Client, req *DetachShardsRequest, opts ...client.CallOption) (*DetachShardsResponse, error) {
+	wResp := &detachShardsResponseWrapper{new(DetachShardsResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcDetachShards), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.DetachShardsResponse, nil
+}
+
+// AttachShard executes ControlService.AttachShard RPC.
+func AttachShard(cli *client.Client, req *AttachShardRequest, opts ...client.CallOption) (*AttachShardResponse, error) {
+	wResp := &attachShardResponseWrapper{new(AttachShardResponse)}
+	wReq := &requestWrapper{m: req}
+
+	err := client.SendUnary(cli, common.CallMethodInfoUnary(serviceName, rpcAttachShard), wReq, wResp, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wResp.AttachShardResponse, nil
+}
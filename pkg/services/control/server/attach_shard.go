@@ -0,0 +1,45 @@
+package control
+
+import (
+	"context"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AttachShard opens the new shard and adds it to the storage engine.
+func (s *Server) AttachShard(_ context.Context, req *control.AttachShardRequest) (*control.AttachShardResponse, error) {
+	err := s.isValidRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// check availability
+	err = s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := req.GetBody().GetConfig()
+	if len(cfg) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing shard configuration")
+	}
+
+	id, err := s.shardManager.AttachShard(cfg)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &control.AttachShardResponse{
+		Body: &control.AttachShardResponse_Body{
+			Shard_ID: *id,
+		},
+	}
+
+	err = SignMessage(s.key, resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
@@ -0,0 +1,53 @@
+package control
+
+import (
+	"context"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) CompactTree(ctx context.Context, req *control.CompactTreeRequest) (*control.CompactTreeResponse, error) {
+	err := s.isValidRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// check availability
+	err = s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.treeService == nil {
+		return nil, status.Error(codes.Internal, "tree service is disabled")
+	}
+
+	b := req.GetBody()
+
+	var cnr cid.ID
+	if err := cnr.Decode(b.GetContainerId()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	height, removed, err := s.treeService.Compact(ctx, cnr, b.GetTreeId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &control.CompactTreeResponse{
+		Body: &control.CompactTreeResponse_Body{
+			Height:  height,
+			Removed: removed,
+		},
+	}
+
+	err = SignMessage(s.key, resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp, nil
+}
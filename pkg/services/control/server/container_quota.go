@@ -0,0 +1,104 @@
+package control
+
+import (
+	"context"
+
+	containerCore "github.com/epicchainlabs/epicchain-node/pkg/core/container"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/engine"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) SetContainerQuota(_ context.Context, req *control.SetContainerQuotaRequest) (*control.SetContainerQuotaResponse, error) {
+	err := s.isValidRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// check availability
+	err = s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	var cnr cid.ID
+	if err := cnr.Decode(req.GetBody().GetContainerId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	q := req.GetBody().GetQuota()
+
+	err = s.storage.SetContainerQuota(cnr, containerCore.Quota{
+		SoftSize:    q.GetSoftSize(),
+		HardSize:    q.GetHardSize(),
+		SoftObjects: q.GetSoftObjects(),
+		HardObjects: q.GetHardObjects(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &control.SetContainerQuotaResponse{
+		Body: &control.SetContainerQuotaResponse_Body{},
+	}
+
+	err = SignMessage(s.key, resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp, nil
+}
+
+func (s *Server) GetContainerUsage(_ context.Context, req *control.GetContainerUsageRequest) (*control.GetContainerUsageResponse, error) {
+	err := s.isValidRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// check availability
+	err = s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	var cnr cid.ID
+	if err := cnr.Decode(req.GetBody().GetContainerId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var prm engine.ContainerSizePrm
+	prm.SetContainerID(cnr)
+
+	usage, err := s.storage.ContainerSize(prm)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	q, err := s.storage.ContainerQuota(cnr)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &control.GetContainerUsageResponse{
+		Body: &control.GetContainerUsageResponse_Body{
+			Size:    usage.Size(),
+			Objects: usage.Objects(),
+			Quota: &control.ContainerQuota{
+				SoftSize:    q.SoftSize,
+				HardSize:    q.HardSize,
+				SoftObjects: q.SoftObjects,
+				HardObjects: q.HardObjects,
+			},
+		},
+	}
+
+	err = SignMessage(s.key, resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp, nil
+}
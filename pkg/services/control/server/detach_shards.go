@@ -0,0 +1,53 @@
+package control
+
+import (
+	"context"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ShardManager changes the set of the storage engine shards. The changes
+// must survive the node restart.
+type ShardManager interface {
+	// DetachShards closes the shards and removes them from the storage engine.
+	DetachShards(ids []*shard.ID) error
+
+	// AttachShard opens the shard with the configuration in YAML or JSON
+	// format and adds it to the storage engine. Returns the shard ID.
+	AttachShard(cfg []byte) (*shard.ID, error)
+}
+
+// DetachShards closes the shards and removes them from the storage engine.
+func (s *Server) DetachShards(_ context.Context, req *control.DetachShardsRequest) (*control.DetachShardsResponse, error) {
+	err := s.isValidRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// check availability
+	err = s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	rawIDs := req.GetBody().GetShard_ID()
+	if len(rawIDs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no shards to detach")
+	}
+
+	err = s.shardManager.DetachShards(s.getShardIDList(rawIDs))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &control.DetachShardsResponse{Body: new(control.DetachShardsResponse_Body)}
+
+	err = SignMessage(s.key, resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
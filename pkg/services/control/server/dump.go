@@ -26,14 +26,19 @@ func (s *Server) DumpShard(_ context.Context, req *control.DumpShardRequest) (*c
 	var prm shard.DumpPrm
 	prm.WithPath(req.GetBody().GetFilepath())
 	prm.WithIgnoreErrors(req.GetBody().GetIgnoreErrors())
+	if req.GetBody().GetIncremental() {
+		prm.WithIncremental(req.GetBody().GetSince())
+	}
 
-	err = s.storage.DumpShard(shardID, prm)
+	res, err := s.storage.DumpShard(shardID, prm)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	resp := new(control.DumpShardResponse)
-	resp.SetBody(new(control.DumpShardResponse_Body))
+	resp.SetBody(&control.DumpShardResponse_Body{
+		Marker: res.Marker(),
+	})
 
 	err = SignMessage(s.key, resp)
 	if err != nil {
@@ -0,0 +1,79 @@
+package control
+
+import (
+	"context"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReloadChange describes the configuration change applied by the reload.
+type ReloadChange struct {
+	// Section is the configuration section the change belongs to.
+	Section string
+
+	// Target is the changed entity, e.g. a shard or a parameter.
+	Target string
+
+	// Action is the action applied to the target.
+	Action string
+
+	// Err is the error the change failed with, nil on success.
+	Err error
+}
+
+// ReloadStatus provides the outcome of the node configuration reloads.
+type ReloadStatus interface {
+	// LastReload returns the time the last configuration reload finished
+	// and the changes it applied. Zero time means the configuration has not
+	// been reloaded yet.
+	LastReload() (time.Time, []ReloadChange)
+}
+
+// GetReloadStatus returns the outcome of the last node configuration reload.
+func (s *Server) GetReloadStatus(_ context.Context, req *control.GetReloadStatusRequest) (*control.GetReloadStatusResponse, error) {
+	err := s.isValidRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// check availability
+	err = s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	t, changes := s.reloadStatus.LastReload()
+
+	body := &control.GetReloadStatusResponse_Body{
+		Changes: make([]*control.GetReloadStatusResponse_Body_Change, len(changes)),
+	}
+
+	if !t.IsZero() {
+		body.Time = t.Unix()
+	}
+
+	for i := range changes {
+		ch := &control.GetReloadStatusResponse_Body_Change{
+			Section: changes[i].Section,
+			Target:  changes[i].Target,
+			Action:  changes[i].Action,
+		}
+
+		if changes[i].Err != nil {
+			ch.Error = changes[i].Err.Error()
+		}
+
+		body.Changes[i] = ch
+	}
+
+	resp := &control.GetReloadStatusResponse{Body: body}
+
+	err = SignMessage(s.key, resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
@@ -0,0 +1,57 @@
+package control
+
+import (
+	"context"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListReplicationTasks lists the object replication tasks being handled by
+// the node.
+func (s *Server) ListReplicationTasks(_ context.Context, req *control.ListReplicationTasksRequest) (*control.ListReplicationTasksResponse, error) {
+	err := s.isValidRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// check availability
+	err = s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := s.replicator.Tasks()
+	body := &control.ListReplicationTasksResponse_Body{
+		Tasks: make([]*control.ListReplicationTasksResponse_Body_Task, len(tasks)),
+	}
+
+	for i := range tasks {
+		cnr := tasks[i].Address.Container()
+		obj := tasks[i].Address.Object()
+
+		t := &control.ListReplicationTasksResponse_Body_Task{
+			ContainerId: cnr[:],
+			ObjectId:    obj[:],
+			Copies:      tasks[i].Copies,
+			Nodes:       make([][]byte, len(tasks[i].Nodes)),
+			Active:      tasks[i].Active,
+			Started:     tasks[i].Started.Unix(),
+		}
+
+		for j := range tasks[i].Nodes {
+			t.Nodes[j] = tasks[i].Nodes[j].PublicKey()
+		}
+
+		body.Tasks[i] = t
+	}
+
+	resp := &control.ListReplicationTasksResponse{Body: body}
+
+	err = SignMessage(s.key, resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
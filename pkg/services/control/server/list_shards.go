@@ -2,9 +2,11 @@ package control
 
 import (
 	"context"
+	"time"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/blobstor"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/shard/mode"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/writecache"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -38,7 +40,17 @@ func (s *Server) ListShards(_ context.Context, req *control.ListShardsRequest) (
 		si.SetMetabasePath(sh.MetaBaseInfo.Path)
 		si.Blobstor = blobstorInfoToProto(sh.BlobStorInfo)
 		si.SetWriteCachePath(sh.WriteCacheInfo.Path)
+		if sh.WriteCacheInfo.Path != "" {
+			si.WritecacheFlushPolicy = flushPolicyToProto(sh.WriteCacheInfo.FlushPolicy)
+		}
 		si.SetPiloramaPath(sh.PiloramaInfo.Path)
+		si.Scrub = &control.ShardScrubStatus{
+			Enabled:   sh.Scrub.Enabled,
+			Checked:   sh.Scrub.Checked,
+			Total:     sh.Scrub.Total,
+			Corrupted: sh.Scrub.Corrupted,
+			Passes:    sh.Scrub.Passes,
+		}
 
 		var m control.ShardMode
 
@@ -81,3 +93,15 @@ func blobstorInfoToProto(info blobstor.Info) []*control.BlobstorInfo {
 	}
 	return res
 }
+
+func flushPolicyToProto(p writecache.FlushPolicy) *control.WriteCacheFlushPolicy {
+	res := &control.WriteCacheFlushPolicy{
+		MaxAge:    uint64(p.MaxAge / time.Second),
+		FillRatio: p.FillRatio,
+		RateLimit: p.RateLimit,
+	}
+	for i := range p.Windows {
+		res.Windows = append(res.Windows, p.Windows[i].String())
+	}
+	return res
+}
@@ -0,0 +1,104 @@
+package control
+
+import (
+	"context"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/engine"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) StartShardRebalance(_ context.Context, req *control.StartShardRebalanceRequest) (*control.StartShardRebalanceResponse, error) {
+	err := s.isValidRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// check availability
+	err = s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	var prm engine.RebalancePrm
+	prm.SetRate(req.GetBody().GetRate())
+
+	err = s.storage.StartRebalance(prm)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &control.StartShardRebalanceResponse{Body: &control.StartShardRebalanceResponse_Body{}}
+
+	err = SignMessage(s.key, resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
+
+func (s *Server) GetShardRebalanceStatus(_ context.Context, req *control.GetShardRebalanceStatusRequest) (*control.GetShardRebalanceStatusResponse, error) {
+	err := s.isValidRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// check availability
+	err = s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	st := s.storage.RebalanceStatus()
+
+	body := &control.GetShardRebalanceStatusResponse_Body{
+		Running:     st.Running(),
+		Rate:        st.Rate(),
+		ShardsTotal: uint32(st.ShardsTotal()),
+		ShardsDone:  uint32(st.ShardsDone()),
+		Processed:   st.Processed(),
+		Moved:       st.Moved(),
+		Failed:      st.Failed(),
+	}
+	if t := st.StartedAt(); !t.IsZero() {
+		body.StartedAt = t.Unix()
+	}
+	if st.Error() != nil {
+		body.Error = st.Error().Error()
+	}
+
+	resp := &control.GetShardRebalanceStatusResponse{Body: body}
+
+	err = SignMessage(s.key, resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
+
+func (s *Server) StopShardRebalance(_ context.Context, req *control.StopShardRebalanceRequest) (*control.StopShardRebalanceResponse, error) {
+	err := s.isValidRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// check availability
+	err = s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.storage.StopRebalance()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &control.StopShardRebalanceResponse{Body: &control.StopShardRebalanceResponse_Body{}}
+
+	err = SignMessage(s.key, resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
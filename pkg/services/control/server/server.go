@@ -73,6 +73,10 @@ type cfg struct {
 	treeService TreeService
 
 	storage *engine.StorageEngine
+
+	reloadStatus ReloadStatus
+
+	shardManager ShardManager
 }
 
 // New creates, initializes and returns new Server instance.
@@ -93,7 +97,7 @@ func New(key *ecdsa.PrivateKey, authorizedKeys [][]byte, healthChecker HealthChe
 
 // MarkReady marks server available. Before this call none of the other calls
 // are available except for the health checks.
-func (s *Server) MarkReady(e *engine.StorageEngine, nm netmap.Source, c container.Source, r *replicator.Replicator, st NodeState, tr TreeService) {
+func (s *Server) MarkReady(e *engine.StorageEngine, nm netmap.Source, c container.Source, r *replicator.Replicator, st NodeState, tr TreeService, rs ReloadStatus, sm ShardManager) {
 	panicOnNil := func(name string, service any) {
 		if service == nil {
 			panic(fmt.Sprintf("'%s' is nil", name))
@@ -106,6 +110,8 @@ func (s *Server) MarkReady(e *engine.StorageEngine, nm netmap.Source, c containe
 	panicOnNil("replicator", r)
 	panicOnNil("node state", st)
 	panicOnNil("tree service", st)
+	panicOnNil("reload status", rs)
+	panicOnNil("shard manager", sm)
 
 	s.storage = e
 	s.netMapSrc = nm
@@ -113,6 +119,8 @@ func (s *Server) MarkReady(e *engine.StorageEngine, nm netmap.Source, c containe
 	s.replicator = r
 	s.nodeState = st
 	s.treeService = tr
+	s.reloadStatus = rs
+	s.shardManager = sm
 
 	s.available.Store(true)
 }
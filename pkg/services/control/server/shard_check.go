@@ -0,0 +1,144 @@
+package control
+
+import (
+	"context"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/engine"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/control"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"github.com/mr-tron/base58"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultShardCheckIssueLimit is a number of the found problems returned
+// by GetShardCheckStatus if the limit is not set in the request. It is
+// also the maximum one.
+const defaultShardCheckIssueLimit = 1000
+
+// StartShardCheck starts checking consistency of the shard metabases with
+// the stored objects in the background. Found problems are not repaired.
+func (s *Server) StartShardCheck(_ context.Context, req *control.StartShardCheckRequest) (*control.StartShardCheckResponse, error) {
+	err := s.isValidRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// check availability
+	err = s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	var prm engine.CheckShardPrm
+	prm.SetShardIDList(s.getShardIDList(req.GetBody().GetShard_ID()))
+
+	err = s.storage.StartShardCheck(prm)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &control.StartShardCheckResponse{Body: &control.StartShardCheckResponse_Body{}}
+
+	err = SignMessage(s.key, resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
+
+func (s *Server) GetShardCheckStatus(_ context.Context, req *control.GetShardCheckStatusRequest) (*control.GetShardCheckStatusResponse, error) {
+	err := s.isValidRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// check availability
+	err = s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.GetBody().GetLimit())
+	if limit == 0 || limit > defaultShardCheckIssueLimit {
+		limit = defaultShardCheckIssueLimit
+	}
+
+	st := s.storage.ShardCheckStatus()
+
+	body := &control.GetShardCheckStatusResponse_Body{
+		Running:     st.Running(),
+		Checked:     uint32(st.Checked()),
+		TotalIssues: uint64(st.IssueCount()),
+	}
+	for _, id := range st.ShardIDs() {
+		raw, err := base58.Decode(id)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		body.Shard_ID = append(body.Shard_ID, raw)
+	}
+	if t := st.StartedAt(); !t.IsZero() {
+		body.StartedAt = t.Unix()
+	}
+	if st.Error() != nil {
+		body.Error = st.Error().Error()
+	}
+
+	for _, issue := range st.Issues(int(req.GetBody().GetOffset()), limit) {
+		sid, err := base58.Decode(issue.ShardID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		i := &control.GetShardCheckStatusResponse_Body_Issue{
+			Shard_ID: sid,
+			Problem:  issue.Problem.String(),
+			Stored:   issue.Stored,
+			Actual:   issue.Actual,
+		}
+		if cnr := issue.Container; cnr != (cid.ID{}) {
+			i.ContainerId = cnr[:]
+		}
+		if obj := issue.Object; obj != (oid.ID{}) {
+			i.ObjectId = obj[:]
+		}
+
+		body.Issues = append(body.Issues, i)
+	}
+
+	resp := &control.GetShardCheckStatusResponse{Body: body}
+
+	err = SignMessage(s.key, resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
+
+func (s *Server) StopShardCheck(_ context.Context, req *control.StopShardCheckRequest) (*control.StopShardCheckResponse, error) {
+	err := s.isValidRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// check availability
+	err = s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.storage.StopShardCheck()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &control.StopShardCheckResponse{Body: &control.StopShardCheckResponse_Body{}}
+
+	err = SignMessage(s.key, resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
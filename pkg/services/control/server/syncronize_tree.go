@@ -12,6 +12,10 @@ import (
 // TreeService represents a tree service instance.
 type TreeService interface {
 	Synchronize(ctx context.Context, cnr cid.ID, treeID string) error
+	// Compact removes operations synchronized by all container nodes from
+	// the tree log. Returns the compaction height and the number of removed
+	// operations.
+	Compact(ctx context.Context, cnr cid.ID, treeID string) (uint64, uint64, error)
 }
 
 func (s *Server) SynchronizeTree(ctx context.Context, req *control.SynchronizeTreeRequest) (*control.SynchronizeTreeResponse, error) {
@@ -142,6 +142,13 @@ func (x *DumpShardRequest_Body) SetIgnoreErrors(ignore bool) {
 	x.IgnoreErrors = ignore
 }
 
+// SetIncremental sets incremental dump flag and the marker of the previous
+// incremental dump for the dump shard request.
+func (x *DumpShardRequest_Body) SetIncremental(since uint64) {
+	x.Incremental = true
+	x.Since = since
+}
+
 // SetBody sets request body.
 func (x *DumpShardRequest) SetBody(v *DumpShardRequest_Body) {
 	if x != nil {
@@ -176,3 +176,341 @@ func TestSynchronizeTreeRequest_Body_StableMarshal(t *testing.T) {
 		},
 	)
 }
+
+func TestGetShardRebalanceStatusResponse_Body_StableMarshal(t *testing.T) {
+	testStableMarshal(t,
+		generateGetShardRebalanceStatusResponseBody(),
+		new(control.GetShardRebalanceStatusResponse_Body),
+		func(m1, m2 protoMessage) bool {
+			return equalGetShardRebalanceStatusResponseBodies(
+				m1.(*control.GetShardRebalanceStatusResponse_Body),
+				m2.(*control.GetShardRebalanceStatusResponse_Body),
+			)
+		},
+	)
+}
+
+func generateGetShardRebalanceStatusResponseBody() *control.GetShardRebalanceStatusResponse_Body {
+	return &control.GetShardRebalanceStatusResponse_Body{
+		Running:     true,
+		Rate:        100,
+		StartedAt:   1700000000,
+		ShardsTotal: 3,
+		ShardsDone:  1,
+		Processed:   1000,
+		Moved:       300,
+		Failed:      2,
+		Error:       "some error",
+	}
+}
+
+func equalGetShardRebalanceStatusResponseBodies(b1, b2 *control.GetShardRebalanceStatusResponse_Body) bool {
+	return b1.GetRunning() == b2.GetRunning() &&
+		b1.GetRate() == b2.GetRate() &&
+		b1.GetStartedAt() == b2.GetStartedAt() &&
+		b1.GetShardsTotal() == b2.GetShardsTotal() &&
+		b1.GetShardsDone() == b2.GetShardsDone() &&
+		b1.GetProcessed() == b2.GetProcessed() &&
+		b1.GetMoved() == b2.GetMoved() &&
+		b1.GetFailed() == b2.GetFailed() &&
+		b1.GetError() == b2.GetError()
+}
+
+func TestGetShardEvacuationStatusResponse_Body_StableMarshal(t *testing.T) {
+	testStableMarshal(t,
+		generateGetShardEvacuationStatusResponseBody(),
+		new(control.GetShardEvacuationStatusResponse_Body),
+		func(m1, m2 protoMessage) bool {
+			return equalGetShardEvacuationStatusResponseBodies(
+				m1.(*control.GetShardEvacuationStatusResponse_Body),
+				m2.(*control.GetShardEvacuationStatusResponse_Body),
+			)
+		},
+	)
+}
+
+func generateGetShardEvacuationStatusResponseBody() *control.GetShardEvacuationStatusResponse_Body {
+	return &control.GetShardEvacuationStatusResponse_Body{
+		Running:     true,
+		Shard_ID:    [][]byte{{1, 2, 3}, {4, 5, 6}},
+		StartedAt:   1700000000,
+		Evacuated:   300,
+		Failed:      2,
+		Remaining:   700,
+		Error:       "some error",
+		Interrupted: true,
+	}
+}
+
+func equalGetShardEvacuationStatusResponseBodies(b1, b2 *control.GetShardEvacuationStatusResponse_Body) bool {
+	if len(b1.Shard_ID) != len(b2.Shard_ID) {
+		return false
+	}
+
+	for i := range b1.Shard_ID {
+		if !bytes.Equal(b1.Shard_ID[i], b2.Shard_ID[i]) {
+			return false
+		}
+	}
+
+	return b1.GetRunning() == b2.GetRunning() &&
+		b1.GetStartedAt() == b2.GetStartedAt() &&
+		b1.GetEvacuated() == b2.GetEvacuated() &&
+		b1.GetFailed() == b2.GetFailed() &&
+		b1.GetRemaining() == b2.GetRemaining() &&
+		b1.GetError() == b2.GetError() &&
+		b1.GetInterrupted() == b2.GetInterrupted()
+}
+
+func TestCompactTreeResponse_Body_StableMarshal(t *testing.T) {
+	testStableMarshal(t,
+		&control.CompactTreeResponse_Body{
+			Height:  42,
+			Removed: 100,
+		},
+		new(control.CompactTreeResponse_Body),
+		func(m1, m2 protoMessage) bool {
+			b1 := m1.(*control.CompactTreeResponse_Body)
+			b2 := m2.(*control.CompactTreeResponse_Body)
+			return b1.GetHeight() == b2.GetHeight() &&
+				b1.GetRemoved() == b2.GetRemoved()
+		},
+	)
+}
+
+func TestGetContainerUsageResponse_Body_StableMarshal(t *testing.T) {
+	testStableMarshal(t,
+		&control.GetContainerUsageResponse_Body{
+			Size:    1 << 20,
+			Objects: 42,
+			Quota: &control.ContainerQuota{
+				SoftSize:    1 << 30,
+				HardSize:    2 << 30,
+				HardObjects: 1000,
+			},
+		},
+		new(control.GetContainerUsageResponse_Body),
+		func(m1, m2 protoMessage) bool {
+			b1 := m1.(*control.GetContainerUsageResponse_Body)
+			b2 := m2.(*control.GetContainerUsageResponse_Body)
+			return b1.GetSize() == b2.GetSize() &&
+				b1.GetObjects() == b2.GetObjects() &&
+				b1.GetQuota().GetSoftSize() == b2.GetQuota().GetSoftSize() &&
+				b1.GetQuota().GetHardSize() == b2.GetQuota().GetHardSize() &&
+				b1.GetQuota().GetSoftObjects() == b2.GetQuota().GetSoftObjects() &&
+				b1.GetQuota().GetHardObjects() == b2.GetQuota().GetHardObjects()
+		},
+	)
+}
+
+func TestGetShardCheckStatusRequest_Body_StableMarshal(t *testing.T) {
+	testStableMarshal(t,
+		&control.GetShardCheckStatusRequest_Body{
+			Offset: 100,
+			Limit:  50,
+		},
+		new(control.GetShardCheckStatusRequest_Body),
+		func(m1, m2 protoMessage) bool {
+			b1 := m1.(*control.GetShardCheckStatusRequest_Body)
+			b2 := m2.(*control.GetShardCheckStatusRequest_Body)
+			return b1.GetOffset() == b2.GetOffset() && b1.GetLimit() == b2.GetLimit()
+		},
+	)
+}
+
+func TestGetShardCheckStatusResponse_Body_StableMarshal(t *testing.T) {
+	testStableMarshal(t,
+		&control.GetShardCheckStatusResponse_Body{
+			Running:     true,
+			Shard_ID:    [][]byte{{1, 2, 3}, {4, 5, 6}},
+			StartedAt:   1700000000,
+			Checked:     1,
+			Error:       "some error",
+			TotalIssues: 10,
+			Issues: []*control.GetShardCheckStatusResponse_Body_Issue{
+				{
+					Shard_ID:    []byte{1, 2, 3},
+					Problem:     "wrong_storage_id",
+					ContainerId: []byte{4, 5, 6},
+					ObjectId:    []byte{7, 8, 9},
+					Stored:      "",
+					Actual:      "706561706f64",
+				},
+				{
+					Shard_ID: []byte{1, 2, 3},
+					Problem:  "object_counters",
+					Stored:   "phy=2,logic=1",
+					Actual:   "phy=1,logic=1",
+				},
+			},
+		},
+		new(control.GetShardCheckStatusResponse_Body),
+		func(m1, m2 protoMessage) bool {
+			b1 := m1.(*control.GetShardCheckStatusResponse_Body)
+			b2 := m2.(*control.GetShardCheckStatusResponse_Body)
+			if b1.GetRunning() != b2.GetRunning() ||
+				len(b1.GetShard_ID()) != len(b2.GetShard_ID()) ||
+				b1.GetStartedAt() != b2.GetStartedAt() ||
+				b1.GetChecked() != b2.GetChecked() ||
+				b1.GetError() != b2.GetError() ||
+				b1.GetTotalIssues() != b2.GetTotalIssues() {
+				return false
+			}
+			for i := range b1.GetShard_ID() {
+				if !bytes.Equal(b1.GetShard_ID()[i], b2.GetShard_ID()[i]) {
+					return false
+				}
+			}
+
+			i1, i2 := b1.GetIssues(), b2.GetIssues()
+			if len(i1) != len(i2) {
+				return false
+			}
+			for i := range i1 {
+				if !bytes.Equal(i1[i].GetShard_ID(), i2[i].GetShard_ID()) ||
+					i1[i].GetProblem() != i2[i].GetProblem() ||
+					!bytes.Equal(i1[i].GetContainerId(), i2[i].GetContainerId()) ||
+					!bytes.Equal(i1[i].GetObjectId(), i2[i].GetObjectId()) ||
+					i1[i].GetStored() != i2[i].GetStored() ||
+					i1[i].GetActual() != i2[i].GetActual() {
+					return false
+				}
+			}
+			return true
+		},
+	)
+}
+
+func TestListReplicationTasksResponse_Body_StableMarshal(t *testing.T) {
+	testStableMarshal(t,
+		&control.ListReplicationTasksResponse_Body{
+			Tasks: []*control.ListReplicationTasksResponse_Body_Task{
+				{
+					ContainerId: []byte{1, 2, 3},
+					ObjectId:    []byte{4, 5, 6},
+					Copies:      2,
+					Nodes:       [][]byte{{7, 8}, {9, 10}},
+					Active:      true,
+					Started:     1700000000,
+				},
+				{
+					ContainerId: []byte{1, 2, 3},
+					ObjectId:    []byte{11, 12, 13},
+					Copies:      1,
+					Started:     1700000001,
+				},
+			},
+		},
+		new(control.ListReplicationTasksResponse_Body),
+		func(m1, m2 protoMessage) bool {
+			t1 := m1.(*control.ListReplicationTasksResponse_Body).GetTasks()
+			t2 := m2.(*control.ListReplicationTasksResponse_Body).GetTasks()
+			if len(t1) != len(t2) {
+				return false
+			}
+			for i := range t1 {
+				if !bytes.Equal(t1[i].GetContainerId(), t2[i].GetContainerId()) ||
+					!bytes.Equal(t1[i].GetObjectId(), t2[i].GetObjectId()) ||
+					t1[i].GetCopies() != t2[i].GetCopies() ||
+					len(t1[i].GetNodes()) != len(t2[i].GetNodes()) ||
+					t1[i].GetActive() != t2[i].GetActive() ||
+					t1[i].GetStarted() != t2[i].GetStarted() {
+					return false
+				}
+				for j := range t1[i].GetNodes() {
+					if !bytes.Equal(t1[i].GetNodes()[j], t2[i].GetNodes()[j]) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+	)
+}
+
+func TestGetReloadStatusResponse_Body_StableMarshal(t *testing.T) {
+	testStableMarshal(t,
+		&control.GetReloadStatusResponse_Body{
+			Time: 1700000000,
+			Changes: []*control.GetReloadStatusResponse_Body_Change{
+				{
+					Section: "engine",
+					Target:  "/storage/data",
+					Action:  "added",
+				},
+				{
+					Section: "object",
+					Target:  "put.pool_size_remote",
+					Action:  "updated",
+					Error:   "any error",
+				},
+			},
+		},
+		new(control.GetReloadStatusResponse_Body),
+		func(m1, m2 protoMessage) bool {
+			b1 := m1.(*control.GetReloadStatusResponse_Body)
+			b2 := m2.(*control.GetReloadStatusResponse_Body)
+			if b1.GetTime() != b2.GetTime() || len(b1.GetChanges()) != len(b2.GetChanges()) {
+				return false
+			}
+			for i, ch := range b1.GetChanges() {
+				other := b2.GetChanges()[i]
+				if ch.GetSection() != other.GetSection() ||
+					ch.GetTarget() != other.GetTarget() ||
+					ch.GetAction() != other.GetAction() ||
+					ch.GetError() != other.GetError() {
+					return false
+				}
+			}
+			return true
+		},
+	)
+}
+
+func TestDetachShardsRequest_Body_StableMarshal(t *testing.T) {
+	testStableMarshal(t,
+		&control.DetachShardsRequest_Body{
+			Shard_ID: [][]byte{{0, 1, 2, 3, 4}, {5, 6, 7, 8, 9}},
+		},
+		new(control.DetachShardsRequest_Body),
+		func(m1, m2 protoMessage) bool {
+			b1 := m1.(*control.DetachShardsRequest_Body)
+			b2 := m2.(*control.DetachShardsRequest_Body)
+			if len(b1.GetShard_ID()) != len(b2.GetShard_ID()) {
+				return false
+			}
+			for i := range b1.GetShard_ID() {
+				if !bytes.Equal(b1.GetShard_ID()[i], b2.GetShard_ID()[i]) {
+					return false
+				}
+			}
+			return true
+		},
+	)
+}
+
+func TestAttachShardRequest_Body_StableMarshal(t *testing.T) {
+	testStableMarshal(t,
+		&control.AttachShardRequest_Body{
+			Config: []byte("metabase:\n  path: /storage/meta\n"),
+		},
+		new(control.AttachShardRequest_Body),
+		func(m1, m2 protoMessage) bool {
+			return bytes.Equal(m1.(*control.AttachShardRequest_Body).GetConfig(),
+				m2.(*control.AttachShardRequest_Body).GetConfig())
+		},
+	)
+}
+
+func TestAttachShardResponse_Body_StableMarshal(t *testing.T) {
+	testStableMarshal(t,
+		&control.AttachShardResponse_Body{
+			Shard_ID: []byte{0, 1, 2, 3, 4},
+		},
+		new(control.AttachShardResponse_Body),
+		func(m1, m2 protoMessage) bool {
+			return bytes.Equal(m1.(*control.AttachShardResponse_Body).GetShard_ID(),
+				m2.(*control.AttachShardResponse_Body).GetShard_ID())
+		},
+	)
+}
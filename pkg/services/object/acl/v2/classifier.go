@@ -8,6 +8,7 @@ import (
 	"github.com/epicchainlabs/epicchain-sdk-go/container/acl"
 	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
 	"github.com/epicchainlabs/epicchain-sdk-go/netmap"
+	"github.com/epicchainlabs/epicchain-sdk-go/user"
 	"go.uber.org/zap"
 )
 
@@ -18,8 +19,9 @@ type senderClassifier struct {
 }
 
 type classifyResult struct {
-	role acl.Role
-	key  []byte
+	role  acl.Role
+	key   []byte
+	owner user.ID
 }
 
 func (c senderClassifier) classify(
@@ -36,8 +38,9 @@ func (c senderClassifier) classify(
 	// if request owner is the same as container owner, return RoleUser
 	if ownerID.Equals(cnr.Owner()) {
 		return &classifyResult{
-			role: acl.RoleOwner,
-			key:  ownerKey,
+			role:  acl.RoleOwner,
+			key:   ownerKey,
+			owner: *ownerID,
 		}, nil
 	}
 
@@ -48,8 +51,9 @@ func (c senderClassifier) classify(
 			zap.String("error", err.Error()))
 	} else if isInnerRingNode {
 		return &classifyResult{
-			role: acl.RoleInnerRing,
-			key:  ownerKey,
+			role:  acl.RoleInnerRing,
+			key:   ownerKey,
+			owner: *ownerID,
 		}, nil
 	}
 
@@ -62,15 +66,17 @@ func (c senderClassifier) classify(
 			zap.String("error", err.Error()))
 	} else if isContainerNode {
 		return &classifyResult{
-			role: acl.RoleContainer,
-			key:  ownerKey,
+			role:  acl.RoleContainer,
+			key:   ownerKey,
+			owner: *ownerID,
 		}, nil
 	}
 
 	// if none of above, return RoleOthers
 	return &classifyResult{
-		role: acl.RoleOthers,
-		key:  ownerKey,
+		role:  acl.RoleOthers,
+		key:   ownerKey,
+		owner: *ownerID,
 	}, nil
 }
 
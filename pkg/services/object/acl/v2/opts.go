@@ -4,6 +4,7 @@ import (
 	"github.com/epicchainlabs/epicchain-node/pkg/core/container"
 	"github.com/epicchainlabs/epicchain-node/pkg/core/netmap"
 	objectSvc "github.com/epicchainlabs/epicchain-node/pkg/services/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/ratelimit"
 	"go.uber.org/zap"
 )
 
@@ -49,3 +50,11 @@ func WithIRFetcher(v InnerRingFetcher) Option {
 		c.irFetcher = v
 	}
 }
+
+// WithRateLimiter returns option to set limiter of the requests rate checked
+// after the request sender is classified.
+func WithRateLimiter(v *ratelimit.Limiter) Option {
+	return func(c *cfg) {
+		c.limiter = v
+	}
+}
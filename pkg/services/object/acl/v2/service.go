@@ -9,6 +9,7 @@ import (
 	"github.com/epicchainlabs/epicchain-node/pkg/core/container"
 	"github.com/epicchainlabs/epicchain-node/pkg/core/netmap"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/ratelimit"
 	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
 	"github.com/epicchainlabs/epicchain-sdk-go/container/acl"
 	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
@@ -69,6 +70,8 @@ type cfg struct {
 
 	nm netmap.Source
 
+	limiter *ratelimit.Limiter
+
 	next object.ServiceServer
 }
 
@@ -619,6 +622,13 @@ func (b Service) findRequestInfo(req MetaWithToken, idCnr cid.ID, op acl.Op) (in
 		return info, err
 	}
 
+	if b.limiter != nil {
+		err = b.limiter.Allow(res.owner, idCnr, res.role)
+		if err != nil {
+			return info, err
+		}
+	}
+
 	info.basicACL = cnr.Value.BasicACL()
 	info.requestRole = res.role
 	info.operation = op
@@ -0,0 +1,179 @@
+package ec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	containercore "github.com/epicchainlabs/epicchain-node/pkg/core/container"
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	"github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+)
+
+// ErrNotEnoughParts is returned when the available parts are not enough to
+// restore the object.
+var ErrNotEnoughParts = errors.New("not enough parts to restore the object")
+
+// Head returns header of the parent object taken from the first found part.
+// Returns [apistatus.ErrObjectNotFound] if no part is found on the nodes.
+func Head(ctx context.Context, s Storage, nodes []Node, parent oid.Address) (*object.Object, error) {
+	var hdr *object.Object
+
+	_ = iterateParts(ctx, s, nodes, parent, false, func(p Part, _ *object.Object) bool {
+		hdr = p.Parent
+		return true
+	})
+	if hdr != nil {
+		return hdr, nil
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return nil, apistatus.ErrObjectNotFound
+}
+
+// Get restores the parent object with payload from its parts stored on the
+// nodes. Parts are read until enough of them are received. Returns
+// [apistatus.ErrObjectNotFound] if no part is found and [ErrNotEnoughParts]
+// if the found parts are not enough to restore the payload.
+func Get(ctx context.Context, s Storage, rule containercore.ErasureCoding, nodes []Node, parent oid.Address) (*object.Object, error) {
+	var (
+		hdr    *object.Object
+		shards = make([][]byte, rule.TotalParts())
+		found  = make([]bool, rule.TotalParts())
+		n      int
+	)
+
+	err := iterateParts(ctx, s, nodes, parent, true, func(p Part, obj *object.Object) bool {
+		if p.Index >= len(shards) || found[p.Index] {
+			return false
+		}
+
+		hdr = p.Parent
+		shards[p.Index] = obj.Payload()
+		found[p.Index] = true
+		n++
+
+		return n == rule.DataParts
+	})
+
+	switch {
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	case hdr == nil:
+		return nil, apistatus.ErrObjectNotFound
+	case n < rule.DataParts:
+		return nil, fmt.Errorf("%w: %d of %d required parts are available, last error: %v",
+			ErrNotEnoughParts, n, rule.DataParts, err)
+	}
+
+	payload, err := decode(rule, shards, hdr.PayloadSize())
+	if err != nil {
+		return nil, err
+	}
+
+	hdr.SetPayload(payload)
+
+	err = hdr.VerifyPayloadChecksum()
+	if err != nil {
+		return nil, fmt.Errorf("restored payload: %w", err)
+	}
+
+	return hdr, nil
+}
+
+// HasPart checks whether the node stores the part of the parent object with
+// the given index.
+func HasPart(ctx context.Context, s Storage, node Node, parent oid.Address, index int) (bool, error) {
+	ids, err := s.SearchParts(ctx, node, parent)
+	if err != nil {
+		return false, fmt.Errorf("search parts: %w", err)
+	}
+
+	for i := range ids {
+		hdr, err := s.HeadPart(ctx, node, addressOf(parent.Container(), ids[i]))
+		if err != nil {
+			if errors.Is(err, apistatus.ErrObjectNotFound) {
+				continue
+			}
+
+			return false, fmt.Errorf("read part header: %w", err)
+		}
+
+		p, err := ReadPart(hdr)
+		if err == nil && p.Index == index {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// iterateParts reads parts of the parent object from the nodes and passes
+// them to f until it returns true. Failures of particular nodes and invalid
+// parts are skipped, the last error is returned for diagnostics.
+func iterateParts(ctx context.Context, s Storage, nodes []Node, parent oid.Address, withPayload bool, f func(Part, *object.Object) bool) error {
+	var lastErr error
+
+	for i := range nodes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		ids, err := s.SearchParts(ctx, nodes[i], parent)
+		if err != nil {
+			lastErr = fmt.Errorf("search parts: %w", err)
+			continue
+		}
+
+		for j := range ids {
+			var obj *object.Object
+
+			addr := addressOf(parent.Container(), ids[j])
+			if withPayload {
+				obj, err = s.GetPart(ctx, nodes[i], addr)
+			} else {
+				obj, err = s.HeadPart(ctx, nodes[i], addr)
+			}
+
+			if err != nil {
+				lastErr = fmt.Errorf("read part %s: %w", addr, err)
+				continue
+			}
+
+			p, err := ReadPart(obj)
+			if err != nil {
+				lastErr = fmt.Errorf("read part %s: %w", addr, err)
+				continue
+			}
+
+			if id, _ := p.Parent.ID(); id != parent.Object() {
+				continue
+			}
+
+			if withPayload {
+				if err = obj.VerifyPayloadChecksum(); err != nil {
+					lastErr = fmt.Errorf("read part %s: %w", addr, err)
+					continue
+				}
+			}
+
+			if f(p, obj) {
+				return nil
+			}
+		}
+	}
+
+	return lastErr
+}
+
+func addressOf(cnr cid.ID, obj oid.ID) oid.Address {
+	var addr oid.Address
+	addr.SetContainer(cnr)
+	addr.SetObject(obj)
+	return addr
+}
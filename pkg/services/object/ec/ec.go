@@ -0,0 +1,245 @@
+// Package ec implements erasure coding of the objects stored in the
+// containers with EC storage policy.
+//
+// Payload of each regular object is split into data parts and parity parts
+// are computed from them (see [containercore.ErasureCoding]). Every part is
+// stored as a separate regular object on its own container node. Part objects
+// are formed and signed by the storage node, they inherit the container,
+// owner, version, creation and expiration epochs of the original (parent)
+// object and carry its header in the attributes, so the parent object can be
+// restored from any sufficient set of parts. Part objects are deterministic:
+// the same part formed by different nodes has the same ID.
+package ec
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+
+	containercore "github.com/epicchainlabs/epicchain-node/pkg/core/container"
+	"github.com/epicchainlabs/epicchain-sdk-go/checksum"
+	neofscrypto "github.com/epicchainlabs/epicchain-sdk-go/crypto"
+	"github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"github.com/klauspost/reedsolomon"
+)
+
+// Attributes of the part objects.
+const (
+	// AttributeParent is an ID of the parent object.
+	AttributeParent = "__NEOFS__EC_PARENT"
+	// AttributeIndex is an index of the part starting from zero. Data parts
+	// go first, then parity ones.
+	AttributeIndex = "__NEOFS__EC_INDEX"
+	// AttributeHeader is a base64-encoded binary header of the parent object.
+	AttributeHeader = "__NEOFS__EC_HEADER"
+)
+
+// ErrNotPart is returned by [ReadPart] for objects that are not parts of
+// another object.
+var ErrNotPart = errors.New("object is not an EC part")
+
+// Part describes the object part.
+type Part struct {
+	// Parent is a header of the parent object without payload.
+	Parent *object.Object
+	// Index is an index of the part.
+	Index int
+}
+
+// IsPart checks whether the object is a part of another object.
+func IsPart(obj *object.Object) bool {
+	for _, a := range obj.Attributes() {
+		if a.Key() == AttributeParent {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReadPart reads part information from the object header. Returns
+// [ErrNotPart] if the object is not a part.
+func ReadPart(obj *object.Object) (Part, error) {
+	var parent, index, hdr string
+
+	for _, a := range obj.Attributes() {
+		switch a.Key() {
+		case AttributeParent:
+			parent = a.Value()
+		case AttributeIndex:
+			index = a.Value()
+		case AttributeHeader:
+			hdr = a.Value()
+		}
+	}
+
+	if parent == "" {
+		return Part{}, ErrNotPart
+	}
+
+	var (
+		p   Part
+		err error
+	)
+
+	p.Index, err = strconv.Atoi(index)
+	if err != nil {
+		return Part{}, fmt.Errorf("invalid %s attribute: %w", AttributeIndex, err)
+	}
+
+	if p.Index < 0 || p.Index >= containercore.MaxECParts {
+		return Part{}, fmt.Errorf("invalid %s attribute: index %d out of range", AttributeIndex, p.Index)
+	}
+
+	bin, err := base64.StdEncoding.DecodeString(hdr)
+	if err != nil {
+		return Part{}, fmt.Errorf("invalid %s attribute: %w", AttributeHeader, err)
+	}
+
+	p.Parent = object.New()
+
+	err = p.Parent.Unmarshal(bin)
+	if err != nil {
+		return Part{}, fmt.Errorf("invalid %s attribute: decode parent header: %w", AttributeHeader, err)
+	}
+
+	err = p.Parent.VerifyID()
+	if err != nil {
+		return Part{}, fmt.Errorf("invalid %s attribute: %w", AttributeHeader, err)
+	}
+
+	if id, _ := p.Parent.ID(); id.EncodeToString() != parent {
+		return Part{}, fmt.Errorf("parent ID %s differs from the one in the parent header %s", parent, id)
+	}
+
+	return p, nil
+}
+
+// Split splits payload of the object into the parts according to the rule
+// and returns part objects signed by the signer. The object must be complete:
+// with ID and signature.
+func Split(obj *object.Object, rule containercore.ErasureCoding, signer neofscrypto.Signer) ([]*object.Object, error) {
+	shards, err := encode(rule, obj.Payload())
+	if err != nil {
+		return nil, err
+	}
+
+	hdr, err := obj.CutPayload().Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("encode object header: %w", err)
+	}
+
+	parent, _ := obj.ID()
+	hdrAttr := base64.StdEncoding.EncodeToString(hdr)
+	parts := make([]*object.Object, len(shards))
+
+	for i := range shards {
+		parts[i], err = formPart(obj, parent, hdrAttr, i, shards[i], signer)
+		if err != nil {
+			return nil, fmt.Errorf("form part #%d: %w", i, err)
+		}
+	}
+
+	return parts, nil
+}
+
+func formPart(obj *object.Object, parent oid.ID, hdr string, i int, shard []byte, signer neofscrypto.Signer) (*object.Object, error) {
+	attrs := make([]object.Attribute, 0, 4)
+
+	for _, a := range obj.Attributes() {
+		if a.Key() == object.AttributeExpirationEpoch {
+			attrs = append(attrs, a)
+		}
+	}
+
+	for _, kv := range [][2]string{
+		{AttributeParent, parent.EncodeToString()},
+		{AttributeIndex, strconv.Itoa(i)},
+		{AttributeHeader, hdr},
+	} {
+		var a object.Attribute
+		a.SetKey(kv[0])
+		a.SetValue(kv[1])
+		attrs = append(attrs, a)
+	}
+
+	cnr, _ := obj.ContainerID()
+
+	part := object.New()
+	part.SetVersion(obj.Version())
+	part.SetContainerID(cnr)
+	part.SetOwnerID(obj.OwnerID())
+	part.SetCreationEpoch(obj.CreationEpoch())
+	part.SetType(object.TypeRegular)
+	part.SetAttributes(attrs...)
+	part.SetPayload(shard)
+	part.SetPayloadSize(uint64(len(shard)))
+
+	if _, ok := obj.PayloadHomomorphicHash(); ok {
+		var cs checksum.Checksum
+		checksum.Calculate(&cs, checksum.TZ, shard)
+		part.SetPayloadHomomorphicHash(cs)
+	}
+
+	err := part.SetVerificationFields(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return part, nil
+}
+
+// encode splits data into the data parts and calculates the parity ones.
+func encode(rule containercore.ErasureCoding, data []byte) ([][]byte, error) {
+	if len(data) == 0 {
+		return make([][]byte, rule.TotalParts()), nil
+	}
+
+	enc, err := reedsolomon.New(rule.DataParts, rule.ParityParts)
+	if err != nil {
+		return nil, fmt.Errorf("init Reed-Solomon encoder: %w", err)
+	}
+
+	shards, err := enc.Split(data)
+	if err != nil {
+		return nil, fmt.Errorf("split data into parts: %w", err)
+	}
+
+	err = enc.Encode(shards)
+	if err != nil {
+		return nil, fmt.Errorf("compute parity parts: %w", err)
+	}
+
+	return shards, nil
+}
+
+// decode restores data of the given size from the parts. Missing parts must
+// be nil, at least rule.DataParts parts are required.
+func decode(rule containercore.ErasureCoding, shards [][]byte, size uint64) ([]byte, error) {
+	if size == 0 {
+		return []byte{}, nil
+	}
+
+	enc, err := reedsolomon.New(rule.DataParts, rule.ParityParts)
+	if err != nil {
+		return nil, fmt.Errorf("init Reed-Solomon encoder: %w", err)
+	}
+
+	err = enc.ReconstructData(shards)
+	if err != nil {
+		return nil, fmt.Errorf("reconstruct data parts: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(int(size))
+
+	err = enc.Join(&buf, shards, int(size))
+	if err != nil {
+		return nil, fmt.Errorf("join data parts: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
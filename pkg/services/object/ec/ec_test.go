@@ -0,0 +1,277 @@
+package ec
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"testing"
+
+	"github.com/epicchainlabs/epicchain-go/pkg/crypto/keys"
+	containercore "github.com/epicchainlabs/epicchain-node/pkg/core/container"
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
+	neofsecdsa "github.com/epicchainlabs/epicchain-sdk-go/crypto/ecdsa"
+	"github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	usertest "github.com/epicchainlabs/epicchain-sdk-go/user/test"
+	"github.com/stretchr/testify/require"
+)
+
+// testStorage emulates in-process container nodes storing objects in memory.
+type testStorage struct {
+	mtx   sync.Mutex
+	nodes map[string]map[oid.ID]*object.Object
+}
+
+func newTestStorage() *testStorage {
+	return &testStorage{nodes: make(map[string]map[oid.ID]*object.Object)}
+}
+
+func nodeKey(node Node) string {
+	if node.Local {
+		return "local"
+	}
+
+	return string(node.Info.PublicKey())
+}
+
+func testNodes(n int) []Node {
+	nodes := make([]Node, n)
+	for i := range nodes {
+		nodes[i].Info.SetPublicKey([]byte{byte(i)})
+	}
+
+	return nodes
+}
+
+func (s *testStorage) SearchParts(_ context.Context, node Node, parent oid.Address) ([]oid.ID, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var res []oid.ID
+
+	for id, obj := range s.nodes[nodeKey(node)] {
+		for _, a := range obj.Attributes() {
+			if a.Key() == AttributeParent && a.Value() == parent.Object().EncodeToString() {
+				res = append(res, id)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (s *testStorage) HeadPart(ctx context.Context, node Node, addr oid.Address) (*object.Object, error) {
+	obj, err := s.GetPart(ctx, node, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.CutPayload(), nil
+}
+
+func (s *testStorage) GetPart(_ context.Context, node Node, addr oid.Address) (*object.Object, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	obj, ok := s.nodes[nodeKey(node)][addr.Object()]
+	if !ok {
+		return nil, apistatus.ErrObjectNotFound
+	}
+
+	return obj, nil
+}
+
+func (s *testStorage) PutPart(_ context.Context, node Node, part *object.Object) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	m, ok := s.nodes[nodeKey(node)]
+	if !ok {
+		m = make(map[oid.ID]*object.Object)
+		s.nodes[nodeKey(node)] = m
+	}
+
+	id, _ := part.ID()
+	m[id] = part
+
+	return nil
+}
+
+func (s *testStorage) remove(node Node) {
+	s.mtx.Lock()
+	delete(s.nodes, nodeKey(node))
+	s.mtx.Unlock()
+}
+
+func testSigner(t *testing.T) neofsecdsa.SignerRFC6979 {
+	key, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	return neofsecdsa.SignerRFC6979(key.PrivateKey)
+}
+
+func testObject(t *testing.T, size int) *object.Object {
+	payload := make([]byte, size)
+	_, _ = rand.Read(payload)
+
+	obj := object.New()
+	obj.SetContainerID(cidtest.ID())
+	owner := usertest.ID(t)
+	obj.SetOwnerID(&owner)
+	obj.SetCreationEpoch(10)
+	obj.SetPayload(payload)
+	obj.SetPayloadSize(uint64(size))
+
+	require.NoError(t, obj.SetVerificationFields(testSigner(t)))
+
+	return obj
+}
+
+func storeParts(t *testing.T, s Storage, nodes []Node, obj *object.Object, rule containercore.ErasureCoding) []*object.Object {
+	parts, err := Split(obj, rule, testSigner(t))
+	require.NoError(t, err)
+	require.Len(t, parts, rule.TotalParts())
+
+	for i := range parts {
+		require.NoError(t, s.PutPart(context.Background(), nodes[i], parts[i]))
+	}
+
+	return parts
+}
+
+func parentAddress(obj *object.Object) oid.Address {
+	cnr, _ := obj.ContainerID()
+	id, _ := obj.ID()
+
+	return addressOf(cnr, id)
+}
+
+func TestSplit(t *testing.T) {
+	rule := containercore.ErasureCoding{DataParts: 3, ParityParts: 2}
+	obj := testObject(t, 1000)
+	signer := testSigner(t)
+
+	parts, err := Split(obj, rule, signer)
+	require.NoError(t, err)
+	require.Len(t, parts, rule.TotalParts())
+
+	parentID, _ := obj.ID()
+
+	for i := range parts {
+		require.True(t, IsPart(parts[i]))
+		require.NoError(t, parts[i].CheckVerificationFields())
+
+		p, err := ReadPart(parts[i])
+		require.NoError(t, err)
+		require.Equal(t, i, p.Index)
+
+		id, _ := p.Parent.ID()
+		require.Equal(t, parentID, id)
+		require.Equal(t, obj.PayloadSize(), p.Parent.PayloadSize())
+	}
+
+	t.Run("deterministic", func(t *testing.T) {
+		again, err := Split(obj, rule, signer)
+		require.NoError(t, err)
+
+		for i := range parts {
+			id1, _ := parts[i].ID()
+			id2, _ := again[i].ID()
+			require.Equal(t, id1, id2)
+		}
+	})
+
+	t.Run("not a part", func(t *testing.T) {
+		require.False(t, IsPart(obj))
+
+		_, err := ReadPart(obj)
+		require.ErrorIs(t, err, ErrNotPart)
+	})
+}
+
+func TestGet(t *testing.T) {
+	rule := containercore.ErasureCoding{DataParts: 3, ParityParts: 2}
+
+	for _, size := range []int{0, 1, 1000, 1024} {
+		s := newTestStorage()
+		nodes := testNodes(rule.TotalParts())
+		obj := testObject(t, size)
+
+		storeParts(t, s, nodes, obj, rule)
+
+		// parity parts are enough to restore the data ones
+		s.remove(nodes[0])
+		s.remove(nodes[2])
+
+		res, err := Get(context.Background(), s, rule, nodes, parentAddress(obj))
+		require.NoError(t, err, size)
+		require.Equal(t, obj.Payload(), res.Payload(), size)
+
+		id, _ := res.ID()
+		expID, _ := obj.ID()
+		require.Equal(t, expID, id)
+	}
+
+	t.Run("not enough parts", func(t *testing.T) {
+		s := newTestStorage()
+		nodes := testNodes(rule.TotalParts())
+		obj := testObject(t, 1000)
+
+		storeParts(t, s, nodes, obj, rule)
+
+		s.remove(nodes[0])
+		s.remove(nodes[3])
+		s.remove(nodes[4])
+
+		_, err := Get(context.Background(), s, rule, nodes, parentAddress(obj))
+		require.ErrorIs(t, err, ErrNotEnoughParts)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		s := newTestStorage()
+
+		_, err := Get(context.Background(), s, rule, testNodes(rule.TotalParts()), parentAddress(testObject(t, 10)))
+		require.ErrorIs(t, err, apistatus.ErrObjectNotFound)
+	})
+}
+
+func TestHead(t *testing.T) {
+	rule := containercore.ErasureCoding{DataParts: 2, ParityParts: 1}
+	s := newTestStorage()
+	nodes := testNodes(rule.TotalParts())
+	obj := testObject(t, 100)
+
+	storeParts(t, s, nodes, obj, rule)
+	s.remove(nodes[0])
+
+	hdr, err := Head(context.Background(), s, nodes, parentAddress(obj))
+	require.NoError(t, err)
+	require.Equal(t, obj.CutPayload(), hdr)
+
+	_, err = Head(context.Background(), s, nodes, parentAddress(testObject(t, 10)))
+	require.ErrorIs(t, err, apistatus.ErrObjectNotFound)
+}
+
+func TestHasPart(t *testing.T) {
+	rule := containercore.ErasureCoding{DataParts: 2, ParityParts: 1}
+	s := newTestStorage()
+	nodes := testNodes(rule.TotalParts())
+	obj := testObject(t, 100)
+	addr := parentAddress(obj)
+
+	storeParts(t, s, nodes, obj, rule)
+	s.remove(nodes[1])
+
+	ok, err := HasPart(context.Background(), s, nodes[0], addr, 0)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = HasPart(context.Background(), s, nodes[0], addr, 1)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = HasPart(context.Background(), s, nodes[1], addr, 1)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
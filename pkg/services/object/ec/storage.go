@@ -0,0 +1,197 @@
+package ec
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/core/client"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/engine"
+	internalclient "github.com/epicchainlabs/epicchain-node/pkg/services/object/internal/client"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/util"
+	"github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+)
+
+// Node describes the container node storing object parts.
+type Node struct {
+	// Local is set for the local node, Info is not used in this case.
+	Local bool
+	// Info is a descriptor of the remote node.
+	Info client.NodeInfo
+}
+
+// Storage provides access to the object parts stored on the container nodes.
+type Storage interface {
+	// SearchParts returns IDs of the parent object parts stored on the node.
+	SearchParts(ctx context.Context, node Node, parent oid.Address) ([]oid.ID, error)
+	// HeadPart reads header of the part object stored on the node.
+	HeadPart(ctx context.Context, node Node, addr oid.Address) (*object.Object, error)
+	// GetPart reads the part object stored on the node.
+	GetPart(ctx context.Context, node Node, addr oid.Address) (*object.Object, error)
+	// PutPart saves the part object on the node.
+	PutPart(ctx context.Context, node Node, part *object.Object) error
+}
+
+// ClientConstructor provides clients of the remote nodes.
+type ClientConstructor interface {
+	Get(client.NodeInfo) (client.MultiAddressClient, error)
+}
+
+// NodeStorage is a [Storage] working with the local storage engine and with
+// the remote nodes over the network. Remote requests are signed by the node
+// key and are not forwarded further.
+type NodeStorage struct {
+	local *engine.StorageEngine
+
+	keys *util.KeyStorage
+
+	clients ClientConstructor
+}
+
+// NewNodeStorage returns new NodeStorage working with the given local storage
+// engine and remote node clients.
+func NewNodeStorage(e *engine.StorageEngine, keys *util.KeyStorage, clients ClientConstructor) *NodeStorage {
+	return &NodeStorage{
+		local:   e,
+		keys:    keys,
+		clients: clients,
+	}
+}
+
+func partFilters(parent oid.ID) object.SearchFilters {
+	var fs object.SearchFilters
+	fs.AddFilter(AttributeParent, parent.EncodeToString(), object.MatchStringEqual)
+	return fs
+}
+
+// remotePrm is a common part of the remote request parameters.
+type remotePrm interface {
+	SetClient(client.Client)
+	SetContext(context.Context)
+	SetPrivateKey(*ecdsa.PrivateKey)
+	SetTTL(uint32)
+}
+
+// prepareRemote sets common parameters of the request to the remote node.
+func (s *NodeStorage) prepareRemote(ctx context.Context, node Node, prm remotePrm) error {
+	c, err := s.clients.Get(node.Info)
+	if err != nil {
+		return fmt.Errorf("could not create SDK client %s: %w", node.Info.AddressGroup(), err)
+	}
+
+	key, err := s.keys.GetKey(nil)
+	if err != nil {
+		return fmt.Errorf("could not receive private key: %w", err)
+	}
+
+	prm.SetContext(ctx)
+	prm.SetClient(c)
+	prm.SetPrivateKey(key)
+	prm.SetTTL(1)
+
+	return nil
+}
+
+// SearchParts implements [Storage].
+func (s *NodeStorage) SearchParts(ctx context.Context, node Node, parent oid.Address) ([]oid.ID, error) {
+	fs := partFilters(parent.Object())
+
+	if node.Local {
+		addrs, err := engine.Select(s.local, parent.Container(), fs)
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]oid.ID, len(addrs))
+		for i := range addrs {
+			ids[i] = addrs[i].Object()
+		}
+
+		return ids, nil
+	}
+
+	var prm internalclient.SearchObjectsPrm
+
+	err := s.prepareRemote(ctx, node, &prm)
+	if err != nil {
+		return nil, err
+	}
+
+	prm.SetContainerID(parent.Container())
+	prm.SetFilters(fs)
+
+	res, err := internalclient.SearchObjects(prm)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.IDList(), nil
+}
+
+// HeadPart implements [Storage].
+func (s *NodeStorage) HeadPart(ctx context.Context, node Node, addr oid.Address) (*object.Object, error) {
+	if node.Local {
+		return engine.Head(s.local, addr)
+	}
+
+	var prm internalclient.HeadObjectPrm
+
+	err := s.prepareRemote(ctx, node, &prm)
+	if err != nil {
+		return nil, err
+	}
+
+	prm.SetAddress(addr)
+	prm.SetRawFlag()
+
+	res, err := internalclient.HeadObject(prm)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Header(), nil
+}
+
+// GetPart implements [Storage].
+func (s *NodeStorage) GetPart(ctx context.Context, node Node, addr oid.Address) (*object.Object, error) {
+	if node.Local {
+		return engine.Get(s.local, addr)
+	}
+
+	var prm internalclient.GetObjectPrm
+
+	err := s.prepareRemote(ctx, node, &prm)
+	if err != nil {
+		return nil, err
+	}
+
+	prm.SetAddress(addr)
+	prm.SetRawFlag()
+
+	res, err := internalclient.GetObject(prm)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Object(), nil
+}
+
+// PutPart implements [Storage].
+func (s *NodeStorage) PutPart(ctx context.Context, node Node, part *object.Object) error {
+	if node.Local {
+		return engine.Put(s.local, part)
+	}
+
+	var prm internalclient.PutObjectPrm
+
+	err := s.prepareRemote(ctx, node, &prm)
+	if err != nil {
+		return err
+	}
+
+	prm.SetObject(part)
+
+	_, err = internalclient.PutObject(prm)
+	return err
+}
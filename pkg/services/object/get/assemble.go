@@ -31,6 +31,18 @@ func (exec *execCtx) assemble() {
 	// `execCtx` so it should be disabled there.
 	exec.disableForwarding()
 
+	if exec.ctxRange() != nil {
+		if children, ok := exec.cachedChildren(); ok {
+			exec.log.Debug("assembling the range from the cached children")
+
+			if !exec.rangeFromCachedChildren(children) {
+				exec.forgetChildren()
+			}
+
+			return
+		}
+	}
+
 	exec.log.Debug("trying to assemble the object...")
 
 	splitInfo := exec.splitInfo()
@@ -59,15 +71,11 @@ func (exec *execCtx) assemble() {
 			if ok := exec.writeCollectedHeader(); ok {
 				exec.overtakePayloadDirectly(children, nil, true)
 			}
-		} else {
-			// TODO: #1155 choose one-by-one restoring algorithm according to size
-			//  * if size > MAX => go right-to-left with HEAD and back with GET
-			//  * else go right-to-left with GET and compose in single object before writing
-
-			if ok := exec.overtakePayloadInReverse(children[len(children)-1]); ok {
-				// payload of all children except the last are written, write last payload
-				exec.writeObjectPayload(exec.collectedObject)
-			}
+		} else if measured, ok := exec.measureChildren(children); ok {
+			// children listed in the linking object are not necessarily
+			// chained by the previous IDs (e.g. multipart uploads)
+			exec.cacheChildren(measured)
+			exec.rangeFromChildren(measured)
 		}
 	} else if prev != nil {
 		if ok := exec.writeCollectedHeader(); ok {
@@ -66,6 +66,8 @@ func (exec *execCtx) processV2Link(linkID oid.ID) bool {
 		return false
 	}
 
+	exec.cacheChildren(link.Objects())
+
 	if exec.ctxRange() == nil {
 		// GET case
 
@@ -98,44 +100,7 @@ func (exec *execCtx) processV2Link(linkID oid.ID) bool {
 		return true
 	}
 
-	return exec.rangeFromLink(link)
-}
-
-func (exec *execCtx) rangeFromLink(link objectSDK.Link) bool {
-	children := link.Objects()
-	first, firstOffset, last, lastBound := requiredChildren(exec.ctxRange(), children)
-
-	for i := first; i <= last; i++ {
-		child := children[i]
-
-		var rngPerChild *objectSDK.Range
-		if i == first || i == last {
-			rngPerChild = new(objectSDK.Range)
-
-			if i == first {
-				rngPerChild.SetOffset(uint64(firstOffset))
-				rngPerChild.SetLength(uint64(child.ObjectSize()) - uint64(firstOffset))
-			}
-			if i == last {
-				rngPerChild.SetLength(uint64(lastBound) - rngPerChild.GetOffset())
-			}
-		}
-
-		part, ok := exec.getChild(child.ObjectID(), rngPerChild, false)
-		if !ok {
-			return false
-		}
-
-		if !exec.writeObjectPayload(part) {
-			// we have payload, we want to send it but can't so stop here
-			return true
-		}
-	}
-
-	exec.status = statusOK
-	exec.err = nil
-
-	return true
+	return exec.rangeFromChildren(link.Objects())
 }
 
 // it is required for ranges to be in the bounds of the all objects' payload;
@@ -0,0 +1,259 @@
+package getsvc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// maxConcurrentHeads limits the number of child headers requested
+	// simultaneously to measure the children.
+	maxConcurrentHeads = 16
+
+	// maxBufferedChildren limits the number of child payload ranges requested
+	// simultaneously, and thus buffered before being written in order.
+	maxBufferedChildren = 4
+
+	// childrenCacheSize is a number of split objects with cached sizes of
+	// their children.
+	childrenCacheSize = 1000
+)
+
+// cachedChildren returns cached children of the requested object with their
+// payload sizes.
+func (exec *execCtx) cachedChildren() ([]objectSDK.MeasuredObject, bool) {
+	if exec.svc.childrenCache == nil {
+		return nil, false
+	}
+
+	return exec.svc.childrenCache.Get(exec.address())
+}
+
+// cacheChildren caches children of the requested object with their payload
+// sizes. Objects are immutable, so the children never change.
+func (exec *execCtx) cacheChildren(children []objectSDK.MeasuredObject) {
+	if exec.svc.childrenCache != nil && len(children) > 0 {
+		exec.svc.childrenCache.Add(exec.address(), children)
+	}
+}
+
+// forgetChildren drops cached children of the requested object.
+func (exec *execCtx) forgetChildren() {
+	if exec.svc.childrenCache != nil {
+		exec.svc.childrenCache.Remove(exec.address())
+	}
+}
+
+// rangeFromCachedChildren checks the requested range against the cached
+// children and writes it.
+func (exec *execCtx) rangeFromCachedChildren(children []objectSDK.MeasuredObject) bool {
+	var parSize uint64
+	for i := range children {
+		parSize += uint64(children[i].ObjectSize())
+	}
+
+	rng := exec.ctxRange()
+	seekOff := rng.GetOffset()
+	seekTo := seekOff + rng.GetLength()
+
+	if seekTo < seekOff || parSize < seekOff || parSize < seekTo {
+		var errOutOfRange apistatus.ObjectOutOfRange
+
+		exec.err = &errOutOfRange
+		exec.status = statusAPIResponse
+
+		return true
+	}
+
+	return exec.rangeFromChildren(children)
+}
+
+// rangeFromChildren writes the requested range of the object payload. Only
+// children overlapping the range are read, up to maxBufferedChildren of them
+// at once. The range must be in the bounds of the children payload.
+func (exec *execCtx) rangeFromChildren(children []objectSDK.MeasuredObject) bool {
+	first, firstOffset, last, lastBound := requiredChildren(exec.ctxRange(), children)
+	if first < 0 {
+		// empty range at the end of the payload
+		exec.status = statusOK
+		exec.err = nil
+
+		return true
+	}
+
+	ctx, cancel := context.WithCancel(exec.context())
+	defer cancel()
+
+	exec.prm.common = exec.prm.common.WithLocalOnly(false)
+
+	type result struct {
+		obj *objectSDK.Object
+		statusError
+	}
+
+	var (
+		results = make([]chan result, last-first+1)
+		slots   = make(chan struct{}, maxBufferedChildren)
+	)
+
+	for i := range results {
+		results[i] = make(chan result, 1)
+	}
+
+	// execution context is not accessed concurrently
+	prm := exec.prm
+	prm.addr.SetContainer(exec.containerID())
+
+	go func() {
+		for i := first; i <= last; i++ {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			child := children[i]
+
+			var rngPerChild *objectSDK.Range
+			if i == first || i == last {
+				rngPerChild = new(objectSDK.Range)
+
+				if i == first {
+					rngPerChild.SetOffset(uint64(firstOffset))
+					rngPerChild.SetLength(uint64(child.ObjectSize()) - uint64(firstOffset))
+				}
+				if i == last {
+					rngPerChild.SetLength(uint64(lastBound) - rngPerChild.GetOffset())
+				}
+			}
+
+			res := results[i-first]
+
+			go func() {
+				obj, st := exec.svc.readChild(ctx, prm, exec.log, child.ObjectID(), rngPerChild)
+				res <- result{obj: obj, statusError: st}
+			}()
+		}
+	}()
+
+	for i := range results {
+		var res result
+
+		select {
+		case res = <-results[i]:
+		case <-ctx.Done():
+			exec.status = statusUndefined
+			exec.err = ctx.Err()
+
+			return false
+		}
+
+		<-slots
+
+		if res.status != statusOK {
+			exec.statusError = res.statusError
+			return false
+		}
+
+		if !exec.writeObjectPayload(res.obj) {
+			// we have payload, we want to send it but can't so stop here
+			return true
+		}
+	}
+
+	exec.status = statusOK
+	exec.err = nil
+
+	return true
+}
+
+// readChild reads the payload range of the child object from the container
+// set in the parameters. Unlike getChild, it does not use the execution
+// context and may be called concurrently.
+func (s *Service) readChild(ctx context.Context, p RangePrm, log *zap.Logger, id oid.ID, rng *objectSDK.Range) (*objectSDK.Object, statusError) {
+	if rng != nil {
+		log = log.With(zap.String("child range", prettyRange(rng)))
+	}
+
+	w := NewSimpleObjectWriter()
+
+	p.objWriter = w
+	p.SetRange(rng)
+	p.addr.SetObject(id)
+
+	st := s.get(ctx, p.commonPrm, withPayloadRange(rng), withLogger(log))
+
+	return w.Object(), st
+}
+
+// measureChildren heads the children concurrently to collect their payload
+// sizes.
+func (exec *execCtx) measureChildren(children []oid.ID) ([]objectSDK.MeasuredObject, bool) {
+	var (
+		wg       errgroup.Group
+		measured = make([]objectSDK.MeasuredObject, len(children))
+	)
+
+	wg.SetLimit(maxConcurrentHeads)
+
+	exec.prm.common = exec.prm.common.WithLocalOnly(false)
+
+	// execution context is not accessed concurrently
+	var (
+		ctx  = exec.context()
+		prm  = exec.prm
+		addr = exec.address()
+	)
+
+	prm.addr.SetContainer(addr.Container())
+
+	for i := range children {
+		iCopy := i
+
+		wg.Go(func() error {
+			p := prm
+			p.addr.SetObject(children[iCopy])
+
+			hPrm := HeadPrm{
+				commonPrm: p.commonPrm,
+			}
+
+			w := NewSimpleObjectWriter()
+			hPrm.SetHeaderWriter(w)
+
+			err := exec.svc.Head(ctx, hPrm)
+			if err != nil {
+				return err
+			}
+
+			child := w.Object()
+			if par := child.Parent(); par != nil && !equalAddresses(addr, object.AddressOf(par)) {
+				return errors.New("parent address in child object differs")
+			}
+
+			measured[iCopy].SetObjectID(children[iCopy])
+			measured[iCopy].SetObjectSize(uint32(child.PayloadSize()))
+
+			return nil
+		})
+	}
+
+	err := wg.Wait()
+	if err != nil {
+		exec.status = statusUndefined
+		exec.err = err
+
+		exec.log.Debug("could not measure children", zap.Error(err))
+
+		return nil, false
+	}
+
+	return measured, true
+}
@@ -0,0 +1,133 @@
+package getsvc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/util"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object_manager/placement"
+	"github.com/epicchainlabs/epicchain-node/pkg/util/logger/test"
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	"github.com/epicchainlabs/epicchain-sdk-go/container"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	netmaptest "github.com/epicchainlabs/epicchain-sdk-go/netmap/test"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	oidtest "github.com/epicchainlabs/epicchain-sdk-go/object/id/test"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRangeFromChildren(t *testing.T) {
+	ctx := context.Background()
+
+	var cnr container.Container
+	cnr.SetPlacementPolicy(netmaptest.PlacementPolicy())
+
+	var idCnr cid.ID
+	cnr.CalculateID(&idCnr)
+
+	const curEpoch = 13
+
+	storage := newTestStorage()
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+	svc.assembly = true
+	svc.traverserGenerator = &testTraverserGenerator{
+		c: cnr,
+		b: map[uint64]placement.Builder{
+			curEpoch: &testPlacementBuilder{},
+		},
+	}
+	svc.clientCache = &testClientCache{}
+	svc.currentEpochReceiver = testEpochReceiver(curEpoch)
+	svc.childrenCache, _ = lru.New[oid.Address, []objectSDK.MeasuredObject](childrenCacheSize)
+
+	addr := oidtest.Address()
+	addr.SetContainer(idCnr)
+
+	children, childIDs, payload := generateChain(10, idCnr)
+
+	parent := generateObject(addr, nil, nil)
+	parent.SetPayloadSize(uint64(len(payload)))
+
+	measured := make([]objectSDK.MeasuredObject, len(children))
+	for i := range children {
+		storage.addPhy(object.AddressOf(children[i]), children[i])
+
+		measured[i].SetObjectID(childIDs[i])
+		measured[i].SetObjectSize(uint32(children[i].PayloadSize()))
+	}
+
+	var link objectSDK.Link
+	link.SetObjects(measured)
+
+	linkAddr := oidtest.Address()
+	linkAddr.SetContainer(idCnr)
+
+	linkObj := generateObject(linkAddr, nil, nil)
+	linkObj.WriteLink(link)
+	linkObj.SetParent(parent)
+	storage.addPhy(linkAddr, linkObj)
+
+	splitInfo := objectSDK.NewSplitInfo()
+	splitInfo.SetFirstPart(childIDs[0])
+	splitInfo.SetLink(linkAddr.Object())
+	storage.addVirtual(addr, splitInfo)
+
+	readRange := func(off, ln uint64) ([]byte, error) {
+		w := NewSimpleObjectWriter()
+
+		var p RangePrm
+		p.SetChunkWriter(w)
+		p.common = new(util.CommonPrm)
+		p.WithAddress(addr)
+
+		rng := objectSDK.NewRange()
+		rng.SetOffset(off)
+		rng.SetLength(ln)
+		p.SetRange(rng)
+
+		err := svc.GetRange(ctx, p)
+
+		return w.Object().Payload(), err
+	}
+
+	for _, rng := range [][2]uint64{
+		{0, uint64(len(payload))},
+		{15, 42},
+		{90, 10},
+		{33, 1},
+	} {
+		res, err := readRange(rng[0], rng[1])
+		require.NoError(t, err, rng)
+		require.Equal(t, payload[rng[0]:rng[0]+rng[1]], res, rng)
+	}
+
+	_, err := readRange(95, 10)
+	require.ErrorAs(t, err, new(*apistatus.ObjectOutOfRange))
+
+	t.Run("cached", func(t *testing.T) {
+		delete(storage.phy, linkAddr.EncodeToString())
+
+		res, err := readRange(33, 20)
+		require.NoError(t, err)
+		require.Equal(t, payload[33:53], res)
+
+		_, err = readRange(95, 10)
+		require.ErrorAs(t, err, new(*apistatus.ObjectOutOfRange))
+	})
+
+	t.Run("missing child", func(t *testing.T) {
+		storage.inhume(object.AddressOf(children[3]))
+
+		_, err := readRange(25, 10)
+		require.Error(t, err)
+
+		_, ok := svc.childrenCache.Get(addr)
+		require.False(t, ok)
+	})
+}
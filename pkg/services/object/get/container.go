@@ -24,7 +24,9 @@ func (exec *execCtx) executeOnContainer() {
 		return
 	}
 
-	exec.processEpoch(epoch)
+	if !exec.processEpoch(epoch) {
+		exec.processErasureCoding(epoch)
+	}
 }
 
 func (exec *execCtx) processEpoch(epoch uint64) bool {
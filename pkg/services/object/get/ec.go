@@ -0,0 +1,103 @@
+package getsvc
+
+import (
+	"errors"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/core/client"
+	containerCore "github.com/epicchainlabs/epicchain-node/pkg/core/container"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/ec"
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	"go.uber.org/zap"
+)
+
+// processErasureCoding restores the object from its parts if the container
+// objects are erasure coded. Called when no container node has the object.
+func (exec *execCtx) processErasureCoding(epoch uint64) {
+	if exec.svc.ecStorage == nil {
+		return
+	}
+
+	cnr, err := exec.svc.ecContainers.Get(exec.containerID())
+	if err != nil {
+		exec.log.Debug("could not get container to check erasure coding",
+			zap.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	rule, err := containerCore.ErasureCodingFromAttributes(cnr.Value)
+	if err != nil || !rule.Enabled() {
+		return
+	}
+
+	traverser, ok := exec.generateTraverser(exec.address(), epoch)
+	if !ok {
+		return
+	}
+
+	// traverser does not contain the local node which may store a part too
+	nodes := []ec.Node{{Local: true}}
+	processed := make(map[string]struct{})
+
+	for addrs := traverser.Next(); len(addrs) > 0; addrs = traverser.Next() {
+		for i := range addrs {
+			key := string(addrs[i].PublicKey())
+			if _, ok := processed[key]; ok {
+				continue
+			}
+
+			processed[key] = struct{}{}
+
+			var node ec.Node
+			client.NodeInfoFromNetmapElement(&node.Info, addrs[i])
+			nodes = append(nodes, node)
+		}
+	}
+
+	exec.log.Debug("trying to restore the object from erasure coded parts...",
+		zap.Stringer("rule", rule),
+	)
+
+	var obj *objectSDK.Object
+
+	if exec.headOnly() {
+		obj, err = ec.Head(exec.context(), exec.svc.ecStorage, nodes, exec.address())
+	} else {
+		obj, err = ec.Get(exec.context(), exec.svc.ecStorage, rule, nodes, exec.address())
+	}
+
+	switch {
+	case errors.Is(err, apistatus.ErrObjectNotFound):
+		exec.log.Debug("no object parts found")
+	case err != nil:
+		exec.status = statusUndefined
+		exec.err = err
+
+		exec.log.Debug("could not restore the object from parts",
+			zap.String("error", err.Error()),
+		)
+	default:
+		if rng := exec.ctxRange(); rng != nil {
+			from := rng.GetOffset()
+			to := from + rng.GetLength()
+
+			if to < from || obj.PayloadSize() < to {
+				var errOutOfRange apistatus.ObjectOutOfRange
+
+				exec.err = &errOutOfRange
+				exec.status = statusAPIResponse
+
+				return
+			}
+
+			obj.SetPayload(obj.Payload()[from:to])
+		}
+
+		exec.status = statusOK
+		exec.err = nil
+		exec.collectedObject = obj
+		exec.writeCollectedObject()
+	}
+}
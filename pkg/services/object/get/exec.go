@@ -319,7 +319,11 @@ func (exec *execCtx) writeObjectPayload(obj *objectSDK.Object) bool {
 		return true
 	}
 
-	err := exec.prm.objWriter.WriteChunk(obj.Payload())
+	return exec.writePayloadChunk(obj.Payload())
+}
+
+func (exec *execCtx) writePayloadChunk(chunk []byte) bool {
+	err := exec.prm.objWriter.WriteChunk(chunk)
 
 	switch {
 	default:
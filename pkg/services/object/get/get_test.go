@@ -1,10 +1,12 @@
 package getsvc
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
 	"testing"
 
@@ -165,6 +167,15 @@ func (s *testStorage) get(exec *execCtx) (*objectSDK.Object, error) {
 	return nil, errNotFound
 }
 
+func (s *testStorage) getStream(exec *execCtx) (*objectSDK.Object, io.ReadCloser, error) {
+	obj, err := s.get(exec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return obj.CutPayload(), io.NopCloser(bytes.NewReader(obj.Payload())), nil
+}
+
 func cutToRange(o *objectSDK.Object, rng *objectSDK.Range) *objectSDK.Object {
 	if rng == nil {
 		return o
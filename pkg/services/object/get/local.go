@@ -2,16 +2,30 @@ package getsvc
 
 import (
 	"errors"
+	"fmt"
+	"io"
 
 	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
 	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
 	"go.uber.org/zap"
 )
 
+// streamChunkSize is a size of the payload chunks read from the local storage
+// and written to the response stream.
+const streamChunkSize = 256 << 10
+
 func (exec *execCtx) executeLocal() {
-	var err error
+	var (
+		err     error
+		payload io.ReadCloser
+	)
 
-	exec.collectedObject, err = exec.svc.localStorage.get(exec)
+	if exec.headOnly() || exec.ctxRange() != nil {
+		exec.collectedObject, err = exec.svc.localStorage.get(exec)
+	} else {
+		// payload is streamed to keep memory used by the request bounded
+		exec.collectedObject, payload, err = exec.svc.localStorage.getStream(exec)
+	}
 
 	var errSplitInfo *objectSDK.SplitInfoError
 
@@ -26,7 +40,12 @@ func (exec *execCtx) executeLocal() {
 	case err == nil:
 		exec.status = statusOK
 		exec.err = nil
-		exec.writeCollectedObject()
+
+		if payload != nil {
+			exec.writeCollectedStream(payload)
+		} else {
+			exec.writeCollectedObject()
+		}
 	case errors.Is(err, apistatus.Error):
 		if errors.Is(err, apistatus.ErrObjectNotFound) {
 			exec.status = statusNotFound
@@ -43,3 +62,51 @@ func (exec *execCtx) executeLocal() {
 		exec.err = objectSDK.NewSplitInfoError(exec.infoSplit)
 	}
 }
+
+// writeCollectedStream writes the collected object header and its payload read
+// from the stream. The stream is closed.
+func (exec *execCtx) writeCollectedStream(payload io.ReadCloser) {
+	defer func() {
+		if err := payload.Close(); err != nil {
+			exec.log.Debug("could not close payload stream", zap.Error(err))
+		}
+	}()
+
+	if !exec.writeCollectedHeader() {
+		return
+	}
+
+	buf := make([]byte, streamChunkSize)
+
+	for {
+		var (
+			n   int
+			err error
+		)
+
+		for n < len(buf) && err == nil {
+			var k int
+			k, err = payload.Read(buf[n:])
+			n += k
+		}
+
+		if n > 0 && !exec.writePayloadChunk(buf[:n]) {
+			return
+		}
+
+		if errors.Is(err, io.EOF) {
+			return
+		}
+
+		if err != nil {
+			// the header has already been written, so the object can not be
+			// requested from the other nodes
+			exec.status = statusAPIResponse
+			exec.err = fmt.Errorf("read payload stream: %w", err)
+
+			exec.log.Debug("could not read payload stream", zap.Error(err))
+
+			return
+		}
+	}
+}
@@ -89,7 +89,8 @@ type commonPrm struct {
 }
 
 // ChunkWriter is an interface of target component
-// to write payload chunk.
+// to write payload chunk. The chunk must not be retained
+// after WriteChunk returns.
 type ChunkWriter interface {
 	WriteChunk([]byte) error
 }
@@ -1,14 +1,19 @@
 package getsvc
 
 import (
+	"io"
+
 	"github.com/epicchainlabs/epicchain-node/pkg/core/client"
+	"github.com/epicchainlabs/epicchain-node/pkg/core/container"
 	"github.com/epicchainlabs/epicchain-node/pkg/core/netmap"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/engine"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/ec"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/object/util"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/object_manager/placement"
 	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
 	"github.com/epicchainlabs/epicchain-sdk-go/object"
 	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"go.uber.org/zap"
 )
 
@@ -31,6 +36,9 @@ type cfg struct {
 
 	localStorage interface {
 		get(*execCtx) (*object.Object, error)
+		// getStream returns header of the requested object and opens its
+		// payload stream, it is used for GET requests only.
+		getStream(*execCtx) (*object.Object, io.ReadCloser, error)
 	}
 
 	clientCache interface {
@@ -46,14 +54,24 @@ type cfg struct {
 	}
 
 	keyStore *util.KeyStorage
+
+	ecContainers container.Source
+
+	ecStorage ec.Storage
+
+	// payload sizes of the split object children
+	childrenCache *lru.Cache[oid.Address, []object.MeasuredObject]
 }
 
 func defaultCfg() *cfg {
+	childrenCache, _ := lru.New[oid.Address, []object.MeasuredObject](childrenCacheSize) // no error, size is positive
+
 	return &cfg{
-		assembly:     true,
-		log:          zap.L(),
-		localStorage: new(storageEngineWrapper),
-		clientCache:  new(clientCacheWrapper),
+		assembly:      true,
+		log:           zap.L(),
+		localStorage:  new(storageEngineWrapper),
+		clientCache:   new(clientCacheWrapper),
+		childrenCache: childrenCache,
 	}
 }
 
@@ -129,3 +147,12 @@ func WithKeyStorage(store *util.KeyStorage) Option {
 		c.keyStore = store
 	}
 }
+
+// WithErasureCoding returns option to restore objects of the containers with
+// EC storage policy from their parts.
+func WithErasureCoding(cnrs container.Source, s ec.Storage) Option {
+	return func(c *cfg) {
+		c.ecContainers = cnrs
+		c.ecStorage = s
+	}
+}
@@ -198,6 +198,10 @@ func (c *clientWrapper) get(exec *execCtx, key *ecdsa.PrivateKey) (*object.Objec
 	return res.Object(), nil
 }
 
+func (e *storageEngineWrapper) getStream(exec *execCtx) (*object.Object, io.ReadCloser, error) {
+	return e.engine.GetStream(exec.address())
+}
+
 func (e *storageEngineWrapper) get(exec *execCtx) (*object.Object, error) {
 	if exec.headOnly() {
 		var headPrm engine.HeadPrm
@@ -36,6 +36,9 @@ type distributedTarget struct {
 
 	relay func(nodeDesc) error
 
+	// set if the container objects are erasure coded
+	erasure *ecPlacement
+
 	fmt *object.FormatValidator
 
 	log *zap.Logger
@@ -161,6 +164,10 @@ func (t *distributedTarget) Close() (oid.ID, error) {
 		}
 	}
 
+	if t.needsErasureCoding() {
+		return t.putParts()
+	}
+
 	return t.iteratePlacement(t.sendObject)
 }
 
@@ -0,0 +1,186 @@
+package putsvc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/core/client"
+	containerCore "github.com/epicchainlabs/epicchain-node/pkg/core/container"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/ec"
+	svcutil "github.com/epicchainlabs/epicchain-node/pkg/services/object/util"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object_manager/placement"
+	"github.com/epicchainlabs/epicchain-node/pkg/util"
+	neofscrypto "github.com/epicchainlabs/epicchain-sdk-go/crypto"
+	neofsecdsa "github.com/epicchainlabs/epicchain-sdk-go/crypto/ecdsa"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"go.uber.org/zap"
+)
+
+// ecPlacement describes storage of the erasure coded object parts instead of
+// the full object replicas.
+type ecPlacement struct {
+	ctx context.Context
+
+	rule containerCore.ErasureCoding
+
+	storage ec.Storage
+
+	// signs the part objects
+	signer neofscrypto.Signer
+}
+
+// prepareErasureCoding returns erasure coding parameters of the container
+// objects, nil if objects should be replicated. Objects saved locally are
+// never split: these are either parts sent by other nodes or replicas.
+func (p *Streamer) prepareErasureCoding(prm *PutInitPrm) (*ecPlacement, error) {
+	if p.ecStorage == nil || prm.common.LocalOnly() {
+		return nil, nil
+	}
+
+	rule, err := containerCore.ErasureCodingFromAttributes(prm.cnr)
+	if err != nil {
+		idCnr, _ := prm.hdr.ContainerID()
+		p.log.Debug("invalid container erasure coding attributes, ignoring",
+			zap.Stringer("container", idCnr), zap.Error(err))
+
+		return nil, nil
+	}
+
+	if !rule.Enabled() {
+		return nil, nil
+	}
+
+	key, err := p.keyStorage.GetKey(nil)
+	if err != nil {
+		return nil, fmt.Errorf("(%T) could not receive node key: %w", p, err)
+	}
+
+	return &ecPlacement{
+		ctx:     p.ctx,
+		rule:    rule,
+		storage: p.ecStorage,
+		signer:  neofsecdsa.SignerRFC6979(*key),
+	}, nil
+}
+
+// needsErasureCoding checks whether the object should be split into parts.
+// Only regular objects are erasure coded, other types are used by the system
+// and are replicated according to the placement policy.
+func (t *distributedTarget) needsErasureCoding() bool {
+	return t.erasure != nil && t.obj.Type() == objectSDK.TypeRegular && !ec.IsPart(t.obj)
+}
+
+// ecNodes returns container nodes to store the object parts on, the i-th
+// node stores the i-th part.
+func (t *distributedTarget) ecNodes() ([]ec.Node, error) {
+	opts := make([]placement.Option, 0, len(t.traversalState.opts)+1)
+	opts = append(opts, t.traversalState.opts...)
+	opts = append(opts, placement.WithoutSuccessTracking())
+
+	traverser, err := placement.NewTraverser(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("(%T) could not create object placement traverser: %w", t, err)
+	}
+
+	n := t.erasure.rule.TotalParts()
+	nodes := make([]ec.Node, 0, n)
+	seen := make(map[string]struct{}, n)
+
+	for len(nodes) < n {
+		addrs := traverser.Next()
+		if len(addrs) == 0 {
+			break
+		}
+
+		for i := 0; i < len(addrs) && len(nodes) < n; i++ {
+			key := string(addrs[i].PublicKey())
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+
+			node := ec.Node{Local: t.isLocalKey(addrs[i].PublicKey())}
+			if !node.Local {
+				client.NodeInfoFromNetmapElement(&node.Info, addrs[i])
+			}
+
+			nodes = append(nodes, node)
+		}
+	}
+
+	if len(nodes) < n {
+		return nil, fmt.Errorf("not enough container nodes for %s: %d", t.erasure.rule, len(nodes))
+	}
+
+	return nodes, nil
+}
+
+// putParts splits the object into parts and saves them on the container
+// nodes. All parts must be saved for the operation to succeed.
+func (t *distributedTarget) putParts() (oid.ID, error) {
+	nodes, err := t.ecNodes()
+	if err != nil {
+		return oid.ID{}, err
+	}
+
+	parts, err := ec.Split(t.obj, t.erasure.rule, t.erasure.signer)
+	if err != nil {
+		return oid.ID{}, fmt.Errorf("(%T) could not split object into parts: %w", t, err)
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mtx    sync.Mutex
+		stored int
+		resErr error
+	)
+
+	for i := range parts {
+		var workerPool util.WorkerPool
+		if nodes[i].Local {
+			workerPool = t.localPool
+		} else {
+			workerPool = t.remotePool
+		}
+
+		node, part := nodes[i], parts[i]
+
+		wg.Add(1)
+
+		if err := workerPool.Submit(func() {
+			defer wg.Done()
+
+			err := t.erasure.storage.PutPart(t.erasure.ctx, node, part)
+
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			if err != nil {
+				resErr = err
+				svcutil.LogServiceError(t.log, "PUT", node.Info.AddressGroup(), err)
+				return
+			}
+
+			stored++
+		}); err != nil {
+			wg.Done()
+
+			svcutil.LogWorkerPoolError(t.log, "PUT", err)
+
+			break
+		}
+	}
+
+	wg.Wait()
+
+	if stored < len(parts) {
+		return oid.ID{}, errIncompletePut{singleErr: resErr}
+	}
+
+	id, _ := t.obj.ID()
+
+	return id, nil
+}
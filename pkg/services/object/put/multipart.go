@@ -0,0 +1,644 @@
+package putsvc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strconv"
+
+	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/session/storage"
+	"github.com/epicchainlabs/epicchain-sdk-go/checksum"
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	neofscrypto "github.com/epicchainlabs/epicchain-sdk-go/crypto"
+	neofsecdsa "github.com/epicchainlabs/epicchain-sdk-go/crypto/ecdsa"
+	objectSDK "github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"github.com/epicchainlabs/epicchain-sdk-go/user"
+	"github.com/epicchainlabs/epicchain-sdk-go/version"
+	"github.com/epicchainlabs/tzhash/tz"
+	"go.uber.org/zap"
+)
+
+// MultipartStorage stores states of the multipart uploads.
+type MultipartStorage interface {
+	// CreateUpload saves the new upload.
+	CreateUpload(storage.MultipartUpload) error
+	// Upload returns the upload by its ID. Must return
+	// [storage.ErrUploadNotFound] if there is no such upload.
+	Upload(id oid.ID) (*storage.MultipartUpload, error)
+	// PutUploadPart adds the uploaded part to the upload. Must return
+	// [storage.ErrUploadNotFound] if there is no such upload and
+	// [storage.ErrUploadCompleting] if the upload is being completed.
+	PutUploadPart(id oid.ID, p storage.MultipartPart) error
+	// SetUploadCompleting atomically marks the upload as being completed or
+	// clears the mark and returns the upload state. Must return
+	// [storage.ErrUploadNotFound] if there is no such upload and
+	// [storage.ErrUploadCompleting] if the upload is already being completed.
+	SetUploadCompleting(id oid.ID, completing bool) (*storage.MultipartUpload, error)
+	// SetUploadLink saves IDs of the link object and the uploaded object
+	// formed on the upload completion. Must return [storage.ErrUploadNotFound]
+	// if there is no such upload.
+	SetUploadLink(id, link, obj oid.ID) error
+	// RemoveUpload removes the upload.
+	RemoveUpload(id oid.ID) error
+	// ExpiredUploads returns uploads expired since provided epoch.
+	ExpiredUploads(epoch uint64) ([]storage.MultipartUpload, error)
+}
+
+// PayloadReader reads payload of the objects stored in NeoFS.
+type PayloadReader interface {
+	// ReadPayload writes payload of the object to w.
+	ReadPayload(ctx context.Context, addr oid.Address, w io.Writer) error
+}
+
+// multipartUploadLifetime is a number of epochs the multipart upload may be
+// completed in, the upload is aborted after it.
+const multipartUploadLifetime = 100
+
+var errMultipartPayload = errors.New("payload is not allowed for the multipart upload operation")
+
+// multipartTarget serves multipart upload operations. Payload of each part is
+// stored in the child objects sharing the split ID of the upload. The upload
+// is completed by the link object listing the children of all parts in order
+// and carrying the header of the uploaded object (V1 split chain).
+type multipartTarget struct {
+	*Streamer
+
+	prm *PutInitPrm
+
+	req objectcore.MultipartRequest
+
+	// signs the objects formed by the node
+	signer neofscrypto.Signer
+
+	// nil for init
+	upload *storage.MultipartUpload
+
+	hdr *objectSDK.Object
+
+	// part upload state
+	buf  []byte
+	part storage.MultipartPart
+}
+
+func (p *Streamer) initMultipartTarget(prm *PutInitPrm, req objectcore.MultipartRequest, key *ecdsa.PrivateKey) error {
+	if p.multipart == nil {
+		return errors.New("multipart uploads are not supported")
+	}
+
+	t := &multipartTarget{
+		Streamer: p,
+		prm:      prm,
+		req:      req,
+		signer:   neofsecdsa.SignerRFC6979(*key),
+	}
+
+	if req.Action != objectcore.MultipartInit {
+		u, err := p.multipart.Upload(req.Upload)
+		if err != nil {
+			return fmt.Errorf("(%T) could not get multipart upload %s: %w", p, req.Upload, err)
+		}
+
+		cnr, _ := prm.hdr.ContainerID()
+		owner := prm.hdr.OwnerID()
+
+		if cnr != u.Container || owner == nil || !owner.Equals(u.Owner) {
+			return fmt.Errorf("multipart upload %s belongs to another container or owner", req.Upload)
+		}
+
+		if u.Completing && req.Action != objectcore.MultipartComplete {
+			return fmt.Errorf("(%T) multipart upload %s: %w", p, req.Upload, storage.ErrUploadCompleting)
+		}
+
+		t.upload = u
+		t.part.Number = req.Part
+	}
+
+	p.target = t
+
+	return nil
+}
+
+func (t *multipartTarget) WriteHeader(hdr *objectSDK.Object) error {
+	if t.req.Action != objectcore.MultipartInit {
+		return nil
+	}
+
+	if hdr.Type() != objectSDK.TypeRegular {
+		return fmt.Errorf("multipart upload of %s object", hdr.Type())
+	}
+
+	if hdr.HasParent() {
+		return errors.New("multipart upload of the child object")
+	}
+
+	if err := t.fmtValidator.Validate(hdr, true); err != nil {
+		return fmt.Errorf("(%T) coult not validate object format: %w", t, err)
+	}
+
+	t.hdr = hdr
+
+	return nil
+}
+
+func (t *multipartTarget) Write(p []byte) (int, error) {
+	if t.req.Action != objectcore.MultipartPart {
+		if len(p) > 0 {
+			return 0, errMultipartPayload
+		}
+
+		return 0, nil
+	}
+
+	t.buf = append(t.buf, p...)
+
+	for uint64(len(t.buf)) >= t.maxPayloadSz {
+		err := t.writeChild(t.buf[:t.maxPayloadSz])
+		if err != nil {
+			return 0, err
+		}
+
+		t.buf = t.buf[t.maxPayloadSz:]
+	}
+
+	return len(p), nil
+}
+
+func (t *multipartTarget) Close() (oid.ID, error) {
+	switch t.req.Action {
+	case objectcore.MultipartInit:
+		return t.init()
+	case objectcore.MultipartPart:
+		return t.closePart()
+	case objectcore.MultipartComplete:
+		return t.complete()
+	default:
+		return t.abort()
+	}
+}
+
+func (t *multipartTarget) init() (oid.ID, error) {
+	var u storage.MultipartUpload
+
+	_, err := rand.Read(u.ID[:])
+	if err != nil {
+		return oid.ID{}, fmt.Errorf("generate upload ID: %w", err)
+	}
+
+	hdr := objectSDK.New()
+	t.hdr.CopyTo(hdr)
+	hdr.SetPayload(nil)
+	hdr.SetPayloadSize(0)
+
+	u.Header, err = hdr.Marshal()
+	if err != nil {
+		return oid.ID{}, fmt.Errorf("encode object header: %w", err)
+	}
+
+	u.Container, _ = hdr.ContainerID()
+	u.Owner = *hdr.OwnerID()
+	u.SplitID = objectSDK.NewSplitID().ToV2()
+	u.ExpiredAt = t.networkState.CurrentEpoch() + multipartUploadLifetime
+
+	err = t.multipart.CreateUpload(u)
+	if err != nil {
+		return oid.ID{}, fmt.Errorf("(%T) could not save multipart upload: %w", t, err)
+	}
+
+	return u.ID, nil
+}
+
+// newChild returns child object of the uploaded object with the given payload
+// and without verification fields.
+func (t *multipartTarget) newChild(payload []byte) *objectSDK.Object {
+	ver := version.Current()
+
+	obj := objectSDK.New()
+	obj.SetVersion(&ver)
+	obj.SetContainerID(t.upload.Container)
+	obj.SetOwnerID(&t.upload.Owner)
+	obj.SetCreationEpoch(t.networkState.CurrentEpoch())
+	obj.SetType(objectSDK.TypeRegular)
+	obj.SetSplitID(objectSDK.NewSplitIDFromV2(t.upload.SplitID))
+	obj.SetSessionToken(t.prm.common.SessionToken())
+	obj.SetPayload(payload)
+	obj.SetPayloadSize(uint64(len(payload)))
+
+	if !t.prm.cnr.IsHomomorphicHashingDisabled() {
+		var cs checksum.Checksum
+		checksum.Calculate(&cs, checksum.TZ, payload)
+		obj.SetPayloadHomomorphicHash(cs)
+	}
+
+	return obj
+}
+
+func (t *multipartTarget) writeChild(payload []byte) error {
+	obj := t.newChild(payload)
+
+	err := obj.SetVerificationFields(t.signer)
+	if err != nil {
+		return fmt.Errorf("could not sign child object: %w", err)
+	}
+
+	id, err := t.putObject(obj)
+	if err != nil {
+		return err
+	}
+
+	t.part.Children = append(t.part.Children, id)
+	t.part.Size += uint64(len(payload))
+
+	return nil
+}
+
+// putObject saves the ready object in the container.
+func (t *multipartTarget) putObject(obj *objectSDK.Object) (oid.ID, error) {
+	target := t.newCommonTarget(t.prm)
+
+	err := target.WriteHeader(obj)
+	if err != nil {
+		return oid.ID{}, err
+	}
+
+	_, err = target.Write(obj.Payload())
+	if err != nil {
+		return oid.ID{}, err
+	}
+
+	return target.Close()
+}
+
+func (t *multipartTarget) closePart() (oid.ID, error) {
+	if len(t.buf) > 0 {
+		err := t.writeChild(t.buf)
+		if err != nil {
+			return oid.ID{}, err
+		}
+	}
+
+	err := t.multipart.PutUploadPart(t.upload.ID, t.part)
+	if err != nil {
+		if errors.Is(err, storage.ErrUploadNotFound) || errors.Is(err, storage.ErrUploadCompleting) {
+			// upload has been completed or aborted concurrently
+			if rmErr := t.removeObjects(t.upload.Container, t.part.Children); rmErr != nil {
+				t.log.Warn("could not remove children of the dropped part",
+					zap.Stringer("upload", t.upload.ID), zap.Error(rmErr))
+			}
+		}
+
+		return oid.ID{}, fmt.Errorf("(%T) could not save uploaded part: %w", t, err)
+	}
+
+	return t.upload.ID, nil
+}
+
+// complete marks the upload as being completed, so no parts are added after
+// the parts list is read, and saves the link object. The mark is cleared if
+// the link object is not saved, so the completion can be retried. The
+// completion of the already marked upload (e.g. interrupted by the node
+// restart) is resumed: the upload is dropped if its link object is saved,
+// otherwise the link object is saved again.
+func (t *multipartTarget) complete() (oid.ID, error) {
+	u, err := t.multipart.SetUploadCompleting(t.upload.ID, true)
+	resumed := errors.Is(err, storage.ErrUploadCompleting)
+	if resumed {
+		u, err = t.multipart.Upload(t.upload.ID)
+	}
+	if err != nil {
+		return oid.ID{}, fmt.Errorf("(%T) could not start multipart upload completion: %w", t, err)
+	}
+
+	t.upload = u
+
+	if resumed {
+		saved, err := t.linkSaved(t.ctx, *u)
+		if err != nil {
+			return oid.ID{}, err
+		}
+
+		if saved {
+			t.removeCompletedUpload(u.ID)
+			return *u.Object, nil
+		}
+	}
+
+	id, err := t.completeUpload(u)
+	if err != nil {
+		// the mark of the resumed completion is not owned, it may be
+		// running concurrently
+		if !resumed {
+			if _, rErr := t.multipart.SetUploadCompleting(u.ID, false); rErr != nil {
+				t.log.Warn("could not reset multipart upload completion",
+					zap.Stringer("upload", u.ID), zap.Error(rErr))
+			}
+		}
+
+		return oid.ID{}, err
+	}
+
+	return id, nil
+}
+
+func (t *multipartTarget) completeUpload(u *storage.MultipartUpload) (oid.ID, error) {
+	if len(u.Parts) == 0 {
+		return oid.ID{}, errors.New("no uploaded parts")
+	}
+
+	// children of the replaced parts can be removed only before the link
+	// object appears
+	err := t.removeObjects(u.Container, u.Garbage)
+	if err != nil {
+		t.log.Warn("could not remove children of the replaced parts",
+			zap.Stringer("upload", u.ID), zap.Error(err))
+	}
+
+	var (
+		size     uint64
+		children = make([]oid.ID, 0, len(u.Parts))
+	)
+
+	for i := range u.Parts {
+		size += u.Parts[i].Size
+		children = append(children, u.Parts[i].Children...)
+	}
+
+	parent := objectSDK.New()
+
+	err = parent.Unmarshal(u.Header)
+	if err != nil {
+		return oid.ID{}, fmt.Errorf("decode object header: %w", err)
+	}
+
+	err = t.setPayloadChecksums(parent, children, size)
+	if err != nil {
+		return oid.ID{}, err
+	}
+
+	ver := version.Current()
+	parent.SetVersion(&ver)
+	parent.SetCreationEpoch(t.networkState.CurrentEpoch())
+	parent.SetSessionToken(t.prm.common.SessionToken())
+	parent.SetPayloadSize(size)
+
+	err = parent.SetIDWithSignature(t.signer)
+	if err != nil {
+		return oid.ID{}, fmt.Errorf("could not sign object: %w", err)
+	}
+
+	link := t.newChild(nil)
+	link.SetChildren(children...)
+	link.SetParent(parent)
+
+	err = link.SetVerificationFields(t.signer)
+	if err != nil {
+		return oid.ID{}, fmt.Errorf("could not sign link object: %w", err)
+	}
+
+	id, _ := parent.ID()
+	linkID, _ := link.ID()
+
+	err = t.multipart.SetUploadLink(u.ID, linkID, id)
+	if err != nil {
+		return oid.ID{}, fmt.Errorf("(%T) could not save link object ID: %w", t, err)
+	}
+
+	_, err = t.putObject(link)
+	if err != nil {
+		return oid.ID{}, fmt.Errorf("(%T) could not save link object: %w", t, err)
+	}
+
+	t.removeCompletedUpload(u.ID)
+
+	return id, nil
+}
+
+func (t *multipartTarget) removeCompletedUpload(id oid.ID) {
+	err := t.multipart.RemoveUpload(id)
+	if err != nil {
+		t.log.Warn("could not remove completed multipart upload",
+			zap.Stringer("upload", id), zap.Error(err))
+	}
+}
+
+// linkSaved checks whether the link object of the upload is saved. Returns
+// false if the upload has no link object formed.
+func (c *cfg) linkSaved(ctx context.Context, u storage.MultipartUpload) (bool, error) {
+	if u.Link == nil {
+		return false, nil
+	}
+
+	var addr oid.Address
+	addr.SetContainer(u.Container)
+	addr.SetObject(*u.Link)
+
+	// link object has no payload
+	err := c.payloadReader.ReadPayload(ctx, addr, io.Discard)
+	if err == nil {
+		return true, nil
+	} else if errors.Is(err, apistatus.ErrObjectNotFound) {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("(%T) could not check link object %s: %w", c, *u.Link, err)
+}
+
+// setPayloadChecksums reads payload of the children in order and sets
+// checksums of the full payload.
+func (t *multipartTarget) setPayloadChecksums(obj *objectSDK.Object, children []oid.ID, size uint64) error {
+	var (
+		sha = sha256.New()
+		tzh hash.Hash
+		w   = &countingWriter{w: sha}
+	)
+
+	if !t.prm.cnr.IsHomomorphicHashingDisabled() {
+		tzh = tz.New()
+		w.w = io.MultiWriter(sha, tzh)
+	}
+
+	var addr oid.Address
+	addr.SetContainer(t.upload.Container)
+
+	for i := range children {
+		addr.SetObject(children[i])
+
+		err := t.payloadReader.ReadPayload(t.ctx, addr, w)
+		if err != nil {
+			return fmt.Errorf("(%T) could not read payload of the child %s: %w", t, children[i], err)
+		}
+	}
+
+	if w.n != size {
+		return fmt.Errorf("read payload size %d differs from the uploaded one %d", w.n, size)
+	}
+
+	var cs checksum.Checksum
+
+	cs.SetSHA256([sha256.Size]byte(sha.Sum(nil)))
+	obj.SetPayloadChecksum(cs)
+
+	if tzh != nil {
+		cs.SetTillichZemor([tz.Size]byte(tzh.Sum(nil)))
+		obj.SetPayloadHomomorphicHash(cs)
+	}
+
+	return nil
+}
+
+// abort removes the upload with all its parts. The upload is marked as being
+// completed first, so it can't be aborted during the completion and no parts
+// are added after they are listed.
+func (t *multipartTarget) abort() (oid.ID, error) {
+	u, err := t.multipart.SetUploadCompleting(t.upload.ID, true)
+	if err != nil {
+		return oid.ID{}, fmt.Errorf("(%T) could not start multipart upload abort: %w", t, err)
+	}
+
+	err = t.abortUpload(*u)
+	if err != nil {
+		if _, rErr := t.multipart.SetUploadCompleting(u.ID, false); rErr != nil {
+			t.log.Warn("could not reset multipart upload abort",
+				zap.Stringer("upload", u.ID), zap.Error(rErr))
+		}
+
+		return oid.ID{}, err
+	}
+
+	return u.ID, nil
+}
+
+// AbortExpiredUploads aborts multipart uploads expired since provided epoch
+// and removes their parts.
+func (p *Service) AbortExpiredUploads(ctx context.Context, epoch uint64) {
+	if p.multipart == nil {
+		return
+	}
+
+	uu, err := p.multipart.ExpiredUploads(epoch)
+	if err != nil {
+		p.log.Error("could not list expired multipart uploads", zap.Error(err))
+		return
+	}
+
+	for i := range uu {
+		u, err := p.multipart.SetUploadCompleting(uu[i].ID, true)
+		if errors.Is(err, storage.ErrUploadNotFound) {
+			// completed or aborted concurrently
+			continue
+		} else if errors.Is(err, storage.ErrUploadCompleting) {
+			// the completion or abort has been interrupted, the parts are
+			// referenced by the link object if it is saved
+			var saved bool
+			saved, err = p.linkSaved(ctx, uu[i])
+			if err == nil {
+				if saved {
+					err = p.multipart.RemoveUpload(uu[i].ID)
+				} else {
+					err = p.abortUpload(uu[i])
+				}
+			}
+		} else if err == nil {
+			err = p.abortUpload(*u)
+		}
+
+		if err != nil {
+			p.log.Error("could not abort expired multipart upload",
+				zap.Stringer("upload", uu[i].ID), zap.Error(err))
+
+			continue
+		}
+
+		p.log.Info("expired multipart upload aborted", zap.Stringer("upload", uu[i].ID))
+	}
+}
+
+func (c *cfg) abortUpload(u storage.MultipartUpload) error {
+	ids := u.Garbage
+	for i := range u.Parts {
+		ids = append(ids, u.Parts[i].Children...)
+	}
+
+	err := c.removeObjects(u.Container, ids)
+	if err != nil {
+		return fmt.Errorf("remove uploaded parts: %w", err)
+	}
+
+	err = c.multipart.RemoveUpload(u.ID)
+	if err != nil {
+		return fmt.Errorf("remove upload: %w", err)
+	}
+
+	return nil
+}
+
+// removeObjects removes the objects by the tombstone owned by the node.
+func (c *cfg) removeObjects(cnr cid.ID, ids []oid.ID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	key, err := c.keyStorage.GetKey(nil)
+	if err != nil {
+		return fmt.Errorf("could not receive node key: %w", err)
+	}
+
+	owner := user.ResolveFromECDSAPublicKey(key.PublicKey)
+	exp := c.networkState.CurrentEpoch() + c.tombLifetime
+
+	tomb := objectSDK.NewTombstone()
+	tomb.SetExpirationEpoch(exp)
+	tomb.SetMembers(ids)
+
+	payload, err := tomb.Marshal()
+	if err != nil {
+		return fmt.Errorf("could not marshal tombstone: %w", err)
+	}
+
+	var a objectSDK.Attribute
+	a.SetKey(objectSDK.AttributeExpirationEpoch)
+	a.SetValue(strconv.FormatUint(exp, 10))
+
+	obj := objectSDK.New()
+	obj.SetContainerID(cnr)
+	obj.SetOwnerID(&owner)
+	obj.SetType(objectSDK.TypeTombstone)
+	obj.SetAttributes(a)
+
+	streamer, err := (&Service{cfg: c}).Put(context.Background())
+	if err != nil {
+		return err
+	}
+
+	err = streamer.Init(new(PutInitPrm).WithObject(obj))
+	if err != nil {
+		return err
+	}
+
+	err = streamer.SendChunk(new(PutChunkPrm).WithChunk(payload))
+	if err != nil {
+		return err
+	}
+
+	_, err = streamer.Close()
+
+	return err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n uint64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += uint64(n)
+	return n, err
+}
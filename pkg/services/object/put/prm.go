@@ -19,6 +19,8 @@ type PutInitPrm struct {
 
 	copiesNumber uint32
 
+	ec *ecPlacement
+
 	relay func(client.NodeInfo, client.MultiAddressClient) error
 }
 
@@ -7,8 +7,10 @@ import (
 	"github.com/epicchainlabs/epicchain-node/pkg/core/container"
 	"github.com/epicchainlabs/epicchain-node/pkg/core/netmap"
 	"github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/ec"
 	objutil "github.com/epicchainlabs/epicchain-node/pkg/services/object/util"
 	"github.com/epicchainlabs/epicchain-node/pkg/util"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
 	"go.uber.org/zap"
 )
 
@@ -20,6 +22,15 @@ type MaxSizeSource interface {
 	MaxObjectSize() uint64
 }
 
+// ContainerQuotas checks container quotas of the local storage.
+type ContainerQuotas interface {
+	// CheckContainerQuota checks whether an object with the given payload
+	// size can be stored in the container limited by the given quota set by
+	// the container owner. Returns an error if hard limits are exceeded and
+	// true if soft ones are.
+	CheckContainerQuota(cnr cid.ID, q container.Quota, size uint64) (bool, error)
+}
+
 type Service struct {
 	*cfg
 }
@@ -39,6 +50,8 @@ type cfg struct {
 
 	cnrSrc container.Source
 
+	quotas ContainerQuotas
+
 	netMapSrc netmap.Source
 
 	remotePool, localPool util.WorkerPool
@@ -53,6 +66,14 @@ type cfg struct {
 
 	clientConstructor ClientConstructor
 
+	ecStorage ec.Storage
+
+	multipart MultipartStorage
+
+	payloadReader PayloadReader
+
+	tombLifetime uint64
+
 	log *zap.Logger
 }
 
@@ -110,6 +131,13 @@ func WithContainerSource(v container.Source) Option {
 	}
 }
 
+// WithContainerQuotas returns option to check container quotas on PUT.
+func WithContainerQuotas(v ContainerQuotas) Option {
+	return func(c *cfg) {
+		c.quotas = v
+	}
+}
+
 func WithNetworkMapSource(v netmap.Source) Option {
 	return func(c *cfg) {
 		c.netMapSrc = v
@@ -154,6 +182,27 @@ func WithClientConstructor(v ClientConstructor) Option {
 	}
 }
 
+// WithErasureCoding returns option to store erasure coded parts of the
+// regular objects in the containers with EC storage policy. Without it, such
+// objects are replicated according to the container placement policy.
+func WithErasureCoding(s ec.Storage) Option {
+	return func(c *cfg) {
+		c.ecStorage = s
+	}
+}
+
+// WithMultipartUploads returns option to serve multipart uploads keeping their
+// states in the given storage. Payload of the uploaded parts is read with r to
+// calculate the object checksum on completion. Parts of the aborted uploads
+// are removed with tombstones living for tombLifetime epochs.
+func WithMultipartUploads(s MultipartStorage, r PayloadReader, tombLifetime uint64) Option {
+	return func(c *cfg) {
+		c.multipart = s
+		c.payloadReader = r
+		c.tombLifetime = tombLifetime
+	}
+}
+
 func WithLogger(l *zap.Logger) Option {
 	return func(c *cfg) {
 		c.log = l
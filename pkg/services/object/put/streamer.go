@@ -2,17 +2,20 @@ package putsvc
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/core/client"
+	containerCore "github.com/epicchainlabs/epicchain-node/pkg/core/container"
 	"github.com/epicchainlabs/epicchain-node/pkg/core/netmap"
+	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/object/internal"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/object/util"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/object_manager/placement"
-	neofsecdsa "github.com/epicchainlabs/epicchain-sdk-go/crypto/ecdsa"
 	"github.com/epicchainlabs/epicchain-sdk-go/object"
 	"github.com/epicchainlabs/epicchain-sdk-go/user"
+	"go.uber.org/zap"
 )
 
 type Streamer struct {
@@ -61,6 +64,10 @@ func (p *Streamer) initTarget(prm *PutInitPrm) error {
 		return fmt.Errorf("(%T) could not prepare put parameters: %w", p, err)
 	}
 
+	if err := p.checkQuota(prm); err != nil {
+		return err
+	}
+
 	p.maxPayloadSz = p.maxSizeSrc.MaxObjectSize()
 	if p.maxPayloadSz == 0 {
 		return fmt.Errorf("(%T) could not obtain max object size parameter", p)
@@ -84,9 +91,46 @@ func (p *Streamer) initTarget(prm *PutInitPrm) error {
 		return nil
 	}
 
+	// prepare trusted-Put object target
+
+	sessionKey, err := p.sessionKey(prm)
+	if err != nil {
+		return err
+	}
+
+	mp, err := objectcore.MultipartRequestFromXHeaders(prm.common.XHeaders())
+	if err != nil {
+		return err
+	}
+
+	if mp.Action != objectcore.MultipartNone {
+		return p.initMultipartTarget(prm, mp, sessionKey)
+	}
+
 	sToken := prm.common.SessionToken()
 
-	// prepare trusted-Put object target
+	p.target = &validatingTarget{
+		fmt:              p.fmtValidator,
+		unpreparedObject: true,
+		nextTarget: newSlicingTarget(
+			p.ctx,
+			p.maxPayloadSz,
+			!homomorphicChecksumRequired,
+			user.NewAutoIDSigner(*sessionKey),
+			sToken,
+			p.networkState.CurrentEpoch(),
+			p.newCommonTarget(prm),
+		),
+		homomorphicChecksumRequired: homomorphicChecksumRequired,
+	}
+
+	return nil
+}
+
+// sessionKey returns the private key to sign the objects formed by the node
+// for the trusted PUT request.
+func (p *Streamer) sessionKey(prm *PutInitPrm) (*ecdsa.PrivateKey, error) {
+	sToken := prm.common.SessionToken()
 
 	// get private token from local storage
 	var sessionInfo *util.SessionInfo
@@ -100,42 +144,25 @@ func (p *Streamer) initTarget(prm *PutInitPrm) error {
 
 	sessionKey, err := p.keyStorage.GetKey(sessionInfo)
 	if err != nil {
-		return fmt.Errorf("(%T) could not receive session key: %w", p, err)
+		return nil, fmt.Errorf("(%T) could not receive session key: %w", p, err)
 	}
 
-	signer := neofsecdsa.SignerRFC6979(*sessionKey)
-
 	// In case session token is missing, the line above returns the default key.
 	// If it isn't owner key, replication attempts will fail, thus this check.
 	if sToken == nil {
 		ownerObj := prm.hdr.OwnerID()
 		if ownerObj == nil {
-			return errors.New("missing object owner")
+			return nil, errors.New("missing object owner")
 		}
 
-		ownerSession := user.ResolveFromECDSAPublicKey(signer.PublicKey)
+		ownerSession := user.ResolveFromECDSAPublicKey(sessionKey.PublicKey)
 
 		if !ownerObj.Equals(ownerSession) {
-			return fmt.Errorf("(%T) session token is missing but object owner id is different from the default key", p)
+			return nil, fmt.Errorf("(%T) session token is missing but object owner id is different from the default key", p)
 		}
 	}
 
-	p.target = &validatingTarget{
-		fmt:              p.fmtValidator,
-		unpreparedObject: true,
-		nextTarget: newSlicingTarget(
-			p.ctx,
-			p.maxPayloadSz,
-			!homomorphicChecksumRequired,
-			user.NewAutoIDSigner(*sessionKey),
-			sToken,
-			p.networkState.CurrentEpoch(),
-			p.newCommonTarget(prm),
-		),
-		homomorphicChecksumRequired: homomorphicChecksumRequired,
-	}
-
-	return nil
+	return sessionKey, nil
 }
 
 func (p *Streamer) preparePrm(prm *PutInitPrm) error {
@@ -189,6 +216,37 @@ func (p *Streamer) preparePrm(prm *PutInitPrm) error {
 	// set placement builder
 	prm.traverseOpts = append(prm.traverseOpts, placement.UseBuilder(builder))
 
+	prm.ec, err = p.prepareErasureCoding(prm)
+
+	return err
+}
+
+// checkQuota checks container quotas of the local storage. Payload size of
+// the objects sliced by the node is not known in advance, so only the
+// current usage is checked for them.
+func (p *Streamer) checkQuota(prm *PutInitPrm) error {
+	if p.quotas == nil {
+		return nil
+	}
+
+	idCnr, _ := prm.hdr.ContainerID()
+
+	q, err := containerCore.QuotaFromAttributes(prm.cnr)
+	if err != nil {
+		p.log.Debug("invalid container quota attributes, ignoring",
+			zap.Stringer("container", idCnr), zap.Error(err))
+	}
+
+	soft, err := p.quotas.CheckContainerQuota(idCnr, q, prm.hdr.PayloadSize())
+	if err != nil {
+		return fmt.Errorf("(%T) container quota check: %w", p, err)
+	}
+
+	if soft {
+		p.log.Warn("container soft quota exceeded",
+			zap.Stringer("container", idCnr))
+	}
+
 	return nil
 }
 
@@ -241,9 +299,10 @@ func (p *Streamer) newCommonTarget(prm *PutInitPrm) internal.Target {
 
 			return rt
 		},
-		relay: relay,
-		fmt:   p.fmtValidator,
-		log:   p.log,
+		relay:   relay,
+		erasure: prm.ec,
+		fmt:     p.fmtValidator,
+		log:     p.log,
 
 		isLocalKey: p.netmapKeys.IsLocalKey,
 	}
@@ -0,0 +1,188 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-sdk-go/container/acl"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	"github.com/epicchainlabs/epicchain-sdk-go/user"
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+)
+
+// Limited key classes reported to Metrics.
+const (
+	ClassOwner     = "owner"
+	ClassContainer = "container"
+	ClassRole      = "role"
+)
+
+// maxBuckets is a number of per-key buckets of a class after which the least
+// recently used ones are dropped.
+const maxBuckets = 1 << 16
+
+// Rule is a token bucket limit. Rate is a number of requests per second,
+// zero rate does not limit anything. Burst is a number of requests that can
+// be made at once, zero burst equals to the one second of the rate (but not
+// less than one request).
+type Rule struct {
+	Rate  float64
+	Burst uint64
+}
+
+// Limits groups the rate limits of the object requests.
+type Limits struct {
+	// Owner limits requests of each request owner. Not applied to the
+	// container and Inner Ring nodes.
+	Owner Rule
+
+	// Container limits requests to each container. Not applied to the
+	// container and Inner Ring nodes.
+	Container Rule
+
+	// Roles limit requests of all the senders with the given role in total.
+	Roles map[acl.Role]Rule
+}
+
+// Metrics collects the rate limiting statistics.
+type Metrics interface {
+	// IncRateLimitedRequests increases the number of requests rejected by the
+	// limit of the given class.
+	IncRateLimitedRequests(class string)
+}
+
+// bucket is a token bucket implemented as a virtual clock: tat is the time
+// the bucket gets full.
+type bucket struct {
+	tat time.Time
+}
+
+// take returns the new bucket state after taking one token and true if the
+// token is available.
+func (b bucket) take(now time.Time, r Rule) (bucket, bool) {
+	interval := time.Duration(float64(time.Second) / r.Rate)
+
+	burst := r.Burst
+	if burst == 0 {
+		burst = uint64(r.Rate)
+		if burst == 0 {
+			burst = 1
+		}
+	}
+
+	tat := b.tat
+	if tat.Before(now) {
+		tat = now
+	}
+
+	tat = tat.Add(interval)
+
+	return bucket{tat: tat}, tat.Sub(now) <= time.Duration(burst)*interval
+}
+
+type class[K comparable] struct {
+	name    string
+	buckets *simplelru.LRU[K, bucket]
+}
+
+func newClass[K comparable](name string) class[K] {
+	// error is returned for the non-positive size only
+	buckets, _ := simplelru.NewLRU[K, bucket](maxBuckets, nil)
+
+	return class[K]{
+		name:    name,
+		buckets: buckets,
+	}
+}
+
+// Limiter checks object requests against the token bucket rate limits keyed
+// by the request owner, the container and the request role.
+type Limiter struct {
+	metrics Metrics
+
+	mtx sync.Mutex
+
+	limits Limits
+
+	owners     class[string]
+	containers class[cid.ID]
+	roles      class[acl.Role]
+}
+
+// New creates Limiter with the given limits. Metrics are optional.
+func New(limits Limits, metrics Metrics) *Limiter {
+	return &Limiter{
+		metrics:    metrics,
+		limits:     limits,
+		owners:     newClass[string](ClassOwner),
+		containers: newClass[cid.ID](ClassContainer),
+		roles:      newClass[acl.Role](ClassRole),
+	}
+}
+
+// SetLimits replaces the limits. Current bucket states are kept.
+func (l *Limiter) SetLimits(limits Limits) {
+	l.mtx.Lock()
+	l.limits = limits
+	l.mtx.Unlock()
+}
+
+type check struct {
+	apply func()
+	class string
+	ok    bool
+}
+
+func take[K comparable](now time.Time, c class[K], k K, r Rule) check {
+	if r.Rate <= 0 {
+		return check{ok: true}
+	}
+
+	b, _ := c.buckets.Get(k)
+	b, ok := b.take(now, r)
+
+	return check{
+		apply: func() {
+			c.buckets.Add(k, b)
+		},
+		class: c.name,
+		ok:    ok,
+	}
+}
+
+// Allow checks whether the request of the owner with the role to the
+// container fits the limits. The request is counted only if it fits all of
+// them. Returns TooManyRequests otherwise.
+func (l *Limiter) Allow(owner user.ID, cnr cid.ID, role acl.Role) error {
+	now := time.Now()
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	checks := []check{take(now, l.roles, role, l.limits.Roles[role])}
+
+	if role != acl.RoleContainer && role != acl.RoleInnerRing {
+		checks = append(checks,
+			take(now, l.owners, string(owner.WalletBytes()), l.limits.Owner),
+			take(now, l.containers, cnr, l.limits.Container),
+		)
+	}
+
+	for i := range checks {
+		if !checks[i].ok {
+			if l.metrics != nil {
+				l.metrics.IncRateLimitedRequests(checks[i].class)
+			}
+
+			return NewTooManyRequests(checks[i].class)
+		}
+	}
+
+	for i := range checks {
+		if checks[i].apply != nil {
+			checks[i].apply()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	"github.com/epicchainlabs/epicchain-sdk-go/container/acl"
+	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
+	usertest "github.com/epicchainlabs/epicchain-sdk-go/user/test"
+	"github.com/stretchr/testify/require"
+)
+
+type testMetrics map[string]int
+
+func (m testMetrics) IncRateLimitedRequests(class string) {
+	m[class]++
+}
+
+func requireLimited(t *testing.T, err error, class string) {
+	var st TooManyRequests
+	require.True(t, errors.As(err, &st), err)
+	require.Equal(t, class, st.Class())
+}
+
+func TestBucket(t *testing.T) {
+	now := time.Now()
+	r := Rule{Rate: 10, Burst: 2}
+
+	var (
+		b  bucket
+		ok bool
+	)
+
+	b, ok = b.take(now, r)
+	require.True(t, ok)
+	b, ok = b.take(now, r)
+	require.True(t, ok)
+	_, ok = b.take(now, r)
+	require.False(t, ok)
+
+	// token is restored in 1/rate
+	b, ok = b.take(now.Add(100*time.Millisecond), r)
+	require.True(t, ok)
+
+	// default burst is one second of the rate
+	b = bucket{}
+	for i := 0; i < 10; i++ {
+		b, ok = b.take(now, Rule{Rate: 10})
+		require.True(t, ok)
+	}
+	_, ok = b.take(now, Rule{Rate: 10})
+	require.False(t, ok)
+
+	_, ok = bucket{}.take(now, Rule{Rate: 0.5})
+	require.True(t, ok)
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	owner := usertest.ID(t)
+	cnr := cidtest.ID()
+
+	t.Run("owner", func(t *testing.T) {
+		metrics := make(testMetrics)
+		l := New(Limits{Owner: Rule{Rate: 1, Burst: 1}}, metrics)
+
+		require.NoError(t, l.Allow(owner, cnr, acl.RoleOthers))
+		requireLimited(t, l.Allow(owner, cidtest.ID(), acl.RoleOthers), ClassOwner)
+		require.NoError(t, l.Allow(usertest.ID(t), cnr, acl.RoleOthers))
+
+		// system roles are not limited
+		require.NoError(t, l.Allow(owner, cnr, acl.RoleContainer))
+		require.NoError(t, l.Allow(owner, cnr, acl.RoleInnerRing))
+
+		require.Equal(t, testMetrics{ClassOwner: 1}, metrics)
+	})
+
+	t.Run("container", func(t *testing.T) {
+		l := New(Limits{Container: Rule{Rate: 1, Burst: 1}}, nil)
+
+		require.NoError(t, l.Allow(owner, cnr, acl.RoleOwner))
+		requireLimited(t, l.Allow(usertest.ID(t), cnr, acl.RoleOthers), ClassContainer)
+		require.NoError(t, l.Allow(owner, cidtest.ID(), acl.RoleOwner))
+	})
+
+	t.Run("role", func(t *testing.T) {
+		l := New(Limits{Roles: map[acl.Role]Rule{
+			acl.RoleContainer: {Rate: 1, Burst: 1},
+		}}, nil)
+
+		require.NoError(t, l.Allow(owner, cnr, acl.RoleContainer))
+		requireLimited(t, l.Allow(usertest.ID(t), cidtest.ID(), acl.RoleContainer), ClassRole)
+		require.NoError(t, l.Allow(owner, cnr, acl.RoleOthers))
+	})
+
+	t.Run("rejected request is not counted", func(t *testing.T) {
+		l := New(Limits{
+			Owner:     Rule{Rate: 1, Burst: 2},
+			Container: Rule{Rate: 1, Burst: 1},
+		}, nil)
+
+		require.NoError(t, l.Allow(owner, cnr, acl.RoleOthers))
+		requireLimited(t, l.Allow(owner, cnr, acl.RoleOthers), ClassContainer)
+		require.NoError(t, l.Allow(owner, cidtest.ID(), acl.RoleOthers))
+	})
+
+	t.Run("bucket eviction", func(t *testing.T) {
+		l := New(Limits{Container: Rule{Rate: 1, Burst: 1}}, nil)
+
+		require.NoError(t, l.Allow(owner, cnr, acl.RoleOthers))
+		for i := 0; i < maxBuckets; i++ {
+			require.NoError(t, l.Allow(owner, cidtest.ID(), acl.RoleOthers))
+		}
+		require.Equal(t, maxBuckets, l.containers.buckets.Len())
+
+		// the least recently used bucket is dropped
+		require.NoError(t, l.Allow(owner, cnr, acl.RoleOthers))
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		l := New(Limits{Owner: Rule{Rate: 1, Burst: 1}}, nil)
+
+		require.NoError(t, l.Allow(owner, cnr, acl.RoleOthers))
+		requireLimited(t, l.Allow(owner, cnr, acl.RoleOthers), ClassOwner)
+
+		l.SetLimits(Limits{})
+		require.NoError(t, l.Allow(owner, cnr, acl.RoleOthers))
+	})
+}
+
+func TestTooManyRequests(t *testing.T) {
+	st := NewTooManyRequests(ClassOwner).ErrorToV2()
+
+	require.EqualValues(t, 2055, st.Code())
+	require.Contains(t, st.Message(), ClassOwner)
+
+	// wrapped status keeps its code on the server side
+	st = apistatus.ErrorToV2(fmt.Errorf("request rejected: %w", NewTooManyRequests(ClassOwner)))
+	require.EqualValues(t, 2055, st.Code())
+
+	// the code is not known to the clients, but the failure is not mistaken
+	// for the internal server error
+	received := apistatus.ErrorFromV2(st)
+	require.ErrorIs(t, received, apistatus.ErrUnrecognizedStatusV2)
+	require.NotErrorIs(t, received, apistatus.ErrServerInternal)
+}
@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"fmt"
+
+	"github.com/epicchainlabs/neofs-api-go/v2/object"
+	"github.com/epicchainlabs/neofs-api-go/v2/status"
+)
+
+// StatusTooManyRequests is a local object failure status code returned when
+// the request exceeds the rate limit. Unlike the internal server error, it
+// tells the client that the request may be retried later.
+const StatusTooManyRequests status.Code = 7
+
+// TooManyRequests describes the status of the object request failure caused
+// by the exceeded rate limit.
+type TooManyRequests struct {
+	class string
+}
+
+// NewTooManyRequests returns TooManyRequests status for the limit of the
+// given key class.
+func NewTooManyRequests(class string) TooManyRequests {
+	return TooManyRequests{class: class}
+}
+
+// Class returns the key class of the exceeded limit.
+func (x TooManyRequests) Class() string {
+	return x.class
+}
+
+func (x TooManyRequests) Error() string {
+	return fmt.Sprintf("status: code = %d message = %s", x.code(), x.message())
+}
+
+func (x TooManyRequests) message() string {
+	return "too many requests: " + x.class + " rate limit exceeded"
+}
+
+func (x TooManyRequests) code() status.Code {
+	c := StatusTooManyRequests
+	object.GlobalizeFail(&c)
+	return c
+}
+
+// ErrorToV2 implements apistatus.StatusV2 interface.
+func (x TooManyRequests) ErrorToV2() *status.Status {
+	var st status.Status
+	st.SetCode(x.code())
+	st.SetMessage(x.message())
+	return &st
+}
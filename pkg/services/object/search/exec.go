@@ -3,6 +3,7 @@ package searchsvc
 import (
 	"context"
 
+	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
 	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
 	"github.com/epicchainlabs/epicchain-sdk-go/object"
 	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
@@ -21,6 +22,9 @@ type execCtx struct {
 
 	prm Prm
 
+	// page collects the results of the paginated search, nil otherwise
+	page *pageWriter
+
 	statusError
 
 	log *zap.Logger
@@ -32,6 +36,12 @@ const (
 )
 
 func (exec *execCtx) prepare() {
+	if p := exec.prm.page; p.Limit > 0 || p.Cursor != nil {
+		exec.page = newPageWriter(p, exec.prm.writer)
+		exec.prm.writer = exec.page
+		return
+	}
+
 	if _, ok := exec.prm.writer.(*uniqueIDWriter); !ok {
 		exec.prm.writer = newUniqueAddressWriter(exec.prm.writer)
 	}
@@ -63,6 +73,10 @@ func (exec *execCtx) searchFilters() object.SearchFilters {
 	return exec.prm.filters
 }
 
+func (exec *execCtx) searchPage() objectcore.SearchPage {
+	return exec.prm.page
+}
+
 func (exec *execCtx) writeIDList(ids []oid.ID) {
 	var err error
 
@@ -2,6 +2,7 @@ package searchsvc
 
 import (
 	coreclient "github.com/epicchainlabs/epicchain-node/pkg/core/client"
+	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/object/util"
 	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
 	"github.com/epicchainlabs/epicchain-sdk-go/object"
@@ -18,6 +19,8 @@ type Prm struct {
 
 	filters object.SearchFilters
 
+	page objectcore.SearchPage
+
 	forwarder RequestForwarder
 }
 
@@ -56,3 +59,9 @@ func (p *Prm) WithContainerID(id cid.ID) {
 func (p *Prm) WithSearchFilters(fs object.SearchFilters) {
 	p.filters = fs
 }
+
+// WithPage sets the page of the paginated search. The page is collected from
+// all container nodes and written at once.
+func (p *Prm) WithPage(page objectcore.SearchPage) {
+	p.page = page
+}
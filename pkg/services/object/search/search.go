@@ -35,6 +35,10 @@ func (s *Service) Search(ctx context.Context, prm Prm) error {
 
 	exec.execute()
 
+	if exec.page != nil && exec.statusError.err == nil {
+		return exec.page.flush()
+	}
+
 	return exec.statusError.err
 }
 
@@ -1,11 +1,13 @@
 package searchsvc
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"testing"
 
@@ -317,6 +319,62 @@ func TestGetRemoteSmall(t *testing.T) {
 			require.Contains(t, w.ids, id)
 		}
 	})
+
+	t.Run("paginated", func(t *testing.T) {
+		var addr oid.Address
+		addr.SetContainer(id)
+
+		ns, as := testNodeMatrix(t, placementDim)
+
+		builder := &testPlacementBuilder{
+			vectors: map[string][][]netmap.NodeInfo{
+				addr.EncodeToString(): ns,
+			},
+		}
+
+		all := generateIDs(20)
+
+		// nodes ignoring pagination return unordered overlapping lists
+		c1 := newTestStorage()
+		c1.addResult(id, all[:12], nil)
+
+		c2 := newTestStorage()
+		c2.addResult(id, all[8:], nil)
+
+		svc := newSvc(builder, &testClientCache{
+			clients: map[string]*testStorage{
+				as[0][0]: c1,
+				as[0][1]: c2,
+			},
+		})
+
+		sort.Slice(all, func(i, j int) bool { return bytes.Compare(all[i][:], all[j][:]) < 0 })
+
+		const limit = 6
+
+		var got []oid.ID
+		var page object.SearchPage
+		page.Limit = limit
+
+		for {
+			w := new(simpleIDWriter)
+
+			p := newPrm(id, w)
+			p.WithPage(page)
+
+			require.NoError(t, svc.Search(ctx, p))
+			require.LessOrEqual(t, len(w.ids), limit)
+
+			got = append(got, w.ids...)
+			if len(w.ids) < limit {
+				break
+			}
+
+			page.Cursor = &w.ids[len(w.ids)-1]
+		}
+
+		require.Equal(t, all, got)
+	})
 }
 
 func TestNumericFilters(t *testing.T) {
@@ -1,9 +1,12 @@
 package searchsvc
 
 import (
+	"bytes"
+	"sort"
 	"sync"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/core/client"
+	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/engine"
 	internalclient "github.com/epicchainlabs/epicchain-node/pkg/services/object/internal/client"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/object/util"
@@ -18,6 +21,20 @@ type uniqueIDWriter struct {
 	writer IDListWriter
 }
 
+// pageWriter collects object IDs of the paginated search from the local
+// storage and remote nodes. Each writing is merged with the previous ones, so
+// not more than a page of IDs is kept.
+type pageWriter struct {
+	mtx sync.Mutex
+
+	page objectcore.SearchPage
+
+	// ordered IDs following the cursor, not more than the limit
+	ids []oid.ID
+
+	writer IDListWriter
+}
+
 type clientConstructorWrapper struct {
 	constructor ClientConstructor
 }
@@ -57,6 +74,71 @@ func (w *uniqueIDWriter) WriteIDs(list []oid.ID) error {
 	return w.writer.WriteIDs(list)
 }
 
+func newPageWriter(page objectcore.SearchPage, w IDListWriter) *pageWriter {
+	return &pageWriter{
+		page:   page,
+		writer: w,
+	}
+}
+
+func (w *pageWriter) WriteIDs(list []oid.ID) error {
+	// nodes not supporting pagination return unordered lists of all objects
+	ids := make([]oid.ID, 0, len(list))
+	for i := range list {
+		if w.page.Cursor == nil || compareIDs(list[i], *w.page.Cursor) > 0 {
+			ids = append(ids, list[i])
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return compareIDs(ids[i], ids[j]) < 0 })
+
+	w.mtx.Lock()
+	w.ids = mergeSortedIDs(w.ids, ids, w.page.Limit)
+	w.mtx.Unlock()
+
+	return nil
+}
+
+// flush writes the collected page.
+func (w *pageWriter) flush() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if len(w.ids) == 0 {
+		return nil
+	}
+
+	return w.writer.WriteIDs(w.ids)
+}
+
+func compareIDs(a, b oid.ID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// mergeSortedIDs merges two ordered lists of IDs into a single ordered one
+// without duplicates and cuts it to the limit if it is not zero.
+func mergeSortedIDs(a, b []oid.ID, limit uint32) []oid.ID {
+	res := make([]oid.ID, 0, len(a)+len(b))
+
+	for (len(a) > 0 || len(b) > 0) && (limit == 0 || len(res) < int(limit)) {
+		var next oid.ID
+
+		if len(b) == 0 || len(a) > 0 && compareIDs(a[0], b[0]) <= 0 {
+			next, a = a[0], a[1:]
+		} else {
+			next, b = b[0], b[1:]
+		}
+
+		if len(res) > 0 && res[len(res)-1] == next {
+			continue
+		}
+
+		res = append(res, next)
+	}
+
+	return res
+}
+
 func (c *clientConstructorWrapper) get(info client.NodeInfo) (searchClient, error) {
 	clt, err := c.constructor.Get(info)
 	if err != nil {
@@ -111,6 +193,10 @@ func (e *storageEngineWrapper) search(exec *execCtx) ([]oid.ID, error) {
 	var selectPrm engine.SelectPrm
 	selectPrm.WithFilters(exec.searchFilters())
 	selectPrm.WithContainerID(exec.containerID())
+	selectPrm.WithLimit(exec.searchPage().Limit)
+	if c := exec.searchPage().Cursor; c != nil {
+		selectPrm.WithCursor(*c)
+	}
 
 	r, err := e.storage.Select(selectPrm)
 	if err != nil {
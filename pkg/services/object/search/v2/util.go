@@ -12,6 +12,7 @@ import (
 	"github.com/epicchainlabs/neofs-api-go/v2/session"
 	"github.com/epicchainlabs/neofs-api-go/v2/signature"
 	"github.com/epicchainlabs/epicchain-node/pkg/core/client"
+	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
 	"github.com/epicchainlabs/epicchain-node/pkg/network"
 	objectSvc "github.com/epicchainlabs/epicchain-node/pkg/services/object"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/object/internal"
@@ -133,8 +134,14 @@ func (s *Service) toPrm(req *objectV2.SearchRequest, stream objectSvc.SearchStre
 		}))
 	}
 
+	page, err := objectcore.SearchPageFromXHeaders(commonPrm.XHeaders())
+	if err != nil {
+		return nil, err
+	}
+
 	p.WithContainerID(id)
 	p.WithSearchFilters(object.NewSearchFiltersFromV2(body.GetFilters()))
+	p.WithPage(page)
 
 	return p, nil
 }
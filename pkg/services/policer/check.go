@@ -104,6 +104,10 @@ func (p *Policer) processObject(ctx context.Context, addrWithType objectcore.Add
 		return
 	}
 
+	if p.processErasureCoding(ctx, addrWithType, cnr.Value) {
+		return
+	}
+
 	policy := cnr.Value.PlacementPolicy()
 
 	nn, err := p.placementBuilder.BuildPlacement(idCnr, &idObj, policy)
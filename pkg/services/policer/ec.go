@@ -0,0 +1,359 @@
+package policer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/core/client"
+	containercore "github.com/epicchainlabs/epicchain-node/pkg/core/container"
+	netmapcore "github.com/epicchainlabs/epicchain-node/pkg/core/netmap"
+	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/ec"
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	containerSDK "github.com/epicchainlabs/epicchain-sdk-go/container"
+	"github.com/epicchainlabs/epicchain-sdk-go/netmap"
+	"github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"go.uber.org/zap"
+)
+
+// processErasureCoding checks placement of the local object part if the
+// container objects are erasure coded. Returns false if the object is not a
+// part and must be processed according to the container placement policy.
+func (p *Policer) processErasureCoding(ctx context.Context, addrWithType objectcore.AddressWithType, cnr containerSDK.Container) bool {
+	if p.ecStorage == nil || addrWithType.Type != object.TypeRegular {
+		return false
+	}
+
+	rule, err := containercore.ErasureCodingFromAttributes(cnr)
+	if err != nil || !rule.Enabled() {
+		return false
+	}
+
+	addr := addrWithType.Address
+
+	hdr, err := p.ecStorage.HeadPart(ctx, ec.Node{Local: true}, addr)
+	if err != nil {
+		p.log.Error("could not read local object header",
+			zap.Stringer("object", addr),
+			zap.String("error", err.Error()),
+		)
+
+		return true
+	}
+
+	part, err := ec.ReadPart(hdr)
+	if err != nil {
+		if errors.Is(err, ec.ErrNotPart) {
+			return false
+		}
+
+		p.log.Error("invalid erasure coded object part",
+			zap.Stringer("object", addr),
+			zap.String("error", err.Error()),
+		)
+
+		return true
+	}
+
+	p.processPart(ctx, addr, part, cnr, rule)
+
+	return true
+}
+
+func (p *Policer) processPart(ctx context.Context, addr oid.Address, part ec.Part, cnr containerSDK.Container, rule containercore.ErasureCoding) {
+	var parent oid.Address
+	parentID, _ := part.Parent.ID()
+	parent.SetContainer(addr.Container())
+	parent.SetObject(parentID)
+
+	nn, err := p.placementBuilder.BuildPlacement(addr.Container(), &parentID, cnr.PlacementPolicy())
+	if err != nil {
+		p.log.Error("could not build placement vector for object",
+			zap.Stringer("cid", addr.Container()),
+			zap.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	if p.parentRemoved(ctx, parent, nn) {
+		p.log.Info("parent object of the part is removed, removing the part...",
+			zap.Stringer("object", addr),
+			zap.Stringer("parent", parent),
+		)
+
+		p.cbRedundantCopy(addr)
+
+		return
+	}
+
+	nodes, err := p.partNodes(nn, rule.TotalParts())
+	if err != nil {
+		p.log.Error("could not select nodes for object parts",
+			zap.Stringer("object", parent),
+			zap.Stringer("rule", rule),
+			zap.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	if part.Index >= len(nodes) {
+		p.log.Error("part index is out of the erasure coding rule",
+			zap.Stringer("object", addr),
+			zap.Int("index", part.Index),
+			zap.Stringer("rule", rule),
+		)
+
+		return
+	}
+
+	if !nodes[part.Index].Local {
+		p.movePart(ctx, addr, parent, part.Index, nodes[part.Index])
+		return
+	}
+
+	p.checkParts(ctx, parent, part.Index, nodes, rule)
+}
+
+// livingParentsCacheSize is the maximum number of parents of the erasure
+// coded parts remembered as not removed in the current epoch.
+const livingParentsCacheSize = 1 << 16
+
+// parentRemoved checks whether the parent object of the part is removed.
+// Tombstones are broadcast to the container nodes on a best-effort basis and
+// are not split into parts, so the local storage may miss the tombstone of
+// the parent. The container nodes are asked until one of them reports the
+// removal, unavailable nodes are skipped. Parents not removed are not checked
+// remotely again until the next epoch.
+func (p *Policer) parentRemoved(ctx context.Context, parent oid.Address, nn [][]netmap.NodeInfo) bool {
+	_, err := p.ecStorage.HeadPart(ctx, ec.Node{Local: true}, parent)
+	if errors.Is(err, apistatus.ErrObjectAlreadyRemoved) {
+		return true
+	}
+
+	if p.livingParents.Contains(parent) {
+		return false
+	}
+
+	p.cfg.RLock()
+	headTimeout := p.headTimeout
+	p.cfg.RUnlock()
+
+	seen := make(map[string]struct{})
+
+	for i := range nn {
+		for j := range nn[i] {
+			key := string(nn[i][j].PublicKey())
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+
+			if nn[i][j].IsMaintenance() || p.netmapKeys.IsLocalKey(nn[i][j].PublicKey()) {
+				continue
+			}
+
+			var node ec.Node
+
+			err = client.NodeInfoFromRawNetmapElement(&node.Info, netmapcore.Node(nn[i][j]))
+			if err != nil {
+				continue
+			}
+
+			callCtx, cancel := context.WithTimeout(ctx, headTimeout)
+			_, err = p.ecStorage.HeadPart(callCtx, node, parent)
+			cancel()
+
+			if errors.Is(err, apistatus.ErrObjectAlreadyRemoved) {
+				return true
+			}
+		}
+	}
+
+	p.livingParents.Add(parent, struct{}{})
+
+	return false
+}
+
+// partNodes returns the first n distinct nodes of the placement vectors, the
+// i-th node stores the i-th part.
+func (p *Policer) partNodes(nn [][]netmap.NodeInfo, n int) ([]ecNode, error) {
+	nodes := make([]ecNode, 0, n)
+	seen := make(map[string]struct{}, n)
+
+	for i := range nn {
+		for j := 0; j < len(nn[i]) && len(nodes) < n; j++ {
+			key := string(nn[i][j].PublicKey())
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+
+			node := ecNode{
+				Node:        ec.Node{Local: p.netmapKeys.IsLocalKey(nn[i][j].PublicKey())},
+				maintenance: nn[i][j].IsMaintenance(),
+			}
+
+			if !node.Local {
+				err := client.NodeInfoFromRawNetmapElement(&node.Info, netmapcore.Node(nn[i][j]))
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			nodes = append(nodes, node)
+		}
+	}
+
+	if len(nodes) < n {
+		return nil, errors.New("not enough container nodes")
+	}
+
+	return nodes, nil
+}
+
+// ecNode is a container node storing object part.
+type ecNode struct {
+	ec.Node
+
+	maintenance bool
+}
+
+func (p *Policer) hasPart(ctx context.Context, node ecNode, parent oid.Address, index int) (bool, error) {
+	p.cfg.RLock()
+	headTimeout := p.headTimeout
+	p.cfg.RUnlock()
+
+	callCtx, cancel := context.WithTimeout(ctx, headTimeout)
+	defer cancel()
+
+	return ec.HasPart(callCtx, p.ecStorage, node.Node, parent, index)
+}
+
+// movePart moves the local part to the node it belongs to.
+func (p *Policer) movePart(ctx context.Context, addr, parent oid.Address, index int, node ecNode) {
+	if node.maintenance {
+		// the node may not respond, hold the part until it is back
+		return
+	}
+
+	ok, err := p.hasPart(ctx, node, parent, index)
+	if err != nil {
+		p.log.Error("could not check the part on the container node",
+			zap.Stringer("object", addr),
+			zap.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	if !ok {
+		obj, err := p.ecStorage.GetPart(ctx, ec.Node{Local: true}, addr)
+		if err == nil {
+			err = p.ecStorage.PutPart(ctx, node.Node, obj)
+		}
+
+		if err != nil {
+			p.log.Error("could not move the part to the container node",
+				zap.Stringer("object", addr),
+				zap.String("error", err.Error()),
+			)
+
+			return
+		}
+	}
+
+	p.log.Info("part of the object is stored on another node, removing the local copy...",
+		zap.Stringer("object", addr),
+	)
+
+	p.cbRedundantCopy(addr)
+}
+
+// checkParts checks that the nodes following the local one store their
+// parts and restores the missing ones. The check stops on the first node
+// storing its part: each holder checks its successors, so all parts are
+// covered without checking every part on every node.
+func (p *Policer) checkParts(ctx context.Context, parent oid.Address, index int, nodes []ecNode, rule containercore.ErasureCoding) {
+	var missing []int
+
+	for j := 1; j < len(nodes); j++ {
+		i := (index + j) % len(nodes)
+		if nodes[i].maintenance {
+			break
+		}
+
+		ok, err := p.hasPart(ctx, nodes[i], parent, i)
+		if err != nil {
+			p.log.Error("could not check the part on the container node",
+				zap.Stringer("object", parent),
+				zap.Int("index", i),
+				zap.String("error", err.Error()),
+			)
+
+			break
+		}
+
+		if ok {
+			break
+		}
+
+		missing = append(missing, i)
+	}
+
+	if len(missing) == 0 {
+		return
+	}
+
+	p.log.Debug("shortage of object parts detected",
+		zap.Stringer("object", parent),
+		zap.Ints("parts", missing),
+	)
+
+	ecNodes := make([]ec.Node, len(nodes))
+	for i := range nodes {
+		ecNodes[i] = nodes[i].Node
+	}
+
+	obj, err := ec.Get(ctx, p.ecStorage, rule, ecNodes, parent)
+	if err != nil {
+		p.log.Error("could not restore the object to repair its parts",
+			zap.Stringer("object", parent),
+			zap.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	parts, err := ec.Split(obj, rule, p.ecSigner)
+	if err != nil {
+		p.log.Error("could not split the object into parts",
+			zap.Stringer("object", parent),
+			zap.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	for _, i := range missing {
+		err = p.ecStorage.PutPart(ctx, nodes[i].Node, parts[i])
+		if err != nil {
+			p.log.Error("could not save the restored part",
+				zap.Stringer("object", parent),
+				zap.Int("index", i),
+				zap.String("error", err.Error()),
+			)
+
+			continue
+		}
+
+		p.log.Debug("object part restored",
+			zap.Stringer("object", parent),
+			zap.Int("index", i),
+		)
+	}
+}
@@ -0,0 +1,320 @@
+package policer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-go/pkg/crypto/keys"
+	containercore "github.com/epicchainlabs/epicchain-node/pkg/core/container"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/ec"
+	"github.com/epicchainlabs/epicchain-node/pkg/util/logger/test"
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	containerSDK "github.com/epicchainlabs/epicchain-sdk-go/container"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
+	neofsecdsa "github.com/epicchainlabs/epicchain-sdk-go/crypto/ecdsa"
+	"github.com/epicchainlabs/epicchain-sdk-go/netmap"
+	"github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	usertest "github.com/epicchainlabs/epicchain-sdk-go/user/test"
+	"github.com/stretchr/testify/require"
+)
+
+// testPartStorage emulates in-process container nodes storing objects in
+// memory.
+type testPartStorage struct {
+	mtx   sync.Mutex
+	nodes map[string]map[oid.ID]*object.Object
+	// removed contains the objects with the tombstones stored on the node
+	removed map[string]map[oid.ID]struct{}
+}
+
+func partNodeKey(node ec.Node) string {
+	if node.Local {
+		return "local"
+	}
+
+	return string(node.Info.PublicKey())
+}
+
+func (s *testPartStorage) SearchParts(_ context.Context, node ec.Node, parent oid.Address) ([]oid.ID, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var res []oid.ID
+
+	for id, obj := range s.nodes[partNodeKey(node)] {
+		for _, a := range obj.Attributes() {
+			if a.Key() == ec.AttributeParent && a.Value() == parent.Object().EncodeToString() {
+				res = append(res, id)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (s *testPartStorage) HeadPart(ctx context.Context, node ec.Node, addr oid.Address) (*object.Object, error) {
+	obj, err := s.GetPart(ctx, node, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.CutPayload(), nil
+}
+
+func (s *testPartStorage) GetPart(_ context.Context, node ec.Node, addr oid.Address) (*object.Object, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.removed[partNodeKey(node)][addr.Object()]; ok {
+		return nil, apistatus.ErrObjectAlreadyRemoved
+	}
+
+	obj, ok := s.nodes[partNodeKey(node)][addr.Object()]
+	if !ok {
+		return nil, apistatus.ErrObjectNotFound
+	}
+
+	return obj, nil
+}
+
+func (s *testPartStorage) PutPart(_ context.Context, node ec.Node, part *object.Object) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	m, ok := s.nodes[partNodeKey(node)]
+	if !ok {
+		m = make(map[oid.ID]*object.Object)
+		s.nodes[partNodeKey(node)] = m
+	}
+
+	id, _ := part.ID()
+	m[id] = part
+
+	return nil
+}
+
+func (s *testPartStorage) remove(node ec.Node, addr oid.Address) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	m, ok := s.removed[partNodeKey(node)]
+	if !ok {
+		m = make(map[oid.ID]struct{})
+		s.removed[partNodeKey(node)] = m
+	}
+
+	m[addr.Object()] = struct{}{}
+}
+
+func (s *testPartStorage) has(node ec.Node, part *object.Object) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	id, _ := part.ID()
+	_, ok := s.nodes[partNodeKey(node)][id]
+
+	return ok
+}
+
+type testPlacement [][]netmap.NodeInfo
+
+func (x testPlacement) BuildPlacement(cid.ID, *oid.ID, netmap.PlacementPolicy) ([][]netmap.NodeInfo, error) {
+	return x, nil
+}
+
+type testLocalKey []byte
+
+func (x testLocalKey) IsLocalKey(key []byte) bool {
+	return bytes.Equal(x, key)
+}
+
+type ecTestEnv struct {
+	storage *testPartStorage
+	nodes   []netmap.NodeInfo
+	local   netmap.NodeInfo
+	parts   []*object.Object
+	parent  oid.Address
+	rule    containercore.ErasureCoding
+}
+
+func newECTestEnv(t *testing.T, rule containercore.ErasureCoding) *ecTestEnv {
+	env := &ecTestEnv{
+		storage: &testPartStorage{
+			nodes:   make(map[string]map[oid.ID]*object.Object),
+			removed: make(map[string]map[oid.ID]struct{}),
+		},
+		rule: rule,
+	}
+
+	for i := 0; i < rule.TotalParts(); i++ {
+		var node netmap.NodeInfo
+		node.SetPublicKey([]byte{byte(i)})
+		node.SetNetworkEndpoints("/ip4/127.0.0.1/tcp/" + strconv.Itoa(8080+i))
+		env.nodes = append(env.nodes, node)
+	}
+
+	key, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	payload := make([]byte, 1000)
+	_, _ = rand.Read(payload)
+
+	obj := object.New()
+	obj.SetContainerID(cidtest.ID())
+	owner := usertest.ID(t)
+	obj.SetOwnerID(&owner)
+	obj.SetPayload(payload)
+	obj.SetPayloadSize(uint64(len(payload)))
+	require.NoError(t, obj.SetVerificationFields(neofsecdsa.SignerRFC6979(key.PrivateKey)))
+
+	env.parts, err = ec.Split(obj, rule, neofsecdsa.SignerRFC6979(key.PrivateKey))
+	require.NoError(t, err)
+
+	cnr, _ := obj.ContainerID()
+	id, _ := obj.ID()
+	env.parent.SetContainer(cnr)
+	env.parent.SetObject(id)
+
+	return env
+}
+
+func (env *ecTestEnv) ecNode(i int) ec.Node {
+	if bytes.Equal(env.nodes[i].PublicKey(), env.local.PublicKey()) {
+		return ec.Node{Local: true}
+	}
+
+	var node ec.Node
+	node.Info.SetPublicKey(env.nodes[i].PublicKey())
+
+	return node
+}
+
+func (env *ecTestEnv) partAddress(i int) oid.Address {
+	var addr oid.Address
+	id, _ := env.parts[i].ID()
+	addr.SetContainer(env.parent.Container())
+	addr.SetObject(id)
+
+	return addr
+}
+
+func (env *ecTestEnv) policer(t *testing.T, removed *[]oid.Address) *Policer {
+	key, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	return New(
+		WithLogger(test.NewLogger(false)),
+		WithHeadTimeout(time.Second),
+		WithPlacementBuilder(testPlacement{env.nodes}),
+		WithNetmapKeys(testLocalKey(env.local.PublicKey())),
+		WithRedundantCopyCallback(func(addr oid.Address) {
+			*removed = append(*removed, addr)
+		}),
+		WithErasureCoding(env.storage, neofsecdsa.SignerRFC6979(key.PrivateKey)),
+	)
+}
+
+func (env *ecTestEnv) processLocalPart(t *testing.T, p *Policer, index int) {
+	part, err := ec.ReadPart(env.parts[index])
+	require.NoError(t, err)
+
+	p.processPart(context.Background(), env.partAddress(index), part, containerSDK.Container{}, env.rule)
+}
+
+func TestPolicer_ErasureCoding(t *testing.T) {
+	rule := containercore.ErasureCoding{DataParts: 2, ParityParts: 2}
+
+	t.Run("restore missing parts", func(t *testing.T) {
+		env := newECTestEnv(t, rule)
+		env.local = env.nodes[0]
+
+		require.NoError(t, env.storage.PutPart(context.Background(), env.ecNode(0), env.parts[0]))
+		require.NoError(t, env.storage.PutPart(context.Background(), env.ecNode(3), env.parts[3]))
+
+		var removed []oid.Address
+		env.processLocalPart(t, env.policer(t, &removed), 0)
+
+		require.Empty(t, removed)
+
+		for i := range env.parts {
+			require.True(t, env.storage.has(env.ecNode(i), env.parts[i]), i)
+		}
+	})
+
+	t.Run("move misplaced part", func(t *testing.T) {
+		env := newECTestEnv(t, rule)
+		env.local = env.nodes[2]
+
+		// the local node stores the first part instead of the third one
+		require.NoError(t, env.storage.PutPart(context.Background(), env.ecNode(2), env.parts[0]))
+
+		var removed []oid.Address
+		env.processLocalPart(t, env.policer(t, &removed), 0)
+
+		require.True(t, env.storage.has(env.ecNode(0), env.parts[0]))
+		require.Equal(t, []oid.Address{env.partAddress(0)}, removed)
+	})
+
+	t.Run("parent removed on another node", func(t *testing.T) {
+		env := newECTestEnv(t, rule)
+		env.local = env.nodes[0]
+
+		require.NoError(t, env.storage.PutPart(context.Background(), env.ecNode(0), env.parts[0]))
+		// the tombstone has reached the last node only
+		env.storage.remove(env.ecNode(3), env.parent)
+
+		var removed []oid.Address
+		env.processLocalPart(t, env.policer(t, &removed), 0)
+
+		require.Equal(t, []oid.Address{env.partAddress(0)}, removed)
+
+		// the removed object is not restored
+		for i := 1; i < len(env.parts); i++ {
+			require.False(t, env.storage.has(env.ecNode(i), env.parts[i]), i)
+		}
+	})
+
+	t.Run("parent removal checked once per epoch", func(t *testing.T) {
+		env := newECTestEnv(t, rule)
+		env.local = env.nodes[1]
+
+		for i := range env.parts {
+			require.NoError(t, env.storage.PutPart(context.Background(), env.ecNode(i), env.parts[i]))
+		}
+
+		var removed []oid.Address
+		p := env.policer(t, &removed)
+		env.processLocalPart(t, p, 1)
+		require.Empty(t, removed)
+
+		// the tombstone reaches the last node after the check
+		env.storage.remove(env.ecNode(3), env.parent)
+		env.processLocalPart(t, p, 1)
+		require.Empty(t, removed)
+
+		p.HandleNetmapChange(netmap.NetMap{}, netmap.NetMap{})
+		env.processLocalPart(t, p, 1)
+		require.Equal(t, []oid.Address{env.partAddress(1)}, removed)
+	})
+
+	t.Run("all parts in place", func(t *testing.T) {
+		env := newECTestEnv(t, rule)
+		env.local = env.nodes[1]
+
+		for i := range env.parts {
+			require.NoError(t, env.storage.PutPart(context.Background(), env.ecNode(i), env.parts[i]))
+		}
+
+		var removed []oid.Address
+		env.processLocalPart(t, env.policer(t, &removed), 1)
+
+		require.Empty(t, removed)
+	})
+}
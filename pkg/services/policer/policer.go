@@ -7,10 +7,13 @@ import (
 	"github.com/epicchainlabs/epicchain-node/pkg/core/container"
 	"github.com/epicchainlabs/epicchain-node/pkg/core/netmap"
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/engine"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object/ec"
 	headsvc "github.com/epicchainlabs/epicchain-node/pkg/services/object/head"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/object_manager/placement"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/replicator"
+	neofscrypto "github.com/epicchainlabs/epicchain-sdk-go/crypto"
 	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/panjf2000/ants/v2"
 	"go.uber.org/zap"
 )
@@ -52,6 +55,14 @@ type Policer struct {
 	*cfg
 
 	objsInWork *objectsInWork
+
+	priorityQueue *priorityQueue
+	netmapDiffs   chan netmapDiff
+
+	// livingParents contains parents of the erasure coded parts not removed
+	// in the current epoch, the container nodes are not asked about them
+	// once again until the next epoch.
+	livingParents *lru.Cache[oid.Address, struct{}]
 }
 
 // Option is an option for Policer constructor.
@@ -100,6 +111,11 @@ type cfg struct {
 	rebalanceFreq time.Duration
 
 	network Network
+
+	ecStorage ec.Storage
+	ecSigner  neofscrypto.Signer
+
+	metrics MetricsWriter
 }
 
 func defaultCfg() *cfg {
@@ -121,11 +137,19 @@ func New(opts ...Option) *Policer {
 
 	c.log = c.log.With(zap.String("component", "Object Policer"))
 
+	q := newPriorityQueue()
+	q.metrics = c.metrics
+
+	livingParents, _ := lru.New[oid.Address, struct{}](livingParentsCacheSize) // no error, size is positive
+
 	return &Policer{
 		cfg: c,
 		objsInWork: &objectsInWork{
 			objs: make(map[oid.Address]struct{}, c.maxCapacity),
 		},
+		priorityQueue: q,
+		netmapDiffs:   make(chan netmapDiff, netmapDiffQueueSize),
+		livingParents: livingParents,
 	}
 }
 
@@ -240,3 +264,20 @@ func WithObjectBatchSize(s uint32) Option {
 		c.batchSize = s
 	}
 }
+
+// WithErasureCoding returns option to check and repair parts of the objects
+// in the containers with EC storage policy. Restored parts are signed by the
+// given signer.
+func WithErasureCoding(s ec.Storage, signer neofscrypto.Signer) Option {
+	return func(c *cfg) {
+		c.ecStorage = s
+		c.ecSigner = signer
+	}
+}
+
+// WithMetrics returns option to set metrics collector of Policer.
+func WithMetrics(m MetricsWriter) Option {
+	return func(c *cfg) {
+		c.metrics = m
+	}
+}
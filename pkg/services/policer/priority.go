@@ -0,0 +1,388 @@
+package policer
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+
+	containercore "github.com/epicchainlabs/epicchain-node/pkg/core/container"
+	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/engine"
+	"github.com/epicchainlabs/epicchain-node/pkg/services/object_manager/placement"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	"github.com/epicchainlabs/epicchain-sdk-go/netmap"
+	"github.com/epicchainlabs/epicchain-sdk-go/object"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxPriorityQueueSize limits the number of objects waiting for the
+	// prioritized processing. Objects not fitting the queue are processed
+	// in the storage order.
+	maxPriorityQueueSize = 1 << 16
+
+	// netmapDiffQueueSize limits the number of network map changes waiting
+	// for the local objects to be prioritized.
+	netmapDiffQueueSize = 16
+)
+
+// MetricsWriter is an interface of the Policer metrics collector.
+type MetricsWriter interface {
+	// SetPolicerQueueDepth sets the number of objects in the priority queue
+	// with the given replica deficit.
+	SetPolicerQueueDepth(deficit uint32, depth uint64)
+}
+
+// prioritizedObject is an object waiting for the prioritized processing.
+type prioritizedObject struct {
+	object objectcore.AddressWithType
+
+	// deficit is an estimated number of the object replicas lost with the
+	// nodes that left the network map.
+	deficit uint32
+
+	// leftEpoch is the latest epoch when a node storing the object replica
+	// left the network map.
+	leftEpoch uint64
+
+	index int
+}
+
+// objectHeap implements heap.Interface with the objects having the largest
+// deficit first. Objects with the same deficit are ordered by the recency of
+// the replica loss.
+type objectHeap []*prioritizedObject
+
+func (h objectHeap) Len() int { return len(h) }
+
+func (h objectHeap) Less(i, j int) bool {
+	if h[i].deficit != h[j].deficit {
+		return h[i].deficit > h[j].deficit
+	}
+	return h[i].leftEpoch > h[j].leftEpoch
+}
+
+func (h objectHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *objectHeap) Push(x any) {
+	obj := x.(*prioritizedObject)
+	obj.index = len(*h)
+	*h = append(*h, obj)
+}
+
+func (h *objectHeap) Pop() any {
+	old := *h
+	n := len(old)
+	obj := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return obj
+}
+
+// priorityQueue holds the local objects that should be processed before the
+// others because their replicas have been lost.
+type priorityQueue struct {
+	mtx sync.Mutex
+
+	heap   objectHeap
+	index  map[oid.Address]*prioritizedObject
+	depths map[uint32]uint64
+
+	metrics MetricsWriter
+}
+
+func newPriorityQueue() *priorityQueue {
+	return &priorityQueue{
+		index:  make(map[oid.Address]*prioritizedObject),
+		depths: make(map[uint32]uint64),
+	}
+}
+
+// push adds the object to the queue. If the object is already queued, its
+// deficit is increased. Returns false if the queue is full.
+func (q *priorityQueue) push(obj objectcore.AddressWithType, deficit uint32, leftEpoch uint64) bool {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if queued, ok := q.index[obj.Address]; ok {
+		q.decDepth(queued.deficit)
+
+		queued.deficit += deficit
+		if leftEpoch > queued.leftEpoch {
+			queued.leftEpoch = leftEpoch
+		}
+
+		heap.Fix(&q.heap, queued.index)
+		q.incDepth(queued.deficit)
+
+		return true
+	}
+
+	if len(q.heap) >= maxPriorityQueueSize {
+		return false
+	}
+
+	queued := &prioritizedObject{
+		object:    obj,
+		deficit:   deficit,
+		leftEpoch: leftEpoch,
+	}
+
+	heap.Push(&q.heap, queued)
+	q.index[obj.Address] = queued
+	q.incDepth(deficit)
+
+	return true
+}
+
+// pop removes and returns up to n objects with the highest priority.
+func (q *priorityQueue) pop(n uint32) []prioritizedObject {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if uint32(len(q.heap)) < n {
+		n = uint32(len(q.heap))
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	res := make([]prioritizedObject, n)
+
+	for i := range res {
+		obj := heap.Pop(&q.heap).(*prioritizedObject)
+		delete(q.index, obj.object.Address)
+		q.decDepth(obj.deficit)
+
+		res[i] = *obj
+	}
+
+	return res
+}
+
+// remove drops the object from the queue if it is there.
+func (q *priorityQueue) remove(addr oid.Address) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	obj, ok := q.index[addr]
+	if !ok {
+		return
+	}
+
+	heap.Remove(&q.heap, obj.index)
+	delete(q.index, addr)
+	q.decDepth(obj.deficit)
+}
+
+func (q *priorityQueue) len() int {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	return len(q.heap)
+}
+
+func (q *priorityQueue) incDepth(deficit uint32) {
+	q.depths[deficit]++
+	q.reportDepth(deficit)
+}
+
+func (q *priorityQueue) decDepth(deficit uint32) {
+	q.depths[deficit]--
+	q.reportDepth(deficit)
+
+	if q.depths[deficit] == 0 {
+		delete(q.depths, deficit)
+	}
+}
+
+func (q *priorityQueue) reportDepth(deficit uint32) {
+	if q.metrics != nil {
+		q.metrics.SetPolicerQueueDepth(deficit, q.depths[deficit])
+	}
+}
+
+// netmapDiff describes the nodes that left the network map.
+type netmapDiff struct {
+	// prev is the network map the nodes were in.
+	prev netmap.NetMap
+
+	// epoch is the epoch of the network map the nodes are missing in.
+	epoch uint64
+
+	// departed contains public keys of the nodes.
+	departed map[string]struct{}
+}
+
+// HandleNetmapChange prioritizes the processing of the local objects which
+// replicas were placed on the nodes that left the network map between prev
+// and cur. The objects are found in the background, the ones with the largest
+// number of the lost replicas are processed first. Parents of the erasure
+// coded parts are checked for removal once again in the new epoch.
+func (p *Policer) HandleNetmapChange(prev, cur netmap.NetMap) {
+	p.livingParents.Purge()
+
+	curNodes := cur.Nodes()
+	present := make(map[string]struct{}, len(curNodes))
+
+	for i := range curNodes {
+		present[string(curNodes[i].PublicKey())] = struct{}{}
+	}
+
+	d := netmapDiff{
+		prev:     prev,
+		epoch:    cur.Epoch(),
+		departed: make(map[string]struct{}),
+	}
+
+	prevNodes := prev.Nodes()
+	for i := range prevNodes {
+		key := string(prevNodes[i].PublicKey())
+		if _, ok := present[key]; !ok {
+			d.departed[key] = struct{}{}
+		}
+	}
+
+	if len(d.departed) == 0 {
+		return
+	}
+
+	p.log.Info("nodes left the network map, prioritizing objects with lost replicas",
+		zap.Uint64("epoch", d.epoch),
+		zap.Int("nodes", len(d.departed)),
+	)
+
+	select {
+	case p.netmapDiffs <- d:
+	default:
+		p.log.Warn("too many network map changes are being handled, skipping prioritization",
+			zap.Uint64("epoch", d.epoch))
+	}
+}
+
+func (p *Policer) netmapDiffWorker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-p.netmapDiffs:
+			p.prioritizeObjects(ctx, d)
+		}
+	}
+}
+
+// prioritizeObjects walks the local objects and queues the ones with the
+// replicas lost according to the diff.
+func (p *Policer) prioritizeObjects(ctx context.Context, d netmapDiff) {
+	p.cfg.RLock()
+	batchSize := p.batchSize
+	p.cfg.RUnlock()
+
+	var (
+		addrs     []objectcore.AddressWithType
+		cursor    *engine.Cursor
+		err       error
+		cnrNodes  = make(map[cid.ID][][]netmap.NodeInfo)
+		queued    int
+		truncated bool
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		addrs, cursor, err = p.jobQueue.Select(cursor, batchSize)
+		if err != nil {
+			if !errors.Is(err, engine.ErrEndOfListing) {
+				p.log.Warn("failure at object select for prioritization", zap.Error(err))
+			}
+			break
+		}
+
+		for i := range addrs {
+			deficit := p.replicaDeficit(d, addrs[i], cnrNodes)
+			if deficit == 0 {
+				continue
+			}
+
+			if !p.priorityQueue.push(addrs[i], deficit, d.epoch) {
+				truncated = true
+				continue
+			}
+
+			queued++
+		}
+	}
+
+	p.log.Info("objects with lost replicas prioritized",
+		zap.Uint64("epoch", d.epoch),
+		zap.Int("objects", queued),
+		zap.Bool("queue overflow", truncated),
+	)
+}
+
+// replicaDeficit returns the number of the object replicas placed on the
+// departed nodes. Container nodes are cached in cnrNodes.
+func (p *Policer) replicaDeficit(d netmapDiff, obj objectcore.AddressWithType, cnrNodes map[cid.ID][][]netmap.NodeInfo) uint32 {
+	idCnr := obj.Address.Container()
+
+	cnr, err := p.cnrSrc.Get(idCnr)
+	if err != nil {
+		return 0
+	}
+
+	if rule, err := containercore.ErasureCodingFromAttributes(cnr.Value); err == nil && rule.Enabled() {
+		// parts are placed and restored by the erasure coding rules
+		return 0
+	}
+
+	policy := cnr.Value.PlacementPolicy()
+
+	cn, ok := cnrNodes[idCnr]
+	if !ok {
+		cn, err = d.prev.ContainerNodes(policy, idCnr)
+		if err != nil {
+			cn = nil
+		}
+		cnrNodes[idCnr] = cn
+	}
+
+	if cn == nil {
+		return 0
+	}
+
+	idObj := obj.Address.Object()
+
+	nn, err := placement.BuildObjectPlacement(&d.prev, cn, &idObj)
+	if err != nil {
+		return 0
+	}
+
+	var deficit uint32
+
+	for i := range nn {
+		replicas := int(policy.ReplicaNumberByIndex(i))
+		if obj.Type == object.TypeLock || obj.Type == object.TypeLink {
+			// all container nodes store such objects, see processNodes
+			replicas = len(nn[i])
+		}
+
+		for j := 0; j < replicas && j < len(nn[i]); j++ {
+			if _, ok := d.departed[string(nn[i][j].PublicKey())]; ok {
+				deficit++
+			}
+		}
+	}
+
+	return deficit
+}
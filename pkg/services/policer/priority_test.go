@@ -0,0 +1,134 @@
+package policer
+
+import (
+	"strconv"
+	"testing"
+
+	containercore "github.com/epicchainlabs/epicchain-node/pkg/core/container"
+	objectcore "github.com/epicchainlabs/epicchain-node/pkg/core/object"
+	"github.com/epicchainlabs/epicchain-node/pkg/util/logger/test"
+	apistatus "github.com/epicchainlabs/epicchain-sdk-go/client/status"
+	containerSDK "github.com/epicchainlabs/epicchain-sdk-go/container"
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	"github.com/epicchainlabs/epicchain-sdk-go/netmap"
+	"github.com/epicchainlabs/epicchain-sdk-go/object"
+	oidtest "github.com/epicchainlabs/epicchain-sdk-go/object/id/test"
+	"github.com/stretchr/testify/require"
+)
+
+type testQueueMetrics map[uint32]uint64
+
+func (m testQueueMetrics) SetPolicerQueueDepth(deficit uint32, depth uint64) {
+	m[deficit] = depth
+}
+
+type testContainerSource map[cid.ID]containerSDK.Container
+
+func (s testContainerSource) Get(id cid.ID) (*containercore.Container, error) {
+	cnr, ok := s[id]
+	if !ok {
+		return nil, apistatus.ErrContainerNotFound
+	}
+
+	return &containercore.Container{Value: cnr}, nil
+}
+
+func TestPriorityQueue(t *testing.T) {
+	metrics := make(testQueueMetrics)
+
+	q := newPriorityQueue()
+	q.metrics = metrics
+
+	objs := make([]objectcore.AddressWithType, 4)
+	for i := range objs {
+		objs[i].Address = oidtest.Address()
+	}
+
+	require.True(t, q.push(objs[0], 1, 10))
+	require.True(t, q.push(objs[1], 2, 10))
+	require.True(t, q.push(objs[2], 1, 12))
+	require.True(t, q.push(objs[3], 1, 11))
+	require.Equal(t, testQueueMetrics{1: 3, 2: 1}, metrics)
+
+	// accumulated deficit raises the priority
+	require.True(t, q.push(objs[0], 2, 9))
+	require.Equal(t, testQueueMetrics{1: 2, 2: 1, 3: 1}, metrics)
+
+	q.remove(objs[3].Address)
+	require.Equal(t, 3, q.len())
+
+	res := q.pop(2)
+	require.Len(t, res, 2)
+	require.Equal(t, objs[0], res[0].object)
+	require.EqualValues(t, 3, res[0].deficit)
+	require.EqualValues(t, 10, res[0].leftEpoch)
+	require.Equal(t, objs[1], res[1].object)
+
+	res = q.pop(2)
+	require.Len(t, res, 1)
+	require.Equal(t, objs[2], res[0].object)
+
+	require.Empty(t, q.pop(2))
+	require.Equal(t, testQueueMetrics{1: 0, 2: 0, 3: 0}, metrics)
+}
+
+func TestPolicer_HandleNetmapChange(t *testing.T) {
+	nodes := make([]netmap.NodeInfo, 4)
+	for i := range nodes {
+		nodes[i].SetPublicKey([]byte{byte(i)})
+		nodes[i].SetNetworkEndpoints("/ip4/127.0.0.1/tcp/" + strconv.Itoa(8080+i))
+	}
+
+	var prev, cur netmap.NetMap
+	prev.SetEpoch(10)
+	prev.SetNodes(nodes)
+	cur.SetEpoch(11)
+	cur.SetNodes(nodes[2:])
+
+	var policy netmap.PlacementPolicy
+	require.NoError(t, policy.DecodeString("REP 4"))
+
+	var cnr containerSDK.Container
+	cnr.SetPlacementPolicy(policy)
+
+	addr := oidtest.Address()
+	cnrSrc := testContainerSource{addr.Container(): cnr}
+
+	p := New(
+		WithLogger(test.NewLogger(false)),
+		WithContainerSource(cnrSrc),
+	)
+
+	p.HandleNetmapChange(prev, prev)
+	require.Empty(t, p.netmapDiffs)
+
+	p.HandleNetmapChange(prev, cur)
+	require.Len(t, p.netmapDiffs, 1)
+
+	d := <-p.netmapDiffs
+	require.EqualValues(t, 11, d.epoch)
+	require.Equal(t, map[string]struct{}{
+		string(nodes[0].PublicKey()): {},
+		string(nodes[1].PublicKey()): {},
+	}, d.departed)
+
+	cnrNodes := make(map[cid.ID][][]netmap.NodeInfo)
+
+	require.EqualValues(t, 2, p.replicaDeficit(d, objectcore.AddressWithType{Address: addr}, cnrNodes))
+	require.Contains(t, cnrNodes, addr.Container())
+
+	// objects of unknown containers are not prioritized
+	require.Zero(t, p.replicaDeficit(d, objectcore.AddressWithType{Address: oidtest.Address()}, cnrNodes))
+
+	t.Run("lock", func(t *testing.T) {
+		require.NoError(t, policy.DecodeString("REP 1 CBF 4"))
+		cnr.SetPlacementPolicy(policy)
+
+		lockAddr := oidtest.Address()
+		cnrSrc[lockAddr.Container()] = cnr
+
+		obj := objectcore.AddressWithType{Address: lockAddr, Type: object.TypeLock}
+
+		require.EqualValues(t, 2, p.replicaDeficit(d, obj, cnrNodes))
+	})
+}
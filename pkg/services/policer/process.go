@@ -16,6 +16,7 @@ func (p *Policer) Run(ctx context.Context) {
 	}()
 
 	go p.poolCapacityWorker(ctx)
+	go p.netmapDiffWorker(ctx)
 	p.shardPolicyWorker(ctx)
 }
 
@@ -45,37 +46,40 @@ func (p *Policer) shardPolicyWorker(ctx context.Context) {
 		default:
 		}
 
-		addrs, cursor, err = p.jobQueue.Select(cursor, batchSize)
-		if err != nil {
-			if errors.Is(err, engine.ErrEndOfListing) {
-				time.Sleep(time.Second) // finished whole cycle, sleep a bit
-				continue
-			}
-			p.log.Warn("failure at object select for replication", zap.Error(err))
-		}
+		// objects with lost replicas go first, the rest are walked in the
+		// storage order
+		prioritized := p.priorityQueue.pop(batchSize)
+		if len(prioritized) > 0 {
+			for i := range prioritized {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
 
-		for i := range addrs {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				addr := addrs[i]
-				if p.objsInWork.inWork(addr.Address) {
-					// do not process an object
-					// that is in work
+				if !p.submitObject(ctx, prioritized[i].object) {
+					p.priorityQueue.push(prioritized[i].object, prioritized[i].deficit, prioritized[i].leftEpoch)
+				}
+			}
+		} else {
+			addrs, cursor, err = p.jobQueue.Select(cursor, batchSize)
+			if err != nil {
+				if errors.Is(err, engine.ErrEndOfListing) {
+					time.Sleep(time.Second) // finished whole cycle, sleep a bit
 					continue
 				}
+				p.log.Warn("failure at object select for replication", zap.Error(err))
+			}
 
-				err = p.taskPool.Submit(func() {
-					p.objsInWork.add(addr.Address)
-
-					p.processObject(ctx, addr)
-
-					p.objsInWork.remove(addr.Address)
-				})
-				if err != nil {
-					p.log.Warn("pool submission", zap.Error(err))
+			for i := range addrs {
+				select {
+				case <-ctx.Done():
+					return
+				default:
 				}
+
+				p.priorityQueue.remove(addrs[i].Address)
+				p.submitObject(ctx, addrs[i])
 			}
 		}
 
@@ -90,6 +94,30 @@ func (p *Policer) shardPolicyWorker(ctx context.Context) {
 	}
 }
 
+// submitObject submits the object processing to the pool unless the object
+// is already in work. Returns false if the pool rejected the task.
+func (p *Policer) submitObject(ctx context.Context, addr objectcore.AddressWithType) bool {
+	if p.objsInWork.inWork(addr.Address) {
+		// do not process an object
+		// that is in work
+		return true
+	}
+
+	err := p.taskPool.Submit(func() {
+		p.objsInWork.add(addr.Address)
+
+		p.processObject(ctx, addr)
+
+		p.objsInWork.remove(addr.Address)
+	})
+	if err != nil {
+		p.log.Warn("pool submission", zap.Error(err))
+		return false
+	}
+
+	return true
+}
+
 func (p *Policer) poolCapacityWorker(ctx context.Context) {
 	p.cfg.RLock()
 	maxCapacity := p.maxCapacity
@@ -0,0 +1,108 @@
+package replicator
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultNodeErrorBudget is the default number of consecutive replication
+// errors after which the node is suspended.
+const DefaultNodeErrorBudget = 3
+
+const (
+	// retryBackoff is a pause before the first retry of the failed
+	// replication, it is doubled for each next retry.
+	retryBackoff = 500 * time.Millisecond
+
+	// suspendPeriod is a period the node is excluded from the replication
+	// for after its error budget is exhausted for the first time, it is
+	// doubled for each next suspension up to maxSuspendPeriod.
+	suspendPeriod = 10 * time.Second
+
+	// maxSuspendPeriod limits the node suspension period.
+	maxSuspendPeriod = 10 * time.Minute
+)
+
+type nodeErrorState struct {
+	// errors is a number of consecutive errors since the last success or
+	// suspension.
+	errors uint32
+
+	// suspensions is a number of consecutive suspensions.
+	suspensions uint32
+
+	// suspendedTill is the time the node is excluded from the replication
+	// till.
+	suspendedTill time.Time
+}
+
+// nodeErrors tracks the replication errors of the remote nodes. Each node
+// has a budget of consecutive errors, the failed replications are retried
+// with backoff until the budget is exhausted. Then the node is suspended for
+// a period growing with each next suspension. Successful replication resets
+// the node state.
+type nodeErrors struct {
+	mtx sync.Mutex
+
+	budget uint32
+	nodes  map[string]*nodeErrorState
+}
+
+func newNodeErrors(budget uint32) *nodeErrors {
+	if budget == 0 {
+		budget = DefaultNodeErrorBudget
+	}
+
+	return &nodeErrors{
+		budget: budget,
+		nodes:  make(map[string]*nodeErrorState),
+	}
+}
+
+// available checks whether the node is not suspended at the moment.
+func (x *nodeErrors) available(now time.Time, node []byte) bool {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	st, ok := x.nodes[string(node)]
+
+	return !ok || !now.Before(st.suspendedTill)
+}
+
+// failure registers the replication error. Returns the pause before the
+// retry and true if the node error budget allows retrying, false if the
+// node is suspended.
+func (x *nodeErrors) failure(now time.Time, node []byte) (time.Duration, bool) {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	st, ok := x.nodes[string(node)]
+	if !ok {
+		st = new(nodeErrorState)
+		x.nodes[string(node)] = st
+	}
+
+	st.errors++
+
+	if st.errors < x.budget {
+		return retryBackoff << (st.errors - 1), true
+	}
+
+	period := maxSuspendPeriod
+	if st.suspensions < 16 && suspendPeriod<<st.suspensions < maxSuspendPeriod {
+		period = suspendPeriod << st.suspensions
+	}
+
+	st.errors = 0
+	st.suspensions++
+	st.suspendedTill = now.Add(period)
+
+	return 0, false
+}
+
+// success resets the node state.
+func (x *nodeErrors) success(node []byte) {
+	x.mtx.Lock()
+	delete(x.nodes, string(node))
+	x.mtx.Unlock()
+}
@@ -0,0 +1,116 @@
+package replicator
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// burstWindow is a time for which the unused bandwidth is accumulated.
+	burstWindow = time.Second
+
+	// maxIdleBuckets is a number of per-node buckets after which the idle
+	// ones are dropped.
+	maxIdleBuckets = 1024
+)
+
+// bucket is a token bucket with the capacity of burstWindow of its rate
+// implemented as a virtual clock: next is the time the bucket gets empty.
+type bucket struct {
+	rate float64
+	next time.Time
+}
+
+// reserve takes n tokens from the bucket and returns the time to wait
+// before they are available.
+func (b *bucket) reserve(now time.Time, n uint64) time.Duration {
+	if b.rate == 0 || n == 0 {
+		return 0
+	}
+
+	if start := now.Add(-burstWindow); b.next.Before(start) {
+		b.next = start
+	}
+
+	b.next = b.next.Add(time.Duration(float64(n) / b.rate * float64(time.Second)))
+
+	return b.next.Sub(now)
+}
+
+// bandwidthLimiter limits the replication traffic of all the nodes and of
+// each node separately. Zero rate does not limit anything.
+type bandwidthLimiter struct {
+	mtx sync.Mutex
+
+	global   bucket
+	nodeRate float64
+	nodes    map[string]*bucket
+}
+
+func newBandwidthLimiter(global, perNode uint64) *bandwidthLimiter {
+	return &bandwidthLimiter{
+		global:   bucket{rate: float64(global)},
+		nodeRate: float64(perNode),
+		nodes:    make(map[string]*bucket),
+	}
+}
+
+// reserve takes size bytes from the global and the node budgets and returns
+// the time to wait before they are available.
+func (l *bandwidthLimiter) reserve(now time.Time, node []byte, size uint64) time.Duration {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	wait := l.global.reserve(now, size)
+	if wait < 0 {
+		wait = 0
+	}
+
+	if l.nodeRate == 0 {
+		return wait
+	}
+
+	if len(l.nodes) >= maxIdleBuckets {
+		for k, b := range l.nodes {
+			if b.next.Before(now) {
+				delete(l.nodes, k)
+			}
+		}
+	}
+
+	b, ok := l.nodes[string(node)]
+	if !ok {
+		b = &bucket{rate: l.nodeRate}
+		l.nodes[string(node)] = b
+	}
+
+	if w := b.reserve(now, size); w > wait {
+		wait = w
+	}
+
+	return wait
+}
+
+// wait blocks until size bytes may be sent to the node. Returns false if ctx
+// is done earlier.
+func (l *bandwidthLimiter) wait(ctx context.Context, node []byte, size uint64) bool {
+	return sleep(ctx, l.reserve(time.Now(), node, size))
+}
+
+// sleep blocks for d. Returns false if ctx is done earlier.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
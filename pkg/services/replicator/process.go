@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"sync"
+	"time"
 
 	putsvc "github.com/epicchainlabs/epicchain-node/pkg/services/object/put"
 	"github.com/epicchainlabs/epicchain-sdk-go/client"
@@ -20,18 +22,27 @@ type TaskResult interface {
 
 // HandleTask executes replication task inside invoking goroutine.
 // Passes all the nodes that accepted the replication to the TaskResult.
+//
+// The object is sent to as many nodes at once as the number of the copies
+// left, the nodes that failed are replaced by the next ones from the task
+// list. Object is read from the local storage once for all the nodes.
 func (p *Replicator) HandleTask(ctx context.Context, task Task, res TaskResult) {
+	entry := p.tasks.add(task)
+
 	defer func() {
+		p.tasks.remove(entry)
+
 		p.log.Debug("finish work",
 			zap.Uint32("amount of unfinished replicas", task.quantity),
 		)
 	}()
 
-	var err error
-	var prm *putsvc.RemotePutPrm
-	var stream io.ReadSeeker
-	binReplication := task.obj == nil
-	if binReplication {
+	var (
+		size   uint64
+		stream io.ReadSeeker
+	)
+
+	if task.obj == nil {
 		b, err := p.localStorage.GetBytes(task.addr)
 		if err != nil {
 			p.log.Error("could not get object from local storage",
@@ -40,48 +51,123 @@ func (p *Replicator) HandleTask(ctx context.Context, task Task, res TaskResult)
 
 			return
 		}
-		stream = bytes.NewReader(b)
-		if len(task.nodes) > 1 {
-			stream = client.DemuxReplicatedObject(stream)
-		}
+
+		size = uint64(len(b))
+		// replication message is encoded once and shared by all the nodes
+		stream = client.DemuxReplicatedObject(bytes.NewReader(b))
 	} else {
-		prm = new(putsvc.RemotePutPrm).WithObject(task.obj)
+		size = task.obj.PayloadSize()
 	}
 
-	for i := 0; task.quantity > 0 && i < len(task.nodes); i++ {
-		select {
-		case <-ctx.Done():
-			return
-		default:
+	nodes := task.nodes
+
+	for task.quantity > 0 && len(nodes) > 0 {
+		var targets []netmap.NodeInfo
+
+		for uint32(len(targets)) < task.quantity && len(nodes) > 0 {
+			node := nodes[0]
+			nodes = nodes[1:]
+
+			if !p.nodeErrors.available(time.Now(), node.PublicKey()) {
+				p.log.Debug("node is suspended after replication errors, skipping",
+					zap.String("node", netmap.StringifyPublicKey(node)),
+					zap.Stringer("object", task.addr),
+				)
+
+				continue
+			}
+
+			targets = append(targets, node)
+		}
+
+		if len(targets) == 0 {
+			break
 		}
 
-		log := p.log.With(
-			zap.String("node", netmap.StringifyPublicKey(task.nodes[i])),
-			zap.Stringer("object", task.addr),
+		p.tasks.setTargets(entry, task.quantity, targets)
+
+		var (
+			wg        sync.WaitGroup
+			succeeded = make([]bool, len(targets))
 		)
 
+		for i := range targets {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+				succeeded[i] = p.replicateToNode(ctx, entry, task, targets[i], stream, size)
+			}(i)
+		}
+
+		wg.Wait()
+
+		for i := range targets {
+			if succeeded[i] {
+				task.quantity--
+
+				res.SubmitSuccessfulReplication(targets[i])
+			}
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// replicateToNode sends the object to the node within the bandwidth budget.
+// Failed sends are retried with backoff while the node error budget allows.
+// Binary object is sent from the stream if it is set, task object otherwise.
+func (p *Replicator) replicateToNode(ctx context.Context, entry *taskEntry, task Task, node netmap.NodeInfo, stream io.ReadSeeker, size uint64) bool {
+	log := p.log.With(
+		zap.String("node", netmap.StringifyPublicKey(node)),
+		zap.Stringer("object", task.addr),
+	)
+
+	for {
+		if !p.bandwidth.wait(ctx, node.PublicKey(), size) {
+			return false
+		}
+
+		p.tasks.startSending(entry)
+
 		callCtx, cancel := context.WithTimeout(ctx, p.putTimeout)
 
-		if binReplication {
-			err = p.remoteSender.ReplicateObjectToNode(callCtx, task.addr.Object(), stream, task.nodes[i])
+		var err error
+		if stream != nil {
 			// note that we don't need to reset stream because it is used exactly once
 			// according to the client.DemuxReplicatedObject above
+			err = p.remoteSender.ReplicateObjectToNode(callCtx, task.addr.Object(), stream, node)
 		} else {
-			err = p.remoteSender.PutObject(callCtx, prm.WithNodeInfo(task.nodes[i]))
+			err = p.remoteSender.PutObject(callCtx, new(putsvc.RemotePutPrm).WithObject(task.obj).WithNodeInfo(node))
 		}
 
 		cancel()
 
-		if err != nil {
-			log.Error("could not replicate object",
-				zap.String("error", err.Error()),
-			)
-		} else {
+		p.tasks.stopSending(entry)
+
+		if err == nil {
+			p.nodeErrors.success(node.PublicKey())
+
 			log.Debug("object successfully replicated")
 
-			task.quantity--
+			return true
+		}
+
+		log.Error("could not replicate object",
+			zap.String("error", err.Error()),
+		)
+
+		retryIn, ok := p.nodeErrors.failure(time.Now(), node.PublicKey())
+		if !ok {
+			log.Warn("node error budget is exhausted, suspending replication to it")
+
+			return false
+		}
 
-			res.SubmitSuccessfulReplication(task.nodes[i])
+		if !sleep(ctx, retryIn) {
+			return false
 		}
 	}
 }
@@ -0,0 +1,234 @@
+package replicator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	putsvc "github.com/epicchainlabs/epicchain-node/pkg/services/object/put"
+	"github.com/epicchainlabs/epicchain-node/pkg/util/logger/test"
+	"github.com/epicchainlabs/epicchain-sdk-go/netmap"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	oidtest "github.com/epicchainlabs/epicchain-sdk-go/object/id/test"
+	"github.com/stretchr/testify/require"
+)
+
+type testStorage []byte
+
+func (s testStorage) GetBytes(oid.Address) ([]byte, error) {
+	return s, nil
+}
+
+// testSender fails the given number of first sends to each node.
+type testSender struct {
+	mtx      sync.Mutex
+	failures map[string]int
+	sent     map[string]int
+	attempts map[string]int
+	onSend   func()
+}
+
+func (s *testSender) PutObject(context.Context, *putsvc.RemotePutPrm) error {
+	panic("unexpected call")
+}
+
+func (s *testSender) ReplicateObjectToNode(_ context.Context, _ oid.ID, src io.ReadSeeker, node netmap.NodeInfo) error {
+	if s.onSend != nil {
+		s.onSend()
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	key := string(node.PublicKey())
+	s.attempts[key]++
+
+	if s.failures[key] > 0 {
+		s.failures[key]--
+		return errors.New("any error")
+	}
+
+	s.sent[key]++
+
+	return nil
+}
+
+type testResult []netmap.NodeInfo
+
+func (r *testResult) SubmitSuccessfulReplication(node netmap.NodeInfo) {
+	*r = append(*r, node)
+}
+
+func testNodes(n int) []netmap.NodeInfo {
+	nodes := make([]netmap.NodeInfo, n)
+	for i := range nodes {
+		nodes[i].SetPublicKey([]byte{byte(i)})
+	}
+	return nodes
+}
+
+func newTestReplicator(sender *testSender, opts ...Option) *Replicator {
+	r := New(append([]Option{
+		WithLogger(test.NewLogger(false)),
+		WithPutTimeout(time.Second),
+	}, opts...)...)
+
+	r.localStorage = testStorage("object")
+	r.remoteSender = sender
+
+	return r
+}
+
+func TestReplicator_HandleTask(t *testing.T) {
+	nodes := testNodes(4)
+
+	t.Run("fan-out", func(t *testing.T) {
+		sender := &testSender{
+			failures: map[string]int{string(nodes[0].PublicKey()): 100},
+			sent:     make(map[string]int),
+			attempts: make(map[string]int),
+		}
+
+		r := newTestReplicator(sender, WithNodeErrorBudget(1))
+
+		var task Task
+		task.SetObjectAddress(oidtest.Address())
+		task.SetCopiesNumber(2)
+		task.SetNodes(nodes)
+
+		var res testResult
+		r.HandleTask(context.Background(), task, &res)
+
+		// failed node is replaced with the next one
+		require.ElementsMatch(t, nodes[1:3], res)
+		require.Equal(t, map[string]int{
+			string(nodes[1].PublicKey()): 1,
+			string(nodes[2].PublicKey()): 1,
+		}, sender.sent)
+		require.Equal(t, 1, sender.attempts[string(nodes[0].PublicKey())])
+		require.Empty(t, r.Tasks())
+
+		// suspended node is skipped
+		res = nil
+		task.SetCopiesNumber(1)
+		r.HandleTask(context.Background(), task, &res)
+
+		require.Equal(t, testResult{nodes[1]}, res)
+		require.Equal(t, 1, sender.attempts[string(nodes[0].PublicKey())])
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		sender := &testSender{
+			failures: map[string]int{string(nodes[0].PublicKey()): 1},
+			sent:     make(map[string]int),
+			attempts: make(map[string]int),
+		}
+
+		r := newTestReplicator(sender, WithNodeErrorBudget(2))
+
+		var task Task
+		task.SetObjectAddress(oidtest.Address())
+		task.SetCopiesNumber(1)
+		task.SetNodes(nodes)
+
+		var res testResult
+		r.HandleTask(context.Background(), task, &res)
+
+		require.Equal(t, testResult{nodes[0]}, res)
+		require.Equal(t, 2, sender.attempts[string(nodes[0].PublicKey())])
+		require.True(t, r.nodeErrors.available(time.Now(), nodes[0].PublicKey()))
+	})
+
+	t.Run("tasks", func(t *testing.T) {
+		addr := oidtest.Address()
+
+		var (
+			r     *Replicator
+			mtx   sync.Mutex
+			tasks []TaskInfo
+		)
+
+		sender := &testSender{
+			failures: make(map[string]int),
+			sent:     make(map[string]int),
+			attempts: make(map[string]int),
+			onSend: func() {
+				mtx.Lock()
+				tasks = r.Tasks()
+				mtx.Unlock()
+			},
+		}
+
+		r = newTestReplicator(sender)
+
+		var task Task
+		task.SetObjectAddress(addr)
+		task.SetCopiesNumber(2)
+		task.SetNodes(nodes)
+
+		var res testResult
+		r.HandleTask(context.Background(), task, &res)
+
+		require.Len(t, res, 2)
+		require.Empty(t, r.Tasks())
+
+		require.Len(t, tasks, 1)
+		require.Equal(t, addr, tasks[0].Address)
+		require.EqualValues(t, 2, tasks[0].Copies)
+		require.Equal(t, nodes[:2], tasks[0].Nodes)
+		require.True(t, tasks[0].Active)
+	})
+}
+
+func TestBandwidthLimiter(t *testing.T) {
+	now := time.Now()
+
+	l := newBandwidthLimiter(500, 100)
+
+	// burst
+	require.Zero(t, l.reserve(now, []byte{1}, 100))
+	require.Equal(t, time.Second, l.reserve(now, []byte{1}, 100))
+
+	// other nodes are limited by the global budget only
+	require.Zero(t, l.reserve(now, []byte{2}, 100))
+	require.Zero(t, l.reserve(now, []byte{3}, 100))
+	require.Zero(t, l.reserve(now, []byte{4}, 100))
+	require.Equal(t, 200*time.Millisecond, l.reserve(now, []byte{5}, 100))
+
+	l = newBandwidthLimiter(0, 0)
+	require.Zero(t, l.reserve(now, []byte{1}, 1<<30))
+}
+
+func TestNodeErrors(t *testing.T) {
+	now := time.Now()
+	node := []byte{1}
+
+	x := newNodeErrors(3)
+
+	d, ok := x.failure(now, node)
+	require.True(t, ok)
+	require.Equal(t, retryBackoff, d)
+
+	d, ok = x.failure(now, node)
+	require.True(t, ok)
+	require.Equal(t, 2*retryBackoff, d)
+	require.True(t, x.available(now, node))
+
+	_, ok = x.failure(now, node)
+	require.False(t, ok)
+	require.False(t, x.available(now, node))
+	require.True(t, x.available(now.Add(suspendPeriod), node))
+
+	for i := 0; i < 3; i++ {
+		_, ok = x.failure(now, node)
+	}
+	require.False(t, ok)
+	require.False(t, x.available(now.Add(suspendPeriod), node))
+	require.True(t, x.available(now.Add(2*suspendPeriod), node))
+
+	x.success(node)
+	require.True(t, x.available(now, node))
+}
@@ -1,10 +1,14 @@
 package replicator
 
 import (
+	"context"
+	"io"
 	"time"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/engine"
 	putsvc "github.com/epicchainlabs/epicchain-node/pkg/services/object/put"
+	"github.com/epicchainlabs/epicchain-sdk-go/netmap"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
 	"go.uber.org/zap"
 )
 
@@ -12,19 +16,41 @@ import (
 // local objects to remote nodes.
 type Replicator struct {
 	*cfg
+
+	bandwidth  *bandwidthLimiter
+	nodeErrors *nodeErrors
+	tasks      *taskRegistry
 }
 
 // Option is an option for Policer constructor.
 type Option func(*cfg)
 
+// remoteSender sends objects to the remote nodes, implemented by
+// [putsvc.RemoteSender].
+type remoteSender interface {
+	PutObject(context.Context, *putsvc.RemotePutPrm) error
+	ReplicateObjectToNode(context.Context, oid.ID, io.ReadSeeker, netmap.NodeInfo) error
+}
+
+// localStorage provides binary objects to replicate, implemented by
+// [engine.StorageEngine].
+type localStorage interface {
+	GetBytes(oid.Address) ([]byte, error)
+}
+
 type cfg struct {
 	putTimeout time.Duration
 
 	log *zap.Logger
 
-	remoteSender *putsvc.RemoteSender
+	remoteSender remoteSender
+
+	localStorage localStorage
 
-	localStorage *engine.StorageEngine
+	bandwidth     uint64
+	nodeBandwidth uint64
+
+	nodeErrorBudget uint32
 }
 
 func defaultCfg() *cfg {
@@ -42,7 +68,10 @@ func New(opts ...Option) *Replicator {
 	c.log = c.log.With(zap.String("component", "Object Replicator"))
 
 	return &Replicator{
-		cfg: c,
+		cfg:        c,
+		bandwidth:  newBandwidthLimiter(c.bandwidth, c.nodeBandwidth),
+		nodeErrors: newNodeErrors(c.nodeErrorBudget),
+		tasks:      newTaskRegistry(),
 	}
 }
 
@@ -73,3 +102,23 @@ func WithLocalStorage(v *engine.StorageEngine) Option {
 		c.localStorage = v
 	}
 }
+
+// WithBandwidth returns option to limit the replication traffic: bandwidth
+// is the number of bytes sent to all the nodes per second, nodeBandwidth is
+// the number of bytes sent to each node per second. Zero means no limit.
+func WithBandwidth(bandwidth, nodeBandwidth uint64) Option {
+	return func(c *cfg) {
+		c.bandwidth = bandwidth
+		c.nodeBandwidth = nodeBandwidth
+	}
+}
+
+// WithNodeErrorBudget returns option to set the number of consecutive
+// replication errors after which the node is suspended from the replication.
+// Failed replications are retried with backoff until the budget is
+// exhausted. Zero means DefaultNodeErrorBudget.
+func WithNodeErrorBudget(n uint32) Option {
+	return func(c *cfg) {
+		c.nodeErrorBudget = n
+	}
+}
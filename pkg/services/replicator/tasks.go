@@ -0,0 +1,119 @@
+package replicator
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/epicchainlabs/epicchain-sdk-go/netmap"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+)
+
+// TaskInfo describes the replication task being handled.
+type TaskInfo struct {
+	// Address is the address of the replicated object.
+	Address oid.Address
+
+	// Copies is a number of the copies left to replicate.
+	Copies uint32
+
+	// Nodes are the nodes the object is being replicated to at the moment.
+	Nodes []netmap.NodeInfo
+
+	// Active is true if the object is being transmitted to at least one of
+	// the nodes. Otherwise, the task is queued waiting for the bandwidth
+	// budget or for the retry.
+	Active bool
+
+	// Started is the time the task handling started.
+	Started time.Time
+}
+
+type taskEntry struct {
+	info TaskInfo
+
+	// sending is a number of the nodes the object is being transmitted to.
+	sending int
+}
+
+// taskRegistry holds the replication tasks being handled.
+type taskRegistry struct {
+	mtx sync.Mutex
+
+	tasks map[*taskEntry]struct{}
+}
+
+func newTaskRegistry() *taskRegistry {
+	return &taskRegistry{
+		tasks: make(map[*taskEntry]struct{}),
+	}
+}
+
+func (r *taskRegistry) add(t Task) *taskEntry {
+	e := &taskEntry{
+		info: TaskInfo{
+			Address: t.addr,
+			Copies:  t.quantity,
+			Started: time.Now(),
+		},
+	}
+
+	r.mtx.Lock()
+	r.tasks[e] = struct{}{}
+	r.mtx.Unlock()
+
+	return e
+}
+
+func (r *taskRegistry) remove(e *taskEntry) {
+	r.mtx.Lock()
+	delete(r.tasks, e)
+	r.mtx.Unlock()
+}
+
+func (r *taskRegistry) setTargets(e *taskEntry, copies uint32, nodes []netmap.NodeInfo) {
+	r.mtx.Lock()
+	e.info.Copies = copies
+	e.info.Nodes = nodes
+	r.mtx.Unlock()
+}
+
+func (r *taskRegistry) startSending(e *taskEntry) {
+	r.mtx.Lock()
+	e.sending++
+	r.mtx.Unlock()
+}
+
+func (r *taskRegistry) stopSending(e *taskEntry) {
+	r.mtx.Lock()
+	e.sending--
+	r.mtx.Unlock()
+}
+
+// list returns the tasks being handled in the order they were started.
+func (r *taskRegistry) list() []TaskInfo {
+	r.mtx.Lock()
+
+	res := make([]TaskInfo, 0, len(r.tasks))
+	for e := range r.tasks {
+		info := e.info
+		info.Nodes = append([]netmap.NodeInfo(nil), e.info.Nodes...)
+		info.Active = e.sending > 0
+
+		res = append(res, info)
+	}
+
+	r.mtx.Unlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Started.Before(res[j].Started)
+	})
+
+	return res
+}
+
+// Tasks returns the replication tasks being handled at the moment, both
+// active and queued, in the order they were started.
+func (p *Replicator) Tasks() []TaskInfo {
+	return p.tasks.list()
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"github.com/epicchainlabs/epicchain-sdk-go/user"
+)
+
+// ErrUploadNotFound is returned when the requested multipart upload is
+// missing in the storage.
+var ErrUploadNotFound = errors.New("multipart upload not found")
+
+// ErrUploadCompleting is returned when the multipart upload being completed
+// is modified or completed once again.
+var ErrUploadCompleting = errors.New("multipart upload is being completed")
+
+// MultipartUpload represents the state of the multipart object upload.
+type MultipartUpload struct {
+	// ID is a unique identifier of the upload.
+	ID oid.ID `json:"id"`
+
+	// Owner is the owner of the uploaded object.
+	Owner user.ID `json:"-"`
+
+	// Container is the container of the uploaded object.
+	Container cid.ID `json:"container"`
+
+	// Header is a binary header of the uploaded object without payload
+	// related fields.
+	Header []byte `json:"header"`
+
+	// SplitID is a binary split ID of the object children.
+	SplitID []byte `json:"split_id"`
+
+	// ExpiredAt is the last epoch the upload is active, expired uploads are
+	// aborted.
+	ExpiredAt uint64 `json:"expired_at"`
+
+	// Parts are the uploaded parts ordered by their numbers.
+	Parts []MultipartPart `json:"parts,omitempty"`
+
+	// Garbage contains children of the replaced parts that must be removed.
+	Garbage []oid.ID `json:"garbage,omitempty"`
+
+	// Completing is set when the upload completion is started, no parts
+	// can be added after it.
+	Completing bool `json:"completing,omitempty"`
+
+	// Link is the ID of the link object completing the upload. It is set
+	// before the link object is saved, so the interrupted completion can be
+	// checked.
+	Link *oid.ID `json:"link,omitempty"`
+
+	// Object is the ID of the uploaded object, it is set along with Link.
+	Object *oid.ID `json:"object,omitempty"`
+}
+
+// MultipartPart describes the uploaded part of the multipart upload.
+type MultipartPart struct {
+	// Number is the number of the part.
+	Number uint32 `json:"number"`
+
+	// Size is the payload size of the part.
+	Size uint64 `json:"size"`
+
+	// Children are the child objects storing the part payload in order.
+	Children []oid.ID `json:"children"`
+}
+
+// SetPart adds the part to the upload. Children of the replaced part with the
+// same number are moved to the garbage.
+func (x *MultipartUpload) SetPart(p MultipartPart) {
+	i := sort.Search(len(x.Parts), func(i int) bool { return x.Parts[i].Number >= p.Number })
+	if i < len(x.Parts) && x.Parts[i].Number == p.Number {
+		x.Garbage = append(x.Garbage, x.Parts[i].Children...)
+		x.Parts[i] = p
+
+		return
+	}
+
+	x.Parts = append(x.Parts, MultipartPart{})
+	copy(x.Parts[i+1:], x.Parts[i:])
+	x.Parts[i] = p
+}
+
+// multipartUploadJSON is a JSON representation of MultipartUpload since
+// user.ID can not be encoded directly.
+type multipartUploadJSON struct {
+	MultipartUpload
+	Owner string `json:"owner"`
+}
+
+// Marshal encodes the upload into a binary form.
+func (x MultipartUpload) Marshal() ([]byte, error) {
+	return json.Marshal(multipartUploadJSON{
+		MultipartUpload: x,
+		Owner:           x.Owner.EncodeToString(),
+	})
+}
+
+// Unmarshal decodes the upload from the binary form.
+func (x *MultipartUpload) Unmarshal(data []byte) error {
+	var v multipartUploadJSON
+
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+
+	err = v.MultipartUpload.Owner.DecodeString(v.Owner)
+	if err != nil {
+		return fmt.Errorf("invalid owner: %w", err)
+	}
+
+	*x = v.MultipartUpload
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package persistent
+
+import (
+	"fmt"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/services/session/storage"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	"go.etcd.io/bbolt"
+)
+
+var multipartBucket = []byte("multipart")
+
+// CreateUpload saves the new multipart upload.
+func (s *TokenStore) CreateUpload(u storage.MultipartUpload) error {
+	data, err := u.Marshal()
+	if err != nil {
+		return fmt.Errorf("encode upload: %w", err)
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(multipartBucket).Put(u.ID[:], data)
+	})
+}
+
+// Upload returns the multipart upload by its ID. Returns
+// [storage.ErrUploadNotFound] if there is no such upload.
+func (s *TokenStore) Upload(id oid.ID) (*storage.MultipartUpload, error) {
+	var u *storage.MultipartUpload
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		u, err = getUpload(tx.Bucket(multipartBucket), id)
+		return err
+	})
+
+	return u, err
+}
+
+// PutUploadPart adds the uploaded part to the multipart upload. Returns
+// [storage.ErrUploadNotFound] if there is no such upload and
+// [storage.ErrUploadCompleting] if the upload is being completed.
+func (s *TokenStore) PutUploadPart(id oid.ID, p storage.MultipartPart) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(multipartBucket)
+
+		u, err := getUpload(b, id)
+		if err != nil {
+			return err
+		}
+
+		if u.Completing {
+			return storage.ErrUploadCompleting
+		}
+
+		u.SetPart(p)
+
+		return putUpload(b, u)
+	})
+}
+
+// SetUploadCompleting marks the multipart upload as being completed or
+// clears the mark and returns the upload state. Returns
+// [storage.ErrUploadNotFound] if there is no such upload and
+// [storage.ErrUploadCompleting] if the upload is already being completed.
+func (s *TokenStore) SetUploadCompleting(id oid.ID, completing bool) (*storage.MultipartUpload, error) {
+	var u *storage.MultipartUpload
+
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(multipartBucket)
+
+		var err error
+		u, err = getUpload(b, id)
+		if err != nil {
+			return err
+		}
+
+		if completing && u.Completing {
+			return storage.ErrUploadCompleting
+		}
+
+		u.Completing = completing
+
+		return putUpload(b, u)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// SetUploadLink saves IDs of the link object and the uploaded object formed on
+// the multipart upload completion. Returns [storage.ErrUploadNotFound] if
+// there is no such upload.
+func (s *TokenStore) SetUploadLink(id, link, obj oid.ID) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(multipartBucket)
+
+		u, err := getUpload(b, id)
+		if err != nil {
+			return err
+		}
+
+		u.Link = &link
+		u.Object = &obj
+
+		return putUpload(b, u)
+	})
+}
+
+// RemoveUpload removes the multipart upload. No-op if there is no such upload.
+func (s *TokenStore) RemoveUpload(id oid.ID) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(multipartBucket).Delete(id[:])
+	})
+}
+
+// ExpiredUploads returns all multipart uploads expired since provided epoch.
+func (s *TokenStore) ExpiredUploads(epoch uint64) ([]storage.MultipartUpload, error) {
+	var res []storage.MultipartUpload
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(multipartBucket).ForEach(func(k, v []byte) error {
+			var u storage.MultipartUpload
+
+			err := u.Unmarshal(v)
+			if err != nil {
+				return fmt.Errorf("decode upload %x: %w", k, err)
+			}
+
+			if u.ExpiredAt < epoch {
+				res = append(res, u)
+			}
+
+			return nil
+		})
+	})
+
+	return res, err
+}
+
+func getUpload(b *bbolt.Bucket, id oid.ID) (*storage.MultipartUpload, error) {
+	data := b.Get(id[:])
+	if data == nil {
+		return nil, storage.ErrUploadNotFound
+	}
+
+	u := new(storage.MultipartUpload)
+
+	err := u.Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode upload: %w", err)
+	}
+
+	return u, nil
+}
+
+func putUpload(b *bbolt.Bucket, u *storage.MultipartUpload) error {
+	data, err := u.Marshal()
+	if err != nil {
+		return fmt.Errorf("encode upload: %w", err)
+	}
+
+	return b.Put(u.ID[:], data)
+}
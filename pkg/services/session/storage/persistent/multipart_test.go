@@ -0,0 +1,89 @@
+package persistent
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/services/session/storage"
+	cidtest "github.com/epicchainlabs/epicchain-sdk-go/container/id/test"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+	oidtest "github.com/epicchainlabs/epicchain-sdk-go/object/id/test"
+	usertest "github.com/epicchainlabs/epicchain-sdk-go/user/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenStore_Multipart(t *testing.T) {
+	ts, err := NewTokenStore(filepath.Join(t.TempDir(), ".storage"))
+	require.NoError(t, err)
+
+	defer ts.Close()
+
+	u := storage.MultipartUpload{
+		ID:        oidtest.ID(),
+		Owner:     usertest.ID(t),
+		Container: cidtest.ID(),
+		Header:    []byte("header"),
+		SplitID:   []byte("split ID"),
+		ExpiredAt: 10,
+	}
+
+	_, err = ts.Upload(u.ID)
+	require.ErrorIs(t, err, storage.ErrUploadNotFound)
+	require.ErrorIs(t, ts.PutUploadPart(u.ID, storage.MultipartPart{Number: 1}), storage.ErrUploadNotFound)
+
+	require.NoError(t, ts.CreateUpload(u))
+
+	p1 := storage.MultipartPart{Number: 1, Size: 1, Children: []oid.ID{oidtest.ID()}}
+	p2 := storage.MultipartPart{Number: 2, Size: 2, Children: []oid.ID{oidtest.ID()}}
+	p2New := storage.MultipartPart{Number: 2, Size: 3, Children: []oid.ID{oidtest.ID(), oidtest.ID()}}
+
+	require.NoError(t, ts.PutUploadPart(u.ID, p2))
+	require.NoError(t, ts.PutUploadPart(u.ID, p1))
+	require.NoError(t, ts.PutUploadPart(u.ID, p2New))
+
+	res, err := ts.Upload(u.ID)
+	require.NoError(t, err)
+
+	u.Parts = []storage.MultipartPart{p1, p2New}
+	u.Garbage = p2.Children
+	require.Equal(t, u, *res)
+
+	_, err = ts.SetUploadCompleting(oidtest.ID(), true)
+	require.ErrorIs(t, err, storage.ErrUploadNotFound)
+
+	res, err = ts.SetUploadCompleting(u.ID, true)
+	require.NoError(t, err)
+	u.Completing = true
+	require.Equal(t, u, *res)
+
+	_, err = ts.SetUploadCompleting(u.ID, true)
+	require.ErrorIs(t, err, storage.ErrUploadCompleting)
+	require.ErrorIs(t, ts.PutUploadPart(u.ID, p1), storage.ErrUploadCompleting)
+
+	res, err = ts.SetUploadCompleting(u.ID, false)
+	require.NoError(t, err)
+	u.Completing = false
+	require.Equal(t, u, *res)
+
+	link, obj := oidtest.ID(), oidtest.ID()
+	require.ErrorIs(t, ts.SetUploadLink(oidtest.ID(), link, obj), storage.ErrUploadNotFound)
+	require.NoError(t, ts.SetUploadLink(u.ID, link, obj))
+
+	res, err = ts.Upload(u.ID)
+	require.NoError(t, err)
+	u.Link, u.Object = &link, &obj
+	require.Equal(t, u, *res)
+
+	expired, err := ts.ExpiredUploads(u.ExpiredAt)
+	require.NoError(t, err)
+	require.Empty(t, expired)
+
+	expired, err = ts.ExpiredUploads(u.ExpiredAt + 1)
+	require.NoError(t, err)
+	require.Equal(t, []storage.MultipartUpload{u}, expired)
+
+	require.NoError(t, ts.RemoveUpload(u.ID))
+
+	_, err = ts.Upload(u.ID)
+	require.ErrorIs(t, err, storage.ErrUploadNotFound)
+}
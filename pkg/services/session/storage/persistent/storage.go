@@ -48,6 +48,11 @@ func NewTokenStore(path string, opts ...Option) (*TokenStore, error) {
 
 	err = db.Update(func(tx *bbolt.Tx) error {
 		_, err := tx.CreateBucketIfNotExists(sessionsBucket)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateBucketIfNotExists(multipartBucket)
 		return err
 	})
 	if err != nil {
@@ -0,0 +1,153 @@
+package temporary
+
+import (
+	"fmt"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/services/session/storage"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
+)
+
+// CreateUpload saves the new multipart upload.
+func (s *TokenStore) CreateUpload(u storage.MultipartUpload) error {
+	data, err := u.Marshal()
+	if err != nil {
+		return fmt.Errorf("encode upload: %w", err)
+	}
+
+	s.mtx.Lock()
+	s.uploads[u.ID] = data
+	s.mtx.Unlock()
+
+	return nil
+}
+
+// Upload returns the multipart upload by its ID. Returns
+// [storage.ErrUploadNotFound] if there is no such upload.
+func (s *TokenStore) Upload(id oid.ID) (*storage.MultipartUpload, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return s.upload(id)
+}
+
+// PutUploadPart adds the uploaded part to the multipart upload. Returns
+// [storage.ErrUploadNotFound] if there is no such upload and
+// [storage.ErrUploadCompleting] if the upload is being completed.
+func (s *TokenStore) PutUploadPart(id oid.ID, p storage.MultipartPart) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	u, err := s.upload(id)
+	if err != nil {
+		return err
+	}
+
+	if u.Completing {
+		return storage.ErrUploadCompleting
+	}
+
+	u.SetPart(p)
+
+	return s.putUpload(u)
+}
+
+// SetUploadCompleting marks the multipart upload as being completed or
+// clears the mark and returns the upload state. Returns
+// [storage.ErrUploadNotFound] if there is no such upload and
+// [storage.ErrUploadCompleting] if the upload is already being completed.
+func (s *TokenStore) SetUploadCompleting(id oid.ID, completing bool) (*storage.MultipartUpload, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	u, err := s.upload(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if completing && u.Completing {
+		return nil, storage.ErrUploadCompleting
+	}
+
+	u.Completing = completing
+
+	return u, s.putUpload(u)
+}
+
+// SetUploadLink saves IDs of the link object and the uploaded object formed on
+// the multipart upload completion. Returns [storage.ErrUploadNotFound] if
+// there is no such upload.
+func (s *TokenStore) SetUploadLink(id, link, obj oid.ID) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	u, err := s.upload(id)
+	if err != nil {
+		return err
+	}
+
+	u.Link = &link
+	u.Object = &obj
+
+	return s.putUpload(u)
+}
+
+// upload decodes the upload, s.mtx must be held.
+func (s *TokenStore) upload(id oid.ID) (*storage.MultipartUpload, error) {
+	data, ok := s.uploads[id]
+	if !ok {
+		return nil, storage.ErrUploadNotFound
+	}
+
+	u := new(storage.MultipartUpload)
+
+	err := u.Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode upload: %w", err)
+	}
+
+	return u, nil
+}
+
+// putUpload encodes the upload, s.mtx must be held.
+func (s *TokenStore) putUpload(u *storage.MultipartUpload) error {
+	data, err := u.Marshal()
+	if err != nil {
+		return fmt.Errorf("encode upload: %w", err)
+	}
+
+	s.uploads[u.ID] = data
+
+	return nil
+}
+
+// RemoveUpload removes the multipart upload. No-op if there is no such upload.
+func (s *TokenStore) RemoveUpload(id oid.ID) error {
+	s.mtx.Lock()
+	delete(s.uploads, id)
+	s.mtx.Unlock()
+
+	return nil
+}
+
+// ExpiredUploads returns all multipart uploads expired since provided epoch.
+func (s *TokenStore) ExpiredUploads(epoch uint64) ([]storage.MultipartUpload, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	var res []storage.MultipartUpload
+
+	for id, data := range s.uploads {
+		var u storage.MultipartUpload
+
+		err := u.Unmarshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("decode upload %s: %w", id, err)
+		}
+
+		if u.ExpiredAt < epoch {
+			res = append(res, u)
+		}
+	}
+
+	return res, nil
+}
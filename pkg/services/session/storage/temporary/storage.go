@@ -5,6 +5,7 @@ import (
 
 	"github.com/mr-tron/base58"
 	"github.com/epicchainlabs/epicchain-node/pkg/services/session/storage"
+	oid "github.com/epicchainlabs/epicchain-sdk-go/object/id"
 	"github.com/epicchainlabs/epicchain-sdk-go/user"
 )
 
@@ -21,6 +22,9 @@ type TokenStore struct {
 	mtx *sync.RWMutex
 
 	tokens map[key]*storage.PrivateToken
+
+	// encoded multipart uploads
+	uploads map[oid.ID][]byte
 }
 
 // NewTokenStore creates, initializes and returns a new TokenStore instance.
@@ -28,8 +32,9 @@ type TokenStore struct {
 // The elements of the instance are stored in the map.
 func NewTokenStore() *TokenStore {
 	return &TokenStore{
-		mtx:    new(sync.RWMutex),
-		tokens: make(map[key]*storage.PrivateToken),
+		mtx:     new(sync.RWMutex),
+		tokens:  make(map[key]*storage.PrivateToken),
+		uploads: make(map[oid.ID][]byte),
 	}
 }
 
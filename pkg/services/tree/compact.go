@@ -0,0 +1,157 @@
+package tree
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	cid "github.com/epicchainlabs/epicchain-sdk-go/container/id"
+	netmapSDK "github.com/epicchainlabs/epicchain-sdk-go/netmap"
+	"go.uber.org/zap"
+)
+
+// compactForest is a tree storage capable of operation log compaction.
+type compactForest interface {
+	TreeCompact(cid cid.ID, treeID string, height uint64) (uint64, error)
+	TreeLogSize(cid cid.ID, treeID string) (uint64, error)
+}
+
+// errCompactionNotSupported is returned when the tree storage can't compact
+// operation logs.
+var errCompactionNotSupported = errors.New("log compaction is not supported by the storage")
+
+// CompactTree removes operations below the height agreed by all container
+// nodes from the tree log. Returns the height and the number of removed
+// operations. Zero height means that the nodes haven't agreed on the height
+// yet and nothing has been removed.
+func (s *Service) CompactTree(ctx context.Context, cnr cid.ID, treeID string) (uint64, uint64, error) {
+	nodes, pos, err := s.getContainerNodes(cnr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("can't get container nodes: %w", err)
+	}
+
+	if pos < 0 {
+		return 0, 0, ErrNotInContainer
+	}
+
+	height, removed, err := s.compactTree(ctx, cnr, treeID, randomizeNodeOrder(nodes, pos))
+	s.reportLogSize(cnr, treeID)
+	return height, removed, err
+}
+
+func (s *Service) compactTree(ctx context.Context, cnr cid.ID, treeID string, nodes []netmapSDK.NodeInfo) (uint64, uint64, error) {
+	cf, ok := s.forest.(compactForest)
+	if !ok {
+		return 0, 0, errCompactionNotSupported
+	}
+
+	height, err := s.agreedSyncHeight(ctx, cnr, treeID, nodes)
+	if err != nil || height == 0 {
+		return 0, 0, err
+	}
+
+	removed, err := cf.TreeCompact(cnr, treeID, height)
+	if err != nil {
+		return height, removed, fmt.Errorf("could not compact tree log: %w", err)
+	}
+
+	s.log.Debug("tree log has been compacted",
+		zap.Stringer("cid", cnr),
+		zap.String("tree", treeID),
+		zap.Uint64("height", height),
+		zap.Uint64("removed", removed))
+
+	return height, removed, nil
+}
+
+// syncHeight returns the height below which all operations of the tree have
+// been fetched from every container node.
+func (s *Service) syncHeight(cnr cid.ID, treeID string) uint64 {
+	s.cnrMapMtx.Lock()
+	defer s.cnrMapMtx.Unlock()
+
+	return s.cnrMap[cnr][treeID]
+}
+
+// agreedSyncHeight returns the minimum synchronization height across the
+// local node and the other container nodes. Operations below it are stored
+// by every node, so they can be removed from the log.
+func (s *Service) agreedSyncHeight(ctx context.Context, cnr cid.ID, treeID string, nodes []netmapSDK.NodeInfo) (uint64, error) {
+	return s.nodesSyncHeight(ctx, cnr, treeID, nodes, s.syncHeight(cnr, treeID))
+}
+
+// nodesSyncHeight returns the minimum of the height and the synchronization
+// heights of the nodes.
+func (s *Service) nodesSyncHeight(ctx context.Context, cnr cid.ID, treeID string, nodes []netmapSDK.NodeInfo, height uint64) (uint64, error) {
+	if height == 0 {
+		return 0, nil
+	}
+
+	rawCID := make([]byte, sha256.Size)
+	cnr.Encode(rawCID)
+
+	req := &GetSyncHeightRequest{
+		Body: &GetSyncHeightRequest_Body{
+			ContainerId: rawCID,
+			TreeId:      treeID,
+		},
+	}
+	if err := SignMessage(req, s.key); err != nil {
+		return 0, fmt.Errorf("could not sign request: %w", err)
+	}
+
+	for _, n := range nodes {
+		var resp *GetSyncHeightResponse
+		var lastErr error
+		n.IterateNetworkEndpoints(func(endpoint string) bool {
+			c, err := s.cache.get(ctx, endpoint)
+			if err != nil {
+				lastErr = err
+				return false
+			}
+
+			resp, lastErr = c.GetSyncHeight(ctx, req)
+			return lastErr == nil
+		})
+		if resp == nil {
+			if lastErr == nil {
+				lastErr = errNoSuitableNode
+			}
+			// Every node must agree on the height.
+			return 0, fmt.Errorf("could not get synchronization height of the node %x: %w", n.PublicKey(), lastErr)
+		}
+
+		if h := resp.GetBody().GetHeight(); h < height {
+			height = h
+		}
+		if height == 0 {
+			return 0, nil
+		}
+	}
+
+	return height, nil
+}
+
+// reportLogSize updates the tree log size metric.
+func (s *Service) reportLogSize(cnr cid.ID, treeID string) {
+	if s.metrics == nil {
+		return
+	}
+
+	cf, ok := s.forest.(compactForest)
+	if !ok {
+		return
+	}
+
+	size, err := cf.TreeLogSize(cnr, treeID)
+	if err != nil {
+		s.log.Debug("could not get tree log size",
+			zap.Stringer("cid", cnr),
+			zap.String("tree", treeID),
+			zap.Error(err))
+		return
+	}
+
+	s.metrics.SetTreeLogSize(cnr.EncodeToString(), treeID, size)
+}
@@ -33,6 +33,15 @@ type cfg struct {
 	replicatorWorkerCount     int
 	replicatorTimeout         time.Duration
 	containerCacheSize        int
+	// logCompaction enables operation log compaction after synchronization.
+	logCompaction bool
+	metrics       MetricsRegister
+}
+
+// MetricsRegister is an interface of the tree service metrics collector.
+type MetricsRegister interface {
+	// SetTreeLogSize sets the number of operations stored in the tree log.
+	SetTreeLogSize(cnrID, treeID string, size uint64)
 }
 
 // Option represents configuration option for a tree service.
@@ -116,3 +125,18 @@ func WithReplicationTimeout(t time.Duration) Option {
 		}
 	}
 }
+
+// WithLogCompaction enables compaction of the tree operation logs
+// after each synchronization.
+func WithLogCompaction(enabled bool) Option {
+	return func(c *cfg) {
+		c.logCompaction = enabled
+	}
+}
+
+// WithMetrics sets metrics collector for a tree service.
+func WithMetrics(m MetricsRegister) Option {
+	return func(c *cfg) {
+		c.metrics = m
+	}
+}
@@ -1,10 +1,12 @@
 package tree
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/pilorama"
@@ -555,6 +557,108 @@ func (s *Service) GetOpLog(req *GetOpLogRequest, srv TreeService_GetOpLogServer)
 	}
 }
 
+// snapshotForest is a tree storage capable of exporting and importing
+// tree snapshots.
+type snapshotForest interface {
+	TreeExport(cid cidSDK.ID, treeID string, w io.Writer) (uint64, error)
+	TreeImport(cid cidSDK.ID, treeID string, r io.Reader) (uint64, error)
+}
+
+// errSnapshotNotSupported is returned when the tree storage can't export
+// tree snapshots.
+var errSnapshotNotSupported = errors.New("tree snapshots are not supported by the storage")
+
+// snapshotChunkSize is a maximum size of the snapshot chunk sent in a single
+// GetSnapshotResponse.
+const snapshotChunkSize = 64 * 1024
+
+func (s *Service) GetSnapshot(req *GetSnapshotRequest, srv TreeService_GetSnapshotServer) error {
+	b := req.GetBody()
+
+	var cid cidSDK.ID
+	if err := cid.Decode(b.GetContainerId()); err != nil {
+		return err
+	}
+
+	ns, pos, err := s.getContainerNodes(cid)
+	if err != nil {
+		return err
+	}
+	if pos < 0 {
+		var cli TreeService_GetSnapshotClient
+		var outErr error
+		err := s.forEachNode(srv.Context(), ns, func(c TreeServiceClient) bool {
+			cli, outErr = c.GetSnapshot(srv.Context(), req)
+			return true
+		})
+		if err != nil {
+			return err
+		} else if outErr != nil {
+			return outErr
+		}
+		for {
+			resp, err := cli.Recv()
+			if errors.Is(err, io.EOF) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			if err := srv.Send(resp); err != nil {
+				return err
+			}
+		}
+	}
+
+	sf, ok := s.forest.(snapshotForest)
+	if !ok {
+		return errSnapshotNotSupported
+	}
+
+	w := bufio.NewWriterSize(snapshotStreamWriter{srv}, snapshotChunkSize)
+	if _, err := sf.TreeExport(cid, b.GetTreeId(), w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// snapshotStreamWriter sends written data as snapshot chunks.
+type snapshotStreamWriter struct {
+	srv TreeService_GetSnapshotServer
+}
+
+func (w snapshotStreamWriter) Write(p []byte) (int, error) {
+	err := w.srv.Send(&GetSnapshotResponse{
+		Body: &GetSnapshotResponse_Body{
+			Chunk: p,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *Service) GetSyncHeight(_ context.Context, req *GetSyncHeightRequest) (*GetSyncHeightResponse, error) {
+	b := req.GetBody()
+
+	var cid cidSDK.ID
+	if err := cid.Decode(b.GetContainerId()); err != nil {
+		return nil, err
+	}
+
+	// just verify the signature, the height
+	// is not a secret
+	if err := verifyMessage(req); err != nil {
+		return nil, err
+	}
+
+	return &GetSyncHeightResponse{
+		Body: &GetSyncHeightResponse_Body{
+			Height: s.syncHeight(cid, b.GetTreeId()),
+		},
+	}, nil
+}
+
 func (s *Service) TreeList(ctx context.Context, req *TreeListRequest) (*TreeListResponse, error) {
 	var cid cidSDK.ID
 
@@ -1,6 +1,7 @@
 package tree
 
 import (
+	"bufio"
 	"context"
 	"crypto/sha256"
 	"errors"
@@ -8,6 +9,7 @@ import (
 	"io"
 	"math"
 	"math/rand"
+	"os"
 	"sync"
 
 	"github.com/epicchainlabs/epicchain-node/pkg/local_object_storage/pilorama"
@@ -111,6 +113,19 @@ func (s *Service) synchronizeAllTrees(ctx context.Context, cid cid.ID) error {
 	s.cnrMap[cid] = syncStatus
 	s.cnrMapMtx.Unlock()
 
+	for _, tid := range treesToSync {
+		if s.logCompaction {
+			_, _, err := s.compactTree(ctx, cid, tid, nodes)
+			if err != nil {
+				s.log.Warn("could not compact tree log",
+					zap.Stringer("cid", cid),
+					zap.String("tree", tid),
+					zap.Error(err))
+			}
+		}
+		s.reportLogSize(cid, tid)
+	}
+
 	return nil
 }
 
@@ -146,6 +161,23 @@ func (s *Service) synchronizeTree(ctx context.Context, d pilorama.CIDDescriptor,
 		zap.String("tree", treeID),
 		zap.Uint64("from", from))
 
+	if from == 0 {
+		if h, ok := s.bootstrapTree(ctx, d, treeID, nodes); ok && h > 0 {
+			// The snapshot contains the operations below the height of
+			// the snapshot node only if they have been received by it, so
+			// the operations are skipped below the height every container
+			// node has been synchronized to.
+			h, err := s.nodesSyncHeight(ctx, d.CID, treeID, nodes, h+1)
+			if err != nil {
+				s.log.Debug("could not get synchronization height of the container nodes",
+					zap.Stringer("cid", d.CID),
+					zap.String("tree", treeID),
+					zap.Error(err))
+			}
+			from = h
+		}
+	}
+
 	newHeight := uint64(math.MaxUint64)
 	for _, n := range nodes {
 		height := from
@@ -234,6 +266,111 @@ func (s *Service) synchronizeSingle(ctx context.Context, d pilorama.CIDDescripto
 	}
 }
 
+// bootstrapTree imports the snapshot of the tree from one of the nodes if the
+// tree doesn't exist locally, so that only the operations not synchronized by
+// every container node need to be replayed. Returns the log height of the
+// imported snapshot and true on success.
+func (s *Service) bootstrapTree(ctx context.Context, d pilorama.CIDDescriptor, treeID string,
+	nodes []netmapSDK.NodeInfo) (uint64, bool) {
+	sf, ok := s.forest.(snapshotForest)
+	if !ok {
+		return 0, false
+	}
+
+	exists, err := s.forest.TreeExists(d.CID, treeID)
+	if err != nil || exists {
+		return 0, false
+	}
+
+	rawCID := make([]byte, sha256.Size)
+	d.CID.Encode(rawCID)
+
+	req := &GetSnapshotRequest{
+		Body: &GetSnapshotRequest_Body{
+			ContainerId: rawCID,
+			TreeId:      treeID,
+		},
+	}
+	if err := SignMessage(req, s.key); err != nil {
+		return 0, false
+	}
+
+	var height uint64
+	var imported bool
+	_ = s.forEachNode(ctx, nodes, func(c TreeServiceClient) bool {
+		// The snapshot is fetched completely before the import, because
+		// the storage can be blocked for the whole import duration.
+		f, err := fetchSnapshot(ctx, c, req)
+		if err != nil {
+			s.log.Debug("could not fetch tree snapshot",
+				zap.Stringer("cid", d.CID),
+				zap.String("tree", treeID),
+				zap.Error(err))
+			return false
+		}
+		defer func() {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}()
+
+		height, err = sf.TreeImport(d.CID, treeID, bufio.NewReader(f))
+		if err != nil {
+			s.log.Warn("could not import tree snapshot",
+				zap.Stringer("cid", d.CID),
+				zap.String("tree", treeID),
+				zap.Error(err))
+			return false
+		}
+
+		imported = true
+		return true
+	})
+
+	if imported {
+		s.log.Debug("tree has been bootstrapped from the snapshot",
+			zap.Stringer("cid", d.CID),
+			zap.String("tree", treeID),
+			zap.Uint64("height", height))
+	}
+	return height, imported
+}
+
+// fetchSnapshot writes the snapshot received from the node to a temporary
+// file. Returned file is positioned at the beginning of the snapshot, it must
+// be closed and removed by the caller.
+func fetchSnapshot(ctx context.Context, c TreeServiceClient, req *GetSnapshotRequest) (*os.File, error) {
+	cli, err := c.GetSnapshot(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("can't initialize client: %w", err)
+	}
+
+	f, err := os.CreateTemp("", "tree-snapshot-*")
+	if err != nil {
+		return nil, fmt.Errorf("can't create snapshot file: %w", err)
+	}
+
+	for {
+		resp, err := cli.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err == nil {
+			_, err = f.Write(resp.GetBody().GetChunk())
+		}
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+			return nil, err
+		}
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+	return f, nil
+}
+
 // ErrAlreadySyncing is returned when a service synchronization has already
 // been started.
 var ErrAlreadySyncing = errors.New("service is being synchronized")
@@ -0,0 +1,29 @@
+package util
+
+import "sync/atomic"
+
+// EpochTracker tracks the last handled epoch. Notifications of the replayed
+// or missed blocks may bring the epochs that have already been handled, their
+// side effects must not be repeated. Zero value is ready to use.
+type EpochTracker struct {
+	last atomic.Uint64
+}
+
+// Update sets the last handled epoch if the given one is greater. Returns
+// false if the epoch has already been handled.
+func (x *EpochTracker) Update(epoch uint64) bool {
+	for {
+		cur := x.last.Load()
+		if epoch <= cur {
+			return false
+		}
+		if x.last.CompareAndSwap(cur, epoch) {
+			return true
+		}
+	}
+}
+
+// Last returns the last handled epoch.
+func (x *EpochTracker) Last() uint64 {
+	return x.last.Load()
+}
@@ -0,0 +1,46 @@
+package util_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/epicchainlabs/epicchain-node/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEpochTracker(t *testing.T) {
+	var x util.EpochTracker
+	require.Zero(t, x.Last())
+
+	require.True(t, x.Update(2))
+	require.EqualValues(t, 2, x.Last())
+
+	require.False(t, x.Update(2), "same epoch")
+	require.False(t, x.Update(1), "outdated epoch")
+	require.EqualValues(t, 2, x.Last())
+
+	t.Run("concurrent", func(t *testing.T) {
+		var (
+			x       util.EpochTracker
+			wg      sync.WaitGroup
+			mtx     sync.Mutex
+			updated int
+		)
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if x.Update(5) {
+					mtx.Lock()
+					updated++
+					mtx.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		require.Equal(t, 1, updated, "epoch is handled once")
+		require.EqualValues(t, 5, x.Last())
+	})
+}
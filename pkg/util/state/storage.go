@@ -104,3 +104,29 @@ func (p PersistentStorage) Bytes(key []byte) (res []byte, err error) {
 
 	return
 }
+
+// BlockHeight is a block height value kept in the PersistentStorage under the
+// fixed key.
+type BlockHeight struct {
+	storage PersistentStorage
+	key     []byte
+}
+
+// BlockHeight returns the block height value stored by the specified key.
+func (p PersistentStorage) BlockHeight(key []byte) BlockHeight {
+	return BlockHeight{
+		storage: p,
+		key:     key,
+	}
+}
+
+// LastProcessedBlock returns the stored block height. If the value does not
+// exist, returns 0.
+func (h BlockHeight) LastProcessedBlock() (uint32, error) {
+	return h.storage.UInt32(h.key)
+}
+
+// SetLastProcessedBlock saves the block height in the storage.
+func (h BlockHeight) SetLastProcessedBlock(index uint32) error {
+	return h.storage.SetUInt32(h.key, index)
+}
@@ -52,3 +52,24 @@ func TestPersistentStorage_Bytes(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, bVal, bRes)
 }
+
+func TestPersistentStorage_BlockHeight(t *testing.T) {
+	storage := newStorage(t)
+	key := []byte("height")
+
+	h := storage.BlockHeight(key)
+
+	n, err := h.LastProcessedBlock()
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	require.NoError(t, h.SetLastProcessedBlock(42))
+
+	n, err = h.LastProcessedBlock()
+	require.NoError(t, err)
+	require.EqualValues(t, 42, n)
+
+	n, err = storage.UInt32(key)
+	require.NoError(t, err)
+	require.EqualValues(t, 42, n)
+}